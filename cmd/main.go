@@ -2,15 +2,17 @@ package main
 
 import (
 	"context"
-	"github.com/jackc/pgx/v5/pgxpool"
+	"fmt"
 	"log"
 	"net/http"
+	"time"
 
 	"pr-reviewer-assigment-service/internal/api"
 	"pr-reviewer-assigment-service/internal/api/httphandlers"
+	"pr-reviewer-assigment-service/internal/api/httpmiddleware"
 	"pr-reviewer-assigment-service/internal/application/service"
 	"pr-reviewer-assigment-service/internal/config"
-	"pr-reviewer-assigment-service/internal/infrastructure/postgres"
+	"pr-reviewer-assigment-service/internal/infrastructure/events"
 )
 
 func main() {
@@ -19,33 +21,121 @@ func main() {
 	if err != nil {
 		log.Fatal(err)
 	}
-	pool, err := pgxpool.New(ctx, cfg.DatabaseURL)
+	repos, closeStorage, err := openStorage(ctx, cfg)
 	if err != nil {
-		log.Fatalf("failed to connect to postgres: %v", err)
+		log.Fatal(err)
 	}
-	defer pool.Close()
-
-	// repos
-	userRepo := postgres.NewUserDb(pool)
-	teamRepo := postgres.NewTeamDb(pool)
-	prRepo := postgres.NewPullRequestDb(pool)
+	defer closeStorage()
 
 	// services
-	teamService := service.NewTeamService(userRepo, teamRepo)
-	userService := service.NewUserService(userRepo, prRepo)
-	prService := service.NewPullRequestService(prRepo, userRepo, teamRepo)
-	statsService := service.NewStatsService(prRepo)
+	clock := service.SystemClock{}
+	ids := service.UUIDGenerator{}
+
+	prService := service.NewPullRequestService(repos.prs, repos.users, repos.teams, repos.codeOwners, repos.rules, clock)
+	teamService := service.NewTeamService(repos.users, repos.teams, repos.prs, prService)
+	userService := service.NewUserService(repos.users, repos.prs, repos.teams, prService)
+	statsService := service.NewStatsService(repos.prs)
+	availabilityService := service.NewAvailabilityService(repos.users, repos.unavailability, prService, clock, ids)
+	codeOwnerService := service.NewCodeOwnerService(repos.codeOwners, repos.users, repos.teams)
+	ruleService := service.NewRuleService(repos.rules, repos.users, repos.prs, clock, ids)
+	slaService := service.NewSLAService(repos.slas, repos.teams, prService, events.NewLogPublisher(nil), clock)
+	importService := service.NewImportService(repos.imports)
+	exportService := service.NewExportService(repos.exports)
+	consistencyService := service.NewConsistencyService(repos.consistency, prService)
+	go availabilityService.RunReleaser(ctx, cfg.UnavailabilityCheckInterval)
+	go slaService.RunEscalator(ctx, cfg.SLACheckInterval)
+
 	// handlers
 	teamHandlers := httphandlers.NewTeamHandlers(teamService)
-	userHandlers := httphandlers.NewUserHandlers(userService)
+	userHandlers := httphandlers.NewUserHandlers(userService, availabilityService)
 	prHandlers := httphandlers.NewPullRequestHandlers(prService)
 	statsHandlers := httphandlers.NewStatsHandlers(statsService)
+	codeOwnerHandlers := httphandlers.NewCodeOwnerHandlers(codeOwnerService)
+	ruleHandlers := httphandlers.NewRuleHandlers(ruleService)
+	slaHandlers := httphandlers.NewSLAHandlers(slaService)
+	adminHandlers := httphandlers.NewAdminHandlers(importService, exportService, consistencyService)
+
+	// middlewares
+	var middlewares []func(http.Handler) http.Handler
+	openAPIMode, err := httpmiddleware.ParseOpenAPIMode(cfg.OpenAPIValidation)
+	if err != nil {
+		log.Fatalf("OPENAPI_VALIDATION: %v", err)
+	}
+	if openAPIMode != httpmiddleware.OpenAPIOff {
+		// Внешним слоем, чтобы проверялись и ответы других middleware (401, 429 и т.д.).
+		validator, err := httpmiddleware.NewOpenAPIValidator(api.OpenAPISpec, openAPIMode)
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("WARNING: OpenAPI validation is enabled (%s), do not use it in production", openAPIMode)
+		middlewares = append(middlewares, validator.Middleware)
+	}
+	// До аутентификации, чтобы ограничить и запросы с неверными учётными данными.
+	middlewares = append(middlewares, httpmiddleware.NewIPRateLimiter(
+		httpmiddleware.Limit{Rate: cfg.RateLimitIP.RPS, Burst: cfg.RateLimitIP.Burst},
+	).Middleware)
+	if cfg.AuthEnabled() {
+		authenticators, err := newAuthenticators(cfg)
+		if err != nil {
+			log.Fatal(err)
+		}
+		middlewares = append(middlewares, httpmiddleware.Authenticate(httpmiddleware.DefaultPolicy(prService.TeamOf, userService.TeamOf), authenticators...))
+	} else {
+		log.Println("WARNING: authentication is disabled (AUTH_DISABLED=true), every client has admin access")
+	}
+	// После аутентификации, чтобы корзины велись по проверенному субъекту.
+	middlewares = append(middlewares, newRateLimiter(cfg).Middleware)
+
+	idempotency := httpmiddleware.NewIdempotency(repos.idempotency, cfg.IdempotencyTTL)
+	go idempotency.RunCleanup(ctx, time.Hour)
+	middlewares = append(middlewares, idempotency.Middleware)
 
 	// router
-	handler := api.NewRouter(teamHandlers, userHandlers, prHandlers, statsHandlers)
+	handler := api.NewRouter(teamHandlers, userHandlers, prHandlers, statsHandlers, codeOwnerHandlers, ruleHandlers, slaHandlers, adminHandlers, middlewares...)
 
 	log.Println("listening on " + cfg.HttpPort)
 	if err := http.ListenAndServe(":"+cfg.HttpPort, handler); err != nil {
 		log.Fatalf("server exited: %v", err)
 	}
 }
+
+// newAuthenticators собирает аутентификаторы из конфигурации.
+func newAuthenticators(cfg *config.Config) ([]httpmiddleware.Authenticator, error) {
+	authenticators := make([]httpmiddleware.Authenticator, 0, 2)
+
+	if cfg.AuthJWTSecret != "" {
+		authenticators = append(authenticators, httpmiddleware.NewJWTAuthenticator([]byte(cfg.AuthJWTSecret)))
+	}
+
+	if len(cfg.AuthTokens) > 0 {
+		tokens := make([]httpmiddleware.StaticToken, 0, len(cfg.AuthTokens))
+		for _, t := range cfg.AuthTokens {
+			role, err := httpmiddleware.ParseRole(t.Role)
+			if err != nil {
+				return nil, fmt.Errorf("AUTH_TOKENS: %s: %w", t.Subject, err)
+			}
+			tokens = append(tokens, httpmiddleware.StaticToken{
+				Token: t.Token,
+				Principal: httpmiddleware.Principal{
+					Subject: t.Subject,
+					Role:    role,
+				},
+			})
+		}
+		authenticators = append(authenticators, httpmiddleware.NewStaticTokenAuthenticator(tokens))
+	}
+
+	return authenticators, nil
+}
+
+// newRateLimiter собирает ограничитель частоты запросов из конфигурации.
+func newRateLimiter(cfg *config.Config) *httpmiddleware.RateLimiter {
+	routes := make(map[string]httpmiddleware.Limit, len(cfg.RateLimitRoutes))
+	for route, l := range cfg.RateLimitRoutes {
+		routes[route] = httpmiddleware.Limit{Rate: l.RPS, Burst: l.Burst}
+	}
+	return httpmiddleware.NewRateLimiter(
+		httpmiddleware.Limit{Rate: cfg.RateLimitDefault.RPS, Burst: cfg.RateLimitDefault.Burst},
+		routes,
+	)
+}
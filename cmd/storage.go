@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"pr-reviewer-assigment-service/internal/application/repository"
+	"pr-reviewer-assigment-service/internal/config"
+	"pr-reviewer-assigment-service/internal/infrastructure/memory"
+	"pr-reviewer-assigment-service/internal/infrastructure/postgres"
+	"pr-reviewer-assigment-service/internal/infrastructure/sqlite"
+)
+
+// repositories - реализации репозиториев выбранного хранилища.
+type repositories struct {
+	users          repository.UserRepository
+	teams          repository.TeamRepository
+	prs            repository.PullRequestRepository
+	idempotency    repository.IdempotencyRepository
+	unavailability repository.UnavailabilityRepository
+	codeOwners     repository.CodeOwnerRepository
+	rules          repository.ReviewerRuleRepository
+	slas           repository.ReviewSLARepository
+	imports        repository.ImportRepository
+	exports        repository.ExportRepository
+	consistency    repository.ConsistencyRepository
+}
+
+// openStorage подключает хранилище из cfg.Storage. Возвращённая функция освобождает его ресурсы.
+func openStorage(ctx context.Context, cfg *config.Config) (*repositories, func(), error) {
+	switch cfg.Storage {
+	case config.StorageMemory:
+		log.Println("WARNING: STORAGE=memory, data is lost when the service stops")
+		return newMemoryRepositories(memory.NewStore()), func() {}, nil
+	case config.StoragePostgres:
+		pool, err := pgxpool.New(ctx, cfg.DatabaseURL)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to connect to postgres: %w", err)
+		}
+		return newPostgresRepositories(pool), pool.Close, nil
+	case config.StorageSQLite:
+		db, err := sqlite.Open(ctx, cfg.SQLitePath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open sqlite: %w", err)
+		}
+		return newSQLiteRepositories(db), func() { _ = db.Close() }, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown storage %q", cfg.Storage)
+	}
+}
+
+func newPostgresRepositories(pool *pgxpool.Pool) *repositories {
+	return &repositories{
+		users:          postgres.NewUserDb(pool),
+		teams:          postgres.NewTeamDb(pool),
+		prs:            postgres.NewPullRequestDb(pool),
+		idempotency:    postgres.NewIdempotencyDb(pool),
+		unavailability: postgres.NewUnavailabilityDb(pool),
+		codeOwners:     postgres.NewCodeOwnerDb(pool),
+		rules:          postgres.NewReviewerRuleDb(pool),
+		slas:           postgres.NewReviewSLADb(pool),
+		imports:        postgres.NewImportDb(pool),
+		exports:        postgres.NewExportDb(pool),
+		consistency:    postgres.NewConsistencyDb(pool),
+	}
+}
+
+func newSQLiteRepositories(db *sql.DB) *repositories {
+	return &repositories{
+		users:          sqlite.NewUserDb(db),
+		teams:          sqlite.NewTeamDb(db),
+		prs:            sqlite.NewPullRequestDb(db),
+		idempotency:    sqlite.NewIdempotencyDb(db),
+		unavailability: sqlite.NewUnavailabilityDb(db),
+		codeOwners:     sqlite.NewCodeOwnerDb(db),
+		rules:          sqlite.NewReviewerRuleDb(db),
+		slas:           sqlite.NewReviewSLADb(db),
+		imports:        sqlite.NewImportDb(db),
+		exports:        sqlite.NewExportDb(db),
+		consistency:    sqlite.NewConsistencyDb(db),
+	}
+}
+
+func newMemoryRepositories(store *memory.Store) *repositories {
+	return &repositories{
+		users:          memory.NewUserRepo(store),
+		teams:          memory.NewTeamRepo(store),
+		prs:            memory.NewPullRequestRepo(store),
+		idempotency:    memory.NewIdempotencyRepo(store),
+		unavailability: memory.NewUnavailabilityRepo(store),
+		codeOwners:     memory.NewCodeOwnerRepo(store),
+		rules:          memory.NewReviewerRuleRepo(store),
+		slas:           memory.NewReviewSLARepo(store),
+		imports:        memory.NewImportRepo(store),
+		exports:        memory.NewExportRepo(store),
+		consistency:    memory.NewConsistencyRepo(store),
+	}
+}
@@ -0,0 +1,34 @@
+package dto
+
+import "fmt"
+
+// /codeOwners/upload
+
+// MaxCodeOwnersLength - максимальный размер файла CODEOWNERS в байтах.
+const MaxCodeOwnersLength = 256 * 1024
+
+type CodeOwnersUploadRequest struct {
+	// Content - текст файла CODEOWNERS.
+	Content string `json:"content"`
+}
+
+func (r CodeOwnersUploadRequest) Validate() error {
+	var v validator
+	if len(r.Content) > MaxCodeOwnersLength {
+		v.add("content", fmt.Sprintf("must be at most %d bytes", MaxCodeOwnersLength))
+	}
+	return v.result()
+}
+
+// /codeOwners/list
+
+type CodeOwnerRuleDto struct {
+	Position int      `json:"position"`
+	Pattern  string   `json:"pattern"`
+	Users    []string `json:"users"`
+	Teams    []string `json:"teams"`
+}
+
+type CodeOwnersResponse struct {
+	Rules []CodeOwnerRuleDto `json:"rules"`
+}
@@ -0,0 +1,28 @@
+package dto
+
+import "pr-reviewer-assigment-service/internal/domain"
+
+type ConsistencyIssueDto struct {
+	Kind          string `json:"kind"`
+	PullRequestID string `json:"pull_request_id"`
+	UserID        string `json:"user_id"`
+	Repaired      bool   `json:"repaired"`
+}
+
+type ConsistencyResponse struct {
+	Repair bool                  `json:"repair"`
+	Issues []ConsistencyIssueDto `json:"issues"`
+}
+
+func NewConsistencyResponse(report *domain.ConsistencyReport) ConsistencyResponse {
+	issues := make([]ConsistencyIssueDto, 0, len(report.Issues))
+	for _, issue := range report.Issues {
+		issues = append(issues, ConsistencyIssueDto{
+			Kind:          string(issue.Kind),
+			PullRequestID: issue.PullRequestID,
+			UserID:        issue.UserID,
+			Repaired:      issue.Repaired,
+		})
+	}
+	return ConsistencyResponse{Repair: report.Repair, Issues: issues}
+}
@@ -0,0 +1,297 @@
+package dto
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+// /admin/export
+
+// Значения query-параметра format.
+const (
+	ExportFormatJSONLines = "jsonl"
+	ExportFormatCSV       = "csv"
+)
+
+type ExportRequest struct {
+	Format string // Пустое - JSON Lines
+}
+
+func (r ExportRequest) Validate() error {
+	var v validator
+	switch r.Format {
+	case "", ExportFormatJSONLines, ExportFormatCSV:
+	default:
+		v.add("format", fmt.Sprintf("must be %s or %s", ExportFormatJSONLines, ExportFormatCSV))
+	}
+	return v.result()
+}
+
+// ImportFormat возвращает формат выгрузки; его же принимает /admin/import.
+func (r ExportRequest) ImportFormat() ImportFormat {
+	if r.Format == ExportFormatCSV {
+		return ImportCSV
+	}
+	return ImportJSONLines
+}
+
+// ExportWriter пишет записи выгрузки в формате файла импорта.
+// Для CSV перед первой записью пишется заголовок со всеми ImportColumns.
+type ExportWriter struct {
+	format  ImportFormat
+	json    *json.Encoder
+	csv     *csv.Writer
+	records int
+}
+
+func NewExportWriter(format ImportFormat, w io.Writer) *ExportWriter {
+	ew := &ExportWriter{format: format}
+	if format == ImportCSV {
+		ew.csv = csv.NewWriter(w)
+	} else {
+		ew.json = json.NewEncoder(w)
+		ew.json.SetEscapeHTML(false)
+	}
+	return ew
+}
+
+// Records возвращает, сколько записей уже записано.
+func (w *ExportWriter) Records() int {
+	return w.records
+}
+
+func (w *ExportWriter) Team(teamName string) error {
+	return w.write(ImportRecord{Type: ImportTypeTeam, TeamName: teamName})
+}
+
+func (w *ExportWriter) User(u *domain.User) error {
+	isActive := u.IsActive
+	rec := ImportRecord{
+		Type:           ImportTypeUser,
+		UserID:         u.UserID,
+		Username:       u.Username,
+		TeamName:       u.TeamName,
+		IsActive:       &isActive,
+		MaxOpenReviews: u.MaxOpenReviews,
+	}
+	if len(u.Skills) > 0 {
+		rec.Skills = u.Skills
+	}
+	return w.write(rec)
+}
+
+func (w *ExportWriter) PullRequest(pr *domain.PullRequest) error {
+	rec := ImportRecord{
+		Type:            ImportTypePullRequest,
+		PullRequestID:   pr.PullRequestID,
+		PullRequestName: pr.PullRequestName,
+		AuthorID:        pr.AuthorID,
+		Status:          pr.Status,
+		CreatedAt:       pr.CreatedAt,
+		MergedAt:        pr.MergedAt,
+	}
+	if len(pr.AssignedReviewers) > 0 {
+		times, err := pr.ReviewerAssignedTimes()
+		if err != nil {
+			return err
+		}
+		rec.AssignedReviewers = pr.AssignedReviewers
+		rec.ReviewersAssignedAt = times
+	}
+	return w.write(rec)
+}
+
+func (w *ExportWriter) Escalation(prID string, escalatedAt time.Time) error {
+	return w.write(ImportRecord{Type: ImportTypeEscalation, PullRequestID: prID, EscalatedAt: &escalatedAt})
+}
+
+func (w *ExportWriter) ReviewSLA(sla *domain.ReviewSLA) error {
+	hours := int(sla.SLA / time.Hour)
+	return w.write(ImportRecord{
+		Type:           ImportTypeReviewSLA,
+		TeamName:       sla.TeamName,
+		ReviewSLAHours: &hours,
+		Policy:         string(sla.Policy),
+	})
+}
+
+func (w *ExportWriter) CodeOwnerRule(rule *domain.CodeOwnerRule) error {
+	position := rule.Position
+	rec := ImportRecord{Type: ImportTypeCodeOwnerRule, Position: &position, Pattern: rule.Pattern}
+	if len(rule.Users) > 0 {
+		rec.OwnerUsers = rule.Users
+	}
+	if len(rule.Teams) > 0 {
+		rec.OwnerTeams = rule.Teams
+	}
+	return w.write(rec)
+}
+
+func (w *ExportWriter) ReviewerRule(rule *domain.ReviewerRule) error {
+	createdAt := rule.CreatedAt
+	return w.write(ImportRecord{
+		Type:           ImportTypeReviewerRule,
+		RuleID:         rule.ID,
+		Kind:           string(rule.Kind),
+		AuthorUserID:   rule.Author.UserID,
+		AuthorSkill:    rule.Author.Skill,
+		ReviewerUserID: rule.Reviewer.UserID,
+		ReviewerSkill:  rule.Reviewer.Skill,
+		Description:    rule.Description,
+		CreatedAt:      &createdAt,
+	})
+}
+
+func (w *ExportWriter) Unavailability(u *domain.Unavailability) error {
+	startsAt, endsAt := u.StartsAt, u.EndsAt
+	return w.write(ImportRecord{
+		Type:             ImportTypeUnavailability,
+		UnavailabilityID: u.ID,
+		UserID:           u.UserID,
+		StartsAt:         &startsAt,
+		EndsAt:           &endsAt,
+		Reason:           u.Reason,
+		ReleasedAt:       u.ReleasedAt,
+	})
+}
+
+// Flush дописывает буферизованные данные.
+func (w *ExportWriter) Flush() error {
+	if w.csv == nil {
+		return nil
+	}
+	if w.records == 0 {
+		if err := w.csv.Write(ImportColumns); err != nil {
+			return err
+		}
+	}
+	w.csv.Flush()
+	return w.csv.Error()
+}
+
+func (w *ExportWriter) write(rec ImportRecord) error {
+	if w.csv == nil {
+		if err := w.json.Encode(rec); err != nil {
+			return err
+		}
+		w.records++
+		return nil
+	}
+
+	if w.records == 0 {
+		if err := w.csv.Write(ImportColumns); err != nil {
+			return err
+		}
+	}
+	if err := w.csv.Write(rec.csvRow()); err != nil {
+		return err
+	}
+	w.records++
+	return nil
+}
+
+// csvRow возвращает значения записи в порядке ImportColumns. Обратное преобразование - csvRecord.
+func (r ImportRecord) csvRow() []string {
+	row := make([]string, 0, len(ImportColumns))
+	for _, column := range ImportColumns {
+		var value string
+		switch column {
+		case "type":
+			value = r.Type
+		case "team_name":
+			value = r.TeamName
+		case "user_id":
+			value = r.UserID
+		case "username":
+			value = r.Username
+		case "is_active":
+			if r.IsActive != nil {
+				value = strconv.FormatBool(*r.IsActive)
+			}
+		case "max_open_reviews":
+			if r.MaxOpenReviews != nil {
+				value = strconv.Itoa(*r.MaxOpenReviews)
+			}
+		case "skills":
+			value = strings.Join(r.Skills, ";")
+		case "pull_request_id":
+			value = r.PullRequestID
+		case "pull_request_name":
+			value = r.PullRequestName
+		case "author_id":
+			value = r.AuthorID
+		case "status":
+			value = r.Status
+		case "assigned_reviewers":
+			value = strings.Join(r.AssignedReviewers, ";")
+		case "reviewers_assigned_at":
+			times := make([]string, 0, len(r.ReviewersAssignedAt))
+			for _, at := range r.ReviewersAssignedAt {
+				times = append(times, formatTimestamp(&at))
+			}
+			value = strings.Join(times, ";")
+		case "created_at":
+			value = formatTimestamp(r.CreatedAt)
+		case "merged_at":
+			value = formatTimestamp(r.MergedAt)
+		case "escalated_at":
+			value = formatTimestamp(r.EscalatedAt)
+		case "review_sla_hours":
+			if r.ReviewSLAHours != nil {
+				value = strconv.Itoa(*r.ReviewSLAHours)
+			}
+		case "policy":
+			value = r.Policy
+		case "position":
+			if r.Position != nil {
+				value = strconv.Itoa(*r.Position)
+			}
+		case "pattern":
+			value = r.Pattern
+		case "owner_users":
+			value = strings.Join(r.OwnerUsers, ";")
+		case "owner_teams":
+			value = strings.Join(r.OwnerTeams, ";")
+		case "rule_id":
+			value = r.RuleID
+		case "kind":
+			value = r.Kind
+		case "author_user_id":
+			value = r.AuthorUserID
+		case "author_skill":
+			value = r.AuthorSkill
+		case "reviewer_user_id":
+			value = r.ReviewerUserID
+		case "reviewer_skill":
+			value = r.ReviewerSkill
+		case "description":
+			value = r.Description
+		case "unavailability_id":
+			value = r.UnavailabilityID
+		case "starts_at":
+			value = formatTimestamp(r.StartsAt)
+		case "ends_at":
+			value = formatTimestamp(r.EndsAt)
+		case "reason":
+			value = r.Reason
+		case "released_at":
+			value = formatTimestamp(r.ReleasedAt)
+		}
+		row = append(row, value)
+	}
+	return row
+}
+
+func formatTimestamp(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
@@ -0,0 +1,773 @@
+package dto
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+// /admin/import
+
+const (
+	// MaxImportBodySize - максимальный размер файла импорта в байтах.
+	MaxImportBodySize = 32 << 20
+	// MaxImportRecords - сколько записей можно загрузить одним файлом.
+	MaxImportRecords = 100_000
+	// maxImportLineSize - максимальная длина строки JSON Lines.
+	maxImportLineSize = 64 * 1024
+)
+
+// ImportFormat - формат файла импорта, задаётся заголовком Content-Type.
+type ImportFormat string
+
+const (
+	ImportJSONLines ImportFormat = "application/x-ndjson"
+	ImportCSV       ImportFormat = "text/csv"
+)
+
+// Виды записей файла импорта.
+const (
+	ImportTypeTeam           = "team"
+	ImportTypeUser           = "user"
+	ImportTypePullRequest    = "pull_request"
+	ImportTypeEscalation     = "escalation"
+	ImportTypeReviewSLA      = "review_sla"
+	ImportTypeCodeOwnerRule  = "code_owner_rule"
+	ImportTypeReviewerRule   = "reviewer_rule"
+	ImportTypeUnavailability = "unavailability"
+)
+
+// importTypes - виды записей в порядке выгрузки.
+var importTypes = []string{
+	ImportTypeTeam, ImportTypeUser, ImportTypePullRequest, ImportTypeEscalation,
+	ImportTypeReviewSLA, ImportTypeCodeOwnerRule, ImportTypeReviewerRule, ImportTypeUnavailability,
+}
+
+// ImportColumns - колонки CSV-файла импорта. В JSON Lines поля называются так же.
+// Списки (skills, assigned_reviewers, reviewers_assigned_at, owner_users, owner_teams) в CSV разделяются ';'.
+var ImportColumns = []string{
+	"type",
+	"team_name",
+	"user_id", "username", "is_active", "max_open_reviews", "skills",
+	"pull_request_id", "pull_request_name", "author_id", "status", "assigned_reviewers", "reviewers_assigned_at",
+	"created_at", "merged_at",
+	"escalated_at",
+	"review_sla_hours", "policy",
+	"position", "pattern", "owner_users", "owner_teams",
+	"rule_id", "kind", "author_user_id", "author_skill", "reviewer_user_id", "reviewer_skill", "description",
+	"unavailability_id", "starts_at", "ends_at", "reason", "released_at",
+}
+
+// importFields - поля, допустимые для каждого вида записи (кроме type).
+var importFields = map[string][]string{
+	ImportTypeTeam: {"team_name"},
+	ImportTypeUser: {"user_id", "username", "team_name", "is_active", "max_open_reviews", "skills"},
+	ImportTypePullRequest: {
+		"pull_request_id", "pull_request_name", "author_id", "status",
+		"assigned_reviewers", "reviewers_assigned_at", "created_at", "merged_at",
+	},
+	ImportTypeEscalation:    {"pull_request_id", "escalated_at"},
+	ImportTypeReviewSLA:     {"team_name", "review_sla_hours", "policy"},
+	ImportTypeCodeOwnerRule: {"position", "pattern", "owner_users", "owner_teams"},
+	ImportTypeReviewerRule: {
+		"rule_id", "kind", "author_user_id", "author_skill", "reviewer_user_id", "reviewer_skill",
+		"description", "created_at",
+	},
+	ImportTypeUnavailability: {"unavailability_id", "user_id", "starts_at", "ends_at", "reason", "released_at"},
+}
+
+type ImportRequest struct {
+	DryRun string // Сырое значение query-параметра dry_run
+}
+
+func (r ImportRequest) Validate() error {
+	var v validator
+	if r.DryRun != "" {
+		if _, err := strconv.ParseBool(r.DryRun); err != nil {
+			v.add("dry_run", "must be true or false")
+		}
+	}
+	return v.result()
+}
+
+// IsDryRun возвращает уже проверенный флаг dry_run.
+func (r ImportRequest) IsDryRun() bool {
+	dryRun, _ := strconv.ParseBool(r.DryRun)
+	return dryRun
+}
+
+// ImportRecord - запись файла импорта: команда, пользователь, PR, эскалация PR, SLA команды,
+// правило CODEOWNERS, правило подбора или период недоступности.
+// Заполняются только поля своего вида, остальные должны быть пустыми.
+type ImportRecord struct {
+	Type string `json:"type"`
+
+	TeamName string `json:"team_name,omitempty"`
+
+	UserID         string   `json:"user_id,omitempty"`
+	Username       string   `json:"username,omitempty"`
+	IsActive       *bool    `json:"is_active,omitempty"` // Не задан - пользователь активен
+	MaxOpenReviews *int     `json:"max_open_reviews,omitempty"`
+	Skills         []string `json:"skills,omitempty"`
+
+	PullRequestID     string   `json:"pull_request_id,omitempty"`
+	PullRequestName   string   `json:"pull_request_name,omitempty"`
+	AuthorID          string   `json:"author_id,omitempty"`
+	Status            string   `json:"status,omitempty"`
+	AssignedReviewers []string `json:"assigned_reviewers,omitempty"`
+	// Время назначения ревьюверов в порядке AssignedReviewers. Не задано - назначены при создании PR
+	ReviewersAssignedAt []time.Time `json:"reviewers_assigned_at,omitempty"`
+	CreatedAt           *time.Time  `json:"created_at,omitempty"` // У PR и правила подбора
+	MergedAt            *time.Time  `json:"merged_at,omitempty"`
+
+	EscalatedAt *time.Time `json:"escalated_at,omitempty"`
+
+	ReviewSLAHours *int   `json:"review_sla_hours,omitempty"`
+	Policy         string `json:"policy,omitempty"` // Не задана - NOTIFY
+
+	Position   *int     `json:"position,omitempty"`
+	Pattern    string   `json:"pattern,omitempty"`
+	OwnerUsers []string `json:"owner_users,omitempty"`
+	OwnerTeams []string `json:"owner_teams,omitempty"`
+
+	RuleID         string `json:"rule_id,omitempty"`
+	Kind           string `json:"kind,omitempty"`
+	AuthorUserID   string `json:"author_user_id,omitempty"` // Из пары author_user_id/author_skill задаётся ровно одно поле
+	AuthorSkill    string `json:"author_skill,omitempty"`
+	ReviewerUserID string `json:"reviewer_user_id,omitempty"` // Как и для автора, ровно одно поле
+	ReviewerSkill  string `json:"reviewer_skill,omitempty"`
+	Description    string `json:"description,omitempty"`
+
+	UnavailabilityID string     `json:"unavailability_id,omitempty"`
+	StartsAt         *time.Time `json:"starts_at,omitempty"`
+	EndsAt           *time.Time `json:"ends_at,omitempty"`
+	Reason           string     `json:"reason,omitempty"`
+	ReleasedAt       *time.Time `json:"released_at,omitempty"`
+}
+
+func (r ImportRecord) Validate() error {
+	var v validator
+
+	allowed, ok := importFields[r.Type]
+	if !ok {
+		v.add("type", "must be one of "+strings.Join(importTypes, ", "))
+		return v.result()
+	}
+	for _, field := range r.setFields() {
+		if !slices.Contains(allowed, field) {
+			v.add(field, "is not allowed for type "+r.Type)
+		}
+	}
+
+	switch r.Type {
+	case ImportTypeTeam:
+		v.name("team_name", r.TeamName, MaxNameLength)
+	case ImportTypeUser:
+		v.id("user_id", r.UserID)
+		v.name("username", r.Username, MaxNameLength)
+		if r.TeamName != "" { // Пустая команда - пользователь выведен из всех команд
+			v.name("team_name", r.TeamName, MaxNameLength)
+		}
+		if r.MaxOpenReviews != nil && *r.MaxOpenReviews < 0 {
+			v.add("max_open_reviews", "must be non-negative")
+		}
+		v.stringList("skills", r.Skills, MaxTagLength)
+	case ImportTypePullRequest:
+		v.id("pull_request_id", r.PullRequestID)
+		v.name("pull_request_name", r.PullRequestName, MaxTitleLength)
+		v.id("author_id", r.AuthorID)
+		v.reviewers("assigned_reviewers", r.AssignedReviewers, r.AuthorID)
+		if r.CreatedAt == nil {
+			v.add("created_at", "is required")
+		}
+		if r.ReviewersAssignedAt != nil {
+			if len(r.ReviewersAssignedAt) != len(r.AssignedReviewers) {
+				v.add("reviewers_assigned_at", "must have one timestamp per assigned reviewer")
+			}
+			for _, at := range r.ReviewersAssignedAt {
+				if r.CreatedAt != nil && at.Before(*r.CreatedAt) {
+					v.add("reviewers_assigned_at", "must not be before created_at")
+					break
+				}
+			}
+		}
+		switch domain.PullRequestStatus(r.Status) {
+		case domain.StatusOpen:
+			if r.MergedAt != nil {
+				v.add("merged_at", "must be empty for an OPEN pull request")
+			}
+		case domain.StatusMerged:
+			switch {
+			case r.MergedAt == nil:
+				v.add("merged_at", "is required for a MERGED pull request")
+			case r.CreatedAt != nil && r.MergedAt.Before(*r.CreatedAt):
+				v.add("merged_at", "must not be before created_at")
+			}
+		default:
+			v.add("status", fmt.Sprintf("must be %s or %s", domain.StatusOpen, domain.StatusMerged))
+		}
+	case ImportTypeEscalation:
+		v.id("pull_request_id", r.PullRequestID)
+		if r.EscalatedAt == nil {
+			v.add("escalated_at", "is required")
+		}
+	case ImportTypeReviewSLA:
+		v.name("team_name", r.TeamName, MaxNameLength)
+		if r.ReviewSLAHours == nil || *r.ReviewSLAHours < 1 || *r.ReviewSLAHours > MaxReviewSLAHours {
+			v.add("review_sla_hours", fmt.Sprintf("must be between 1 and %d", MaxReviewSLAHours))
+		}
+		if r.Policy != "" && r.Policy != EscalationPolicyNotify && r.Policy != EscalationPolicyReassign {
+			v.add("policy", fmt.Sprintf("must be %s or %s", EscalationPolicyNotify, EscalationPolicyReassign))
+		}
+	case ImportTypeCodeOwnerRule:
+		if r.Position == nil || *r.Position < 1 {
+			v.add("position", "must be a positive integer")
+		}
+		if r.Pattern == "" {
+			v.add("pattern", "is required")
+		} else if err := domain.ValidateOwnerPattern(r.Pattern); err != nil {
+			v.add("pattern", err.Error())
+		}
+		if len(r.OwnerUsers) > MaxListItems {
+			v.add("owner_users", fmt.Sprintf("must contain at most %d items", MaxListItems))
+		} else {
+			for i, id := range r.OwnerUsers {
+				v.id(fmt.Sprintf("owner_users[%d]", i), id)
+			}
+		}
+		v.stringList("owner_teams", r.OwnerTeams, MaxNameLength)
+	case ImportTypeReviewerRule:
+		v.id("rule_id", r.RuleID)
+		if r.Kind != RuleKindExclude && r.Kind != RuleKindRequire {
+			v.add("kind", fmt.Sprintf("must be %s or %s", RuleKindExclude, RuleKindRequire))
+		}
+		v.importSelector("author", r.AuthorUserID, r.AuthorSkill)
+		v.importSelector("reviewer", r.ReviewerUserID, r.ReviewerSkill)
+		v.text("description", r.Description, MaxDescriptionLength)
+		if r.CreatedAt == nil {
+			v.add("created_at", "is required")
+		}
+	case ImportTypeUnavailability:
+		v.id("unavailability_id", r.UnavailabilityID)
+		v.id("user_id", r.UserID)
+		if r.StartsAt == nil {
+			v.add("starts_at", "is required")
+		}
+		if r.EndsAt == nil {
+			v.add("ends_at", "is required")
+		} else if r.StartsAt != nil && !r.EndsAt.After(*r.StartsAt) {
+			v.add("ends_at", "must be after starts_at")
+		}
+		v.text("reason", r.Reason, MaxReasonLength)
+	}
+
+	return v.result()
+}
+
+// importSelector проверяет селектор правила подбора в плоской записи: задано ровно одно из полей
+// <prefix>_user_id и <prefix>_skill.
+func (v *validator) importSelector(prefix, userID, skill string) {
+	switch {
+	case userID == "" && skill == "":
+		v.add(prefix+"_user_id", fmt.Sprintf("must have %s_user_id or %s_skill", prefix, prefix))
+	case userID != "" && skill != "":
+		v.add(prefix+"_user_id", fmt.Sprintf("must have only one of %s_user_id and %s_skill", prefix, prefix))
+	case userID != "":
+		v.id(prefix+"_user_id", userID)
+	default:
+		v.name(prefix+"_skill", skill, MaxTagLength)
+	}
+}
+
+// setFields возвращает имена заполненных полей записи, кроме type.
+func (r ImportRecord) setFields() []string {
+	var fields []string
+	add := func(name string, set bool) {
+		if set {
+			fields = append(fields, name)
+		}
+	}
+	add("team_name", r.TeamName != "")
+	add("user_id", r.UserID != "")
+	add("username", r.Username != "")
+	add("is_active", r.IsActive != nil)
+	add("max_open_reviews", r.MaxOpenReviews != nil)
+	add("skills", r.Skills != nil)
+	add("pull_request_id", r.PullRequestID != "")
+	add("pull_request_name", r.PullRequestName != "")
+	add("author_id", r.AuthorID != "")
+	add("status", r.Status != "")
+	add("assigned_reviewers", r.AssignedReviewers != nil)
+	add("reviewers_assigned_at", r.ReviewersAssignedAt != nil)
+	add("created_at", r.CreatedAt != nil)
+	add("merged_at", r.MergedAt != nil)
+	add("escalated_at", r.EscalatedAt != nil)
+	add("review_sla_hours", r.ReviewSLAHours != nil)
+	add("policy", r.Policy != "")
+	add("position", r.Position != nil)
+	add("pattern", r.Pattern != "")
+	add("owner_users", r.OwnerUsers != nil)
+	add("owner_teams", r.OwnerTeams != nil)
+	add("rule_id", r.RuleID != "")
+	add("kind", r.Kind != "")
+	add("author_user_id", r.AuthorUserID != "")
+	add("author_skill", r.AuthorSkill != "")
+	add("reviewer_user_id", r.ReviewerUserID != "")
+	add("reviewer_skill", r.ReviewerSkill != "")
+	add("description", r.Description != "")
+	add("unavailability_id", r.UnavailabilityID != "")
+	add("starts_at", r.StartsAt != nil)
+	add("ends_at", r.EndsAt != nil)
+	add("reason", r.Reason != "")
+	add("released_at", r.ReleasedAt != nil)
+	return fields
+}
+
+// appendTo добавляет уже проверенную запись в batch.
+func (r ImportRecord) appendTo(batch *domain.ImportBatch, line int) {
+	switch r.Type {
+	case ImportTypeTeam:
+		batch.Teams = append(batch.Teams, domain.ImportTeam{Line: line, TeamName: r.TeamName})
+	case ImportTypeUser:
+		isActive := r.IsActive == nil || *r.IsActive
+		batch.Users = append(batch.Users, domain.ImportUser{Line: line, User: domain.User{
+			UserID:         r.UserID,
+			Username:       r.Username,
+			TeamName:       r.TeamName,
+			IsActive:       isActive,
+			MaxOpenReviews: r.MaxOpenReviews,
+			Skills:         r.Skills,
+		}})
+	case ImportTypePullRequest:
+		createdAt := r.CreatedAt.UTC()
+		pr := domain.PullRequest{
+			PullRequestID:     r.PullRequestID,
+			PullRequestName:   r.PullRequestName,
+			AuthorID:          r.AuthorID,
+			Status:            r.Status,
+			AssignedReviewers: r.AssignedReviewers,
+			CreatedAt:         &createdAt,
+		}
+		if r.MergedAt != nil {
+			mergedAt := r.MergedAt.UTC()
+			pr.MergedAt = &mergedAt
+		}
+		// В файлах без reviewers_assigned_at ревьюверы считаются назначенными при создании PR.
+		for i, id := range pr.AssignedReviewers {
+			assignedAt := createdAt
+			if r.ReviewersAssignedAt != nil {
+				assignedAt = r.ReviewersAssignedAt[i].UTC()
+			}
+			pr.MarkAssigned(id, assignedAt)
+		}
+		batch.PullRequests = append(batch.PullRequests, domain.ImportPullRequest{Line: line, PullRequest: pr})
+	case ImportTypeEscalation:
+		batch.Escalations = append(batch.Escalations, domain.ImportEscalation{
+			Line:          line,
+			PullRequestID: r.PullRequestID,
+			EscalatedAt:   r.EscalatedAt.UTC(),
+		})
+	case ImportTypeReviewSLA:
+		policy := r.Policy
+		if policy == "" {
+			policy = EscalationPolicyNotify
+		}
+		batch.ReviewSLAs = append(batch.ReviewSLAs, domain.ImportReviewSLA{Line: line, ReviewSLA: domain.ReviewSLA{
+			TeamName: r.TeamName,
+			SLA:      time.Duration(*r.ReviewSLAHours) * time.Hour,
+			Policy:   domain.EscalationPolicy(policy),
+		}})
+	case ImportTypeCodeOwnerRule:
+		rule := domain.CodeOwnerRule{Position: *r.Position, Pattern: r.Pattern, Users: r.OwnerUsers, Teams: r.OwnerTeams}
+		if rule.Users == nil {
+			rule.Users = []string{}
+		}
+		if rule.Teams == nil {
+			rule.Teams = []string{}
+		}
+		batch.CodeOwnerRules = append(batch.CodeOwnerRules, domain.ImportCodeOwnerRule{Line: line, CodeOwnerRule: rule})
+	case ImportTypeReviewerRule:
+		batch.ReviewerRules = append(batch.ReviewerRules, domain.ImportReviewerRule{Line: line, ReviewerRule: domain.ReviewerRule{
+			ID:          r.RuleID,
+			Kind:        domain.ReviewerRuleKind(r.Kind),
+			Author:      domain.UserSelector{UserID: r.AuthorUserID, Skill: r.AuthorSkill},
+			Reviewer:    domain.UserSelector{UserID: r.ReviewerUserID, Skill: r.ReviewerSkill},
+			Description: r.Description,
+			CreatedAt:   r.CreatedAt.UTC(),
+		}})
+	case ImportTypeUnavailability:
+		u := domain.Unavailability{
+			ID:       r.UnavailabilityID,
+			UserID:   r.UserID,
+			StartsAt: r.StartsAt.UTC(),
+			EndsAt:   r.EndsAt.UTC(),
+			Reason:   r.Reason,
+		}
+		if r.ReleasedAt != nil {
+			releasedAt := r.ReleasedAt.UTC()
+			u.ReleasedAt = &releasedAt
+		}
+		batch.Unavailability = append(batch.Unavailability, domain.ImportUnavailability{Line: line, Unavailability: u})
+	}
+}
+
+// ParseImport читает файл импорта и проверяет каждую запись.
+// Записи с ошибками в batch не попадают, их ошибки возвращаются с номерами строк.
+// error - только ошибка чтения r (например, *http.MaxBytesError).
+func ParseImport(format ImportFormat, r io.Reader) (*domain.ImportBatch, []domain.ImportRowError, error) {
+	p := importParser{batch: &domain.ImportBatch{}}
+
+	var err error
+	switch format {
+	case ImportJSONLines:
+		err = p.parseJSONLines(r)
+	case ImportCSV:
+		err = p.parseCSV(r)
+	default:
+		return nil, nil, fmt.Errorf("unsupported import format %q", format)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return p.batch, p.errs, nil
+}
+
+type importParser struct {
+	batch   *domain.ImportBatch
+	errs    []domain.ImportRowError
+	records int
+}
+
+func (p *importParser) reject(line int, field, reason string) {
+	p.errs = append(p.errs, domain.ImportRowError{Line: line, Field: field, Reason: reason})
+}
+
+// add проверяет запись. Возвращает false, если записей больше MaxImportRecords и чтение нужно прекратить.
+func (p *importParser) add(line int, rec ImportRecord) bool {
+	p.records++
+	if p.records > MaxImportRecords {
+		p.reject(line, "", fmt.Sprintf("file must contain at most %d records, the rest is skipped", MaxImportRecords))
+		return false
+	}
+
+	if err := rec.Validate(); err != nil {
+		var vErr *ValidationError
+		if !errors.As(err, &vErr) {
+			p.reject(line, "", err.Error())
+			return true
+		}
+		for _, f := range vErr.Fields {
+			p.reject(line, f.Field, f.Reason)
+		}
+		return true
+	}
+
+	rec.appendTo(p.batch, line)
+	return true
+}
+
+func (p *importParser) parseJSONLines(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), maxImportLineSize)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		raw := bytes.TrimSpace(scanner.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+
+		var rec ImportRecord
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&rec); err != nil {
+			p.reject(line, jsonErrorField(err), jsonErrorReason(err))
+			continue
+		}
+		if dec.More() {
+			p.reject(line, "", "line must contain a single JSON object")
+			continue
+		}
+		if !p.add(line, rec) {
+			return nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			p.reject(line+1, "", fmt.Sprintf("line must be at most %d bytes, the rest is skipped", maxImportLineSize))
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+func jsonErrorField(err error) string {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return typeErr.Field
+	}
+	if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		return strings.Trim(field, `"`)
+	}
+	return ""
+}
+
+func jsonErrorReason(err error) string {
+	var (
+		typeErr *json.UnmarshalTypeError
+		timeErr *time.ParseError
+	)
+	switch {
+	case errors.As(err, &typeErr):
+		return "has wrong type: got JSON " + typeErr.Value
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		return "is not allowed"
+	case errors.As(err, &timeErr):
+		return "timestamps must be in RFC 3339 format"
+	default:
+		return "invalid JSON: " + err.Error()
+	}
+}
+
+func (p *importParser) parseCSV(r io.Reader) error {
+	reader := csv.NewReader(r)
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if line, ok := csvErrorLine(err); ok {
+			p.reject(line, "", err.Error())
+			return nil
+		}
+		return err
+	}
+	columns := make([]string, len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		switch {
+		case !slices.Contains(ImportColumns, name):
+			p.reject(1, name, "unknown column")
+		case slices.Contains(columns[:i], name):
+			p.reject(1, name, "duplicated column")
+		}
+		columns[i] = name
+	}
+	if !slices.Contains(columns, "type") {
+		p.reject(1, "type", "column is required")
+	}
+	if len(p.errs) > 0 {
+		return nil
+	}
+
+	reader.ReuseRecord = true
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			if line, ok := csvErrorLine(err); ok {
+				p.reject(line, "", err.Error())
+				continue
+			}
+			return err
+		}
+
+		line, _ := reader.FieldPos(0)
+		rec, fieldErrs := csvRecord(columns, row)
+		if len(fieldErrs) > 0 {
+			for _, f := range fieldErrs {
+				p.reject(line, f.Field, f.Reason)
+			}
+			continue
+		}
+		if !p.add(line, rec) {
+			return nil
+		}
+	}
+}
+
+// csvErrorLine возвращает номер строки синтаксической ошибки CSV. false - ошибка чтения, а не формата.
+func csvErrorLine(err error) (int, bool) {
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return parseErr.Line, true
+	}
+	return 0, false
+}
+
+// csvRecord собирает запись из строки CSV. Пустая ячейка - поле не задано.
+func csvRecord(columns, row []string) (ImportRecord, []FieldError) {
+	var (
+		rec ImportRecord
+		v   validator
+	)
+	for i, value := range row {
+		if value == "" {
+			continue
+		}
+		switch columns[i] {
+		case "type":
+			rec.Type = value
+		case "team_name":
+			rec.TeamName = value
+		case "user_id":
+			rec.UserID = value
+		case "username":
+			rec.Username = value
+		case "is_active":
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				v.add("is_active", "must be true or false")
+			}
+			rec.IsActive = &b
+		case "max_open_reviews":
+			rec.MaxOpenReviews = v.integer("max_open_reviews", value)
+		case "skills":
+			rec.Skills = strings.Split(value, ";")
+		case "pull_request_id":
+			rec.PullRequestID = value
+		case "pull_request_name":
+			rec.PullRequestName = value
+		case "author_id":
+			rec.AuthorID = value
+		case "status":
+			rec.Status = value
+		case "assigned_reviewers":
+			rec.AssignedReviewers = strings.Split(value, ";")
+		case "reviewers_assigned_at":
+			rec.ReviewersAssignedAt = []time.Time{}
+			for _, raw := range strings.Split(value, ";") {
+				if t := v.timestamp("reviewers_assigned_at", raw); t != nil {
+					rec.ReviewersAssignedAt = append(rec.ReviewersAssignedAt, *t)
+				}
+			}
+		case "created_at":
+			rec.CreatedAt = v.timestamp("created_at", value)
+		case "merged_at":
+			rec.MergedAt = v.timestamp("merged_at", value)
+		case "escalated_at":
+			rec.EscalatedAt = v.timestamp("escalated_at", value)
+		case "review_sla_hours":
+			rec.ReviewSLAHours = v.integer("review_sla_hours", value)
+		case "policy":
+			rec.Policy = value
+		case "position":
+			rec.Position = v.integer("position", value)
+		case "pattern":
+			rec.Pattern = value
+		case "owner_users":
+			rec.OwnerUsers = strings.Split(value, ";")
+		case "owner_teams":
+			rec.OwnerTeams = strings.Split(value, ";")
+		case "rule_id":
+			rec.RuleID = value
+		case "kind":
+			rec.Kind = value
+		case "author_user_id":
+			rec.AuthorUserID = value
+		case "author_skill":
+			rec.AuthorSkill = value
+		case "reviewer_user_id":
+			rec.ReviewerUserID = value
+		case "reviewer_skill":
+			rec.ReviewerSkill = value
+		case "description":
+			rec.Description = value
+		case "unavailability_id":
+			rec.UnavailabilityID = value
+		case "starts_at":
+			rec.StartsAt = v.timestamp("starts_at", value)
+		case "ends_at":
+			rec.EndsAt = v.timestamp("ends_at", value)
+		case "reason":
+			rec.Reason = value
+		case "released_at":
+			rec.ReleasedAt = v.timestamp("released_at", value)
+		}
+	}
+	return rec, v.errs
+}
+
+// integer разбирает целое число из ячейки CSV.
+func (v *validator) integer(field, value string) *int {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		v.add(field, "must be an integer")
+		return nil
+	}
+	return &n
+}
+
+// timestamp разбирает время в формате RFC 3339.
+func (v *validator) timestamp(field, value string) *time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		v.add(field, "must be in RFC 3339 format")
+		return nil
+	}
+	return &t
+}
+
+type ImportRowErrorDto struct {
+	Line   int    `json:"line"`
+	Field  string `json:"field,omitempty"`
+	Reason string `json:"reason"`
+}
+
+type ImportCountsDto struct {
+	Teams          int `json:"teams"`
+	Users          int `json:"users"`
+	PullRequests   int `json:"pull_requests"`
+	Escalations    int `json:"escalations"`
+	ReviewSLAs     int `json:"review_slas"`
+	CodeOwnerRules int `json:"code_owner_rules"`
+	ReviewerRules  int `json:"reviewer_rules"`
+	Unavailability int `json:"unavailability"`
+}
+
+type ImportResponse struct {
+	DryRun bool `json:"dry_run"`
+	// Accepted - сколько записей прошло проверку и загружено (в dry-run - было бы загружено).
+	Accepted ImportCountsDto     `json:"accepted"`
+	Errors   []ImportRowErrorDto `json:"errors"`
+}
+
+// NewImportResponse собирает ответ из отчёта сервиса и ошибок разбора файла, упорядочивая ошибки по строкам.
+func NewImportResponse(report *domain.ImportReport, parseErrs []domain.ImportRowError) ImportResponse {
+	errs := make([]ImportRowErrorDto, 0, len(parseErrs)+len(report.Errors))
+	for _, e := range slices.Concat(parseErrs, report.Errors) {
+		errs = append(errs, ImportRowErrorDto{Line: e.Line, Field: e.Field, Reason: e.Reason})
+	}
+	slices.SortStableFunc(errs, func(a, b ImportRowErrorDto) int { return a.Line - b.Line })
+
+	return ImportResponse{
+		DryRun: report.DryRun,
+		Accepted: ImportCountsDto{
+			Teams:          report.Teams,
+			Users:          report.Users,
+			PullRequests:   report.PullRequests,
+			Escalations:    report.Escalations,
+			ReviewSLAs:     report.ReviewSLAs,
+			CodeOwnerRules: report.CodeOwnerRules,
+			ReviewerRules:  report.ReviewerRules,
+			Unavailability: report.Unavailability,
+		},
+		Errors: errs,
+	}
+}
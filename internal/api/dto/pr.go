@@ -1,15 +1,39 @@
 package dto
 
+import (
+	"fmt"
+	"slices"
+)
+
 //  /pullRequest/create
 
 type PullRequestCreateRequest struct {
 	PullRequestID   string `json:"pull_request_id" validate:"required"`
 	PullRequestName string `json:"pull_request_name" validate:"required"`
 	AuthorID        string `json:"author_id" validate:"required"`
+	// ChangedFiles - пути изменённых файлов, по ним ищутся владельцы в CODEOWNERS.
+	ChangedFiles []string `json:"changed_files,omitempty"`
+	// Labels - метки PR, сопоставляются с навыками участников команды.
+	Labels []string `json:"labels,omitempty"`
+	// RequestedReviewers - ревьюверы, выбранные автором; назначаются до автоматического подбора.
+	RequestedReviewers []string `json:"requested_reviewers,omitempty"`
+}
+
+func (r PullRequestCreateRequest) Validate() error {
+	var v validator
+	v.id("pull_request_id", r.PullRequestID)
+	v.name("pull_request_name", r.PullRequestName, MaxTitleLength)
+	v.id("author_id", r.AuthorID)
+	v.stringList("changed_files", r.ChangedFiles, MaxPathLength)
+	v.stringList("labels", r.Labels, MaxTagLength)
+	v.reviewers("requested_reviewers", r.RequestedReviewers, r.AuthorID)
+	return v.result()
 }
 
 type PullRequestCreateResponse struct {
 	PR PullRequestDto `json:"pr"`
+	// UnfilledReviewerSlots - скольких ревьюверов не удалось назначить (нет свободных кандидатов).
+	UnfilledReviewerSlots int `json:"unfilled_reviewer_slots"`
 }
 
 // /pullRequest/merge
@@ -18,6 +42,12 @@ type PullRequestMergeRequest struct {
 	PullRequestID string `json:"pull_request_id" validate:"required"`
 }
 
+func (r PullRequestMergeRequest) Validate() error {
+	var v validator
+	v.id("pull_request_id", r.PullRequestID)
+	return v.result()
+}
+
 type PullRequestMergeResponse struct {
 	PR PullRequestDto `json:"pr"`
 }
@@ -29,11 +59,80 @@ type PullRequestReassignRequest struct {
 	OldUserID     string `json:"old_user_id" validate:"required"`
 }
 
+func (r PullRequestReassignRequest) Validate() error {
+	var v validator
+	v.id("pull_request_id", r.PullRequestID)
+	v.id("old_user_id", r.OldUserID)
+	return v.result()
+}
+
 type PullRequestReassignResponse struct {
 	PR         PullRequestDto `json:"pr"`
 	ReplacedBy string         `json:"replaced_by"`
 }
 
+// /pullRequest/addReviewer, /pullRequest/removeReviewer
+
+type PullRequestReviewerRequest struct {
+	PullRequestID string `json:"pull_request_id"`
+	UserID        string `json:"user_id"`
+}
+
+func (r PullRequestReviewerRequest) Validate() error {
+	var v validator
+	v.id("pull_request_id", r.PullRequestID)
+	v.id("user_id", r.UserID)
+	return v.result()
+}
+
+type PullRequestResponse struct {
+	PR PullRequestDto `json:"pr"`
+}
+
+// /pullRequest/previewAssignment
+
+type PullRequestPreviewRequest struct {
+	AuthorID           string   `json:"author_id"`
+	ChangedFiles       []string `json:"changed_files,omitempty"`
+	Labels             []string `json:"labels,omitempty"`
+	RequestedReviewers []string `json:"requested_reviewers,omitempty"`
+	// ExcludedUsers - пользователи, которых не назначать автоматически.
+	ExcludedUsers []string `json:"excluded_users,omitempty"`
+}
+
+func (r PullRequestPreviewRequest) Validate() error {
+	var v validator
+	v.id("author_id", r.AuthorID)
+	v.stringList("changed_files", r.ChangedFiles, MaxPathLength)
+	v.stringList("labels", r.Labels, MaxTagLength)
+	v.reviewers("requested_reviewers", r.RequestedReviewers, r.AuthorID)
+	if len(r.ExcludedUsers) > MaxListItems {
+		v.add("excluded_users", fmt.Sprintf("must contain at most %d items", MaxListItems))
+		return v.result()
+	}
+	for i, id := range r.ExcludedUsers {
+		item := fmt.Sprintf("excluded_users[%d]", i)
+		v.id(item, id)
+		if id != "" && slices.Contains(r.RequestedReviewers, id) {
+			v.add(item, "must not be a requested reviewer")
+		}
+	}
+	return v.result()
+}
+
+type RejectedCandidateDto struct {
+	UserID string `json:"user_id"`
+	Reason string `json:"reason"`
+	Detail string `json:"detail,omitempty"`
+}
+
+type PullRequestPreviewResponse struct {
+	Reviewers []string `json:"reviewers"`
+	// UnfilledReviewerSlots - скольких ревьюверов не удалось бы назначить.
+	UnfilledReviewerSlots int                    `json:"unfilled_reviewer_slots"`
+	Rejected              []RejectedCandidateDto `json:"rejected"`
+}
+
 //
 
 type PullRequestDto struct {
@@ -0,0 +1,123 @@
+package dto
+
+import "fmt"
+
+// Виды правил подбора ревьюверов.
+const (
+	RuleKindExclude = "EXCLUDE"
+	RuleKindRequire = "REQUIRE"
+)
+
+// MaxDescriptionLength - максимальная длина описания правила.
+const MaxDescriptionLength = 255
+
+// UserSelectorDto - ровно одно из полей: user_id или skill.
+type UserSelectorDto struct {
+	UserID string `json:"user_id,omitempty"`
+	Skill  string `json:"skill,omitempty"`
+}
+
+// selector проверяет, что в селекторе задано ровно одно поле.
+func (v *validator) selector(field string, s UserSelectorDto) {
+	switch {
+	case s.UserID == "" && s.Skill == "":
+		v.add(field, "must have user_id or skill")
+	case s.UserID != "" && s.Skill != "":
+		v.add(field, "must have only one of user_id and skill")
+	case s.UserID != "":
+		v.id(field+".user_id", s.UserID)
+	default:
+		v.name(field+".skill", s.Skill, MaxTagLength)
+	}
+}
+
+// /rules/add
+
+type RuleAddRequest struct {
+	Kind        string          `json:"kind"`
+	Author      UserSelectorDto `json:"author"`
+	Reviewer    UserSelectorDto `json:"reviewer"`
+	Description string          `json:"description"`
+}
+
+func (r RuleAddRequest) Validate() error {
+	var v validator
+	if r.Kind != RuleKindExclude && r.Kind != RuleKindRequire {
+		v.add("kind", fmt.Sprintf("must be %s or %s", RuleKindExclude, RuleKindRequire))
+	}
+	v.selector("author", r.Author)
+	v.selector("reviewer", r.Reviewer)
+	v.text("description", r.Description, MaxDescriptionLength)
+	return v.result()
+}
+
+type ReviewerRuleDto struct {
+	RuleID      string          `json:"rule_id"`
+	Kind        string          `json:"kind"`
+	Author      UserSelectorDto `json:"author"`
+	Reviewer    UserSelectorDto `json:"reviewer"`
+	Description string          `json:"description"`
+}
+
+type RuleResponse struct {
+	Rule ReviewerRuleDto `json:"rule"`
+}
+
+// /rules/list
+
+type RuleListResponse struct {
+	Rules []ReviewerRuleDto `json:"rules"`
+}
+
+// /rules/delete
+
+type RuleDeleteRequest struct {
+	RuleID string `json:"rule_id"`
+}
+
+func (r RuleDeleteRequest) Validate() error {
+	var v validator
+	v.id("rule_id", r.RuleID)
+	return v.result()
+}
+
+// /rules/evaluate
+
+// RuleEvaluateRequest - либо pull_request_id (проверяются текущие ревьюверы PR),
+// либо author_id и reviewer_ids (проверяется предполагаемый набор).
+type RuleEvaluateRequest struct {
+	PullRequestID string   `json:"pull_request_id,omitempty"`
+	AuthorID      string   `json:"author_id,omitempty"`
+	ReviewerIDs   []string `json:"reviewer_ids,omitempty"`
+}
+
+func (r RuleEvaluateRequest) Validate() error {
+	var v validator
+	switch {
+	case r.PullRequestID != "" && (r.AuthorID != "" || r.ReviewerIDs != nil):
+		v.add("pull_request_id", "must not be combined with author_id and reviewer_ids")
+	case r.PullRequestID != "":
+		v.id("pull_request_id", r.PullRequestID)
+	default:
+		v.id("author_id", r.AuthorID)
+		if len(r.ReviewerIDs) > MaxListItems {
+			v.add("reviewer_ids", fmt.Sprintf("must contain at most %d items", MaxListItems))
+			break
+		}
+		for i, id := range r.ReviewerIDs {
+			v.id(fmt.Sprintf("reviewer_ids[%d]", i), id)
+		}
+	}
+	return v.result()
+}
+
+type RuleViolationDto struct {
+	RuleID     string `json:"rule_id"`
+	Kind       string `json:"kind"`
+	ReviewerID string `json:"reviewer_id,omitempty"`
+	Message    string `json:"message"`
+}
+
+type RuleEvaluateResponse struct {
+	Violations []RuleViolationDto `json:"violations"`
+}
@@ -0,0 +1,79 @@
+package dto
+
+import "fmt"
+
+// Политики эскалации просроченных PR.
+const (
+	EscalationPolicyNotify   = "NOTIFY"
+	EscalationPolicyReassign = "REASSIGN"
+)
+
+// MaxReviewSLAHours - максимальный SLA ревью (30 дней).
+const MaxReviewSLAHours = 720
+
+// /team/setReviewSLA
+
+// TeamSetReviewSLARequest - null в review_sla_hours снимает SLA. Пустая policy - NOTIFY.
+type TeamSetReviewSLARequest struct {
+	TeamName       string `json:"team_name"`
+	ReviewSLAHours *int   `json:"review_sla_hours"`
+	Policy         string `json:"policy,omitempty"`
+}
+
+func (r TeamSetReviewSLARequest) Validate() error {
+	var v validator
+	v.name("team_name", r.TeamName, MaxNameLength)
+	if r.ReviewSLAHours != nil && (*r.ReviewSLAHours < 1 || *r.ReviewSLAHours > MaxReviewSLAHours) {
+		v.add("review_sla_hours", fmt.Sprintf("must be between 1 and %d", MaxReviewSLAHours))
+	}
+	if r.Policy != "" && r.Policy != EscalationPolicyNotify && r.Policy != EscalationPolicyReassign {
+		v.add("policy", fmt.Sprintf("must be %s or %s", EscalationPolicyNotify, EscalationPolicyReassign))
+	}
+	return v.result()
+}
+
+// EscalationPolicy возвращает политику с учётом значения по умолчанию.
+func (r TeamSetReviewSLARequest) EscalationPolicy() string {
+	if r.Policy == "" {
+		return EscalationPolicyNotify
+	}
+	return r.Policy
+}
+
+// TeamReviewSLAResponse - review_sla_hours и policy отсутствуют, если SLA снят.
+type TeamReviewSLAResponse struct {
+	TeamName       string `json:"team_name"`
+	ReviewSLAHours *int   `json:"review_sla_hours,omitempty"`
+	Policy         string `json:"policy,omitempty"`
+}
+
+// /pullRequest/overdue
+
+type PullRequestOverdueRequest struct {
+	TeamName string
+}
+
+func (r PullRequestOverdueRequest) Validate() error {
+	var v validator
+	if r.TeamName != "" {
+		v.name("team_name", r.TeamName, MaxNameLength)
+	}
+	return v.result()
+}
+
+type OverduePullRequestDto struct {
+	PullRequestID     string   `json:"pull_request_id"`
+	PullRequestName   string   `json:"pull_request_name"`
+	AuthorID          string   `json:"author_id"`
+	TeamName          string   `json:"team_name"`
+	AssignedReviewers []string `json:"assigned_reviewers"`
+	CreatedAt         string   `json:"created_at"`
+	ReviewSLAHours    int      `json:"review_sla_hours"`
+	Policy            string   `json:"policy"`
+	OverdueMinutes    int      `json:"overdue_minutes"`
+	EscalatedAt       *string  `json:"escalated_at,omitempty"`
+}
+
+type PullRequestOverdueResponse struct {
+	PullRequests []OverduePullRequestDto `json:"pull_requests"`
+}
@@ -1,5 +1,11 @@
 package dto
 
+import (
+	"fmt"
+
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
 // /team/add
 
 type TeamAddRequest struct {
@@ -13,12 +19,47 @@ type TeamMemberDto struct {
 	IsActive bool   `json:"is_active"`
 }
 
-type TeamAddResponse struct {
+func (r TeamAddRequest) Validate() error {
+	var v validator
+	v.name("team_name", r.TeamName, MaxNameLength)
+	v.members(r.Members)
+	return v.result()
+}
+
+// members проверяет список участников команды.
+func (v *validator) members(members []TeamMemberDto) {
+	if members == nil {
+		v.add("members", "is required")
+	}
+
+	seen := make(map[string]struct{}, len(members))
+	for i, m := range members {
+		prefix := fmt.Sprintf("members[%d].", i)
+		v.id(prefix+"user_id", m.UserID)
+		v.name(prefix+"username", m.Username, MaxNameLength)
+		if _, dup := seen[m.UserID]; dup && m.UserID != "" {
+			v.add(prefix+"user_id", "is duplicated")
+		}
+		seen[m.UserID] = struct{}{}
+	}
+}
+
+type TeamResponse struct {
 	Team TeamDto `json:"team"`
 }
 
 // /team/get
 
+type TeamGetRequest struct {
+	TeamName string
+}
+
+func (r TeamGetRequest) Validate() error {
+	var v validator
+	v.name("team_name", r.TeamName, MaxNameLength)
+	return v.result()
+}
+
 type TeamGetResponse struct {
 	TeamName string          `json:"team_name"`
 	Members  []TeamMemberDto `json:"members"`
@@ -28,3 +69,141 @@ type TeamDto struct {
 	TeamName string          `json:"team_name"`
 	Members  []TeamMemberDto `json:"members"`
 }
+
+// /team/deactivate
+
+type TeamDeactivateRequest struct {
+	TeamName string
+}
+
+func (r TeamDeactivateRequest) Validate() error {
+	var v validator
+	v.name("team_name", r.TeamName, MaxNameLength)
+	return v.result()
+}
+
+// /team/addMembers
+
+type TeamAddMembersRequest struct {
+	TeamName string          `json:"team_name"`
+	Members  []TeamMemberDto `json:"members"`
+}
+
+func (r TeamAddMembersRequest) Validate() error {
+	var v validator
+	v.name("team_name", r.TeamName, MaxNameLength)
+	v.members(r.Members)
+	if r.Members != nil && len(r.Members) == 0 {
+		v.add("members", "must not be empty")
+	}
+	return v.result()
+}
+
+// /team/removeMembers
+
+type TeamRemoveMembersRequest struct {
+	TeamName        string   `json:"team_name"`
+	UserIDs         []string `json:"user_ids"`
+	ReassignReviews bool     `json:"reassign_reviews"`
+}
+
+func (r TeamRemoveMembersRequest) Validate() error {
+	var v validator
+	v.name("team_name", r.TeamName, MaxNameLength)
+	if len(r.UserIDs) == 0 {
+		v.add("user_ids", "must not be empty")
+	}
+
+	seen := make(map[string]struct{}, len(r.UserIDs))
+	for i, id := range r.UserIDs {
+		field := fmt.Sprintf("user_ids[%d]", i)
+		v.id(field, id)
+		if _, dup := seen[id]; dup && id != "" {
+			v.add(field, "is duplicated")
+		}
+		seen[id] = struct{}{}
+	}
+	return v.result()
+}
+
+// /team/rename
+
+type TeamRenameRequest struct {
+	TeamName    string `json:"team_name"`
+	NewTeamName string `json:"new_team_name"`
+}
+
+func (r TeamRenameRequest) Validate() error {
+	var v validator
+	v.name("team_name", r.TeamName, MaxNameLength)
+	v.name("new_team_name", r.NewTeamName, MaxNameLength)
+	if r.NewTeamName != "" && r.NewTeamName == r.TeamName {
+		v.add("new_team_name", "must differ from team_name")
+	}
+	return v.result()
+}
+
+// /team/delete
+
+type TeamDeleteRequest struct {
+	TeamName   string `json:"team_name"`
+	Strategy   string `json:"strategy"`
+	TargetTeam string `json:"target_team"`
+	Force      bool   `json:"force"`
+}
+
+func (r TeamDeleteRequest) Validate() error {
+	var v validator
+	v.name("team_name", r.TeamName, MaxNameLength)
+	switch domain.TeamDeleteStrategy(r.Strategy) {
+	case domain.TeamDeleteMove:
+		v.name("target_team", r.TargetTeam, MaxNameLength)
+		if r.TargetTeam != "" && r.TargetTeam == r.TeamName {
+			v.add("target_team", "must differ from team_name")
+		}
+	case domain.TeamDeleteDeactivate:
+		if r.TargetTeam != "" {
+			v.add("target_team", "is allowed only with strategy move")
+		}
+	case "":
+		v.add("strategy", "is required")
+	default:
+		v.add("strategy", "must be one of: move, deactivate")
+	}
+	return v.result()
+}
+
+// /team/list
+
+// TeamListRequest - параметры query-строки как есть, числа разбираются после проверки.
+type TeamListRequest struct {
+	Prefix string
+	Limit  string
+	Offset string
+}
+
+func (r TeamListRequest) Validate() error {
+	var v validator
+	v.text("prefix", r.Prefix, MaxNameLength)
+	v.limit("limit", r.Limit)
+	v.offset("offset", r.Offset)
+	return v.result()
+}
+
+// Page возвращает limit и offset с учётом значений по умолчанию. Вызывать после Validate.
+func (r TeamListRequest) Page() (limit, offset int) {
+	return parsePage(r.Limit, r.Offset)
+}
+
+type TeamSummaryDto struct {
+	TeamName          string `json:"team_name"`
+	MemberCount       int    `json:"member_count"`
+	ActiveMemberCount int    `json:"active_member_count"`
+}
+
+type TeamListResponse struct {
+	Teams  []TeamSummaryDto `json:"teams"`
+	Total  int              `json:"total"`
+	Limit  int              `json:"limit"`
+	Offset int              `json:"offset"`
+}
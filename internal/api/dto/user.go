@@ -1,5 +1,12 @@
 package dto
 
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"time"
+)
+
 // /users/setIsActive
 
 type UserSetIsActiveRequest struct {
@@ -7,19 +14,87 @@ type UserSetIsActiveRequest struct {
 	IsActive bool   `json:"is_active"`
 }
 
+func (r UserSetIsActiveRequest) Validate() error {
+	var v validator
+	v.id("user_id", r.UserID)
+	return v.result()
+}
+
 type UserResponse struct {
 	User UserDto `json:"user"`
 }
 
 type UserDto struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	TeamName string `json:"team_name"`
-	IsActive bool   `json:"is_active"`
+	UserID         string   `json:"user_id"`
+	Username       string   `json:"username"`
+	TeamName       string   `json:"team_name"`
+	IsActive       bool     `json:"is_active"`
+	MaxOpenReviews *int     `json:"max_open_reviews,omitempty"`
+	Skills         []string `json:"skills,omitempty"`
+}
+
+// /users/setMaxOpenReviews
+
+// UserSetMaxOpenReviewsRequest - null в max_open_reviews снимает лимит.
+type UserSetMaxOpenReviewsRequest struct {
+	UserID         string `json:"user_id"`
+	MaxOpenReviews *int   `json:"max_open_reviews"`
+}
+
+func (r UserSetMaxOpenReviewsRequest) Validate() error {
+	var v validator
+	v.id("user_id", r.UserID)
+	if r.MaxOpenReviews != nil && *r.MaxOpenReviews < 0 {
+		v.add("max_open_reviews", "must be a non-negative integer or null")
+	}
+	return v.result()
+}
+
+// /users/setSkills
+
+// UserSetSkillsRequest - skills заменяет навыки целиком, пустой список их очищает.
+type UserSetSkillsRequest struct {
+	UserID string   `json:"user_id"`
+	Skills []string `json:"skills"`
+}
+
+func (r UserSetSkillsRequest) Validate() error {
+	var v validator
+	v.id("user_id", r.UserID)
+	if r.Skills == nil {
+		v.add("skills", "is required")
+	}
+	v.stringList("skills", r.Skills, MaxTagLength)
+	return v.result()
+}
+
+// /users/moveTeam
+
+type UserMoveTeamRequest struct {
+	UserID          string `json:"user_id"`
+	TeamName        string `json:"team_name"`
+	ReassignReviews bool   `json:"reassign_reviews"`
+}
+
+func (r UserMoveTeamRequest) Validate() error {
+	var v validator
+	v.id("user_id", r.UserID)
+	v.name("team_name", r.TeamName, MaxNameLength)
+	return v.result()
 }
 
 //  /users/getReview
 
+type UserGetReviewRequest struct {
+	UserID string
+}
+
+func (r UserGetReviewRequest) Validate() error {
+	var v validator
+	v.id("user_id", r.UserID)
+	return v.result()
+}
+
 type UserGetReviewResponse struct {
 	UserID       string                `json:"user_id"`
 	PullRequests []PullRequestShortDto `json:"pull_requests"`
@@ -31,3 +106,163 @@ type PullRequestShortDto struct {
 	AuthorID        string `json:"author_id"`
 	Status          string `json:"status"`
 }
+
+// /users/get
+
+type UserGetRequest struct {
+	UserID string
+}
+
+func (r UserGetRequest) Validate() error {
+	var v validator
+	v.id("user_id", r.UserID)
+	return v.result()
+}
+
+type UserSummaryDto struct {
+	UserDto
+	OpenReviewCount int `json:"open_review_count"`
+}
+
+type UserSummaryResponse struct {
+	User UserSummaryDto `json:"user"`
+}
+
+// /users/list
+
+// UserListRequest - параметры query-строки как есть, значения разбираются после проверки.
+type UserListRequest struct {
+	TeamName string
+	IsActive string
+	Username string
+	Cursor   string
+	Limit    string
+}
+
+func (r UserListRequest) Validate() error {
+	var v validator
+	if r.TeamName != "" {
+		v.name("team_name", r.TeamName, MaxNameLength)
+	}
+	if r.IsActive != "" {
+		if _, err := strconv.ParseBool(r.IsActive); err != nil {
+			v.add("is_active", "must be true or false")
+		}
+	}
+	v.text("username", r.Username, MaxNameLength)
+	if r.Cursor != "" {
+		if _, err := decodeUserCursor(r.Cursor); err != nil {
+			v.add("cursor", "is malformed")
+		}
+	}
+	v.limit("limit", r.Limit)
+	return v.result()
+}
+
+// ActiveFlag возвращает фильтр по активности, nil - без фильтра. Вызывать после Validate.
+func (r UserListRequest) ActiveFlag() *bool {
+	if r.IsActive == "" {
+		return nil
+	}
+	b, _ := strconv.ParseBool(r.IsActive)
+	return &b
+}
+
+// AfterUserID возвращает user_id из курсора. Вызывать после Validate.
+func (r UserListRequest) AfterUserID() string {
+	id, _ := decodeUserCursor(r.Cursor)
+	return id
+}
+
+// PageLimit возвращает размер страницы с учётом значения по умолчанию. Вызывать после Validate.
+func (r UserListRequest) PageLimit() int {
+	limit, _ := parsePage(r.Limit, "")
+	return limit
+}
+
+// EncodeUserCursor упаковывает последний user_id страницы в непрозрачный курсор.
+func EncodeUserCursor(userID string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(userID))
+}
+
+func decodeUserCursor(cursor string) (string, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", err
+	}
+	if !idPattern.Match(b) || len(b) > MaxIDLength {
+		return "", fmt.Errorf("invalid user_id in cursor")
+	}
+	return string(b), nil
+}
+
+type UserListResponse struct {
+	Users      []UserSummaryDto `json:"users"`
+	NextCursor string           `json:"next_cursor,omitempty"`
+}
+
+// /users/addUnavailability
+
+type UserAddUnavailabilityRequest struct {
+	UserID   string    `json:"user_id"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	Reason   string    `json:"reason"`
+}
+
+func (r UserAddUnavailabilityRequest) Validate() error {
+	var v validator
+	v.id("user_id", r.UserID)
+	if r.StartsAt.IsZero() {
+		v.add("starts_at", "is required")
+	}
+	if r.EndsAt.IsZero() {
+		v.add("ends_at", "is required")
+	} else if !r.StartsAt.IsZero() && !r.EndsAt.After(r.StartsAt) {
+		v.add("ends_at", "must be after starts_at")
+	}
+	v.text("reason", r.Reason, MaxReasonLength)
+	return v.result()
+}
+
+type UnavailabilityDto struct {
+	UnavailabilityID string  `json:"unavailability_id"`
+	UserID           string  `json:"user_id"`
+	StartsAt         string  `json:"starts_at"`
+	EndsAt           string  `json:"ends_at"`
+	Reason           string  `json:"reason"`
+	ReleasedAt       *string `json:"released_at,omitempty"`
+}
+
+type UnavailabilityResponse struct {
+	Unavailability UnavailabilityDto `json:"unavailability"`
+}
+
+// /users/unavailability
+
+type UserUnavailabilityRequest struct {
+	UserID string
+}
+
+func (r UserUnavailabilityRequest) Validate() error {
+	var v validator
+	v.id("user_id", r.UserID)
+	return v.result()
+}
+
+type UserUnavailabilityResponse struct {
+	UserID         string              `json:"user_id"`
+	Unavailability []UnavailabilityDto `json:"unavailability"`
+}
+
+// /users/removeUnavailability
+
+type UserRemoveUnavailabilityRequest struct {
+	UnavailabilityID string `json:"unavailability_id"`
+}
+
+func (r UserRemoveUnavailabilityRequest) Validate() error {
+	var v validator
+	v.id("unavailability_id", r.UnavailabilityID)
+	return v.result()
+}
@@ -0,0 +1,173 @@
+package dto
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// MaxIDLength - максимальная длина идентификаторов (user_id, pull_request_id).
+	MaxIDLength = 64
+	// MaxNameLength - максимальная длина имён (team_name, username).
+	MaxNameLength = 100
+	// MaxTitleLength - максимальная длина названия PR.
+	MaxTitleLength = 255
+	// MaxReasonLength - максимальная длина причины недоступности.
+	MaxReasonLength = 255
+	// MaxTagLength - максимальная длина навыка пользователя или метки PR.
+	MaxTagLength = 50
+	// MaxPathLength - максимальная длина пути изменённого файла.
+	MaxPathLength = 1024
+	// MaxRequestedReviewers - сколько ревьюверов автор может запросить при создании PR.
+	MaxRequestedReviewers = 2
+	// MaxListItems - сколько элементов можно передать в списках навыков, меток и путей.
+	MaxListItems = 100
+
+	// DefaultPageLimit - размер страницы списков, если limit не передан.
+	DefaultPageLimit = 20
+	// MaxPageLimit - максимальный размер страницы списков.
+	MaxPageLimit = 100
+)
+
+// idPattern - идентификатор: латиница, цифры, '.', '_', '-', начинается с буквы или цифры.
+var idPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// FieldError описывает ошибку валидации одного поля запроса.
+type FieldError struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+// ValidationError - все ошибки валидации запроса.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		parts = append(parts, f.Field+": "+f.Reason)
+	}
+	return "validation failed: " + strings.Join(parts, "; ")
+}
+
+// validator накапливает ошибки по полям.
+type validator struct {
+	errs []FieldError
+}
+
+func (v *validator) add(field, reason string) {
+	v.errs = append(v.errs, FieldError{Field: field, Reason: reason})
+}
+
+// id проверяет обязательный идентификатор.
+func (v *validator) id(field, value string) {
+	switch {
+	case value == "":
+		v.add(field, "is required")
+	case len(value) > MaxIDLength:
+		v.add(field, fmt.Sprintf("must be at most %d characters", MaxIDLength))
+	case !idPattern.MatchString(value):
+		v.add(field, "must contain only latin letters, digits, '.', '_' or '-' and start with a letter or digit")
+	}
+}
+
+// name проверяет обязательное человекочитаемое имя.
+func (v *validator) name(field, value string, maxLen int) {
+	switch {
+	case strings.TrimSpace(value) == "":
+		v.add(field, "is required")
+	case utf8.RuneCountInString(value) > maxLen:
+		v.add(field, fmt.Sprintf("must be at most %d characters", maxLen))
+	case strings.TrimSpace(value) != value:
+		v.add(field, "must not have leading or trailing spaces")
+	case strings.IndexFunc(value, unicode.IsControl) >= 0:
+		v.add(field, "must not contain control characters")
+	}
+}
+
+// text проверяет необязательный свободный текст (фильтры поиска, комментарии).
+func (v *validator) text(field, value string, maxLen int) {
+	switch {
+	case utf8.RuneCountInString(value) > maxLen:
+		v.add(field, fmt.Sprintf("must be at most %d characters", maxLen))
+	case strings.IndexFunc(value, unicode.IsControl) >= 0:
+		v.add(field, "must not contain control characters")
+	}
+}
+
+// stringList проверяет необязательный список строк: число элементов и каждый элемент как name.
+func (v *validator) stringList(field string, values []string, maxLen int) {
+	if len(values) > MaxListItems {
+		v.add(field, fmt.Sprintf("must contain at most %d items", MaxListItems))
+		return
+	}
+	for i, value := range values {
+		v.name(fmt.Sprintf("%s[%d]", field, i), value, maxLen)
+	}
+}
+
+// reviewers проверяет необязательный список запрошенных ревьюверов:
+// не больше MaxRequestedReviewers, без повторов и без автора PR.
+func (v *validator) reviewers(field string, ids []string, authorID string) {
+	if len(ids) > MaxRequestedReviewers {
+		v.add(field, fmt.Sprintf("must contain at most %d items", MaxRequestedReviewers))
+		return
+	}
+	seen := make(map[string]struct{}, len(ids))
+	for i, id := range ids {
+		item := fmt.Sprintf("%s[%d]", field, i)
+		v.id(item, id)
+		if _, dup := seen[id]; dup && id != "" {
+			v.add(item, "is duplicated")
+		}
+		if id != "" && id == authorID {
+			v.add(item, "must not be the author")
+		}
+		seen[id] = struct{}{}
+	}
+}
+
+// limit проверяет необязательный параметр limit из query-строки.
+func (v *validator) limit(field, raw string) {
+	if raw == "" {
+		return
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 1 || n > MaxPageLimit {
+		v.add(field, fmt.Sprintf("must be an integer between 1 and %d", MaxPageLimit))
+	}
+}
+
+// offset проверяет необязательный параметр offset из query-строки.
+func (v *validator) offset(field, raw string) {
+	if raw == "" {
+		return
+	}
+	if n, err := strconv.Atoi(raw); err != nil || n < 0 {
+		v.add(field, "must be a non-negative integer")
+	}
+}
+
+// parsePage переводит уже проверенные limit и offset в числа, подставляя значения по умолчанию.
+func parsePage(rawLimit, rawOffset string) (limit, offset int) {
+	limit = DefaultPageLimit
+	if n, err := strconv.Atoi(rawLimit); err == nil {
+		limit = n
+	}
+	if n, err := strconv.Atoi(rawOffset); err == nil {
+		offset = n
+	}
+	return limit, offset
+}
+
+func (v *validator) result() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return &ValidationError{Fields: v.errs}
+}
@@ -0,0 +1,644 @@
+package dto_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"pr-reviewer-assigment-service/internal/api/dto"
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+func fieldErrors(t *testing.T, err error) map[string]string {
+	t.Helper()
+
+	if err == nil {
+		return nil
+	}
+	var vErr *dto.ValidationError
+	if !errors.As(err, &vErr) {
+		t.Fatalf("expected *dto.ValidationError, got %T: %v", err, err)
+	}
+	fields := make(map[string]string, len(vErr.Fields))
+	for _, f := range vErr.Fields {
+		fields[f.Field] = f.Reason
+	}
+	return fields
+}
+
+func intPtr(v int) *int { return &v }
+
+func TestValidate(t *testing.T) {
+	created := time.Date(2025, 9, 1, 9, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name       string
+		req        interface{ Validate() error }
+		wantFields []string
+	}{
+		{
+			name: "team add ok",
+			req: dto.TeamAddRequest{
+				TeamName: "backend",
+				Members:  []dto.TeamMemberDto{{UserID: "u1", Username: "Alice", IsActive: true}},
+			},
+		},
+		{
+			name:       "team add missing members",
+			req:        dto.TeamAddRequest{TeamName: "backend"},
+			wantFields: []string{"members"},
+		},
+		{
+			name: "team add bad member",
+			req: dto.TeamAddRequest{
+				TeamName: " backend",
+				Members: []dto.TeamMemberDto{
+					{UserID: "u 1", Username: ""},
+					{UserID: "u2", Username: "Bob"},
+					{UserID: "u2", Username: "Bob again"},
+				},
+			},
+			wantFields: []string{"team_name", "members[0].user_id", "members[0].username", "members[2].user_id"},
+		},
+		{
+			name:       "team get empty",
+			req:        dto.TeamGetRequest{},
+			wantFields: []string{"team_name"},
+		},
+		{
+			name:       "team deactivate too long",
+			req:        dto.TeamDeactivateRequest{TeamName: strings.Repeat("x", dto.MaxNameLength+1)},
+			wantFields: []string{"team_name"},
+		},
+		{
+			name:       "team add members empty",
+			req:        dto.TeamAddMembersRequest{TeamName: "backend", Members: []dto.TeamMemberDto{}},
+			wantFields: []string{"members"},
+		},
+		{
+			name:       "team remove members duplicated",
+			req:        dto.TeamRemoveMembersRequest{TeamName: "backend", UserIDs: []string{"u1", "u1", ""}},
+			wantFields: []string{"user_ids[1]", "user_ids[2]"},
+		},
+		{
+			name:       "team remove members missing ids",
+			req:        dto.TeamRemoveMembersRequest{TeamName: "backend"},
+			wantFields: []string{"user_ids"},
+		},
+		{
+			name:       "move team missing team",
+			req:        dto.UserMoveTeamRequest{UserID: "u1"},
+			wantFields: []string{"team_name"},
+		},
+		{
+			name:       "team rename same name",
+			req:        dto.TeamRenameRequest{TeamName: "backend", NewTeamName: "backend"},
+			wantFields: []string{"new_team_name"},
+		},
+		{
+			name:       "team delete move without target",
+			req:        dto.TeamDeleteRequest{TeamName: "backend", Strategy: "move"},
+			wantFields: []string{"target_team"},
+		},
+		{
+			name:       "team delete unknown strategy",
+			req:        dto.TeamDeleteRequest{TeamName: "backend", Strategy: "drop"},
+			wantFields: []string{"strategy"},
+		},
+		{
+			name: "team list defaults ok",
+			req:  dto.TeamListRequest{},
+		},
+		{
+			name:       "team list bad page",
+			req:        dto.TeamListRequest{Prefix: "back\x00", Limit: "101", Offset: "-1"},
+			wantFields: []string{"prefix", "limit", "offset"},
+		},
+		{
+			name: "users list ok",
+			req:  dto.UserListRequest{TeamName: "backend", IsActive: "true", Username: "ali", Cursor: dto.EncodeUserCursor("u1"), Limit: "10"},
+		},
+		{
+			name:       "users list bad filters",
+			req:        dto.UserListRequest{TeamName: " backend", IsActive: "yes", Cursor: "!!", Limit: "0"},
+			wantFields: []string{"team_name", "is_active", "cursor", "limit"},
+		},
+		{
+			name:       "users get empty",
+			req:        dto.UserGetRequest{},
+			wantFields: []string{"user_id"},
+		},
+		{
+			name:       "add unavailability reversed period",
+			req:        dto.UserAddUnavailabilityRequest{UserID: "u1", StartsAt: time.Date(2025, 7, 15, 0, 0, 0, 0, time.UTC), EndsAt: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)},
+			wantFields: []string{"ends_at"},
+		},
+		{
+			name:       "add unavailability missing dates",
+			req:        dto.UserAddUnavailabilityRequest{UserID: "u1", Reason: "sick\n"},
+			wantFields: []string{"starts_at", "ends_at", "reason"},
+		},
+		{
+			name: "set max open reviews null ok",
+			req:  dto.UserSetMaxOpenReviewsRequest{UserID: "u1"},
+		},
+		{
+			name:       "set max open reviews negative",
+			req:        dto.UserSetMaxOpenReviewsRequest{UserID: "u1", MaxOpenReviews: intPtr(-1)},
+			wantFields: []string{"max_open_reviews"},
+		},
+		{
+			name: "set skills empty ok",
+			req:  dto.UserSetSkillsRequest{UserID: "u1", Skills: []string{}},
+		},
+		{
+			name:       "set skills missing",
+			req:        dto.UserSetSkillsRequest{UserID: "u1"},
+			wantFields: []string{"skills"},
+		},
+		{
+			name:       "set skills bad tag",
+			req:        dto.UserSetSkillsRequest{UserID: "u1", Skills: []string{"go", strings.Repeat("x", dto.MaxTagLength+1)}},
+			wantFields: []string{"skills[1]"},
+		},
+		{
+			name: "team delete deactivate ok",
+			req:  dto.TeamDeleteRequest{TeamName: "backend", Strategy: "deactivate", Force: true},
+		},
+		{
+			name:       "set is active bad id",
+			req:        dto.UserSetIsActiveRequest{UserID: "-u1"},
+			wantFields: []string{"user_id"},
+		},
+		{
+			name:       "get review too long id",
+			req:        dto.UserGetReviewRequest{UserID: strings.Repeat("u", dto.MaxIDLength+1)},
+			wantFields: []string{"user_id"},
+		},
+		{
+			name: "pr create ok",
+			req:  dto.PullRequestCreateRequest{PullRequestID: "pr-1001", PullRequestName: "Add search", AuthorID: "u1"},
+		},
+		{
+			name:       "pr create missing fields",
+			req:        dto.PullRequestCreateRequest{PullRequestID: "pr/1"},
+			wantFields: []string{"pull_request_id", "pull_request_name", "author_id"},
+		},
+		{
+			name: "pr create with hints ok",
+			req: dto.PullRequestCreateRequest{
+				PullRequestID: "pr-1001", PullRequestName: "Add search", AuthorID: "u1",
+				ChangedFiles: []string{"internal/search/index.go"}, Labels: []string{"go"},
+			},
+		},
+		{
+			name: "pr create bad hints",
+			req: dto.PullRequestCreateRequest{
+				PullRequestID: "pr-1001", PullRequestName: "Add search", AuthorID: "u1",
+				ChangedFiles: []string{""}, Labels: make([]string, dto.MaxListItems+1),
+			},
+			wantFields: []string{"changed_files[0]", "labels"},
+		},
+		{
+			name: "pr create requested reviewers",
+			req: dto.PullRequestCreateRequest{
+				PullRequestID: "pr-1001", PullRequestName: "Add search", AuthorID: "u1",
+				RequestedReviewers: []string{"u1", "u2", "u2"},
+			},
+			wantFields: []string{"requested_reviewers"},
+		},
+		{
+			name: "pr create requested duplicate",
+			req: dto.PullRequestCreateRequest{
+				PullRequestID: "pr-1001", PullRequestName: "Add search", AuthorID: "u1",
+				RequestedReviewers: []string{"u2", "u2"},
+			},
+			wantFields: []string{"requested_reviewers[1]"},
+		},
+		{
+			name: "pr create requested author",
+			req: dto.PullRequestCreateRequest{
+				PullRequestID: "pr-1001", PullRequestName: "Add search", AuthorID: "u1",
+				RequestedReviewers: []string{"u1"},
+			},
+			wantFields: []string{"requested_reviewers[0]"},
+		},
+		{
+			name:       "pr reviewer missing user",
+			req:        dto.PullRequestReviewerRequest{PullRequestID: "pr-1001"},
+			wantFields: []string{"user_id"},
+		},
+		{
+			name:       "pr merge missing id",
+			req:        dto.PullRequestMergeRequest{},
+			wantFields: []string{"pull_request_id"},
+		},
+		{
+			name:       "pr reassign missing old user",
+			req:        dto.PullRequestReassignRequest{PullRequestID: "pr-1"},
+			wantFields: []string{"old_user_id"},
+		},
+		{
+			name: "pr preview excluded requested reviewer",
+			req: dto.PullRequestPreviewRequest{
+				AuthorID:           "u1",
+				RequestedReviewers: []string{"u2"},
+				ExcludedUsers:      []string{"u3", "u2", ""},
+			},
+			wantFields: []string{"excluded_users[1]", "excluded_users[2]"},
+		},
+		{
+			name:       "team review sla out of range",
+			req:        dto.TeamSetReviewSLARequest{TeamName: "backend", ReviewSLAHours: intPtr(0), Policy: "PAGE"},
+			wantFields: []string{"review_sla_hours", "policy"},
+		},
+		{
+			name:       "overdue long team name",
+			req:        dto.PullRequestOverdueRequest{TeamName: strings.Repeat("t", dto.MaxNameLength+1)},
+			wantFields: []string{"team_name"},
+		},
+		{
+			name: "rule add bad kind and selectors",
+			req: dto.RuleAddRequest{
+				Kind:     "ALLOW",
+				Author:   dto.UserSelectorDto{},
+				Reviewer: dto.UserSelectorDto{UserID: "u2", Skill: "senior"},
+			},
+			wantFields: []string{"kind", "author", "reviewer"},
+		},
+		{
+			name: "rule add long skill",
+			req: dto.RuleAddRequest{
+				Kind:     dto.RuleKindRequire,
+				Author:   dto.UserSelectorDto{Skill: "junior"},
+				Reviewer: dto.UserSelectorDto{Skill: strings.Repeat("s", dto.MaxTagLength+1)},
+			},
+			wantFields: []string{"reviewer.skill"},
+		},
+		{
+			name:       "rule evaluate pr combined with author",
+			req:        dto.RuleEvaluateRequest{PullRequestID: "pr-1", AuthorID: "u1"},
+			wantFields: []string{"pull_request_id"},
+		},
+		{
+			name:       "rule evaluate empty reviewer id",
+			req:        dto.RuleEvaluateRequest{AuthorID: "u1", ReviewerIDs: []string{"u2", ""}},
+			wantFields: []string{"reviewer_ids[1]"},
+		},
+		{
+			name:       "import bad dry_run",
+			req:        dto.ImportRequest{DryRun: "maybe"},
+			wantFields: []string{"dry_run"},
+		},
+		{
+			name:       "import unknown type",
+			req:        dto.ImportRecord{Type: "project", TeamName: "backend"},
+			wantFields: []string{"type"},
+		},
+		{
+			name:       "import team with user fields",
+			req:        dto.ImportRecord{Type: dto.ImportTypeTeam, TeamName: "backend", UserID: "u1"},
+			wantFields: []string{"user_id"},
+		},
+		{
+			name:       "import user negative limit",
+			req:        dto.ImportRecord{Type: dto.ImportTypeUser, UserID: "u1", Username: "Alice", TeamName: "backend", MaxOpenReviews: intPtr(-1)},
+			wantFields: []string{"max_open_reviews"},
+		},
+		{
+			name: "import user without team ok",
+			req:  dto.ImportRecord{Type: dto.ImportTypeUser, UserID: "u1", Username: "Alice"},
+		},
+		{
+			name:       "export unknown format",
+			req:        dto.ExportRequest{Format: "xml"},
+			wantFields: []string{"format"},
+		},
+		{
+			name: "import merged pr without merged_at",
+			req: dto.ImportRecord{Type: dto.ImportTypePullRequest, PullRequestID: "pr-1", PullRequestName: "Add", AuthorID: "u1",
+				Status: "MERGED", CreatedAt: &created},
+			wantFields: []string{"merged_at"},
+		},
+		{
+			name: "import pr reviewed by author",
+			req: dto.ImportRecord{Type: dto.ImportTypePullRequest, PullRequestID: "pr-1", PullRequestName: "Add", AuthorID: "u1",
+				Status: "OPEN", AssignedReviewers: []string{"u1"}},
+			wantFields: []string{"assigned_reviewers[0]", "created_at"},
+		},
+		{
+			name: "import pr with assignment time per reviewer missing",
+			req: dto.ImportRecord{Type: dto.ImportTypePullRequest, PullRequestID: "pr-1", PullRequestName: "Add", AuthorID: "u1",
+				Status: "OPEN", AssignedReviewers: []string{"u2", "u3"}, ReviewersAssignedAt: []time.Time{created}, CreatedAt: &created},
+			wantFields: []string{"reviewers_assigned_at"},
+		},
+		{
+			name: "import pr with reviewer assigned before creation",
+			req: dto.ImportRecord{Type: dto.ImportTypePullRequest, PullRequestID: "pr-1", PullRequestName: "Add", AuthorID: "u1",
+				Status: "OPEN", AssignedReviewers: []string{"u2"}, ReviewersAssignedAt: []time.Time{created.Add(-time.Hour)},
+				CreatedAt: &created},
+			wantFields: []string{"reviewers_assigned_at"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := fieldErrors(t, tc.req.Validate())
+			if len(got) != len(tc.wantFields) {
+				t.Fatalf("expected fields %v, got %v", tc.wantFields, got)
+			}
+			for _, f := range tc.wantFields {
+				if _, ok := got[f]; !ok {
+					t.Fatalf("expected error for %s, got %v", f, got)
+				}
+			}
+		})
+	}
+}
+
+func TestParseImport(t *testing.T) {
+	type rowError struct {
+		line  int
+		field string
+	}
+
+	cases := []struct {
+		name       string
+		format     dto.ImportFormat
+		body       string
+		wantTeams  int
+		wantUsers  int
+		wantPRs    int
+		wantErrors []rowError
+	}{
+		{
+			name:   "json lines",
+			format: dto.ImportJSONLines,
+			body: `{"type":"team","team_name":"backend"}
+
+{"type":"user","user_id":"u1","username":"Alice","team_name":"backend","skills":["go"]}
+{"type":"user","user_id":"u2","username":"Bob","team_name":"backend","is_active":"yes"}
+{"type":"user","user_id":"u3","username":"Charlie","team":"backend"}
+{"type":"pull_request","pull_request_id":"pr-1","pull_request_name":"Add","author_id":"u1","status":"OPEN","created_at":"2025-09-01T09:00:00Z"}
+{"type":"pull_request","pull_request_id":"pr-2","pull_request_name":"Fix","author_id":"u1","status":"MERGED","created_at":"2025-09-01"}
+not json
+`,
+			wantTeams: 1,
+			wantUsers: 1,
+			wantPRs:   1,
+			wantErrors: []rowError{
+				{4, "is_active"},
+				{5, "team"},
+				{7, ""},
+				{8, ""},
+			},
+		},
+		{
+			name:   "csv",
+			format: dto.ImportCSV,
+			body: `type,team_name,user_id,username,is_active,skills,pull_request_id,pull_request_name,author_id,status,assigned_reviewers,created_at,merged_at
+team,backend,,,,,,,,,,,
+user,backend,u1,Alice,true,go;sql,,,,,,,
+user,backend,u2,Bob,no,,,,,,,,
+pull_request,,,,,,pr-1,Add,u1,MERGED,u2;u3,2025-09-01T09:00:00Z,2025-09-02T09:00:00Z
+pull_request,,,,,,pr-2,Fix,u1,OPEN,u1,2025-09-01T09:00:00Z,
+team,backend
+`,
+			wantTeams: 1,
+			wantUsers: 1,
+			wantPRs:   1,
+			wantErrors: []rowError{
+				{4, "is_active"},
+				{6, "assigned_reviewers[0]"},
+				{7, ""},
+			},
+		},
+		{
+			name:   "rules, slas and unavailability",
+			format: dto.ImportJSONLines,
+			body: `{"type":"review_sla","team_name":"backend","review_sla_hours":24}
+{"type":"review_sla","team_name":"backend","review_sla_hours":0,"policy":"PAGE"}
+{"type":"code_owner_rule","position":2,"pattern":"!docs/","owner_users":["u1"]}
+{"type":"reviewer_rule","rule_id":"r1","kind":"EXCLUDE","author_user_id":"u1","author_skill":"go","reviewer_skill":"go","created_at":"2025-09-01T09:00:00Z"}
+{"type":"unavailability","unavailability_id":"v1","user_id":"u1","starts_at":"2025-09-02T09:00:00Z","ends_at":"2025-09-01T09:00:00Z"}
+{"type":"escalation","pull_request_id":"pr-1","escalated_at":"2025-09-02T09:00:00Z","reason":"late"}
+`,
+			wantErrors: []rowError{
+				{2, "review_sla_hours"},
+				{2, "policy"},
+				{3, "pattern"},
+				{4, "author_user_id"},
+				{5, "ends_at"},
+				{6, "reason"},
+			},
+		},
+		{
+			name:       "csv unknown column",
+			format:     dto.ImportCSV,
+			body:       "type,team,user_id\nteam,backend,\n",
+			wantErrors: []rowError{{1, "team"}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			batch, rowErrs, err := dto.ParseImport(tc.format, strings.NewReader(tc.body))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(batch.Teams) != tc.wantTeams || len(batch.Users) != tc.wantUsers || len(batch.PullRequests) != tc.wantPRs {
+				t.Fatalf("expected %d teams, %d users, %d prs, got %+v", tc.wantTeams, tc.wantUsers, tc.wantPRs, batch)
+			}
+			got := make([]rowError, 0, len(rowErrs))
+			for _, e := range rowErrs {
+				got = append(got, rowError{e.Line, e.Field})
+			}
+			if len(got) != len(tc.wantErrors) {
+				t.Fatalf("expected errors %v, got %+v", tc.wantErrors, rowErrs)
+			}
+			for i := range got {
+				if got[i] != tc.wantErrors[i] {
+					t.Fatalf("expected errors %v, got %+v", tc.wantErrors, rowErrs)
+				}
+			}
+		})
+	}
+}
+
+func TestExportWriter_RoundTripsWithImport(t *testing.T) {
+	created := time.Date(2025, 9, 1, 9, 0, 0, 123456000, time.UTC)
+	merged := created.Add(26 * time.Hour)
+	reassigned := created.Add(3 * time.Hour)
+	limit := 3
+
+	users := []domain.User{
+		{UserID: "u1", Username: "Alice, Jr.", TeamName: "backend", IsActive: true, MaxOpenReviews: &limit, Skills: []string{"go", "sql"}},
+		{UserID: "u2", Username: "Bob \"the builder\"", TeamName: "backend", IsActive: false},
+		{UserID: "u3", Username: "Charlie", IsActive: false},
+	}
+	prs := []domain.PullRequest{
+		{PullRequestID: "pr-1", PullRequestName: "Add search", AuthorID: "u1", Status: "MERGED",
+			AssignedReviewers: []string{"u2"}, CreatedAt: &created, MergedAt: &merged},
+		{PullRequestID: "pr-2", PullRequestName: "Fix bug; again", AuthorID: "u2", Status: "OPEN",
+			AssignedReviewers: []string{}, CreatedAt: &created},
+	}
+	prs[0].MarkAssigned("u2", reassigned)
+
+	for _, format := range []dto.ImportFormat{dto.ImportJSONLines, dto.ImportCSV} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf strings.Builder
+			w := dto.NewExportWriter(format, &buf)
+			if err := w.Team("backend"); err != nil {
+				t.Fatalf("Team: %v", err)
+			}
+			for i := range users {
+				if err := w.User(&users[i]); err != nil {
+					t.Fatalf("User: %v", err)
+				}
+			}
+			for i := range prs {
+				if err := w.PullRequest(&prs[i]); err != nil {
+					t.Fatalf("PullRequest: %v", err)
+				}
+			}
+			if err := w.Flush(); err != nil {
+				t.Fatalf("Flush: %v", err)
+			}
+
+			batch, rowErrs, err := dto.ParseImport(format, strings.NewReader(buf.String()))
+			if err != nil || len(rowErrs) != 0 {
+				t.Fatalf("ParseImport: %v, %+v\n%s", err, rowErrs, buf.String())
+			}
+			if len(batch.Teams) != 1 || batch.Teams[0].TeamName != "backend" {
+				t.Fatalf("unexpected teams: %+v", batch.Teams)
+			}
+			if len(batch.Users) != len(users) {
+				t.Fatalf("expected %d users, got %d", len(users), len(batch.Users))
+			}
+			for i, got := range batch.Users {
+				if !reflect.DeepEqual(got.User, users[i]) {
+					t.Fatalf("user %d: got %+v, want %+v", i, got.User, users[i])
+				}
+			}
+			if len(batch.PullRequests) != len(prs) {
+				t.Fatalf("expected %d prs, got %d", len(prs), len(batch.PullRequests))
+			}
+			for i, got := range batch.PullRequests {
+				want := prs[i]
+				if got.PullRequestID != want.PullRequestID || got.PullRequestName != want.PullRequestName ||
+					got.AuthorID != want.AuthorID || got.Status != want.Status ||
+					len(got.AssignedReviewers) != len(want.AssignedReviewers) ||
+					!got.CreatedAt.Equal(*want.CreatedAt) || (want.MergedAt != nil) != (got.MergedAt != nil) ||
+					(want.MergedAt != nil && !got.MergedAt.Equal(*want.MergedAt)) {
+					t.Fatalf("pr %d: got %+v, want %+v", i, got.PullRequest, want)
+				}
+				for _, id := range want.AssignedReviewers {
+					if !got.ReviewerAssignedAt[id].Equal(want.ReviewerAssignedAt[id]) {
+						t.Fatalf("pr %d: reviewer %s assigned at %v, want %v",
+							i, id, got.ReviewerAssignedAt[id], want.ReviewerAssignedAt[id])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestExportWriter_RoundTripsRulesAndSLAs(t *testing.T) {
+	escalated := time.Date(2025, 9, 2, 9, 0, 0, 0, time.UTC)
+	released := escalated.Add(time.Minute)
+
+	sla := domain.ReviewSLA{TeamName: "backend", SLA: 48 * time.Hour, Policy: domain.EscalationReassign}
+	owners := []domain.CodeOwnerRule{
+		{Position: 1, Pattern: "*.go", Users: []string{"u1", "u2"}, Teams: []string{"backend"}},
+		{Position: 4, Pattern: "docs/", Users: []string{}, Teams: []string{}},
+	}
+	rules := []domain.ReviewerRule{
+		{ID: "r1", Kind: domain.RuleExclude, Author: domain.UserSelector{UserID: "u1"}, Reviewer: domain.UserSelector{Skill: "go"},
+			Description: "no self-review, please; really", CreatedAt: escalated},
+		{ID: "r2", Kind: domain.RuleRequire, Author: domain.UserSelector{Skill: "sql"}, Reviewer: domain.UserSelector{UserID: "u2"},
+			CreatedAt: released},
+	}
+	periods := []domain.Unavailability{
+		{ID: "v1", UserID: "u1", StartsAt: escalated, EndsAt: escalated.Add(72 * time.Hour), Reason: "vacation", ReleasedAt: &released},
+		{ID: "v2", UserID: "u2", StartsAt: escalated, EndsAt: escalated.Add(time.Hour)},
+	}
+
+	for _, format := range []dto.ImportFormat{dto.ImportJSONLines, dto.ImportCSV} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf strings.Builder
+			w := dto.NewExportWriter(format, &buf)
+			if err := w.Escalation("pr-1", escalated); err != nil {
+				t.Fatalf("Escalation: %v", err)
+			}
+			if err := w.ReviewSLA(&sla); err != nil {
+				t.Fatalf("ReviewSLA: %v", err)
+			}
+			for i := range owners {
+				if err := w.CodeOwnerRule(&owners[i]); err != nil {
+					t.Fatalf("CodeOwnerRule: %v", err)
+				}
+			}
+			for i := range rules {
+				if err := w.ReviewerRule(&rules[i]); err != nil {
+					t.Fatalf("ReviewerRule: %v", err)
+				}
+			}
+			for i := range periods {
+				if err := w.Unavailability(&periods[i]); err != nil {
+					t.Fatalf("Unavailability: %v", err)
+				}
+			}
+			if err := w.Flush(); err != nil {
+				t.Fatalf("Flush: %v", err)
+			}
+
+			batch, rowErrs, err := dto.ParseImport(format, strings.NewReader(buf.String()))
+			if err != nil || len(rowErrs) != 0 {
+				t.Fatalf("ParseImport: %v, %+v\n%s", err, rowErrs, buf.String())
+			}
+			if len(batch.Escalations) != 1 || batch.Escalations[0].PullRequestID != "pr-1" || !batch.Escalations[0].EscalatedAt.Equal(escalated) {
+				t.Fatalf("unexpected escalations: %+v", batch.Escalations)
+			}
+			if len(batch.ReviewSLAs) != 1 || batch.ReviewSLAs[0].ReviewSLA != sla {
+				t.Fatalf("unexpected slas: %+v", batch.ReviewSLAs)
+			}
+			if len(batch.CodeOwnerRules) != len(owners) {
+				t.Fatalf("expected %d code owner rules, got %+v", len(owners), batch.CodeOwnerRules)
+			}
+			for i, got := range batch.CodeOwnerRules {
+				if !reflect.DeepEqual(got.CodeOwnerRule, owners[i]) {
+					t.Fatalf("code owner rule %d: got %+v, want %+v", i, got.CodeOwnerRule, owners[i])
+				}
+			}
+			if len(batch.ReviewerRules) != len(rules) {
+				t.Fatalf("expected %d reviewer rules, got %+v", len(rules), batch.ReviewerRules)
+			}
+			for i, got := range batch.ReviewerRules {
+				if !reflect.DeepEqual(got.ReviewerRule, rules[i]) {
+					t.Fatalf("reviewer rule %d: got %+v, want %+v", i, got.ReviewerRule, rules[i])
+				}
+			}
+			if len(batch.Unavailability) != len(periods) {
+				t.Fatalf("expected %d periods, got %+v", len(periods), batch.Unavailability)
+			}
+			for i, got := range batch.Unavailability {
+				if !reflect.DeepEqual(got.Unavailability, periods[i]) {
+					t.Fatalf("period %d: got %+v, want %+v", i, got.Unavailability, periods[i])
+				}
+			}
+		})
+	}
+}
+
+func TestExportWriter_EmptyCSVHasHeader(t *testing.T) {
+	var buf strings.Builder
+	w := dto.NewExportWriter(dto.ImportCSV, &buf)
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if got, want := buf.String(), strings.Join(dto.ImportColumns, ",")+"\n"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
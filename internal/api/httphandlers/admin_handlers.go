@@ -0,0 +1,143 @@
+package httphandlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"net/http"
+
+	"pr-reviewer-assigment-service/internal/api/dto"
+	"pr-reviewer-assigment-service/internal/application/service"
+)
+
+// AdminHandlers содержит хендлеры для /admin/*
+type AdminHandlers struct {
+	importService      *service.ImportService
+	exportService      *service.ExportService
+	consistencyService *service.ConsistencyService
+}
+
+func NewAdminHandlers(
+	importService *service.ImportService,
+	exportService *service.ExportService,
+	consistencyService *service.ConsistencyService,
+) *AdminHandlers {
+	return &AdminHandlers{
+		importService:      importService,
+		exportService:      exportService,
+		consistencyService: consistencyService,
+	}
+}
+
+// Import принимает файл JSON Lines (application/x-ndjson) или CSV (text/csv) в формате выгрузки /admin/export.
+// Тело - сам файл, не больше dto.MaxImportBodySize.
+func (h *AdminHandlers) Import(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	req := dto.ImportRequest{DryRun: r.URL.Query().Get("dry_run")}
+	if !validateRequest(w, req) {
+		return
+	}
+
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	format := dto.ImportFormat(mediaType)
+	if err != nil || (format != dto.ImportJSONLines && format != dto.ImportCSV) {
+		WriteError(w, http.StatusUnsupportedMediaType, CodeUnsupportedMediaType,
+			fmt.Sprintf("Content-Type must be %s or %s", dto.ImportJSONLines, dto.ImportCSV))
+		return
+	}
+
+	batch, parseErrs, err := dto.ParseImport(format, http.MaxBytesReader(w, r.Body, dto.MaxImportBodySize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			WriteError(w, http.StatusRequestEntityTooLarge, CodePayloadTooLarge,
+				fmt.Sprintf("import file must not exceed %d bytes", maxBytesErr.Limit))
+			return
+		}
+		log.Printf("read import file: %v", err)
+		writeBadRequest(w, "failed to read request body")
+		return
+	}
+
+	report, err := h.importService.Import(r.Context(), batch, req.IsDryRun())
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, dto.NewImportResponse(report, parseErrs))
+}
+
+// Export отдаёт все данные сервиса потоком в формате /admin/import (format=jsonl или csv).
+// Если выгрузка прервалась после начала ответа, статус уже не изменить: ответ обрывается, ошибка пишется в лог.
+func (h *AdminHandlers) Export(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	req := dto.ExportRequest{Format: r.URL.Query().Get("format")}
+	if !validateRequest(w, req) {
+		return
+	}
+
+	format := req.ImportFormat()
+	extension := dto.ExportFormatJSONLines
+	if format == dto.ImportCSV {
+		extension = dto.ExportFormatCSV
+	}
+	w.Header().Set("Content-Type", string(format))
+	w.Header().Set("Content-Disposition", `attachment; filename="export.`+extension+`"`)
+
+	writer := dto.NewExportWriter(format, w)
+	err := h.exportService.Export(r.Context(), writer)
+	if err == nil {
+		err = writer.Flush()
+	}
+	if err != nil {
+		log.Printf("export: %v", err)
+		if writer.Records() == 0 {
+			w.Header().Del("Content-Disposition")
+			WriteError(w, http.StatusInternalServerError, CodeInternal, "internal server error")
+		}
+	}
+}
+
+// Consistency сообщает о нарушениях целостности: ссылках на несуществующих ревьюверов,
+// неактивных ревьюверах открытых PR и авторах открытых PR вне команд.
+func (h *AdminHandlers) Consistency(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	report, err := h.consistencyService.Check(r.Context())
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, dto.NewConsistencyResponse(report))
+}
+
+// RepairConsistency исправляет нарушения целостности, которые можно исправить автоматически,
+// и возвращает найденные нарушения с отметкой об исправлении.
+func (h *AdminHandlers) RepairConsistency(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	report, err := h.consistencyService.Repair(r.Context())
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, dto.NewConsistencyResponse(report))
+}
@@ -0,0 +1,70 @@
+package httphandlers
+
+import (
+	"net/http"
+
+	"pr-reviewer-assigment-service/internal/api/dto"
+	"pr-reviewer-assigment-service/internal/application/service"
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+// CodeOwnerHandlers содержит хендлеры для /codeOwners/*
+type CodeOwnerHandlers struct {
+	codeOwnerService *service.CodeOwnerService
+}
+
+func NewCodeOwnerHandlers(codeOwnerService *service.CodeOwnerService) *CodeOwnerHandlers {
+	return &CodeOwnerHandlers{codeOwnerService: codeOwnerService}
+}
+
+func (h *CodeOwnerHandlers) Upload(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	var req dto.CodeOwnersUploadRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if !validateRequest(w, req) {
+		return
+	}
+
+	rules, err := h.codeOwnerService.Upload(r.Context(), req.Content)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toCodeOwnersResponse(rules))
+}
+
+func (h *CodeOwnerHandlers) List(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	rules, err := h.codeOwnerService.List(r.Context())
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toCodeOwnersResponse(rules))
+}
+
+func toCodeOwnersResponse(rules []domain.CodeOwnerRule) dto.CodeOwnersResponse {
+	items := make([]dto.CodeOwnerRuleDto, 0, len(rules))
+	for _, rule := range rules {
+		items = append(items, dto.CodeOwnerRuleDto{
+			Position: rule.Position,
+			Pattern:  rule.Pattern,
+			Users:    append(make([]string, 0, len(rule.Users)), rule.Users...),
+			Teams:    append(make([]string, 0, len(rule.Teams)), rule.Teams...),
+		})
+	}
+	return dto.CodeOwnersResponse{Rules: items}
+}
@@ -0,0 +1,143 @@
+package httphandlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"pr-reviewer-assigment-service/internal/api/dto"
+)
+
+// MaxRequestBodySize - максимальный размер тела запроса.
+const MaxRequestBodySize = 1 << 20
+
+// routeBodySizes - маршруты, тело которых может быть больше MaxRequestBodySize.
+var routeBodySizes = map[string]int64{
+	"/admin/import": dto.MaxImportBodySize,
+}
+
+// MaxBodySize возвращает максимальный размер тела запроса к маршруту path.
+// По нему middleware, читающие тело до хендлера, не отклоняют то, что хендлер бы принял.
+func MaxBodySize(path string) int64 {
+	if size, ok := routeBodySizes[path]; ok {
+		return size
+	}
+	return MaxRequestBodySize
+}
+
+// Коды ошибок разбора тела запроса.
+const (
+	CodeInvalidJSON          = "INVALID_JSON"
+	CodeUnknownField         = "UNKNOWN_FIELD"
+	CodePayloadTooLarge      = "PAYLOAD_TOO_LARGE"
+	CodeUnsupportedMediaType = "UNSUPPORTED_MEDIA_TYPE"
+)
+
+// decodeJSON строго разбирает JSON-тело запроса в dst:
+//   - Content-Type должен быть application/json (415 UNSUPPORTED_MEDIA_TYPE);
+//   - тело не больше MaxRequestBodySize (413 PAYLOAD_TOO_LARGE);
+//   - неизвестные поля запрещены (400 UNKNOWN_FIELD);
+//   - после JSON-объекта не должно быть других данных (400 INVALID_JSON);
+//   - поле неверного типа - 400 VALIDATION_ERROR.
+//
+// При ошибке пишет ответ и возвращает false.
+func decodeJSON(w http.ResponseWriter, r *http.Request, dst any) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "application/json" {
+		WriteError(w, http.StatusUnsupportedMediaType, CodeUnsupportedMediaType, "Content-Type must be application/json")
+		return false
+	}
+
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, MaxRequestBodySize))
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(dst); err != nil {
+		writeDecodeError(w, err)
+		return false
+	}
+
+	if err := dec.Decode(&struct{}{}); !errors.Is(err, io.EOF) {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeDecodeError(w, err)
+			return false
+		}
+		WriteError(w, http.StatusBadRequest, CodeInvalidJSON, "request body must contain a single JSON object")
+		return false
+	}
+
+	return true
+}
+
+func writeDecodeError(w http.ResponseWriter, err error) {
+	var (
+		syntaxErr    *json.SyntaxError
+		typeErr      *json.UnmarshalTypeError
+		maxBytesErr  *http.MaxBytesError
+		unknownField string
+	)
+
+	if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		unknownField = strings.Trim(field, `"`)
+	}
+
+	switch {
+	case errors.As(err, &maxBytesErr):
+		WriteError(w, http.StatusRequestEntityTooLarge, CodePayloadTooLarge,
+			fmt.Sprintf("request body must not exceed %d bytes", maxBytesErr.Limit))
+	case errors.Is(err, io.EOF):
+		WriteError(w, http.StatusBadRequest, CodeInvalidJSON, "request body is required")
+	case errors.As(err, &syntaxErr):
+		WriteError(w, http.StatusBadRequest, CodeInvalidJSON,
+			fmt.Sprintf("malformed JSON at offset %d", syntaxErr.Offset))
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		WriteError(w, http.StatusBadRequest, CodeInvalidJSON, "malformed JSON: unexpected end of body")
+	case errors.As(err, &typeErr):
+		field := typeErr.Field
+		if field == "" {
+			field = "body"
+		}
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    CodeValidationError,
+				Message: "request validation failed",
+				Details: []dto.FieldError{{Field: field, Reason: "must be " + jsonTypeName(typeErr.Type)}},
+			},
+		})
+	case unknownField != "":
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    CodeUnknownField,
+				Message: "unknown field " + unknownField,
+				Details: []dto.FieldError{{Field: unknownField, Reason: "is not allowed"}},
+			},
+		})
+	default:
+		WriteError(w, http.StatusBadRequest, CodeInvalidJSON, "invalid JSON body")
+	}
+}
+
+// jsonTypeName возвращает название JSON-типа для Go-типа поля.
+func jsonTypeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	case reflect.Pointer:
+		return jsonTypeName(t.Elem())
+	default:
+		return "an object"
+	}
+}
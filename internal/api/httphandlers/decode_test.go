@@ -0,0 +1,101 @@
+package httphandlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"pr-reviewer-assigment-service/internal/api/httphandlers"
+)
+
+func TestHandlers_StrictJSONDecoding(t *testing.T) {
+	prHandlers := httphandlers.NewPullRequestHandlers(nil)
+
+	cases := []struct {
+		name        string
+		contentType string
+		body        string
+		wantStatus  int
+		wantCode    string
+		wantField   string
+	}{
+		{
+			name:        "unknown field",
+			contentType: "application/json",
+			body:        `{"pull_request_id":"pr-1","author":"u1"}`,
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    "UNKNOWN_FIELD",
+			wantField:   "author",
+		},
+		{
+			name:        "trailing data",
+			contentType: "application/json",
+			body:        `{"pull_request_id":"pr-1"} {"x":1}`,
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    "INVALID_JSON",
+		},
+		{
+			name:        "malformed json",
+			contentType: "application/json",
+			body:        `{"pull_request_id":`,
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    "INVALID_JSON",
+		},
+		{
+			name:        "empty body",
+			contentType: "application/json",
+			body:        ``,
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    "INVALID_JSON",
+		},
+		{
+			name:        "wrong type",
+			contentType: "application/json",
+			body:        `{"pull_request_id":42}`,
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    "VALIDATION_ERROR",
+			wantField:   "pull_request_id",
+		},
+		{
+			name:        "wrong content type",
+			contentType: "text/plain",
+			body:        `{"pull_request_id":"pr-1"}`,
+			wantStatus:  http.StatusUnsupportedMediaType,
+			wantCode:    "UNSUPPORTED_MEDIA_TYPE",
+		},
+		{
+			name:        "too large",
+			contentType: "application/json; charset=utf-8",
+			body:        `{"pull_request_id":"` + strings.Repeat("x", httphandlers.MaxRequestBodySize) + `"}`,
+			wantStatus:  http.StatusRequestEntityTooLarge,
+			wantCode:    "PAYLOAD_TOO_LARGE",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/pullRequest/merge", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", tc.contentType)
+			rec := httptest.NewRecorder()
+
+			prHandlers.Merge(rec, req)
+
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tc.wantStatus, rec.Code, rec.Body.String())
+			}
+
+			var body errorResponse
+			if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if body.Error.Code != tc.wantCode {
+				t.Fatalf("expected %s, got %s (%s)", tc.wantCode, body.Error.Code, body.Error.Message)
+			}
+			if tc.wantField != "" && (len(body.Error.Details) == 0 || body.Error.Details[0].Field != tc.wantField) {
+				t.Fatalf("expected details for %s, got %+v", tc.wantField, body.Error.Details)
+			}
+		})
+	}
+}
@@ -1,7 +1,6 @@
 package httphandlers
 
 import (
-	"encoding/json"
 	"net/http"
 	"time"
 
@@ -26,35 +25,74 @@ func (h *PullRequestHandlers) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req dto.PullRequestCreateRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeBadRequest(w, "invalid JSON body")
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
-	if req.PullRequestID == "" {
-		writeBadRequest(w, "pull_request_id is required")
+	if !validateRequest(w, req) {
 		return
 	}
-	if req.PullRequestName == "" {
-		writeBadRequest(w, "pull_request_name is required")
+
+	hints := domain.ReviewHints{
+		RequestedReviewers: req.RequestedReviewers,
+		ChangedFiles:       req.ChangedFiles,
+		Labels:             req.Labels,
+	}
+	pr, err := h.prService.CreateWithHints(r.Context(), req.PullRequestID, req.PullRequestName, req.AuthorID, hints)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	resp := dto.PullRequestCreateResponse{
+		PR:                    toPullRequestDto(pr),
+		UnfilledReviewerSlots: pr.UnfilledReviewerSlots(),
+	}
+
+	writeJSON(w, http.StatusCreated, resp)
+}
+
+func (h *PullRequestHandlers) PreviewAssignment(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	var req dto.PullRequestPreviewRequest
+	if !decodeJSON(w, r, &req) {
 		return
 	}
-	if req.AuthorID == "" {
-		writeBadRequest(w, "author_id is required")
+
+	if !validateRequest(w, req) {
 		return
 	}
 
-	pr, err := h.prService.Create(r.Context(), req.PullRequestID, req.PullRequestName, req.AuthorID)
+	hints := domain.ReviewHints{
+		RequestedReviewers: req.RequestedReviewers,
+		ChangedFiles:       req.ChangedFiles,
+		Labels:             req.Labels,
+		ExcludedUsers:      req.ExcludedUsers,
+	}
+	selection, err := h.prService.PreviewAssignment(r.Context(), req.AuthorID, hints)
 	if err != nil {
 		writeDomainError(w, err)
 		return
 	}
 
-	resp := dto.PullRequestCreateResponse{
-		PR: toPullRequestDto(pr),
+	resp := dto.PullRequestPreviewResponse{
+		Reviewers:             selection.ReviewerIDs(),
+		UnfilledReviewerSlots: selection.UnfilledReviewerSlots(),
+		Rejected:              make([]dto.RejectedCandidateDto, 0, len(selection.Rejected)),
+	}
+	for _, c := range selection.Rejected {
+		resp.Rejected = append(resp.Rejected, dto.RejectedCandidateDto{
+			UserID: c.UserID,
+			Reason: string(c.Reason),
+			Detail: c.Detail,
+		})
 	}
 
-	writeJSON(w, http.StatusCreated, resp)
+	writeJSON(w, http.StatusOK, resp)
 }
 
 func (h *PullRequestHandlers) Merge(w http.ResponseWriter, r *http.Request) {
@@ -64,13 +102,11 @@ func (h *PullRequestHandlers) Merge(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req dto.PullRequestMergeRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeBadRequest(w, "invalid JSON body")
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
-	if req.PullRequestID == "" {
-		writeBadRequest(w, "pull_request_id is required")
+	if !validateRequest(w, req) {
 		return
 	}
 
@@ -94,17 +130,11 @@ func (h *PullRequestHandlers) Reassign(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req dto.PullRequestReassignRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeBadRequest(w, "invalid JSON body")
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
-	if req.PullRequestID == "" {
-		writeBadRequest(w, "pull_request_id is required")
-		return
-	}
-	if req.OldUserID == "" {
-		writeBadRequest(w, "old_user_id is required")
+	if !validateRequest(w, req) {
 		return
 	}
 
@@ -122,6 +152,54 @@ func (h *PullRequestHandlers) Reassign(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, resp)
 }
 
+func (h *PullRequestHandlers) AddReviewer(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	var req dto.PullRequestReviewerRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if !validateRequest(w, req) {
+		return
+	}
+
+	pr, err := h.prService.AddReviewer(r.Context(), req.PullRequestID, req.UserID)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, dto.PullRequestResponse{PR: toPullRequestDto(pr)})
+}
+
+func (h *PullRequestHandlers) RemoveReviewer(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	var req dto.PullRequestReviewerRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if !validateRequest(w, req) {
+		return
+	}
+
+	pr, err := h.prService.RemoveReviewer(r.Context(), req.PullRequestID, req.UserID)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, dto.PullRequestResponse{PR: toPullRequestDto(pr)})
+}
+
 func toPullRequestDto(pr *domain.PullRequest) dto.PullRequestDto {
 	var createdAtStr *string
 	if pr.CreatedAt != nil {
@@ -140,7 +218,7 @@ func toPullRequestDto(pr *domain.PullRequest) dto.PullRequestDto {
 		PullRequestName:   pr.PullRequestName,
 		AuthorID:          pr.AuthorID,
 		Status:            pr.Status,
-		AssignedReviewers: append([]string(nil), pr.AssignedReviewers...),
+		AssignedReviewers: append(make([]string, 0, len(pr.AssignedReviewers)), pr.AssignedReviewers...),
 		CreatedAt:         createdAtStr,
 		MergedAt:          mergedAtStr,
 	}
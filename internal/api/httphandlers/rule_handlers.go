@@ -0,0 +1,141 @@
+package httphandlers
+
+import (
+	"net/http"
+
+	"pr-reviewer-assigment-service/internal/api/dto"
+	"pr-reviewer-assigment-service/internal/application/service"
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+// RuleHandlers содержит хендлеры для /rules/*
+type RuleHandlers struct {
+	ruleService *service.RuleService
+}
+
+func NewRuleHandlers(ruleService *service.RuleService) *RuleHandlers {
+	return &RuleHandlers{ruleService: ruleService}
+}
+
+func (h *RuleHandlers) Add(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	var req dto.RuleAddRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if !validateRequest(w, req) {
+		return
+	}
+
+	rule, err := h.ruleService.Add(r.Context(), domain.ReviewerRule{
+		Kind:        domain.ReviewerRuleKind(req.Kind),
+		Author:      domain.UserSelector(req.Author),
+		Reviewer:    domain.UserSelector(req.Reviewer),
+		Description: req.Description,
+	})
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, dto.RuleResponse{Rule: toReviewerRuleDto(*rule)})
+}
+
+func (h *RuleHandlers) List(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	rules, err := h.ruleService.List(r.Context())
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	items := make([]dto.ReviewerRuleDto, 0, len(rules))
+	for _, rule := range rules {
+		items = append(items, toReviewerRuleDto(rule))
+	}
+
+	writeJSON(w, http.StatusOK, dto.RuleListResponse{Rules: items})
+}
+
+func (h *RuleHandlers) Delete(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	var req dto.RuleDeleteRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if !validateRequest(w, req) {
+		return
+	}
+
+	rule, err := h.ruleService.Delete(r.Context(), req.RuleID)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, dto.RuleResponse{Rule: toReviewerRuleDto(*rule)})
+}
+
+func (h *RuleHandlers) Evaluate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	var req dto.RuleEvaluateRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if !validateRequest(w, req) {
+		return
+	}
+
+	var violations []domain.RuleViolation
+	var err error
+	if req.PullRequestID != "" {
+		violations, err = h.ruleService.EvaluatePullRequest(r.Context(), req.PullRequestID)
+	} else {
+		violations, err = h.ruleService.Evaluate(r.Context(), req.AuthorID, req.ReviewerIDs)
+	}
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	items := make([]dto.RuleViolationDto, 0, len(violations))
+	for _, v := range violations {
+		items = append(items, dto.RuleViolationDto{
+			RuleID:     v.RuleID,
+			Kind:       string(v.Kind),
+			ReviewerID: v.ReviewerID,
+			Message:    v.Message,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, dto.RuleEvaluateResponse{Violations: items})
+}
+
+func toReviewerRuleDto(rule domain.ReviewerRule) dto.ReviewerRuleDto {
+	return dto.ReviewerRuleDto{
+		RuleID:      rule.ID,
+		Kind:        string(rule.Kind),
+		Author:      dto.UserSelectorDto(rule.Author),
+		Reviewer:    dto.UserSelectorDto(rule.Reviewer),
+		Description: rule.Description,
+	}
+}
@@ -0,0 +1,105 @@
+package httphandlers
+
+import (
+	"net/http"
+	"time"
+
+	"pr-reviewer-assigment-service/internal/api/dto"
+	"pr-reviewer-assigment-service/internal/application/service"
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+// SLAHandlers содержит хендлеры SLA ревью: /team/setReviewSLA и /pullRequest/overdue
+type SLAHandlers struct {
+	slaService *service.SLAService
+}
+
+func NewSLAHandlers(slaService *service.SLAService) *SLAHandlers {
+	return &SLAHandlers{slaService: slaService}
+}
+
+func (h *SLAHandlers) SetTeamSLA(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	var req dto.TeamSetReviewSLARequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if !validateRequest(w, req) {
+		return
+	}
+
+	var sla *time.Duration
+	if req.ReviewSLAHours != nil {
+		d := time.Duration(*req.ReviewSLAHours) * time.Hour
+		sla = &d
+	}
+
+	result, err := h.slaService.SetTeamSLA(r.Context(), req.TeamName, sla, domain.EscalationPolicy(req.EscalationPolicy()))
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	resp := dto.TeamReviewSLAResponse{TeamName: req.TeamName}
+	if result != nil {
+		hours := int(result.SLA / time.Hour)
+		resp.ReviewSLAHours = &hours
+		resp.Policy = string(result.Policy)
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *SLAHandlers) Overdue(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	req := dto.PullRequestOverdueRequest{TeamName: r.URL.Query().Get("team_name")}
+	if !validateRequest(w, req) {
+		return
+	}
+
+	overdue, now, err := h.slaService.Overdue(r.Context(), req.TeamName)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	resp := dto.PullRequestOverdueResponse{
+		PullRequests: make([]dto.OverduePullRequestDto, 0, len(overdue)),
+	}
+	for _, o := range overdue {
+		resp.PullRequests = append(resp.PullRequests, toOverduePullRequestDto(&o, now))
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func toOverduePullRequestDto(o *domain.OverduePullRequest, now time.Time) dto.OverduePullRequestDto {
+	result := dto.OverduePullRequestDto{
+		PullRequestID:     o.PullRequestID,
+		PullRequestName:   o.PullRequestName,
+		AuthorID:          o.AuthorID,
+		TeamName:          o.TeamName,
+		AssignedReviewers: o.AssignedReviewers,
+		CreatedAt:         o.CreatedAt.UTC().Format(time.RFC3339),
+		ReviewSLAHours:    int(o.SLA / time.Hour),
+		Policy:            string(o.Policy),
+		OverdueMinutes:    int(o.OverdueBy(now) / time.Minute),
+	}
+	if result.AssignedReviewers == nil {
+		result.AssignedReviewers = []string{}
+	}
+	if o.EscalatedAt != nil {
+		s := o.EscalatedAt.UTC().Format(time.RFC3339)
+		result.EscalatedAt = &s
+	}
+	return result
+}
@@ -1,7 +1,6 @@
 package httphandlers
 
 import (
-	"encoding/json"
 	"net/http"
 
 	"pr-reviewer-assigment-service/internal/api/dto"
@@ -25,77 +24,91 @@ func (h *TeamHandlers) Add(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req dto.TeamAddRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeBadRequest(w, "invalid JSON body")
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
-	if req.TeamName == "" {
-		writeBadRequest(w, "team_name is required")
+	if !validateRequest(w, req) {
 		return
 	}
 
-	members := make([]domain.TeamMember, 0, len(req.Members))
-	for _, m := range req.Members {
-		members = append(members, domain.TeamMember{
-			UserID:   m.UserID,
-			Username: m.Username,
-			IsActive: m.IsActive,
-		})
+	team, err := h.teamService.Add(r.Context(), req.TeamName, toTeamMembers(req.Members))
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, dto.TeamResponse{Team: toTeamDto(team)})
+}
+
+func (h *TeamHandlers) Get(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeMethodNotAllowed(w)
+		return
 	}
 
-	team, err := h.teamService.Add(r.Context(), req.TeamName, members)
+	req := dto.TeamGetRequest{TeamName: r.URL.Query().Get("team_name")}
+	if !validateRequest(w, req) {
+		return
+	}
+
+	team, err := h.teamService.Get(r.Context(), req.TeamName)
 	if err != nil {
 		writeDomainError(w, err)
 		return
 	}
 
-	resp := dto.TeamAddResponse{
-		Team: dto.TeamDto{
-			TeamName: team.TeamName,
-			Members:  make([]dto.TeamMemberDto, 0, len(team.Members)),
-		},
+	resp := dto.TeamGetResponse{
+		TeamName: team.TeamName,
+		Members:  make([]dto.TeamMemberDto, 0, len(team.Members)),
 	}
 
 	for _, m := range team.Members {
-		resp.Team.Members = append(resp.Team.Members, dto.TeamMemberDto{
+		resp.Members = append(resp.Members, dto.TeamMemberDto{
 			UserID:   m.UserID,
 			Username: m.Username,
 			IsActive: m.IsActive,
 		})
 	}
 
-	writeJSON(w, http.StatusCreated, resp)
+	writeJSON(w, http.StatusOK, resp)
 }
 
-func (h *TeamHandlers) Get(w http.ResponseWriter, r *http.Request) {
+func (h *TeamHandlers) List(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		writeMethodNotAllowed(w)
 		return
 	}
 
-	teamName := r.URL.Query().Get("team_name")
-	if teamName == "" {
-		writeBadRequest(w, "team_name is required")
+	q := r.URL.Query()
+	req := dto.TeamListRequest{
+		Prefix: q.Get("prefix"),
+		Limit:  q.Get("limit"),
+		Offset: q.Get("offset"),
+	}
+	if !validateRequest(w, req) {
 		return
 	}
 
-	team, err := h.teamService.Get(r.Context(), teamName)
+	limit, offset := req.Page()
+	teams, total, err := h.teamService.List(r.Context(), req.Prefix, limit, offset)
 	if err != nil {
 		writeDomainError(w, err)
 		return
 	}
 
-	resp := dto.TeamGetResponse{
-		TeamName: team.TeamName,
-		Members:  make([]dto.TeamMemberDto, 0, len(team.Members)),
+	resp := dto.TeamListResponse{
+		Teams:  make([]dto.TeamSummaryDto, 0, len(teams)),
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
 	}
 
-	for _, m := range team.Members {
-		resp.Members = append(resp.Members, dto.TeamMemberDto{
-			UserID:   m.UserID,
-			Username: m.Username,
-			IsActive: m.IsActive,
+	for _, t := range teams {
+		resp.Teams = append(resp.Teams, dto.TeamSummaryDto{
+			TeamName:          t.TeamName,
+			MemberCount:       t.MemberCount,
+			ActiveMemberCount: t.ActiveMemberCount,
 		})
 	}
 
@@ -108,12 +121,11 @@ func (h *TeamHandlers) DeactivateMembers(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	teamName := r.URL.Query().Get("team_name")
-	if teamName == "" {
-		writeBadRequest(w, "team_name is required")
+	req := dto.TeamDeactivateRequest{TeamName: r.URL.Query().Get("team_name")}
+	if !validateRequest(w, req) {
 		return
 	}
-	err := h.teamService.DeactivateMembers(r.Context(), teamName)
+	err := h.teamService.DeactivateMembers(r.Context(), req.TeamName)
 	if err != nil {
 		writeDomainError(w, err)
 		return
@@ -121,3 +133,127 @@ func (h *TeamHandlers) DeactivateMembers(w http.ResponseWriter, r *http.Request)
 	w.WriteHeader(http.StatusOK)
 	return
 }
+
+func (h *TeamHandlers) AddMembers(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	var req dto.TeamAddMembersRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if !validateRequest(w, req) {
+		return
+	}
+
+	team, err := h.teamService.AddMembers(r.Context(), req.TeamName, toTeamMembers(req.Members))
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, dto.TeamResponse{Team: toTeamDto(team)})
+}
+
+func (h *TeamHandlers) RemoveMembers(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	var req dto.TeamRemoveMembersRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if !validateRequest(w, req) {
+		return
+	}
+
+	team, err := h.teamService.RemoveMembers(r.Context(), req.TeamName, req.UserIDs, req.ReassignReviews)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, dto.TeamResponse{Team: toTeamDto(team)})
+}
+
+func (h *TeamHandlers) Rename(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	var req dto.TeamRenameRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if !validateRequest(w, req) {
+		return
+	}
+
+	team, err := h.teamService.Rename(r.Context(), req.TeamName, req.NewTeamName)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, dto.TeamResponse{Team: toTeamDto(team)})
+}
+
+func (h *TeamHandlers) Delete(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	var req dto.TeamDeleteRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if !validateRequest(w, req) {
+		return
+	}
+
+	team, err := h.teamService.Delete(r.Context(), req.TeamName,
+		domain.TeamDeleteStrategy(req.Strategy), req.TargetTeam, req.Force)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, dto.TeamResponse{Team: toTeamDto(team)})
+}
+
+func toTeamMembers(members []dto.TeamMemberDto) []domain.TeamMember {
+	result := make([]domain.TeamMember, 0, len(members))
+	for _, m := range members {
+		result = append(result, domain.TeamMember{
+			UserID:   m.UserID,
+			Username: m.Username,
+			IsActive: m.IsActive,
+		})
+	}
+	return result
+}
+
+func toTeamDto(team *domain.Team) dto.TeamDto {
+	result := dto.TeamDto{
+		TeamName: team.TeamName,
+		Members:  make([]dto.TeamMemberDto, 0, len(team.Members)),
+	}
+	for _, m := range team.Members {
+		result.Members = append(result.Members, dto.TeamMemberDto{
+			UserID:   m.UserID,
+			Username: m.Username,
+			IsActive: m.IsActive,
+		})
+	}
+	return result
+}
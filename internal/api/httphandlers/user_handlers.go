@@ -1,19 +1,25 @@
 package httphandlers
 
 import (
-	"encoding/json"
 	"net/http"
+	"time"
+
 	"pr-reviewer-assigment-service/internal/api/dto"
 	"pr-reviewer-assigment-service/internal/application/service"
+	"pr-reviewer-assigment-service/internal/domain"
 )
 
 // UserHandlers содержит хендлеры для /users/*
 type UserHandlers struct {
-	userService *service.UserService
+	userService         *service.UserService
+	availabilityService *service.AvailabilityService
 }
 
-func NewUserHandlers(userService *service.UserService) *UserHandlers {
-	return &UserHandlers{userService: userService}
+func NewUserHandlers(userService *service.UserService, availabilityService *service.AvailabilityService) *UserHandlers {
+	return &UserHandlers{
+		userService:         userService,
+		availabilityService: availabilityService,
+	}
 }
 
 func (h *UserHandlers) SetIsActive(w http.ResponseWriter, r *http.Request) {
@@ -23,13 +29,11 @@ func (h *UserHandlers) SetIsActive(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req dto.UserSetIsActiveRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeBadRequest(w, "invalid JSON body")
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
-	if req.UserID == "" {
-		writeBadRequest(w, "user_id is required")
+	if !validateRequest(w, req) {
 		return
 	}
 
@@ -40,30 +44,72 @@ func (h *UserHandlers) SetIsActive(w http.ResponseWriter, r *http.Request) {
 	}
 
 	resp := dto.UserResponse{
-		User: dto.UserDto{
-			UserID:   user.UserID,
-			Username: user.Username,
-			TeamName: user.TeamName,
-			IsActive: user.IsActive,
-		},
+		User: toUserDto(*user),
 	}
 
 	writeJSON(w, http.StatusOK, resp)
 }
 
+func (h *UserHandlers) SetMaxOpenReviews(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	var req dto.UserSetMaxOpenReviewsRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if !validateRequest(w, req) {
+		return
+	}
+
+	user, err := h.userService.SetMaxOpenReviews(r.Context(), req.UserID, req.MaxOpenReviews)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, dto.UserResponse{User: toUserDto(*user)})
+}
+
+func (h *UserHandlers) SetSkills(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	var req dto.UserSetSkillsRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if !validateRequest(w, req) {
+		return
+	}
+
+	user, err := h.userService.SetSkills(r.Context(), req.UserID, req.Skills)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, dto.UserResponse{User: toUserDto(*user)})
+}
+
 func (h *UserHandlers) GetReview(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		writeMethodNotAllowed(w)
 		return
 	}
 
-	userID := r.URL.Query().Get("user_id")
-	if userID == "" {
-		writeBadRequest(w, "user_id is required")
+	req := dto.UserGetReviewRequest{UserID: r.URL.Query().Get("user_id")}
+	if !validateRequest(w, req) {
 		return
 	}
 
-	id, prs, err := h.userService.GetReview(r.Context(), userID)
+	id, prs, err := h.userService.GetReview(r.Context(), req.UserID)
 	if err != nil {
 		writeDomainError(w, err)
 		return
@@ -86,3 +132,205 @@ func (h *UserHandlers) GetReview(w http.ResponseWriter, r *http.Request) {
 
 	writeJSON(w, http.StatusOK, resp)
 }
+
+func (h *UserHandlers) MoveTeam(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	var req dto.UserMoveTeamRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if !validateRequest(w, req) {
+		return
+	}
+
+	user, err := h.userService.MoveTeam(r.Context(), req.UserID, req.TeamName, req.ReassignReviews)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	resp := dto.UserResponse{
+		User: toUserDto(*user),
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *UserHandlers) Get(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	req := dto.UserGetRequest{UserID: r.URL.Query().Get("user_id")}
+	if !validateRequest(w, req) {
+		return
+	}
+
+	user, err := h.userService.Get(r.Context(), req.UserID)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, dto.UserSummaryResponse{User: toUserSummaryDto(*user)})
+}
+
+func (h *UserHandlers) List(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	q := r.URL.Query()
+	req := dto.UserListRequest{
+		TeamName: q.Get("team_name"),
+		IsActive: q.Get("is_active"),
+		Username: q.Get("username"),
+		Cursor:   q.Get("cursor"),
+		Limit:    q.Get("limit"),
+	}
+	if !validateRequest(w, req) {
+		return
+	}
+
+	users, next, err := h.userService.List(r.Context(), service.UserListFilter{
+		TeamName:    req.TeamName,
+		IsActive:    req.ActiveFlag(),
+		Username:    req.Username,
+		AfterUserID: req.AfterUserID(),
+		Limit:       req.PageLimit(),
+	})
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	resp := dto.UserListResponse{
+		Users: make([]dto.UserSummaryDto, 0, len(users)),
+	}
+	for _, u := range users {
+		resp.Users = append(resp.Users, toUserSummaryDto(u))
+	}
+	if next != "" {
+		resp.NextCursor = dto.EncodeUserCursor(next)
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func toUserDto(u domain.User) dto.UserDto {
+	return dto.UserDto{
+		UserID:         u.UserID,
+		Username:       u.Username,
+		TeamName:       u.TeamName,
+		IsActive:       u.IsActive,
+		MaxOpenReviews: u.MaxOpenReviews,
+		Skills:         u.Skills,
+	}
+}
+
+func toUserSummaryDto(u domain.UserSummary) dto.UserSummaryDto {
+	return dto.UserSummaryDto{
+		UserDto:         toUserDto(u.User),
+		OpenReviewCount: u.OpenReviewCount,
+	}
+}
+
+func (h *UserHandlers) AddUnavailability(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	var req dto.UserAddUnavailabilityRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if !validateRequest(w, req) {
+		return
+	}
+
+	u, err := h.availabilityService.Add(r.Context(), req.UserID, req.StartsAt, req.EndsAt, req.Reason)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, dto.UnavailabilityResponse{Unavailability: toUnavailabilityDto(*u)})
+}
+
+func (h *UserHandlers) GetUnavailability(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	req := dto.UserUnavailabilityRequest{UserID: r.URL.Query().Get("user_id")}
+	if !validateRequest(w, req) {
+		return
+	}
+
+	periods, err := h.availabilityService.List(r.Context(), req.UserID)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	resp := dto.UserUnavailabilityResponse{
+		UserID:         req.UserID,
+		Unavailability: make([]dto.UnavailabilityDto, 0, len(periods)),
+	}
+	for _, p := range periods {
+		resp.Unavailability = append(resp.Unavailability, toUnavailabilityDto(p))
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *UserHandlers) RemoveUnavailability(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	var req dto.UserRemoveUnavailabilityRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if !validateRequest(w, req) {
+		return
+	}
+
+	u, err := h.availabilityService.Remove(r.Context(), req.UnavailabilityID)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, dto.UnavailabilityResponse{Unavailability: toUnavailabilityDto(*u)})
+}
+
+func toUnavailabilityDto(u domain.Unavailability) dto.UnavailabilityDto {
+	var releasedAt *string
+	if u.ReleasedAt != nil {
+		s := u.ReleasedAt.UTC().Format(time.RFC3339)
+		releasedAt = &s
+	}
+
+	return dto.UnavailabilityDto{
+		UnavailabilityID: u.ID,
+		UserID:           u.UserID,
+		StartsAt:         u.StartsAt.UTC().Format(time.RFC3339),
+		EndsAt:           u.EndsAt.UTC().Format(time.RFC3339),
+		Reason:           u.Reason,
+		ReleasedAt:       releasedAt,
+	}
+}
@@ -5,16 +5,34 @@ import (
 	"errors"
 	"log"
 	"net/http"
+	"pr-reviewer-assigment-service/internal/api/dto"
 	"pr-reviewer-assigment-service/internal/domain"
 )
 
+// Коды ошибок транспортного уровня (не относятся к домену).
+const (
+	CodeUnauthorized = "UNAUTHORIZED"
+	CodeForbidden    = "FORBIDDEN"
+	CodeRateLimited  = "RATE_LIMITED"
+	CodeInternal     = "INTERNAL_ERROR"
+	CodeBadRequest   = "BAD_REQUEST"
+
+	CodeValidationError   = string(domain.ErrorValidation)
+	CodeContractViolation = "CONTRACT_VIOLATION"
+
+	CodeInvalidIdempotencyKey = "INVALID_IDEMPOTENCY_KEY"
+	CodeIdempotencyKeyReused  = "IDEMPOTENCY_KEY_REUSED"
+	CodeIdempotencyInProgress = "IDEMPOTENCY_IN_PROGRESS"
+)
+
 type errorResponse struct {
 	Error errorBody `json:"error"`
 }
 
 type errorBody struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
+	Code    string           `json:"code"`
+	Message string           `json:"message"`
+	Details []dto.FieldError `json:"details,omitempty"`
 }
 
 func writeJSON(w http.ResponseWriter, status int, v any) {
@@ -23,15 +41,55 @@ func writeJSON(w http.ResponseWriter, status int, v any) {
 	_ = json.NewEncoder(w).Encode(v)
 }
 
+// WriteError пишет ответ с ошибкой в формате errorResponse.
+// Используется middleware, которые не имеют доступа к доменным ошибкам.
+func WriteError(w http.ResponseWriter, status int, code string, message string) {
+	writeJSON(w, status, errorResponse{
+		Error: errorBody{
+			Code:    code,
+			Message: message,
+		},
+	})
+}
+
+// WriteValidationError пишет 400 VALIDATION_ERROR со списком ошибок по полям.
+func WriteValidationError(w http.ResponseWriter, fields []dto.FieldError) {
+	writeJSON(w, http.StatusBadRequest, errorResponse{
+		Error: errorBody{
+			Code:    CodeValidationError,
+			Message: "request validation failed",
+			Details: fields,
+		},
+	})
+}
+
 func writeBadRequest(w http.ResponseWriter, message string) {
 	writeJSON(w, http.StatusBadRequest, errorResponse{
 		Error: errorBody{
-			Code:    string(domain.ErrorNotFound),
+			Code:    CodeBadRequest,
 			Message: message,
 		},
 	})
 }
 
+// validateRequest проверяет запрос и при ошибке пишет 400 VALIDATION_ERROR со списком полей.
+// Возвращает false, если обработку нужно прекратить.
+func validateRequest(w http.ResponseWriter, req interface{ Validate() error }) bool {
+	err := req.Validate()
+	if err == nil {
+		return true
+	}
+
+	var vErr *dto.ValidationError
+	if !errors.As(err, &vErr) {
+		writeBadRequest(w, err.Error())
+		return false
+	}
+
+	WriteValidationError(w, vErr.Fields)
+	return false
+}
+
 func writeMethodNotAllowed(w http.ResponseWriter) {
 	w.Header().Set("Allow", "GET, POST")
 	writeJSON(w, http.StatusMethodNotAllowed, errorResponse{
@@ -59,7 +117,16 @@ func writeDomainError(w http.ResponseWriter, err error) {
 			domain.ErrorPRExists,
 			domain.ErrorNotAssigned,
 			domain.ErrorNoCandidate,
-			domain.ErrorPRMerged:
+			domain.ErrorPRMerged,
+			domain.ErrorHasOpenReviews,
+			domain.ErrorUserInOtherTeam,
+			domain.ErrorTeamHasOpenPRs,
+			domain.ErrorAlreadyAssigned,
+			domain.ErrorReviewerLimit,
+			domain.ErrorRuleViolation,
+			domain.ErrorReviewerUnavailable,
+			domain.ErrorReviewerAtCapacity,
+			domain.ErrorImportConflict:
 			writeJSON(w, http.StatusConflict, errorResponse{
 				Error: errorBody{
 					Code:    string(dErr.Code),
@@ -80,7 +147,7 @@ func writeDomainError(w http.ResponseWriter, err error) {
 
 	writeJSON(w, http.StatusInternalServerError, errorResponse{
 		Error: errorBody{
-			Code:    "INTERNAL_ERROR",
+			Code:    CodeInternal,
 			Message: "internal server error",
 		},
 	})
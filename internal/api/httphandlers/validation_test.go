@@ -0,0 +1,109 @@
+package httphandlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"pr-reviewer-assigment-service/internal/api/dto"
+	"pr-reviewer-assigment-service/internal/api/httphandlers"
+)
+
+type errorResponse struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+		Details []struct {
+			Field  string `json:"field"`
+			Reason string `json:"reason"`
+		} `json:"details"`
+	} `json:"error"`
+}
+
+// Невалидные запросы отклоняются до обращения к сервисам, поэтому сервисы не нужны.
+func TestHandlers_ValidationErrors(t *testing.T) {
+	teamHandlers := httphandlers.NewTeamHandlers(nil)
+	userHandlers := httphandlers.NewUserHandlers(nil, nil)
+	prHandlers := httphandlers.NewPullRequestHandlers(nil)
+	codeOwnerHandlers := httphandlers.NewCodeOwnerHandlers(nil)
+	ruleHandlers := httphandlers.NewRuleHandlers(nil)
+	slaHandlers := httphandlers.NewSLAHandlers(nil)
+	adminHandlers := httphandlers.NewAdminHandlers(nil, nil, nil)
+
+	cases := []struct {
+		name      string
+		handler   http.HandlerFunc
+		method    string
+		target    string
+		body      string
+		wantField string
+	}{
+		{"team add", teamHandlers.Add, http.MethodPost, "/team/add", `{"team_name":"backend","members":[{"user_id":"","username":"Alice"}]}`, "members[0].user_id"},
+		{"team get", teamHandlers.Get, http.MethodGet, "/team/get", "", "team_name"},
+		{"team list", teamHandlers.List, http.MethodGet, "/team/list?limit=abc", "", "limit"},
+		{"team deactivate", teamHandlers.DeactivateMembers, http.MethodPatch, "/team/deactivate?team_name=", "", "team_name"},
+		{"team add members", teamHandlers.AddMembers, http.MethodPost, "/team/addMembers", `{"team_name":"backend","members":[{"user_id":"u1","username":""}]}`, "members[0].username"},
+		{"team remove members", teamHandlers.RemoveMembers, http.MethodPost, "/team/removeMembers", `{"team_name":"backend","user_ids":[]}`, "user_ids"},
+		{"team rename", teamHandlers.Rename, http.MethodPost, "/team/rename", `{"team_name":"backend","new_team_name":" core"}`, "new_team_name"},
+		{"team delete", teamHandlers.Delete, http.MethodPost, "/team/delete", `{"team_name":"backend"}`, "strategy"},
+		{"users move team", userHandlers.MoveTeam, http.MethodPost, "/users/moveTeam", `{"user_id":"u1","team_name":""}`, "team_name"},
+		{"users get", userHandlers.Get, http.MethodGet, "/users/get", "", "user_id"},
+		{"users list", userHandlers.List, http.MethodGet, "/users/list?cursor=%25%25", "", "cursor"},
+		{"users add unavailability", userHandlers.AddUnavailability, http.MethodPost, "/users/addUnavailability", `{"user_id":"u1","starts_at":"2025-07-01T00:00:00Z"}`, "ends_at"},
+		{"users unavailability", userHandlers.GetUnavailability, http.MethodGet, "/users/unavailability", "", "user_id"},
+		{"users remove unavailability", userHandlers.RemoveUnavailability, http.MethodPost, "/users/removeUnavailability", `{}`, "unavailability_id"},
+		{"users set max open reviews", userHandlers.SetMaxOpenReviews, http.MethodPost, "/users/setMaxOpenReviews", `{"user_id":"u1","max_open_reviews":-2}`, "max_open_reviews"},
+		{"users set skills", userHandlers.SetSkills, http.MethodPost, "/users/setSkills", `{"user_id":"u1","skills":["go",""]}`, "skills[1]"},
+		{"users set is active", userHandlers.SetIsActive, http.MethodPost, "/users/setIsActive", `{"user_id":"bad id","is_active":true}`, "user_id"},
+		{"users get review", userHandlers.GetReview, http.MethodGet, "/users/getReview?user_id=%20", "", "user_id"},
+		{"pr create", prHandlers.Create, http.MethodPost, "/pullRequest/create", `{"pull_request_id":"pr-1","pull_request_name":"Add"}`, "author_id"},
+		{"pr create labels", prHandlers.Create, http.MethodPost, "/pullRequest/create", `{"pull_request_id":"pr-1","pull_request_name":"Add","author_id":"u1","labels":[" go"]}`, "labels[0]"},
+		{"pr merge", prHandlers.Merge, http.MethodPost, "/pullRequest/merge", `{}`, "pull_request_id"},
+		{"pr reassign", prHandlers.Reassign, http.MethodPost, "/pullRequest/reassign", `{"pull_request_id":"pr-1"}`, "old_user_id"},
+		{"pr preview", prHandlers.PreviewAssignment, http.MethodPost, "/pullRequest/previewAssignment", `{"labels":["go"]}`, "author_id"},
+		{"pr add reviewer", prHandlers.AddReviewer, http.MethodPost, "/pullRequest/addReviewer", `{"pull_request_id":"pr-1"}`, "user_id"},
+		{"pr remove reviewer", prHandlers.RemoveReviewer, http.MethodPost, "/pullRequest/removeReviewer", `{"pull_request_id":"","user_id":"u2"}`, "pull_request_id"},
+		{"code owners upload", codeOwnerHandlers.Upload, http.MethodPost, "/codeOwners/upload", `{"content":"` + strings.Repeat("a", dto.MaxCodeOwnersLength+1) + `"}`, "content"},
+		{"rule add", ruleHandlers.Add, http.MethodPost, "/rules/add", `{"kind":"EXCLUDE","author":{"user_id":"u1"},"reviewer":{}}`, "reviewer"},
+		{"rule delete", ruleHandlers.Delete, http.MethodPost, "/rules/delete", `{"rule_id":""}`, "rule_id"},
+		{"rule evaluate", ruleHandlers.Evaluate, http.MethodPost, "/rules/evaluate", `{}`, "author_id"},
+		{"team review sla", slaHandlers.SetTeamSLA, http.MethodPost, "/team/setReviewSLA", `{"team_name":"backend","review_sla_hours":1000}`, "review_sla_hours"},
+		{"pr overdue", slaHandlers.Overdue, http.MethodGet, "/pullRequest/overdue?team_name=" + strings.Repeat("t", dto.MaxNameLength+1), "", "team_name"},
+		{"admin import", adminHandlers.Import, http.MethodPost, "/admin/import?dry_run=maybe", "", "dry_run"},
+		{"admin export", adminHandlers.Export, http.MethodGet, "/admin/export?format=xml", "", "format"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.target, strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			tc.handler(rec, req)
+
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("expected 400, got %d: %s", rec.Code, rec.Body.String())
+			}
+
+			var body errorResponse
+			if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if body.Error.Code != "VALIDATION_ERROR" {
+				t.Fatalf("expected VALIDATION_ERROR, got %s", body.Error.Code)
+			}
+
+			found := false
+			for _, d := range body.Error.Details {
+				if d.Field == tc.wantField && d.Reason != "" {
+					found = true
+				}
+			}
+			if !found {
+				t.Fatalf("expected details for %s, got %+v", tc.wantField, body.Error.Details)
+			}
+		})
+	}
+}
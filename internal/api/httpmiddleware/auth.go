@@ -0,0 +1,117 @@
+package httpmiddleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"pr-reviewer-assigment-service/internal/api/httphandlers"
+)
+
+// Role - роль клиента API.
+type Role string
+
+const (
+	RoleAdmin    Role = "admin"
+	RoleTeamLead Role = "team-lead"
+	RoleBot      Role = "bot"
+)
+
+// ParseRole проверяет, что роль известна сервису.
+func ParseRole(s string) (Role, error) {
+	switch Role(s) {
+	case RoleAdmin, RoleTeamLead, RoleBot:
+		return Role(s), nil
+	}
+	return "", errors.New("unknown role: " + s)
+}
+
+// Principal описывает аутентифицированного клиента.
+type Principal struct {
+	Subject string // Идентификатор клиента (имя токена или sub из JWT); для team-lead - его user_id
+	Role    Role   // Роль клиента
+}
+
+var (
+	// ErrNoCredentials - аутентификатор не распознал учётные данные запроса.
+	ErrNoCredentials = errors.New("no credentials")
+	// ErrInvalidCredentials - учётные данные распознаны, но недействительны.
+	ErrInvalidCredentials = errors.New("invalid credentials")
+)
+
+// Authenticator определяет клиента по запросу.
+// Если учётные данные не относятся к аутентификатору - возвращает ErrNoCredentials,
+// чтобы Authenticate попробовал следующий.
+type Authenticator interface {
+	Authenticate(r *http.Request) (*Principal, error)
+}
+
+type principalKey struct{}
+
+// PrincipalFromContext возвращает клиента, положенного в контекст middleware Authenticate.
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok
+}
+
+// WithPrincipal кладёт клиента в контекст.
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// bearerToken достаёт токен из заголовка Authorization: Bearer <token>.
+func bearerToken(r *http.Request) string {
+	h := r.Header.Get("Authorization")
+	const prefix = "bearer "
+	if len(h) < len(prefix) || !strings.EqualFold(h[:len(prefix)], prefix) {
+		return ""
+	}
+	return strings.TrimSpace(h[len(prefix):])
+}
+
+// Authenticate возвращает middleware, которое аутентифицирует запрос цепочкой аутентификаторов
+// и проверяет доступ к маршруту по политике.
+// Без учётных данных или с недействительными - 401, при нехватке прав - 403.
+func Authenticate(policy *Policy, authenticators ...Authenticator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if policy.IsPublic(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			principal, err := authenticate(r, authenticators)
+			if err != nil {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="pr-reviewer"`)
+				httphandlers.WriteError(w, http.StatusUnauthorized, httphandlers.CodeUnauthorized, err.Error())
+				return
+			}
+
+			if !policy.Allows(principal, r) {
+				httphandlers.WriteError(w, http.StatusForbidden, httphandlers.CodeForbidden,
+					"role "+string(principal.Role)+" is not allowed to "+r.Method+" "+r.URL.Path)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), principal)))
+		})
+	}
+}
+
+func authenticate(r *http.Request, authenticators []Authenticator) (*Principal, error) {
+	for _, a := range authenticators {
+		p, err := a.Authenticate(r)
+		if err == nil {
+			return p, nil
+		}
+		if !errors.Is(err, ErrNoCredentials) {
+			return nil, ErrInvalidCredentials
+		}
+	}
+
+	if bearerToken(r) != "" || r.Header.Get(apiTokenHeader) != "" {
+		return nil, ErrInvalidCredentials
+	}
+	return nil, ErrNoCredentials
+}
@@ -0,0 +1,235 @@
+package httpmiddleware_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"pr-reviewer-assigment-service/internal/api/httpmiddleware"
+)
+
+func newAuthHandler() http.Handler {
+	return newAuthHandlerWithTeams(map[string]string{"alice": "backend", "bob": "backend"})
+}
+
+// newAuthHandlerWithTeams собирает обработчик, где команды пользователей берутся из userTeams
+// в момент запроса - как текущие команды из базы.
+func newAuthHandlerWithTeams(userTeams map[string]string) http.Handler {
+	tokens := []httpmiddleware.StaticToken{
+		{Token: "admin-token", Principal: httpmiddleware.Principal{Subject: "root", Role: httpmiddleware.RoleAdmin}},
+		{Token: "lead-token", Principal: httpmiddleware.Principal{Subject: "alice", Role: httpmiddleware.RoleTeamLead}},
+		{Token: "bot-token", Principal: httpmiddleware.Principal{Subject: "ci", Role: httpmiddleware.RoleBot}},
+	}
+
+	prTeams := map[string]string{"pr-backend": "backend", "pr-payments": "payments"}
+	teamOf := func(_ context.Context, prID string) (string, error) {
+		team, ok := prTeams[prID]
+		if !ok {
+			return "", errors.New("pull request not found")
+		}
+		return team, nil
+	}
+	teamOfUser := func(_ context.Context, userID string) (string, error) {
+		team, ok := userTeams[userID]
+		if !ok {
+			return "", errors.New("user not found")
+		}
+		return team, nil
+	}
+
+	mw := httpmiddleware.Authenticate(
+		httpmiddleware.DefaultPolicy(teamOf, teamOfUser),
+		httpmiddleware.NewJWTAuthenticator([]byte("secret")),
+		httpmiddleware.NewStaticTokenAuthenticator(tokens),
+	)
+
+	return mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := httpmiddleware.PrincipalFromContext(r.Context()); !ok && r.URL.Path != "/health" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.Copy(w, r.Body) // Тело должно дойти до хендлера
+	}))
+}
+
+func signJWT(t *testing.T, secret string, claims map[string]any) string {
+	t.Helper()
+
+	enc := func(v any) string {
+		b, err := json.Marshal(v)
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		return base64.RawURLEncoding.EncodeToString(b)
+	}
+
+	unsigned := enc(map[string]string{"alg": "HS256", "typ": "JWT"}) + "." + enc(claims)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unsigned))
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func doRequest(h http.Handler, method, target, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestAuthenticate_StaticTokens(t *testing.T) {
+	h := newAuthHandler()
+
+	cases := []struct {
+		name   string
+		method string
+		target string
+		token  string
+		want   int
+	}{
+		{"public health", http.MethodGet, "/health", "", http.StatusOK},
+		{"no credentials", http.MethodGet, "/team/get?team_name=backend", "", http.StatusUnauthorized},
+		{"unknown token", http.MethodGet, "/team/get?team_name=backend", "nope", http.StatusUnauthorized},
+		{"admin deactivates any team", http.MethodPatch, "/team/deactivate?team_name=payments", "admin-token", http.StatusOK},
+		{"lead deactivates own team", http.MethodPatch, "/team/deactivate?team_name=backend", "lead-token", http.StatusOK},
+		{"lead deactivates other team", http.MethodPatch, "/team/deactivate?team_name=payments", "lead-token", http.StatusForbidden},
+		{"bot creates pr", http.MethodPost, "/pullRequest/create", "bot-token", http.StatusOK},
+		{"bot merges pr", http.MethodPost, "/pullRequest/merge", "bot-token", http.StatusOK},
+		{"bot deactivates team", http.MethodPatch, "/team/deactivate?team_name=backend", "bot-token", http.StatusForbidden},
+		{"lead creates team", http.MethodPost, "/team/add", "lead-token", http.StatusForbidden},
+		{"bot reads stats", http.MethodGet, "/stats/reviewers", "bot-token", http.StatusOK},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := doRequest(h, tc.method, tc.target, tc.token)
+			if rec.Code != tc.want {
+				t.Fatalf("expected %d, got %d: %s", tc.want, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestAuthenticate_TeamLeadOwnPullRequests(t *testing.T) {
+	h := newAuthHandler()
+
+	cases := []struct {
+		name  string
+		path  string
+		body  string
+		token string
+		want  int
+	}{
+		{"lead reassigns own team pr", "/pullRequest/reassign", `{"pull_request_id":"pr-backend","old_user_id":"u1"}`, "lead-token", http.StatusOK},
+		{"lead adds reviewer to own team pr", "/pullRequest/addReviewer", `{"pull_request_id":"pr-backend","user_id":"u1"}`, "lead-token", http.StatusOK},
+		{"lead removes reviewer from other team pr", "/pullRequest/removeReviewer", `{"pull_request_id":"pr-payments","user_id":"u1"}`, "lead-token", http.StatusForbidden},
+		{"lead reassigns other team pr", "/pullRequest/reassign", `{"pull_request_id":"pr-payments","old_user_id":"u1"}`, "lead-token", http.StatusForbidden},
+		{"lead reassigns unknown pr", "/pullRequest/reassign", `{"pull_request_id":"pr-missing","old_user_id":"u1"}`, "lead-token", http.StatusForbidden},
+		{"lead sends invalid body", "/pullRequest/reassign", `{`, "lead-token", http.StatusForbidden},
+		{"admin reassigns any pr", "/pullRequest/reassign", `{"pull_request_id":"pr-payments","old_user_id":"u1"}`, "admin-token", http.StatusOK},
+		{"bot reassigns pr", "/pullRequest/reassign", `{"pull_request_id":"pr-backend","old_user_id":"u1"}`, "bot-token", http.StatusForbidden},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tc.path, strings.NewReader(tc.body))
+			req.Header.Set("Authorization", "Bearer "+tc.token)
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != tc.want {
+				t.Fatalf("expected %d, got %d: %s", tc.want, rec.Code, rec.Body.String())
+			}
+			if rec.Code == http.StatusOK && rec.Body.String() != tc.body {
+				t.Fatalf("expected handler to receive body %s, got %s", tc.body, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestAuthenticate_TeamLeadFollowsCurrentTeam(t *testing.T) {
+	userTeams := map[string]string{"alice": "backend"}
+	h := newAuthHandlerWithTeams(userTeams)
+
+	// Команду переименовали: доступ следует за участником, а не за старым именем.
+	userTeams["alice"] = "platform"
+	if rec := doRequest(h, http.MethodPatch, "/team/deactivate?team_name=backend", "lead-token"); rec.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for the old team name, got %d", rec.Code)
+	}
+	if rec := doRequest(h, http.MethodPatch, "/team/deactivate?team_name=platform", "lead-token"); rec.Code != http.StatusOK {
+		t.Fatalf("expected 200 for the current team, got %d", rec.Code)
+	}
+
+	// Руководитель вне команд ничем не руководит.
+	userTeams["alice"] = ""
+	if rec := doRequest(h, http.MethodPatch, "/team/deactivate?team_name=", "lead-token"); rec.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for a lead without a team, got %d", rec.Code)
+	}
+}
+
+func TestAuthenticate_ErrorBody(t *testing.T) {
+	h := newAuthHandler()
+
+	rec := doRequest(h, http.MethodPost, "/team/add", "bot-token")
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d", rec.Code)
+	}
+
+	var body struct {
+		Error struct {
+			Code string `json:"code"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if body.Error.Code != "FORBIDDEN" {
+		t.Fatalf("expected FORBIDDEN, got %s", body.Error.Code)
+	}
+}
+
+func TestAuthenticate_JWT(t *testing.T) {
+	h := newAuthHandler()
+	exp := time.Now().Add(time.Hour).Unix()
+
+	valid := signJWT(t, "secret", map[string]any{"sub": "bob", "role": "team-lead", "exp": exp})
+	rec := doRequest(h, http.MethodPatch, "/team/deactivate?team_name=backend", valid)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200 for valid jwt, got %d", rec.Code)
+	}
+
+	rec = doRequest(h, http.MethodPatch, "/team/deactivate?team_name=payments", valid)
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for other team, got %d", rec.Code)
+	}
+
+	expired := signJWT(t, "secret", map[string]any{"sub": "bob", "role": "admin", "exp": time.Now().Add(-time.Minute).Unix()})
+	rec = doRequest(h, http.MethodGet, "/stats/reviewers", expired)
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for expired jwt, got %d", rec.Code)
+	}
+
+	forged := signJWT(t, "other-secret", map[string]any{"sub": "bob", "role": "admin", "exp": exp})
+	rec = doRequest(h, http.MethodGet, "/stats/reviewers", forged)
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for forged jwt, got %d", rec.Code)
+	}
+
+	unknownRole := signJWT(t, "secret", map[string]any{"sub": "bob", "role": "root", "exp": exp})
+	rec = doRequest(h, http.MethodGet, "/stats/reviewers", unknownRole)
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for unknown role, got %d", rec.Code)
+	}
+}
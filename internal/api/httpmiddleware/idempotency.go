@@ -0,0 +1,185 @@
+package httpmiddleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"pr-reviewer-assigment-service/internal/api/httphandlers"
+	"pr-reviewer-assigment-service/internal/application/repository"
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+const (
+	idempotencyKeyHeader      = "Idempotency-Key"
+	idempotencyReplayedHeader = "Idempotent-Replayed"
+	maxIdempotencyKeyLength   = 255
+
+	// idempotencyLockTimeout - сколько ждём завершения исходного запроса,
+	// прежде чем считать его брошенным (например, сервис упал посреди обработки).
+	idempotencyLockTimeout = time.Minute
+)
+
+// Idempotency сохраняет ответы на POST/PATCH-запросы с заголовком Idempotency-Key
+// и воспроизводит их при повторе с тем же ключом.
+type Idempotency struct {
+	repo repository.IdempotencyRepository
+	ttl  time.Duration
+	now  func() time.Time
+}
+
+func NewIdempotency(repo repository.IdempotencyRepository, ttl time.Duration) *Idempotency {
+	return &Idempotency{repo: repo, ttl: ttl, now: time.Now}
+}
+
+// Middleware:
+//   - повтор с тем же ключом и запросом получает исходный статус и тело;
+//   - повтор с тем же ключом, но другим запросом - 422 IDEMPOTENCY_KEY_REUSED;
+//   - повтор, пока исходный запрос выполняется - 409 IDEMPOTENCY_IN_PROGRESS.
+//
+// Ответы 5xx не сохраняются, чтобы запрос можно было повторить.
+func (m *Idempotency) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headerKey := r.Header.Get(idempotencyKeyHeader)
+		if headerKey == "" || (r.Method != http.MethodPost && r.Method != http.MethodPatch) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if len(headerKey) > maxIdempotencyKeyLength {
+			httphandlers.WriteError(w, http.StatusBadRequest, httphandlers.CodeInvalidIdempotencyKey,
+				"Idempotency-Key must be at most 255 characters")
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, httphandlers.MaxBodySize(r.URL.Path)))
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				httphandlers.WriteError(w, http.StatusRequestEntityTooLarge, httphandlers.CodePayloadTooLarge,
+					"request body is too large")
+				return
+			}
+			httphandlers.WriteError(w, http.StatusBadRequest, httphandlers.CodeBadRequest, "failed to read request body")
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		now := m.now().UTC()
+		rec := &domain.IdempotencyRecord{
+			Key:         idempotencyStorageKey(r, headerKey),
+			RequestHash: requestHash(r, body),
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(m.ttl),
+		}
+
+		existing, err := m.repo.Reserve(r.Context(), rec, now.Add(-idempotencyLockTimeout))
+		if err != nil {
+			if !errors.Is(err, repository.ErrAlreadyExists) {
+				log.Printf("idempotency reserve: %v", err)
+				httphandlers.WriteError(w, http.StatusInternalServerError, httphandlers.CodeInternal, "internal server error")
+				return
+			}
+			m.replay(w, rec, existing)
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+
+		// Сохраняем результат, даже если клиент уже отключился.
+		ctx := context.WithoutCancel(r.Context())
+		if recorder.status >= http.StatusInternalServerError {
+			if err := m.repo.Release(ctx, rec.Key); err != nil {
+				log.Printf("idempotency release: %v", err)
+			}
+			return
+		}
+		if err := m.repo.Complete(ctx, rec.Key, recorder.status, recorder.Header().Get("Content-Type"), recorder.body.Bytes()); err != nil {
+			log.Printf("idempotency complete: %v", err)
+		}
+	})
+}
+
+func (m *Idempotency) replay(w http.ResponseWriter, rec, existing *domain.IdempotencyRecord) {
+	if existing == nil {
+		// Ключ занимали и освобождали параллельные запросы, пока мы пытались его занять.
+		httphandlers.WriteError(w, http.StatusConflict, httphandlers.CodeIdempotencyInProgress,
+			"request with this Idempotency-Key is still in progress")
+		return
+	}
+	if existing.RequestHash != rec.RequestHash {
+		httphandlers.WriteError(w, http.StatusUnprocessableEntity, httphandlers.CodeIdempotencyKeyReused,
+			"Idempotency-Key was already used for a different request")
+		return
+	}
+	if !existing.Completed() {
+		httphandlers.WriteError(w, http.StatusConflict, httphandlers.CodeIdempotencyInProgress,
+			"request with this Idempotency-Key is still in progress")
+		return
+	}
+
+	if existing.ContentType != "" {
+		w.Header().Set("Content-Type", existing.ContentType)
+	}
+	w.Header().Set(idempotencyReplayedHeader, "true")
+	w.WriteHeader(existing.StatusCode)
+	_, _ = w.Write(existing.Body)
+}
+
+// RunCleanup периодически удаляет просроченные ключи, пока не отменён ctx.
+func (m *Idempotency) RunCleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := m.repo.DeleteExpired(ctx, m.now().UTC()); err != nil {
+				log.Printf("idempotency cleanup: %v", err)
+			}
+		}
+	}
+}
+
+// idempotencyStorageKey привязывает ключ к клиенту, чтобы разные клиенты не видели ответы друг друга.
+func idempotencyStorageKey(r *http.Request, headerKey string) string {
+	sum := sha256.Sum256([]byte(clientKey(r) + "\x00" + headerKey))
+	return hex.EncodeToString(sum[:])
+}
+
+func requestHash(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method + " " + r.URL.RequestURI() + "\x00"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// responseRecorder пропускает ответ клиенту и параллельно запоминает статус и тело.
+type responseRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (rr *responseRecorder) WriteHeader(status int) {
+	if !rr.wroteHeader {
+		rr.status = status
+		rr.wroteHeader = true
+	}
+	rr.ResponseWriter.WriteHeader(status)
+}
+
+func (rr *responseRecorder) Write(b []byte) (int, error) {
+	rr.wroteHeader = true
+	rr.body.Write(b)
+	return rr.ResponseWriter.Write(b)
+}
@@ -0,0 +1,206 @@
+package httpmiddleware_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"pr-reviewer-assigment-service/internal/api/httphandlers"
+	"pr-reviewer-assigment-service/internal/api/httpmiddleware"
+	"pr-reviewer-assigment-service/internal/application/repository"
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+type mockIdempotencyRepo struct {
+	mu   sync.Mutex
+	data map[string]domain.IdempotencyRecord
+}
+
+func newMockIdempotencyRepo() *mockIdempotencyRepo {
+	return &mockIdempotencyRepo{data: make(map[string]domain.IdempotencyRecord)}
+}
+
+func (m *mockIdempotencyRepo) Reserve(ctx context.Context, rec *domain.IdempotencyRecord, staleBefore time.Time) (*domain.IdempotencyRecord, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if existing, ok := m.data[rec.Key]; ok && existing.ExpiresAt.After(rec.CreatedAt) {
+		return &existing, repository.ErrAlreadyExists
+	}
+	m.data[rec.Key] = *rec
+	return nil, nil
+}
+
+func (m *mockIdempotencyRepo) Complete(ctx context.Context, key string, statusCode int, contentType string, body []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	rec, ok := m.data[key]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	rec.StatusCode = statusCode
+	rec.ContentType = contentType
+	rec.Body = append([]byte(nil), body...)
+	m.data[key] = rec
+	return nil
+}
+
+func (m *mockIdempotencyRepo) Release(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.data, key)
+	return nil
+}
+
+func (m *mockIdempotencyRepo) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	return 0, nil
+}
+
+func postWithKey(h http.Handler, path, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Idempotency-Key", key)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestIdempotency_ReplaysResponse(t *testing.T) {
+	calls := 0
+	h := httpmiddleware.NewIdempotency(newMockIdempotencyRepo(), time.Hour).Middleware(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"pr":{"pull_request_id":"pr-1"}}`))
+		}),
+	)
+
+	first := postWithKey(h, "/pullRequest/create", "k1", `{"pull_request_id":"pr-1"}`)
+	second := postWithKey(h, "/pullRequest/create", "k1", `{"pull_request_id":"pr-1"}`)
+
+	if calls != 1 {
+		t.Fatalf("expected handler to run once, ran %d times", calls)
+	}
+	if second.Code != http.StatusCreated {
+		t.Fatalf("expected replayed 201, got %d", second.Code)
+	}
+	if second.Body.String() != first.Body.String() {
+		t.Fatalf("replayed body differs: %q vs %q", second.Body.String(), first.Body.String())
+	}
+	if second.Header().Get("Idempotent-Replayed") != "true" {
+		t.Fatalf("expected Idempotent-Replayed header")
+	}
+}
+
+func TestIdempotency_KeyReusedForDifferentRequest(t *testing.T) {
+	h := httpmiddleware.NewIdempotency(newMockIdempotencyRepo(), time.Hour).Middleware(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}),
+	)
+
+	postWithKey(h, "/pullRequest/reassign", "k1", `{"old_user_id":"u2"}`)
+	rec := postWithKey(h, "/pullRequest/reassign", "k1", `{"old_user_id":"u3"}`)
+
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422, got %d", rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), "IDEMPOTENCY_KEY_REUSED") {
+		t.Fatalf("expected IDEMPOTENCY_KEY_REUSED, got %s", rec.Body.String())
+	}
+}
+
+func TestIdempotency_ServerErrorIsNotStored(t *testing.T) {
+	calls := 0
+	h := httpmiddleware.NewIdempotency(newMockIdempotencyRepo(), time.Hour).Middleware(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			if calls == 1 {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}),
+	)
+
+	postWithKey(h, "/pullRequest/merge", "k1", `{}`)
+	rec := postWithKey(h, "/pullRequest/merge", "k1", `{}`)
+
+	if calls != 2 {
+		t.Fatalf("expected retry after 5xx to reach handler, calls=%d", calls)
+	}
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200 on retry, got %d", rec.Code)
+	}
+}
+
+func TestIdempotency_AcceptsLargeImport(t *testing.T) {
+	var received int
+	h := httpmiddleware.NewIdempotency(newMockIdempotencyRepo(), time.Hour).Middleware(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			received = len(body)
+			w.WriteHeader(http.StatusOK)
+		}),
+	)
+
+	// Файл импорта больше лимита обычных запросов, но в пределах лимита /admin/import.
+	body := strings.Repeat("x", 2*httphandlers.MaxRequestBodySize)
+	if rec := postWithKey(h, "/admin/import", "k1", body); rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if received != len(body) {
+		t.Fatalf("expected handler to receive %d bytes, got %d", len(body), received)
+	}
+
+	if rec := postWithKey(h, "/pullRequest/create", "k2", body); rec.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected 413 for a large body on a regular route, got %d", rec.Code)
+	}
+}
+
+// racingIdempotencyRepo - ключ занимали и освобождали параллельные запросы, занять его не удалось.
+type racingIdempotencyRepo struct {
+	*mockIdempotencyRepo
+}
+
+func (m *racingIdempotencyRepo) Reserve(ctx context.Context, rec *domain.IdempotencyRecord, staleBefore time.Time) (*domain.IdempotencyRecord, error) {
+	return nil, repository.ErrAlreadyExists
+}
+
+func TestIdempotency_ReserveRaceIsConflict(t *testing.T) {
+	h := httpmiddleware.NewIdempotency(&racingIdempotencyRepo{newMockIdempotencyRepo()}, time.Hour).Middleware(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Fatal("handler must not run without a reserved key")
+		}),
+	)
+
+	rec := postWithKey(h, "/pullRequest/merge", "k1", `{}`)
+	if rec.Code != http.StatusConflict || !strings.Contains(rec.Body.String(), "IDEMPOTENCY_IN_PROGRESS") {
+		t.Fatalf("expected 409 IDEMPOTENCY_IN_PROGRESS, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestIdempotency_IgnoresRequestsWithoutKey(t *testing.T) {
+	calls := 0
+	h := httpmiddleware.NewIdempotency(newMockIdempotencyRepo(), time.Hour).Middleware(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+		}),
+	)
+
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/create", strings.NewReader(`{}`))
+		h.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	if calls != 2 {
+		t.Fatalf("expected 2 calls without key, got %d", calls)
+	}
+}
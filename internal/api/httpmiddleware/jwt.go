@@ -0,0 +1,121 @@
+package httpmiddleware
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// JWTAuthenticator проверяет JWT, подписанные HMAC (HS256/HS384/HS512) общим секретом.
+// Проверка выполняется локально, без обращения к внешнему провайдеру.
+type JWTAuthenticator struct {
+	secret []byte
+	now    func() time.Time
+}
+
+func NewJWTAuthenticator(secret []byte) *JWTAuthenticator {
+	return &JWTAuthenticator{secret: secret, now: time.Now}
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ"`
+}
+
+// jwtClaims - поддерживаемые claims. role - приватный claim сервиса.
+type jwtClaims struct {
+	Subject   string `json:"sub"`
+	Role      string `json:"role"`
+	ExpiresAt *int64 `json:"exp"`
+	NotBefore *int64 `json:"nbf"`
+}
+
+// Authenticate проверяет bearer-токен, если он похож на JWT.
+// Токены другого вида пропускаются (ErrNoCredentials) для следующих аутентификаторов.
+func (a *JWTAuthenticator) Authenticate(r *http.Request) (*Principal, error) {
+	token := bearerToken(r)
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrNoCredentials
+	}
+
+	claims, err := a.verify(parts)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCredentials, err)
+	}
+
+	role, err := ParseRole(claims.Role)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCredentials, err)
+	}
+
+	return &Principal{
+		Subject: claims.Subject,
+		Role:    role,
+	}, nil
+}
+
+func (a *JWTAuthenticator) verify(parts []string) (*jwtClaims, error) {
+	headerBytes, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("decode header: %w", err)
+	}
+	var header jwtHeader
+	if err := json.Unmarshal(headerBytes, &header); err != nil {
+		return nil, fmt.Errorf("parse header: %w", err)
+	}
+
+	var newHash func() hash.Hash
+	switch header.Alg {
+	case "HS256":
+		newHash = sha256.New
+	case "HS384":
+		newHash = sha512.New384
+	case "HS512":
+		newHash = sha512.New
+	default:
+		return nil, fmt.Errorf("unsupported alg %q", header.Alg)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("decode signature: %w", err)
+	}
+	mac := hmac.New(newHash, a.secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, fmt.Errorf("signature mismatch")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("decode payload: %w", err)
+	}
+	var claims jwtClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("parse payload: %w", err)
+	}
+
+	now := a.now().Unix()
+	if claims.ExpiresAt == nil {
+		return nil, fmt.Errorf("exp claim is required")
+	}
+	if now >= *claims.ExpiresAt {
+		return nil, fmt.Errorf("token expired")
+	}
+	if claims.NotBefore != nil && now < *claims.NotBefore {
+		return nil, fmt.Errorf("token not valid yet")
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("sub claim is required")
+	}
+
+	return &claims, nil
+}
@@ -0,0 +1,455 @@
+package httpmiddleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	oaerrors "github.com/go-openapi/errors"
+	"github.com/go-openapi/spec"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag/yamlutils"
+	"github.com/go-openapi/validate"
+
+	"pr-reviewer-assigment-service/internal/api/dto"
+	"pr-reviewer-assigment-service/internal/api/httphandlers"
+)
+
+// OpenAPIMode - режим проверки запросов и ответов по OpenAPI-спецификации.
+type OpenAPIMode string
+
+const (
+	// OpenAPIOff - проверка выключена (по умолчанию, для продакшена).
+	OpenAPIOff OpenAPIMode = "off"
+	// OpenAPILog - нарушения только пишутся в лог.
+	OpenAPILog OpenAPIMode = "log"
+	// OpenAPIStrict - невалидный запрос получает 400 VALIDATION_ERROR,
+	// ответ, не соответствующий спецификации, заменяется на 500 CONTRACT_VIOLATION.
+	OpenAPIStrict OpenAPIMode = "strict"
+)
+
+// ParseOpenAPIMode разбирает режим проверки из строки конфигурации.
+func ParseOpenAPIMode(s string) (OpenAPIMode, error) {
+	switch m := OpenAPIMode(strings.ToLower(strings.TrimSpace(s))); m {
+	case "", OpenAPIOff:
+		return OpenAPIOff, nil
+	case OpenAPILog, OpenAPIStrict:
+		return m, nil
+	default:
+		return "", fmt.Errorf("unknown openapi validation mode %q", s)
+	}
+}
+
+// OpenAPIValidator проверяет запросы и ответы по встроенной OpenAPI 3 спецификации.
+// Проверяются только операции, описанные в спецификации: обязательные и типизированные
+// query/header-параметры, JSON-тело запроса, документированность статуса ответа и JSON-тело ответа.
+type OpenAPIValidator struct {
+	mode       OpenAPIMode
+	root       map[string]any
+	operations map[string]*openAPIOperation // ключ - "METHOD /path"
+	logf       func(format string, args ...any)
+}
+
+type openAPIOperation struct {
+	params       []openAPIParam
+	body         *spec.Schema
+	bodyRequired bool
+	responses    map[string]*openAPIResponse // ключ - код статуса или "default"
+}
+
+type openAPIParam struct {
+	name     string
+	in       string
+	required bool
+	schema   *spec.Schema
+}
+
+type openAPIResponse struct {
+	// schema == nil - тело ответа не описано и не проверяется.
+	schema *spec.Schema
+}
+
+// Структуры документа OpenAPI 3, нужные для проверки. Остальные поля игнорируются.
+type (
+	oasDocument struct {
+		Paths      map[string]map[string]json.RawMessage `json:"paths"`
+		Components struct {
+			Parameters    map[string]oasParameter   `json:"parameters"`
+			RequestBodies map[string]oasRequestBody `json:"requestBodies"`
+			Responses     map[string]oasResponse    `json:"responses"`
+		} `json:"components"`
+	}
+	oasOperation struct {
+		Parameters  []oasParameter         `json:"parameters"`
+		RequestBody *oasRequestBody        `json:"requestBody"`
+		Responses   map[string]oasResponse `json:"responses"`
+	}
+	oasParameter struct {
+		Ref      string          `json:"$ref"`
+		Name     string          `json:"name"`
+		In       string          `json:"in"`
+		Required bool            `json:"required"`
+		Schema   json.RawMessage `json:"schema"`
+	}
+	oasRequestBody struct {
+		Ref      string              `json:"$ref"`
+		Required bool                `json:"required"`
+		Content  map[string]oasMedia `json:"content"`
+	}
+	oasResponse struct {
+		Ref     string              `json:"$ref"`
+		Content map[string]oasMedia `json:"content"`
+	}
+	oasMedia struct {
+		Schema json.RawMessage `json:"schema"`
+	}
+)
+
+var httpMethods = map[string]bool{
+	"get": true, "put": true, "post": true, "delete": true,
+	"options": true, "head": true, "patch": true, "trace": true,
+}
+
+// NewOpenAPIValidator разбирает YAML-спецификацию и заранее раскрывает все $ref в схемах.
+func NewOpenAPIValidator(specYAML []byte, mode OpenAPIMode) (*OpenAPIValidator, error) {
+	yamlDoc, err := yamlutils.BytesToYAMLDoc(specYAML)
+	if err != nil {
+		return nil, fmt.Errorf("openapi: parse yaml: %w", err)
+	}
+	raw, err := yamlutils.YAMLToJSON(yamlDoc)
+	if err != nil {
+		return nil, fmt.Errorf("openapi: convert to json: %w", err)
+	}
+
+	v := &OpenAPIValidator{
+		mode:       mode,
+		operations: make(map[string]*openAPIOperation),
+		logf:       log.Printf,
+	}
+	if err := json.Unmarshal(raw, &v.root); err != nil {
+		return nil, fmt.Errorf("openapi: decode document: %w", err)
+	}
+	var doc oasDocument
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("openapi: decode document: %w", err)
+	}
+
+	for path, item := range doc.Paths {
+		for method, rawOp := range item {
+			if !httpMethods[method] {
+				continue
+			}
+			var op oasOperation
+			if err := json.Unmarshal(rawOp, &op); err != nil {
+				return nil, fmt.Errorf("openapi: %s %s: %w", method, path, err)
+			}
+			compiled, err := v.compile(&doc, op)
+			if err != nil {
+				return nil, fmt.Errorf("openapi: %s %s: %w", strings.ToUpper(method), path, err)
+			}
+			v.operations[strings.ToUpper(method)+" "+path] = compiled
+		}
+	}
+
+	return v, nil
+}
+
+// HasOperation сообщает, описана ли операция в спецификации.
+func (v *OpenAPIValidator) HasOperation(method, path string) bool {
+	_, ok := v.operations[method+" "+path]
+	return ok
+}
+
+func (v *OpenAPIValidator) compile(doc *oasDocument, op oasOperation) (*openAPIOperation, error) {
+	compiled := &openAPIOperation{responses: make(map[string]*openAPIResponse, len(op.Responses))}
+
+	for _, p := range op.Parameters {
+		if p.Ref != "" {
+			resolved, ok := doc.Components.Parameters[componentName(p.Ref, "parameters")]
+			if !ok {
+				return nil, fmt.Errorf("unresolved parameter %s", p.Ref)
+			}
+			p = resolved
+		}
+		schema, err := v.schema(p.Schema)
+		if err != nil {
+			return nil, fmt.Errorf("parameter %s: %w", p.Name, err)
+		}
+		compiled.params = append(compiled.params, openAPIParam{
+			name:     p.Name,
+			in:       p.In,
+			required: p.Required,
+			schema:   schema,
+		})
+	}
+
+	if body := op.RequestBody; body != nil {
+		if body.Ref != "" {
+			resolved, ok := doc.Components.RequestBodies[componentName(body.Ref, "requestBodies")]
+			if !ok {
+				return nil, fmt.Errorf("unresolved request body %s", body.Ref)
+			}
+			body = &resolved
+		}
+		schema, err := v.schema(body.Content["application/json"].Schema)
+		if err != nil {
+			return nil, fmt.Errorf("request body: %w", err)
+		}
+		compiled.body = schema
+		compiled.bodyRequired = body.Required
+	}
+
+	for status, resp := range op.Responses {
+		if resp.Ref != "" {
+			resolved, ok := doc.Components.Responses[componentName(resp.Ref, "responses")]
+			if !ok {
+				return nil, fmt.Errorf("unresolved response %s", resp.Ref)
+			}
+			resp = resolved
+		}
+		schema, err := v.schema(resp.Content["application/json"].Schema)
+		if err != nil {
+			return nil, fmt.Errorf("response %s: %w", status, err)
+		}
+		compiled.responses[status] = &openAPIResponse{schema: schema}
+	}
+
+	return compiled, nil
+}
+
+// schema превращает JSON-схему OpenAPI 3 в spec.Schema и раскрывает ссылки на components.
+func (v *OpenAPIValidator) schema(raw json.RawMessage) (*spec.Schema, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	var s spec.Schema
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return nil, err
+	}
+	if err := spec.ExpandSchema(&s, v.root, nil); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func componentName(ref, kind string) string {
+	return strings.TrimPrefix(ref, "#/components/"+kind+"/")
+}
+
+// Middleware проверяет запросы и ответы согласно режиму валидатора.
+// Маршруты, которых нет в спецификации (например, /swagger), пропускаются без проверки.
+func (v *OpenAPIValidator) Middleware(next http.Handler) http.Handler {
+	if v.mode == OpenAPIOff {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		op, ok := v.operations[r.Method+" "+r.URL.Path]
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		route := r.Method + " " + r.URL.Path
+
+		if violations := v.validateRequest(op, r); len(violations) > 0 {
+			v.logf("openapi: request %s does not match spec: %s", route, formatViolations(violations))
+			if v.mode == OpenAPIStrict {
+				httphandlers.WriteValidationError(w, violations)
+				return
+			}
+		}
+
+		recorder := &bufferedResponse{header: make(http.Header), status: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+
+		if violations := v.validateResponse(op, recorder); len(violations) > 0 {
+			v.logf("openapi: response %d for %s does not match spec: %s",
+				recorder.status, route, formatViolations(violations))
+			if v.mode == OpenAPIStrict {
+				httphandlers.WriteError(w, http.StatusInternalServerError, httphandlers.CodeContractViolation,
+					fmt.Sprintf("response %d does not match spec: %s", recorder.status, formatViolations(violations)))
+				return
+			}
+		}
+		recorder.flush(w)
+	})
+}
+
+func (v *OpenAPIValidator) validateRequest(op *openAPIOperation, r *http.Request) []dto.FieldError {
+	var violations []dto.FieldError
+
+	query := r.URL.Query()
+	for _, p := range op.params {
+		var (
+			value   string
+			present bool
+		)
+		switch p.in {
+		case "query":
+			present = query.Has(p.name)
+			value = query.Get(p.name)
+		case "header":
+			value = r.Header.Get(p.name)
+			present = value != ""
+		default:
+			continue
+		}
+		if !present {
+			if p.required {
+				violations = append(violations, dto.FieldError{Field: p.name, Reason: "is required"})
+			}
+			continue
+		}
+		violations = append(violations, v.validateValue(p.schema, p.name, p.in, paramValue(p.schema, value))...)
+	}
+
+	if op.body == nil || !isJSON(r.Header.Get("Content-Type")) {
+		// Неверный Content-Type отклонит сам хендлер (415).
+		return violations
+	}
+
+	maxSize := httphandlers.MaxBodySize(r.URL.Path)
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxSize+1))
+	if err != nil {
+		return violations
+	}
+	r.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), r.Body))
+	if int64(len(body)) > maxSize {
+		return violations // 413 вернёт хендлер
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		if op.bodyRequired {
+			violations = append(violations, dto.FieldError{Field: "body", Reason: "is required"})
+		}
+		return violations
+	}
+
+	var data any
+	if err := json.Unmarshal(body, &data); err != nil {
+		return violations // INVALID_JSON вернёт хендлер
+	}
+	return append(violations, v.validateValue(op.body, "", "body", data)...)
+}
+
+func (v *OpenAPIValidator) validateResponse(op *openAPIOperation, resp *bufferedResponse) []dto.FieldError {
+	documented, ok := op.responses[strconv.Itoa(resp.status)]
+	if !ok {
+		documented, ok = op.responses["default"]
+	}
+	if !ok {
+		return []dto.FieldError{{Field: "status", Reason: fmt.Sprintf("%d is not documented", resp.status)}}
+	}
+	if documented.schema == nil {
+		return nil
+	}
+	if !isJSON(resp.header.Get("Content-Type")) {
+		return []dto.FieldError{{Field: "Content-Type", Reason: "must be application/json"}}
+	}
+
+	var data any
+	if err := json.Unmarshal(resp.body.Bytes(), &data); err != nil {
+		return []dto.FieldError{{Field: "body", Reason: "is not valid JSON"}}
+	}
+	return v.validateValue(documented.schema, "", "body", data)
+}
+
+func (v *OpenAPIValidator) validateValue(schema *spec.Schema, name, in string, data any) []dto.FieldError {
+	if schema == nil {
+		return nil
+	}
+	res := validate.NewSchemaValidator(schema, v.root, name, strfmt.Default).Validate(data)
+	if res.IsValid() {
+		return nil
+	}
+
+	violations := make([]dto.FieldError, 0, len(res.Errors))
+	for _, err := range res.Errors {
+		field, reason := name, err.Error()
+		if vErr, ok := err.(*oaerrors.Validation); ok {
+			if vErr.Name != "" {
+				field = strings.TrimPrefix(vErr.Name, ".")
+			}
+			reason = strings.TrimPrefix(reason, vErr.Name+" in "+vErr.In+" ")
+		}
+		if field == "" {
+			field = in
+		}
+		violations = append(violations, dto.FieldError{Field: field, Reason: reason})
+	}
+	sort.Slice(violations, func(i, j int) bool { return violations[i].Field < violations[j].Field })
+	return violations
+}
+
+// paramValue приводит строковое значение параметра к типу из схемы.
+// Если привести не удалось, значение остаётся строкой и схема сообщит о несоответствии типа.
+func paramValue(schema *spec.Schema, value string) any {
+	if schema == nil {
+		return value
+	}
+	switch {
+	case schema.Type.Contains("integer"):
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return n
+		}
+	case schema.Type.Contains("number"):
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	case schema.Type.Contains("boolean"):
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return value
+}
+
+func isJSON(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == "application/json"
+}
+
+func formatViolations(violations []dto.FieldError) string {
+	parts := make([]string, 0, len(violations))
+	for _, f := range violations {
+		parts = append(parts, f.Field+": "+f.Reason)
+	}
+	return strings.Join(parts, "; ")
+}
+
+// bufferedResponse полностью буферизует ответ, чтобы его можно было проверить до отправки клиенту.
+type bufferedResponse struct {
+	header      http.Header
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (br *bufferedResponse) Header() http.Header {
+	return br.header
+}
+
+func (br *bufferedResponse) WriteHeader(status int) {
+	if !br.wroteHeader {
+		br.status = status
+		br.wroteHeader = true
+	}
+}
+
+func (br *bufferedResponse) Write(b []byte) (int, error) {
+	br.wroteHeader = true
+	return br.body.Write(b)
+}
+
+func (br *bufferedResponse) flush(w http.ResponseWriter) {
+	for k, vs := range br.header {
+		w.Header()[k] = vs
+	}
+	w.WriteHeader(br.status)
+	_, _ = w.Write(br.body.Bytes())
+}
@@ -0,0 +1,211 @@
+package httpmiddleware_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"pr-reviewer-assigment-service/internal/api"
+	"pr-reviewer-assigment-service/internal/api/httphandlers"
+	"pr-reviewer-assigment-service/internal/api/httpmiddleware"
+)
+
+func newOpenAPIHandler(t *testing.T, mode httpmiddleware.OpenAPIMode, status int, body string) http.Handler {
+	t.Helper()
+
+	validator, err := httpmiddleware.NewOpenAPIValidator(api.OpenAPISpec, mode)
+	if err != nil {
+		t.Fatalf("NewOpenAPIValidator: %v", err)
+	}
+	return validator.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+}
+
+func doJSON(h http.Handler, method, target, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func errorCode(t *testing.T, rec *httptest.ResponseRecorder) string {
+	t.Helper()
+
+	var body struct {
+		Error struct {
+			Code string `json:"code"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	return body.Error.Code
+}
+
+// Все маршруты роутера должны быть описаны в спецификации.
+func TestOpenAPI_AllRoutesDocumented(t *testing.T) {
+	validator, err := httpmiddleware.NewOpenAPIValidator(api.OpenAPISpec, httpmiddleware.OpenAPIStrict)
+	if err != nil {
+		t.Fatalf("NewOpenAPIValidator: %v", err)
+	}
+
+	router := api.NewRouter(
+		httphandlers.NewTeamHandlers(nil),
+		httphandlers.NewUserHandlers(nil, nil),
+		httphandlers.NewPullRequestHandlers(nil),
+		httphandlers.NewStatsHandlers(nil),
+		httphandlers.NewCodeOwnerHandlers(nil),
+		httphandlers.NewRuleHandlers(nil),
+		httphandlers.NewSLAHandlers(nil),
+		httphandlers.NewAdminHandlers(nil, nil, nil),
+	).(chi.Routes)
+
+	err = chi.Walk(router, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		if strings.HasPrefix(route, "/swagger") {
+			return nil
+		}
+		if !validator.HasOperation(method, route) {
+			t.Errorf("%s %s is not documented in openapi.yml", method, route)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("walk: %v", err)
+	}
+}
+
+func TestOpenAPI_StrictRequestValidation(t *testing.T) {
+	h := newOpenAPIHandler(t, httpmiddleware.OpenAPIStrict, http.StatusOK, `{}`)
+
+	cases := []struct {
+		name   string
+		method string
+		target string
+		body   string
+	}{
+		{"missing required query", http.MethodGet, "/team/get", ""},
+		{"wrong body type", http.MethodPost, "/pullRequest/create", `{"pull_request_id":42,"pull_request_name":"Add","author_id":"u1"}`},
+		{"missing body field", http.MethodPost, "/pullRequest/merge", `{}`},
+		{"negative capacity", http.MethodPost, "/users/setMaxOpenReviews", `{"user_id":"u1","max_open_reviews":-1}`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := doJSON(h, tc.method, tc.target, tc.body)
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("expected 400, got %d: %s", rec.Code, rec.Body.String())
+			}
+			if code := errorCode(t, rec); code != httphandlers.CodeValidationError {
+				t.Fatalf("expected %s, got %s", httphandlers.CodeValidationError, code)
+			}
+		})
+	}
+}
+
+func TestOpenAPI_StrictResponseValidation(t *testing.T) {
+	cases := []struct {
+		name   string
+		status int
+		body   string
+		want   int
+	}{
+		{"valid", http.StatusOK, `{"pr":{"pull_request_id":"pr-1","pull_request_name":"Add","author_id":"u1","status":"MERGED","assigned_reviewers":[],"mergedAt":"2025-10-24T12:34:56Z"}}`, http.StatusOK},
+		{"documented error", http.StatusNotFound, `{"error":{"code":"NOT_FOUND","message":"pr not found"}}`, http.StatusNotFound},
+		{"wrong enum", http.StatusOK, `{"pr":{"pull_request_id":"pr-1","pull_request_name":"Add","author_id":"u1","status":"CLOSED","assigned_reviewers":[]}}`, http.StatusInternalServerError},
+		{"null array", http.StatusOK, `{"pr":{"pull_request_id":"pr-1","pull_request_name":"Add","author_id":"u1","status":"OPEN","assigned_reviewers":null}}`, http.StatusInternalServerError},
+		{"undocumented status", http.StatusTeapot, `{}`, http.StatusInternalServerError},
+		{"unknown error code", http.StatusNotFound, `{"error":{"code":"GONE","message":"x"}}`, http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := newOpenAPIHandler(t, httpmiddleware.OpenAPIStrict, tc.status, tc.body)
+			rec := doJSON(h, http.MethodPost, "/pullRequest/merge", `{"pull_request_id":"pr-1"}`)
+			if rec.Code != tc.want {
+				t.Fatalf("expected %d, got %d: %s", tc.want, rec.Code, rec.Body.String())
+			}
+			if tc.want == http.StatusInternalServerError {
+				if code := errorCode(t, rec); code != httphandlers.CodeContractViolation {
+					t.Fatalf("expected %s, got %s", httphandlers.CodeContractViolation, code)
+				}
+			} else if rec.Body.String() != tc.body {
+				t.Fatalf("expected body to pass through unchanged, got %s", rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestOpenAPI_NullableIsAccepted(t *testing.T) {
+	h := newOpenAPIHandler(t, httpmiddleware.OpenAPIStrict, http.StatusOK,
+		`{"user":{"user_id":"u1","username":"Alice","team_name":"backend","is_active":true}}`)
+
+	rec := doJSON(h, http.MethodPost, "/users/setMaxOpenReviews", `{"user_id":"u1","max_open_reviews":null}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestOpenAPI_RequestBodyIsPreserved(t *testing.T) {
+	validator, err := httpmiddleware.NewOpenAPIValidator(api.OpenAPISpec, httpmiddleware.OpenAPIStrict)
+	if err != nil {
+		t.Fatalf("NewOpenAPIValidator: %v", err)
+	}
+
+	const body = `{"pull_request_id":"pr-1"}`
+	var got string
+	h := validator.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		got = string(b)
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":{"code":"NOT_FOUND","message":"pr not found"}}`))
+	}))
+
+	doJSON(h, http.MethodPost, "/pullRequest/merge", body)
+	if got != body {
+		t.Fatalf("handler got body %q, want %q", got, body)
+	}
+}
+
+func TestOpenAPI_LogModePassesThrough(t *testing.T) {
+	h := newOpenAPIHandler(t, httpmiddleware.OpenAPILog, http.StatusTeapot, `{"unexpected":true}`)
+
+	rec := doJSON(h, http.MethodGet, "/team/get", "")
+	if rec.Code != http.StatusTeapot {
+		t.Fatalf("expected 418 to pass through, got %d", rec.Code)
+	}
+}
+
+func TestOpenAPI_UndocumentedPathsAreSkipped(t *testing.T) {
+	h := newOpenAPIHandler(t, httpmiddleware.OpenAPIStrict, http.StatusTeapot, `{}`)
+
+	rec := doJSON(h, http.MethodGet, "/swagger/openapi.yml", "")
+	if rec.Code != http.StatusTeapot {
+		t.Fatalf("expected 418, got %d", rec.Code)
+	}
+}
+
+func TestParseOpenAPIMode(t *testing.T) {
+	for in, want := range map[string]httpmiddleware.OpenAPIMode{
+		"":       httpmiddleware.OpenAPIOff,
+		"off":    httpmiddleware.OpenAPIOff,
+		"LOG":    httpmiddleware.OpenAPILog,
+		"strict": httpmiddleware.OpenAPIStrict,
+	} {
+		got, err := httpmiddleware.ParseOpenAPIMode(in)
+		if err != nil || got != want {
+			t.Fatalf("ParseOpenAPIMode(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := httpmiddleware.ParseOpenAPIMode("loud"); err == nil {
+		t.Fatalf("expected error for unknown mode")
+	}
+}
@@ -0,0 +1,185 @@
+package httpmiddleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"pr-reviewer-assigment-service/internal/api/httphandlers"
+)
+
+// Condition - дополнительная проверка доступа для роли (например, "только своя команда").
+type Condition func(p *Principal, r *http.Request) bool
+
+// Rule перечисляет роли, которым разрешён маршрут. nil-условие означает безусловный доступ.
+type Rule map[Role]Condition
+
+// Policy описывает доступ к маршрутам вида "METHOD /path".
+// Маршруты без правила доступны только администратору.
+type Policy struct {
+	publicPaths    map[string]struct{}
+	publicPrefixes []string
+	rules          map[string]Rule
+}
+
+func NewPolicy() *Policy {
+	return &Policy{
+		publicPaths: make(map[string]struct{}),
+		rules:       make(map[string]Rule),
+	}
+}
+
+// Public делает пути доступными без аутентификации. Путь с "*" на конце - префикс.
+func (p *Policy) Public(paths ...string) *Policy {
+	for _, path := range paths {
+		if prefix, ok := strings.CutSuffix(path, "*"); ok {
+			p.publicPrefixes = append(p.publicPrefixes, prefix)
+			continue
+		}
+		p.publicPaths[path] = struct{}{}
+	}
+	return p
+}
+
+// Allow задаёт правило для маршрута.
+func (p *Policy) Allow(method, path string, rule Rule) *Policy {
+	p.rules[method+" "+path] = rule
+	return p
+}
+
+// IsPublic сообщает, нужен ли запросу аутентифицированный клиент.
+func (p *Policy) IsPublic(r *http.Request) bool {
+	if _, ok := p.publicPaths[r.URL.Path]; ok {
+		return true
+	}
+	for _, prefix := range p.publicPrefixes {
+		if strings.HasPrefix(r.URL.Path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Allows проверяет, может ли клиент выполнить запрос.
+func (p *Policy) Allows(principal *Principal, r *http.Request) bool {
+	rule, ok := p.rules[r.Method+" "+r.URL.Path]
+	if !ok {
+		return principal.Role == RoleAdmin
+	}
+
+	cond, ok := rule[principal.Role]
+	if !ok {
+		return false
+	}
+	return cond == nil || cond(principal, r)
+}
+
+// UserTeamFunc возвращает текущую команду пользователя.
+type UserTeamFunc func(ctx context.Context, userID string) (string, error)
+
+// PullRequestTeamFunc возвращает команду автора PR.
+type PullRequestTeamFunc func(ctx context.Context, prID string) (string, error)
+
+// clientTeam возвращает команду клиента - текущую команду пользователя Subject,
+// чтобы доступ следовал за переименованием команды и переводом руководителя.
+// Пустая строка - клиент не состоит в команде или не найден.
+func clientTeam(p *Principal, r *http.Request, teamOfUser UserTeamFunc) string {
+	teamName, err := teamOfUser(r.Context(), p.Subject)
+	if err != nil {
+		return ""
+	}
+	return teamName
+}
+
+// OwnTeamQuery разрешает запрос, только если query-параметр совпадает с командой клиента.
+func OwnTeamQuery(param string, teamOfUser UserTeamFunc) Condition {
+	return func(p *Principal, r *http.Request) bool {
+		teamName := clientTeam(p, r, teamOfUser)
+		return teamName != "" && r.URL.Query().Get(param) == teamName
+	}
+}
+
+// OwnTeamPullRequest разрешает запрос, только если автор PR из поля pull_request_id тела запроса
+// состоит в команде клиента. Тело запроса остаётся доступным хендлеру.
+// Если PR не найден или тело не читается, доступ запрещён.
+func OwnTeamPullRequest(teamOf PullRequestTeamFunc, teamOfUser UserTeamFunc) Condition {
+	return func(p *Principal, r *http.Request) bool {
+		clientTeamName := clientTeam(p, r, teamOfUser)
+		if clientTeamName == "" {
+			return false
+		}
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, httphandlers.MaxBodySize(r.URL.Path)+1))
+		r.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), r.Body))
+		if err != nil {
+			return false
+		}
+
+		var req struct {
+			PullRequestID string `json:"pull_request_id"`
+		}
+		if err := json.Unmarshal(body, &req); err != nil || req.PullRequestID == "" {
+			return false
+		}
+
+		teamName, err := teamOf(r.Context(), req.PullRequestID)
+		return err == nil && teamName == clientTeamName
+	}
+}
+
+// DefaultPolicy - политика доступа к API сервиса:
+//   - чтение доступно всем ролям;
+//   - team-lead может деактивировать только свою команду и менять ревьюверов только PR её участников;
+//     его команда - текущая команда пользователя из Subject (teamOfUser), команда автора PR - teamOf;
+//   - bot может создавать и мёржить PR;
+//   - остальное - только admin.
+func DefaultPolicy(teamOf PullRequestTeamFunc, teamOfUser UserTeamFunc) *Policy {
+	anyRole := Rule{RoleAdmin: nil, RoleTeamLead: nil, RoleBot: nil}
+	ownTeamPR := Rule{RoleAdmin: nil, RoleTeamLead: OwnTeamPullRequest(teamOf, teamOfUser)}
+
+	return NewPolicy().
+		Public("/health", "/swagger", "/swagger/*").
+		Allow(http.MethodGet, "/team/get", anyRole).
+		Allow(http.MethodGet, "/team/list", anyRole).
+		Allow(http.MethodGet, "/users/getReview", anyRole).
+		Allow(http.MethodGet, "/users/get", anyRole).
+		Allow(http.MethodGet, "/users/list", anyRole).
+		Allow(http.MethodGet, "/users/unavailability", anyRole).
+		Allow(http.MethodGet, "/stats/reviewers", anyRole).
+		Allow(http.MethodGet, "/codeOwners/list", anyRole).
+		Allow(http.MethodGet, "/rules/list", anyRole).
+		Allow(http.MethodGet, "/pullRequest/overdue", anyRole).
+		Allow(http.MethodPost, "/rules/evaluate", anyRole).
+		Allow(http.MethodPost, "/pullRequest/previewAssignment", anyRole).
+		Allow(http.MethodPost, "/team/add", Rule{RoleAdmin: nil}).
+		Allow(http.MethodPatch, "/team/deactivate", Rule{
+			RoleAdmin:    nil,
+			RoleTeamLead: OwnTeamQuery("team_name", teamOfUser),
+		}).
+		Allow(http.MethodPost, "/team/addMembers", Rule{RoleAdmin: nil}).
+		Allow(http.MethodPost, "/team/removeMembers", Rule{RoleAdmin: nil}).
+		Allow(http.MethodPost, "/team/rename", Rule{RoleAdmin: nil}).
+		Allow(http.MethodPost, "/team/delete", Rule{RoleAdmin: nil}).
+		Allow(http.MethodPost, "/team/setReviewSLA", Rule{RoleAdmin: nil}).
+		Allow(http.MethodPost, "/users/setIsActive", Rule{RoleAdmin: nil}).
+		Allow(http.MethodPost, "/users/setMaxOpenReviews", Rule{RoleAdmin: nil}).
+		Allow(http.MethodPost, "/users/setSkills", Rule{RoleAdmin: nil}).
+		Allow(http.MethodPost, "/users/moveTeam", Rule{RoleAdmin: nil}).
+		Allow(http.MethodPost, "/users/addUnavailability", Rule{RoleAdmin: nil}).
+		Allow(http.MethodPost, "/users/removeUnavailability", Rule{RoleAdmin: nil}).
+		Allow(http.MethodPost, "/pullRequest/create", Rule{RoleAdmin: nil, RoleBot: nil}).
+		Allow(http.MethodPost, "/pullRequest/merge", Rule{RoleAdmin: nil, RoleBot: nil}).
+		Allow(http.MethodPost, "/pullRequest/reassign", ownTeamPR).
+		Allow(http.MethodPost, "/pullRequest/addReviewer", ownTeamPR).
+		Allow(http.MethodPost, "/pullRequest/removeReviewer", ownTeamPR).
+		Allow(http.MethodPost, "/codeOwners/upload", Rule{RoleAdmin: nil}).
+		Allow(http.MethodPost, "/rules/add", Rule{RoleAdmin: nil}).
+		Allow(http.MethodPost, "/rules/delete", Rule{RoleAdmin: nil}).
+		Allow(http.MethodPost, "/admin/import", Rule{RoleAdmin: nil}).
+		Allow(http.MethodGet, "/admin/export", Rule{RoleAdmin: nil}).
+		Allow(http.MethodGet, "/admin/consistency", Rule{RoleAdmin: nil}).
+		Allow(http.MethodPost, "/admin/consistency/repair", Rule{RoleAdmin: nil})
+}
@@ -0,0 +1,146 @@
+package httpmiddleware
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"pr-reviewer-assigment-service/internal/api/httphandlers"
+)
+
+// Limit - параметры token bucket: Rate токенов в секунду, не больше Burst за раз.
+// Нулевой Rate означает отсутствие ограничения.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// bucketIdleTTL - через сколько простоя корзина удаляется из памяти.
+const bucketIdleTTL = 10 * time.Minute
+
+// RateLimiter ограничивает частоту запросов по алгоритму token bucket.
+// Корзины ведутся отдельно для каждого клиента и маршрута.
+type RateLimiter struct {
+	defaultLimit Limit
+	routes       map[string]Limit                           // ключ - "METHOD /path"
+	bucketKey    func(r *http.Request, route string) string // ключ корзины запроса
+	now          func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+func NewRateLimiter(defaultLimit Limit, routes map[string]Limit) *RateLimiter {
+	return &RateLimiter{
+		defaultLimit: defaultLimit,
+		routes:       routes,
+		bucketKey:    func(r *http.Request, route string) string { return clientKey(r) + "|" + route },
+		now:          time.Now,
+		buckets:      make(map[string]*bucket),
+	}
+}
+
+// NewIPRateLimiter создаёт ограничитель с одной корзиной на IP-адрес для всех маршрутов.
+// Он ставится до аутентификации, чтобы перебор токенов и запросы без учётных данных
+// не проходили дальше, чем позволяет limit.
+func NewIPRateLimiter(limit Limit) *RateLimiter {
+	return &RateLimiter{
+		defaultLimit: limit,
+		bucketKey:    func(r *http.Request, _ string) string { return clientIP(r) },
+		now:          time.Now,
+		buckets:      make(map[string]*bucket),
+	}
+}
+
+// Middleware отвечает 429 RATE_LIMITED с заголовком Retry-After, когда корзина клиента пуста.
+func (l *RateLimiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := r.Method + " " + r.URL.Path
+		limit, ok := l.routes[route]
+		if !ok {
+			limit = l.defaultLimit
+		}
+		if limit.Rate <= 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		allowed, retryAfter := l.take(l.bucketKey(r, route), limit)
+		if !allowed {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			httphandlers.WriteError(w, http.StatusTooManyRequests, httphandlers.CodeRateLimited, "rate limit exceeded")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// take забирает токен из корзины. Если токенов нет - возвращает, через сколько появится следующий.
+func (l *RateLimiter) take(key string, limit Limit) (bool, time.Duration) {
+	now := l.now()
+	burst := float64(max(limit.Burst, 1))
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: burst, last: now}
+		l.buckets[key] = b
+	}
+
+	b.tokens = math.Min(burst, b.tokens+now.Sub(b.last).Seconds()*limit.Rate)
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+
+	wait := time.Duration((1 - b.tokens) / limit.Rate * float64(time.Second))
+	return false, wait
+}
+
+// sweep удаляет давно не используемые корзины, чтобы память не росла с числом клиентов.
+func (l *RateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < bucketIdleTTL {
+		return
+	}
+	for key, b := range l.buckets {
+		if now.Sub(b.last) > bucketIdleTTL {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
+
+// clientKey определяет клиента: субъект, который положил в контекст Authenticate, иначе IP-адрес.
+// Непроверенным заголовкам с токенами верить нельзя: со случайным токеном в каждом запросе
+// клиент получал бы новую корзину и обходил лимит.
+func clientKey(r *http.Request) string {
+	if p, ok := PrincipalFromContext(r.Context()); ok {
+		return "sub:" + p.Subject
+	}
+	return clientIP(r)
+}
+
+// clientIP возвращает ключ IP-адреса клиента.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
@@ -0,0 +1,120 @@
+package httpmiddleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"pr-reviewer-assigment-service/internal/api/httpmiddleware"
+)
+
+func newLimitedHandler() http.Handler {
+	limiter := httpmiddleware.NewRateLimiter(
+		httpmiddleware.Limit{},
+		map[string]httpmiddleware.Limit{
+			"POST /pullRequest/create": {Rate: 0.001, Burst: 2},
+		},
+	)
+	return limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+func TestRateLimiter_LimitsPerRoute(t *testing.T) {
+	h := newLimitedHandler()
+
+	for i := 0; i < 2; i++ {
+		rec := doRequest(h, http.MethodPost, "/pullRequest/create", "ci-token")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("request %d: expected 200, got %d", i, rec.Code)
+		}
+	}
+
+	rec := doRequest(h, http.MethodPost, "/pullRequest/create", "ci-token")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429, got %d", rec.Code)
+	}
+	if rec.Header().Get("Retry-After") == "" {
+		t.Fatalf("expected Retry-After header")
+	}
+	if !strings.Contains(rec.Body.String(), "RATE_LIMITED") {
+		t.Fatalf("expected RATE_LIMITED code, got %s", rec.Body.String())
+	}
+
+	// Маршрут без лимита не ограничивается.
+	for i := 0; i < 10; i++ {
+		rec := doRequest(h, http.MethodGet, "/stats/reviewers", "ci-token")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200 for unlimited route, got %d", rec.Code)
+		}
+	}
+}
+
+// doLimitedRequest отправляет запрос с адреса remoteAddr от имени subject (пустой - без аутентификации).
+func doLimitedRequest(h http.Handler, method, target, subject, remoteAddr string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, nil)
+	req.RemoteAddr = remoteAddr
+	if subject != "" {
+		req = req.WithContext(httpmiddleware.WithPrincipal(req.Context(),
+			&httpmiddleware.Principal{Subject: subject, Role: httpmiddleware.RoleBot}))
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRateLimiter_KeysByClient(t *testing.T) {
+	h := newLimitedHandler()
+
+	for i := 0; i < 2; i++ {
+		doLimitedRequest(h, http.MethodPost, "/pullRequest/create", "ci", "10.0.0.1:1234")
+	}
+
+	rec := doLimitedRequest(h, http.MethodPost, "/pullRequest/create", "other", "10.0.0.1:1234")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected other subject to have its own bucket, got %d", rec.Code)
+	}
+
+	rec = doLimitedRequest(h, http.MethodPost, "/pullRequest/create", "", "10.0.0.2:1234")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected anonymous client keyed by ip to pass, got %d", rec.Code)
+	}
+}
+
+func TestRateLimiter_IgnoresUnverifiedTokens(t *testing.T) {
+	h := newLimitedHandler()
+
+	// Без аутентификации токен в заголовке не проверен, и новый токен не даёт новой корзины.
+	for i, token := range []string{"token-1", "token-2"} {
+		rec := doRequest(h, http.MethodPost, "/pullRequest/create", token)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("request %d: expected 200, got %d", i, rec.Code)
+		}
+	}
+
+	rec := doRequest(h, http.MethodPost, "/pullRequest/create", "token-3")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429 for the same ip with another token, got %d", rec.Code)
+	}
+}
+
+func TestIPRateLimiter_SharesBucketAcrossRoutes(t *testing.T) {
+	limiter := httpmiddleware.NewIPRateLimiter(httpmiddleware.Limit{Rate: 0.001, Burst: 2})
+	h := limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	doLimitedRequest(h, http.MethodGet, "/team/get", "", "10.0.0.1:1234")
+	doLimitedRequest(h, http.MethodGet, "/users/get", "", "10.0.0.1:5678")
+
+	rec := doLimitedRequest(h, http.MethodGet, "/stats/reviewers", "", "10.0.0.1:1234")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429 once the ip bucket is empty, got %d", rec.Code)
+	}
+
+	rec = doLimitedRequest(h, http.MethodGet, "/stats/reviewers", "", "10.0.0.2:1234")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected another ip to pass, got %d", rec.Code)
+	}
+}
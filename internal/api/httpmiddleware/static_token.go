@@ -0,0 +1,46 @@
+package httpmiddleware
+
+import (
+	"crypto/sha256"
+	"net/http"
+)
+
+// apiTokenHeader - альтернативный заголовок для статического токена.
+const apiTokenHeader = "X-API-Token"
+
+// StaticToken описывает статический API-токен и клиента, которому он выдан.
+type StaticToken struct {
+	Token     string
+	Principal Principal
+}
+
+// StaticTokenAuthenticator аутентифицирует клиентов по заранее выданным токенам.
+type StaticTokenAuthenticator struct {
+	// Храним хеши, чтобы время поиска не зависело от совпадения префикса токена.
+	tokens map[[sha256.Size]byte]Principal
+}
+
+func NewStaticTokenAuthenticator(tokens []StaticToken) *StaticTokenAuthenticator {
+	m := make(map[[sha256.Size]byte]Principal, len(tokens))
+	for _, t := range tokens {
+		m[sha256.Sum256([]byte(t.Token))] = t.Principal
+	}
+	return &StaticTokenAuthenticator{tokens: m}
+}
+
+// Authenticate ищет токен из заголовка X-API-Token или Authorization: Bearer.
+func (a *StaticTokenAuthenticator) Authenticate(r *http.Request) (*Principal, error) {
+	token := r.Header.Get(apiTokenHeader)
+	if token == "" {
+		token = bearerToken(r)
+	}
+	if token == "" {
+		return nil, ErrNoCredentials
+	}
+
+	p, ok := a.tokens[sha256.Sum256([]byte(token))]
+	if !ok {
+		return nil, ErrNoCredentials
+	}
+	return &p, nil
+}
@@ -12,13 +12,20 @@ import (
 var OpenAPISpec []byte
 
 // NewRouter принимает все группы хендлеров и возвращает готовый http.Handler.
+// middlewares применяются ко всем маршрутам в переданном порядке (например, аутентификация).
 func NewRouter(
 	teamHandlers *httphandlers.TeamHandlers,
 	userHandlers *httphandlers.UserHandlers,
 	prHandlers *httphandlers.PullRequestHandlers,
 	statsHandlers *httphandlers.StatsHandlers,
+	codeOwnerHandlers *httphandlers.CodeOwnerHandlers,
+	ruleHandlers *httphandlers.RuleHandlers,
+	slaHandlers *httphandlers.SLAHandlers,
+	adminHandlers *httphandlers.AdminHandlers,
+	middlewares ...func(http.Handler) http.Handler,
 ) http.Handler {
 	r := chi.NewRouter()
+	r.Use(middlewares...)
 	r.Get("/swagger/openapi.yml", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/yaml")
 		_, _ = w.Write(OpenAPISpec)
@@ -36,17 +43,48 @@ func NewRouter(
 
 	r.Post("/team/add", teamHandlers.Add)
 	r.Get("/team/get", teamHandlers.Get)
+	r.Get("/team/list", teamHandlers.List)
 	r.Patch("/team/deactivate", teamHandlers.DeactivateMembers)
+	r.Post("/team/addMembers", teamHandlers.AddMembers)
+	r.Post("/team/removeMembers", teamHandlers.RemoveMembers)
+	r.Post("/team/rename", teamHandlers.Rename)
+	r.Post("/team/delete", teamHandlers.Delete)
+	r.Post("/team/setReviewSLA", slaHandlers.SetTeamSLA)
 
 	r.Post("/users/setIsActive", userHandlers.SetIsActive)
+	r.Post("/users/setMaxOpenReviews", userHandlers.SetMaxOpenReviews)
+	r.Post("/users/setSkills", userHandlers.SetSkills)
 	r.Get("/users/getReview", userHandlers.GetReview)
+	r.Get("/users/get", userHandlers.Get)
+	r.Get("/users/list", userHandlers.List)
+	r.Post("/users/addUnavailability", userHandlers.AddUnavailability)
+	r.Get("/users/unavailability", userHandlers.GetUnavailability)
+	r.Post("/users/removeUnavailability", userHandlers.RemoveUnavailability)
+	r.Post("/users/moveTeam", userHandlers.MoveTeam)
 
 	r.Post("/pullRequest/create", prHandlers.Create)
+	r.Post("/pullRequest/previewAssignment", prHandlers.PreviewAssignment)
 	r.Post("/pullRequest/merge", prHandlers.Merge)
 	r.Post("/pullRequest/reassign", prHandlers.Reassign)
+	r.Post("/pullRequest/addReviewer", prHandlers.AddReviewer)
+	r.Post("/pullRequest/removeReviewer", prHandlers.RemoveReviewer)
+	r.Get("/pullRequest/overdue", slaHandlers.Overdue)
 
 	r.Get("/stats/reviewers", statsHandlers.GetReviewerStats)
 
+	r.Post("/codeOwners/upload", codeOwnerHandlers.Upload)
+	r.Get("/codeOwners/list", codeOwnerHandlers.List)
+
+	r.Post("/rules/add", ruleHandlers.Add)
+	r.Get("/rules/list", ruleHandlers.List)
+	r.Post("/rules/delete", ruleHandlers.Delete)
+	r.Post("/rules/evaluate", ruleHandlers.Evaluate)
+
+	r.Post("/admin/import", adminHandlers.Import)
+	r.Get("/admin/export", adminHandlers.Export)
+	r.Get("/admin/consistency", adminHandlers.Consistency)
+	r.Post("/admin/consistency/repair", adminHandlers.RepairConsistency)
+
 	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write([]byte("OK"))
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+// CodeOwnerRepository хранит правила CODEOWNERS.
+type CodeOwnerRepository interface {
+	// Replace атомарно заменяет все правила новым набором.
+	Replace(ctx context.Context, rules []domain.CodeOwnerRule) error
+
+	// List возвращает правила в порядке файла.
+	List(ctx context.Context) ([]domain.CodeOwnerRule, error)
+}
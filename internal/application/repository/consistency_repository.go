@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+// ConsistencyRepository ищет и исправляет нарушения целостности, которые не ловятся ограничениями базы.
+type ConsistencyRepository interface {
+	// Check возвращает нарушения, упорядоченные по виду, PR и пользователю.
+	Check(ctx context.Context) ([]domain.ConsistencyIssue, error)
+	// RemoveDanglingReviewers снимает с PR ревьюверов, которых нет среди пользователей,
+	// и возвращает число снятых назначений. После этого ссылки на пользователей гарантирует база.
+	RemoveDanglingReviewers(ctx context.Context) (int, error)
+}
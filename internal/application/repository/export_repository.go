@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+// ExportRepository выгружает все данные для резервной копии или переноса в другое окружение.
+type ExportRepository interface {
+	// Export читает из одного снимка базы команды, пользователей, PR, эскалации PR, SLA команд,
+	// правила CODEOWNERS, правила подбора и периоды недоступности - в этом порядке -
+	// и передаёт их sink по одной записи, не загружая всё в память. Ошибка sink прерывает выгрузку.
+	Export(ctx context.Context, sink ExportSink) error
+}
+
+// ExportSink принимает записи выгрузки по мере чтения.
+type ExportSink interface {
+	Team(teamName string) error
+	User(u *domain.User) error
+	PullRequest(pr *domain.PullRequest) error
+	Escalation(prID string, escalatedAt time.Time) error
+	ReviewSLA(sla *domain.ReviewSLA) error
+	CodeOwnerRule(rule *domain.CodeOwnerRule) error
+	ReviewerRule(rule *domain.ReviewerRule) error
+	Unavailability(u *domain.Unavailability) error
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+// IdempotencyRepository хранит ответы на запросы с Idempotency-Key.
+type IdempotencyRepository interface {
+	// Reserve занимает ключ под новый запрос.
+	// Если ключ уже занят действующей записью - возвращает её и ErrAlreadyExists.
+	// Просроченные записи и незавершённые записи, созданные раньше staleBefore, перезаписываются.
+	// Если ключ освобождают параллельные запросы и занять его не удаётся, возвращает nil и ErrAlreadyExists.
+	Reserve(ctx context.Context, rec *domain.IdempotencyRecord, staleBefore time.Time) (*domain.IdempotencyRecord, error)
+
+	// Complete сохраняет ответ для занятого ключа.
+	Complete(ctx context.Context, key string, statusCode int, contentType string, body []byte) error
+
+	// Release освобождает ключ, если запрос не удалось выполнить.
+	Release(ctx context.Context, key string) error
+
+	// DeleteExpired удаляет записи, срок действия которых истёк к моменту now.
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+// ImportRepository загружает данные из файла импорта или выгрузки.
+type ImportRepository interface {
+	// Existing возвращает, какие из перечисленных ключей уже есть в базе.
+	Existing(ctx context.Context, keys ImportKeys) (ImportKeys, error)
+
+	// Load загружает записи в одной транзакции: сначала команды, затем пользователей, затем PR,
+	// затем записи, ссылающиеся на них.
+	// ErrAlreadyExists - запись с таким ключом появилась в базе после проверки,
+	// ErrNotFound - пропала команда, пользователь или PR, на которых ссылаются записи. В обоих случаях ничего не загружено.
+	Load(ctx context.Context, batch *domain.ImportBatch) error
+}
+
+// ImportKeys - ключи записей импорта.
+type ImportKeys struct {
+	Teams          []string // team_name
+	Users          []string // user_id
+	PullRequests   []string // pull_request_id
+	Escalations    []string // pull_request_id эскалированных PR
+	ReviewSLAs     []string // team_name команд с SLA
+	CodeOwnerRules []int    // position
+	ReviewerRules  []string // rule_id
+	Unavailability []string // unavailability_id
+}
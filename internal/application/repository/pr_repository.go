@@ -19,6 +19,13 @@ type PullRequestRepository interface {
 	// ListByReviewer возвращает список PR'ов, где пользователь назначен ревьювером.
 	ListByReviewer(ctx context.Context, reviewerID string) ([]domain.PullRequestShort, error)
 
+	// ListByAuthor возвращает список PR'ов автора.
+	ListByAuthor(ctx context.Context, authorID string) ([]domain.PullRequestShort, error)
+
+	// CountOpenByReviewers возвращает число открытых PR у каждого из ревьюверов.
+	// Ревьюверов без открытых PR в результате нет.
+	CountOpenByReviewers(ctx context.Context, reviewerIDs []string) (map[string]int, error)
+
 	// GetReviewerStats получает статистику назначений по ревьюверам.
 	GetReviewerStats(ctx context.Context) ([]domain.ReviewerStat, error)
 }
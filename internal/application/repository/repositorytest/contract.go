@@ -0,0 +1,851 @@
+// Package repositorytest - общий контракт репозиториев команд, пользователей и PR, а также выгрузки и импорта.
+// Один и тот же набор проверок прогоняется на каждой реализации хранилища,
+// чтобы сервисы вели себя одинаково независимо от STORAGE.
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"pr-reviewer-assigment-service/internal/application/repository"
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+// Repositories - проверяемые реализации. Все работают с одним хранилищем.
+type Repositories struct {
+	Users          repository.UserRepository
+	Teams          repository.TeamRepository
+	PullRequests   repository.PullRequestRepository
+	CodeOwners     repository.CodeOwnerRepository
+	SLAs           repository.ReviewSLARepository
+	Rules          repository.ReviewerRuleRepository
+	Unavailability repository.UnavailabilityRepository
+	Export         repository.ExportRepository
+	Import         repository.ImportRepository
+}
+
+// Factory возвращает репозитории поверх пустого хранилища. Вызывается перед каждой проверкой.
+type Factory func(t *testing.T) Repositories
+
+// Run прогоняет контракт на репозиториях из newRepos.
+// Имена команд и ID - строчные латинские, чтобы порядок не зависел от правил сортировки базы.
+func Run(t *testing.T, newRepos Factory) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, r Repositories)
+	}{
+		{"Team/CreateAndGet", testTeamCreateAndGet},
+		{"Team/RenameAndDelete", testTeamRenameAndDelete},
+		{"Team/RenameAndDeleteReferences", testTeamRenameAndDeleteReferences},
+		{"Team/List", testTeamList},
+		{"User/BulkUpsert", testUserBulkUpsert},
+		{"User/Setters", testUserSetters},
+		{"User/Lists", testUserLists},
+		{"PullRequest/CreateAndGet", testPullRequestCreateAndGet},
+		{"PullRequest/Update", testPullRequestUpdate},
+		{"PullRequest/Queries", testPullRequestQueries},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, newRepos(t))
+		})
+	}
+	t.Run("Export/RoundTrip", func(t *testing.T) {
+		testExportRoundTrip(t, newRepos)
+	})
+}
+
+// seed создаёт команды и пользователей.
+func seed(t *testing.T, r Repositories, teams []string, users ...domain.User) {
+	t.Helper()
+	ctx := context.Background()
+	for _, name := range teams {
+		if err := r.Teams.Create(ctx, &domain.Team{TeamName: name}); err != nil {
+			t.Fatalf("create team %s: %v", name, err)
+		}
+	}
+	if err := r.Users.BulkUpsert(ctx, users); err != nil {
+		t.Fatalf("BulkUpsert: %v", err)
+	}
+}
+
+func user(id, team string, active bool) domain.User {
+	return domain.User{UserID: id, Username: "user " + id, TeamName: team, IsActive: active}
+}
+
+var createdAt = time.Date(2025, 10, 20, 9, 0, 0, 0, time.UTC)
+
+// pullRequest возвращает открытый PR, ревьюверы которого назначены при создании.
+func pullRequest(id, author string, reviewers ...string) *domain.PullRequest {
+	created := createdAt
+	pr := &domain.PullRequest{
+		PullRequestID:     id,
+		PullRequestName:   "pr " + id,
+		AuthorID:          author,
+		Status:            string(domain.StatusOpen),
+		AssignedReviewers: reviewers,
+		CreatedAt:         &created,
+	}
+	for _, reviewer := range reviewers {
+		pr.MarkAssigned(reviewer, createdAt)
+	}
+	return pr
+}
+
+func testTeamCreateAndGet(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	seed(t, r, []string{"backend"}, user("u2", "backend", false), user("u1", "backend", true), user("u3", "", true))
+
+	if err := r.Teams.Create(ctx, &domain.Team{TeamName: "backend"}); !errors.Is(err, repository.ErrAlreadyExists) {
+		t.Fatalf("expected ErrAlreadyExists, got %v", err)
+	}
+	if _, err := r.Teams.GetByName(ctx, "missing"); !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	team, err := r.Teams.GetByName(ctx, "backend")
+	if err != nil {
+		t.Fatalf("GetByName: %v", err)
+	}
+	want := []domain.TeamMember{
+		{UserID: "u1", Username: "user u1", IsActive: true},
+		{UserID: "u2", Username: "user u2", IsActive: false},
+	}
+	if team.TeamName != "backend" || !slices.Equal(team.Members, want) {
+		t.Fatalf("expected backend with %+v, got %+v", want, team)
+	}
+
+	if err := r.Teams.Create(ctx, &domain.Team{TeamName: "empty"}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	empty, err := r.Teams.GetByName(ctx, "empty")
+	if err != nil {
+		t.Fatalf("GetByName: %v", err)
+	}
+	if empty.Members == nil || len(empty.Members) != 0 {
+		t.Fatalf("expected empty non-nil members, got %#v", empty.Members)
+	}
+}
+
+func testTeamRenameAndDelete(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	seed(t, r, []string{"backend", "frontend", "empty"}, user("u1", "backend", true))
+
+	if err := r.Teams.Rename(ctx, "missing", "other"); !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if err := r.Teams.Rename(ctx, "backend", "frontend"); !errors.Is(err, repository.ErrAlreadyExists) {
+		t.Fatalf("expected ErrAlreadyExists, got %v", err)
+	}
+	if err := r.Teams.Rename(ctx, "backend", "platform"); err != nil {
+		t.Fatalf("Rename: %v", err)
+	}
+	if _, err := r.Teams.GetByName(ctx, "backend"); !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("expected old name to be gone, got %v", err)
+	}
+	u1, err := r.Users.GetByID(ctx, "u1")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if u1.TeamName != "platform" {
+		t.Fatalf("expected u1 to move with the team, got %q", u1.TeamName)
+	}
+
+	if err := r.Teams.Delete(ctx, "missing"); !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if err := r.Teams.Delete(ctx, "platform"); err == nil {
+		t.Fatalf("expected error deleting team with members")
+	}
+	if err := r.Teams.Delete(ctx, "empty"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := r.Teams.GetByName(ctx, "empty"); !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("expected deleted team to be gone, got %v", err)
+	}
+}
+
+func testTeamRenameAndDeleteReferences(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	seed(t, r, []string{"backend", "frontend"}, user("u1", "backend", true))
+
+	if err := r.CodeOwners.Replace(ctx, []domain.CodeOwnerRule{
+		{Position: 1, Pattern: "/api/", Teams: []string{"frontend", "backend"}},
+		{Position: 2, Pattern: "/web/", Teams: []string{"frontend"}},
+	}); err != nil {
+		t.Fatalf("Replace: %v", err)
+	}
+	if err := r.SLAs.Set(ctx, domain.ReviewSLA{TeamName: "backend", SLA: time.Hour, Policy: domain.EscalationNotify}); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if err := r.PullRequests.Create(ctx, pullRequest("pr-1", "u1")); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	later := createdAt.Add(2 * time.Hour)
+
+	if err := r.Teams.Rename(ctx, "backend", "platform"); err != nil {
+		t.Fatalf("Rename: %v", err)
+	}
+	checkOwnerTeams(t, r, [][]string{{"frontend", "platform"}, {"frontend"}})
+	overdue, err := r.SLAs.ListOverdue(ctx, "platform", later)
+	if err != nil {
+		t.Fatalf("ListOverdue: %v", err)
+	}
+	if len(overdue) != 1 {
+		t.Fatalf("expected SLA to move with the team, got %v", overdue)
+	}
+
+	if err := r.Teams.Delete(ctx, "frontend"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	checkOwnerTeams(t, r, [][]string{{"platform"}, {}})
+
+	// Команда с тем же именем не наследует SLA удалённой.
+	if _, err := r.Users.SetTeam(ctx, "u1", ""); err != nil {
+		t.Fatalf("SetTeam: %v", err)
+	}
+	if err := r.Teams.Delete(ctx, "platform"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	seed(t, r, []string{"platform"})
+	if _, err := r.Users.SetTeam(ctx, "u1", "platform"); err != nil {
+		t.Fatalf("SetTeam: %v", err)
+	}
+	overdue, err = r.SLAs.ListOverdue(ctx, "platform", later)
+	if err != nil {
+		t.Fatalf("ListOverdue: %v", err)
+	}
+	if len(overdue) != 0 {
+		t.Fatalf("expected SLA to be deleted with the team, got %v", overdue)
+	}
+	checkOwnerTeams(t, r, [][]string{{}, {}})
+}
+
+// checkOwnerTeams сверяет команды-владельцы правил по порядку.
+func checkOwnerTeams(t *testing.T, r Repositories, want [][]string) {
+	t.Helper()
+	rules, err := r.CodeOwners.List(context.Background())
+	if err != nil {
+		t.Fatalf("List code owners: %v", err)
+	}
+	if len(rules) != len(want) {
+		t.Fatalf("expected %d rules, got %d", len(want), len(rules))
+	}
+	for i, rule := range rules {
+		if len(rule.Teams) != 0 || len(want[i]) != 0 {
+			if !slices.Equal(rule.Teams, want[i]) {
+				t.Fatalf("rule %s: expected owner teams %v, got %v", rule.Pattern, want[i], rule.Teams)
+			}
+		}
+	}
+}
+
+func testTeamList(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	seed(t, r, []string{"backend", "billing", "frontend"},
+		user("u1", "backend", true), user("u2", "backend", false), user("u3", "billing", true))
+
+	page, total, err := r.Teams.List(ctx, repository.TeamListFilter{Prefix: "B", Limit: 1, Offset: 1})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if total != 2 || len(page) != 1 || page[0] != (domain.TeamSummary{TeamName: "billing", MemberCount: 1, ActiveMemberCount: 1}) {
+		t.Fatalf("expected billing of 2, got %+v of %d", page, total)
+	}
+
+	page, total, err = r.Teams.List(ctx, repository.TeamListFilter{Limit: 10})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	want := []domain.TeamSummary{
+		{TeamName: "backend", MemberCount: 2, ActiveMemberCount: 1},
+		{TeamName: "billing", MemberCount: 1, ActiveMemberCount: 1},
+		{TeamName: "frontend"},
+	}
+	if total != 3 || !slices.Equal(page, want) {
+		t.Fatalf("expected %+v, got %+v of %d", want, page, total)
+	}
+
+	page, total, err = r.Teams.List(ctx, repository.TeamListFilter{Limit: 10, Offset: 5})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if total != 3 || len(page) != 0 {
+		t.Fatalf("expected empty page of 3, got %+v of %d", page, total)
+	}
+}
+
+func testUserBulkUpsert(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	seed(t, r, []string{"backend", "frontend"}, user("u1", "backend", true))
+
+	limit := 2
+	if _, err := r.Users.SetMaxOpenReviews(ctx, "u1", &limit); err != nil {
+		t.Fatalf("SetMaxOpenReviews: %v", err)
+	}
+	if _, err := r.Users.SetSkills(ctx, "u1", []string{"go"}); err != nil {
+		t.Fatalf("SetSkills: %v", err)
+	}
+
+	if err := r.Users.BulkUpsert(ctx, []domain.User{
+		{UserID: "u1", Username: "Alice", TeamName: "frontend", IsActive: false},
+		{UserID: "u2", Username: "Bob"},
+		{UserID: "u2", Username: "Bobby", TeamName: "backend", IsActive: true},
+	}); err != nil {
+		t.Fatalf("BulkUpsert: %v", err)
+	}
+
+	u1, err := r.Users.GetByID(ctx, "u1")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if u1.Username != "Alice" || u1.TeamName != "frontend" || u1.IsActive {
+		t.Fatalf("expected u1 to be updated, got %+v", u1)
+	}
+	if u1.MaxOpenReviews == nil || *u1.MaxOpenReviews != 2 || !slices.Equal(u1.Skills, []string{"go"}) {
+		t.Fatalf("expected upsert to keep limit and skills, got %+v", u1)
+	}
+
+	u2, err := r.Users.GetByID(ctx, "u2")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if u2.Username != "Bobby" || u2.TeamName != "backend" || !u2.IsActive || u2.MaxOpenReviews != nil || len(u2.Skills) != 0 {
+		t.Fatalf("expected last u2 record to win, got %+v", u2)
+	}
+
+	if err := r.Users.BulkUpsert(ctx, []domain.User{
+		{UserID: "u3", Username: "Carol", TeamName: "backend"},
+		{UserID: "u4", Username: "Dave", TeamName: "missing"},
+	}); err == nil {
+		t.Fatalf("expected error for unknown team")
+	}
+	if _, err := r.Users.GetByID(ctx, "u3"); !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("expected failed upsert to change nothing, got %v", err)
+	}
+}
+
+func testUserSetters(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	seed(t, r, []string{"backend", "frontend"}, user("u1", "backend", true))
+
+	for name, call := range map[string]func() (*domain.User, error){
+		"SetActive":         func() (*domain.User, error) { return r.Users.SetActive(ctx, "missing", true) },
+		"SetTeam":           func() (*domain.User, error) { return r.Users.SetTeam(ctx, "missing", "backend") },
+		"SetMaxOpenReviews": func() (*domain.User, error) { return r.Users.SetMaxOpenReviews(ctx, "missing", nil) },
+		"SetSkills":         func() (*domain.User, error) { return r.Users.SetSkills(ctx, "missing", nil) },
+		"GetSummary": func() (*domain.User, error) {
+			s, err := r.Users.GetSummary(ctx, "missing")
+			if s != nil {
+				return &s.User, err
+			}
+			return nil, err
+		},
+	} {
+		if _, err := call(); !errors.Is(err, repository.ErrNotFound) {
+			t.Fatalf("%s: expected ErrNotFound, got %v", name, err)
+		}
+	}
+
+	u, err := r.Users.SetActive(ctx, "u1", false)
+	if err != nil || u.IsActive {
+		t.Fatalf("SetActive: %+v, %v", u, err)
+	}
+	u, err = r.Users.SetTeam(ctx, "u1", "frontend")
+	if err != nil || u.TeamName != "frontend" {
+		t.Fatalf("SetTeam: %+v, %v", u, err)
+	}
+	u, err = r.Users.SetTeam(ctx, "u1", "")
+	if err != nil || u.TeamName != "" {
+		t.Fatalf("SetTeam without team: %+v, %v", u, err)
+	}
+	if _, err := r.Users.SetTeam(ctx, "u1", "missing"); err == nil {
+		t.Fatalf("expected error for unknown team")
+	}
+
+	limit := 3
+	u, err = r.Users.SetMaxOpenReviews(ctx, "u1", &limit)
+	if err != nil || u.MaxOpenReviews == nil || *u.MaxOpenReviews != 3 {
+		t.Fatalf("SetMaxOpenReviews: %+v, %v", u, err)
+	}
+	u, err = r.Users.SetMaxOpenReviews(ctx, "u1", nil)
+	if err != nil || u.MaxOpenReviews != nil {
+		t.Fatalf("SetMaxOpenReviews nil: %+v, %v", u, err)
+	}
+
+	skills := []string{"go", "postgres"}
+	u, err = r.Users.SetSkills(ctx, "u1", skills)
+	if err != nil || !slices.Equal(u.Skills, skills) {
+		t.Fatalf("SetSkills: %+v, %v", u, err)
+	}
+	skills[0] = "changed"
+	got, err := r.Users.GetByID(ctx, "u1")
+	if err != nil || got.Skills[0] != "go" {
+		t.Fatalf("expected stored skills to be independent of the argument, got %+v, %v", got, err)
+	}
+	u, err = r.Users.SetSkills(ctx, "u1", nil)
+	if err != nil || u.Skills == nil || len(u.Skills) != 0 {
+		t.Fatalf("SetSkills nil: %#v, %v", u, err)
+	}
+}
+
+func testUserLists(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	seed(t, r, []string{"backend", "frontend"},
+		user("u1", "backend", true),
+		domain.User{UserID: "u2", Username: "Alina", TeamName: "backend", IsActive: false},
+		domain.User{UserID: "u3", Username: "Malik", TeamName: "backend", IsActive: true},
+		user("u4", "frontend", true),
+		user("u5", "", true),
+	)
+	if err := r.Users.BulkUpsert(ctx, nil); err != nil {
+		t.Fatalf("empty BulkUpsert: %v", err)
+	}
+	for _, pr := range []*domain.PullRequest{
+		pullRequest("pr-1", "u4", "u1", "u3"),
+		pullRequest("pr-2", "u4", "u1"),
+		merged(pullRequest("pr-3", "u4", "u1")),
+	} {
+		if err := r.PullRequests.Create(ctx, pr); err != nil {
+			t.Fatalf("Create %s: %v", pr.PullRequestID, err)
+		}
+	}
+
+	ids := func(users []domain.User) []string {
+		result := make([]string, 0, len(users))
+		for _, u := range users {
+			result = append(result, u.UserID)
+		}
+		slices.Sort(result)
+		return result
+	}
+
+	byIDs, err := r.Users.ListByIDs(ctx, []string{"u1", "u2", "missing"}, false, time.Time{})
+	if err != nil || !slices.Equal(ids(byIDs), []string{"u1", "u2"}) {
+		t.Fatalf("ListByIDs: %v, %v", ids(byIDs), err)
+	}
+	byIDs, err = r.Users.ListByIDs(ctx, []string{"u1", "u2"}, true, createdAt)
+	if err != nil || !slices.Equal(ids(byIDs), []string{"u1"}) {
+		t.Fatalf("ListByIDs onlyActive: %v, %v", ids(byIDs), err)
+	}
+	byIDs, err = r.Users.ListByIDs(ctx, nil, false, time.Time{})
+	if err != nil || len(byIDs) != 0 {
+		t.Fatalf("ListByIDs empty: %v, %v", byIDs, err)
+	}
+
+	byTeam, err := r.Users.ListByTeam(ctx, "backend", false, time.Time{})
+	if err != nil || !slices.Equal(ids(byTeam), []string{"u1", "u2", "u3"}) {
+		t.Fatalf("ListByTeam: %v, %v", ids(byTeam), err)
+	}
+	byTeam, err = r.Users.ListByTeam(ctx, "backend", true, createdAt)
+	if err != nil || !slices.Equal(ids(byTeam), []string{"u1", "u3"}) {
+		t.Fatalf("ListByTeam onlyActive: %v, %v", ids(byTeam), err)
+	}
+	byTeam, err = r.Users.ListByTeam(ctx, "", false, time.Time{})
+	if err != nil || len(byTeam) != 0 {
+		t.Fatalf("ListByTeam without name: %v, %v", ids(byTeam), err)
+	}
+
+	summary, err := r.Users.GetSummary(ctx, "u1")
+	if err != nil || summary.OpenReviewCount != 2 || summary.UserID != "u1" {
+		t.Fatalf("GetSummary: %+v, %v", summary, err)
+	}
+
+	page, err := r.Users.List(ctx, repository.UserListFilter{TeamName: "backend", Username: "LI", Limit: 1})
+	if err != nil || len(page) != 1 || page[0].UserID != "u2" {
+		t.Fatalf("expected [u2], got %+v, %v", page, err)
+	}
+	active := true
+	page, err = r.Users.List(ctx, repository.UserListFilter{IsActive: &active, AfterUserID: "u1", Limit: 2})
+	if err != nil || len(page) != 2 || page[0].UserID != "u3" || page[0].OpenReviewCount != 1 || page[1].UserID != "u4" {
+		t.Fatalf("expected [u3 with 1 open review, u4], got %+v, %v", page, err)
+	}
+}
+
+func merged(pr *domain.PullRequest) *domain.PullRequest {
+	mergedAt := createdAt.Add(time.Hour)
+	pr.Status = string(domain.StatusMerged)
+	pr.MergedAt = &mergedAt
+	return pr
+}
+
+func testPullRequestCreateAndGet(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	seed(t, r, []string{"backend"}, user("u1", "backend", true), user("u2", "backend", true), user("u3", "backend", true))
+
+	pr := pullRequest("pr-1", "u1", "u3", "u2")
+	if err := r.PullRequests.Create(ctx, pr); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if err := r.PullRequests.Create(ctx, pullRequest("pr-1", "u2")); !errors.Is(err, repository.ErrAlreadyExists) {
+		t.Fatalf("expected ErrAlreadyExists, got %v", err)
+	}
+
+	noTime := pullRequest("pr-2", "u1")
+	noTime.CreatedAt = nil
+	noAssignedAt := pullRequest("pr-7", "u1", "u2")
+	noAssignedAt.ReviewerAssignedAt = nil
+	for name, bad := range map[string]*domain.PullRequest{
+		"no created_at":      noTime,
+		"no assigned_at":     noAssignedAt,
+		"unknown author":     pullRequest("pr-3", "missing"),
+		"unknown reviewer":   pullRequest("pr-4", "u1", "u2", "missing"),
+		"duplicate reviewer": pullRequest("pr-5", "u1", "u2", "u2"),
+		"three reviewers":    pullRequest("pr-6", "u1", "u1", "u2", "u3"),
+	} {
+		if err := r.PullRequests.Create(ctx, bad); err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+		if _, err := r.PullRequests.GetByID(ctx, bad.PullRequestID); !errors.Is(err, repository.ErrNotFound) {
+			t.Fatalf("%s: expected nothing to be created, got %v", name, err)
+		}
+	}
+
+	if _, err := r.PullRequests.GetByID(ctx, "missing"); !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	got, err := r.PullRequests.GetByID(ctx, "pr-1")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.PullRequestName != "pr pr-1" || got.AuthorID != "u1" || got.Status != "OPEN" || got.MergedAt != nil {
+		t.Fatalf("unexpected pr: %+v", got)
+	}
+	if !slices.Equal(got.AssignedReviewers, []string{"u3", "u2"}) {
+		t.Fatalf("expected reviewers in assignment order [u3 u2], got %v", got.AssignedReviewers)
+	}
+	if got.CreatedAt == nil || !got.CreatedAt.Equal(createdAt) {
+		t.Fatalf("expected created_at %v, got %v", createdAt, got.CreatedAt)
+	}
+	for _, id := range []string{"u3", "u2"} {
+		if at, ok := got.ReviewerAssignedAt[id]; !ok || !at.Equal(createdAt) {
+			t.Fatalf("expected %s assigned at %v, got %v", id, createdAt, got.ReviewerAssignedAt)
+		}
+	}
+
+	// Изменение возвращённого PR не должно менять хранилище.
+	got.AssignedReviewers[0] = "u1"
+	again, err := r.PullRequests.GetByID(ctx, "pr-1")
+	if err != nil || again.AssignedReviewers[0] != "u3" {
+		t.Fatalf("expected stored reviewers to be independent of the result, got %+v, %v", again, err)
+	}
+}
+
+func testPullRequestUpdate(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	seed(t, r, []string{"backend"}, user("u1", "backend", true), user("u2", "backend", true), user("u3", "backend", true))
+
+	if err := r.PullRequests.Update(ctx, pullRequest("missing", "u1")); !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	if err := r.PullRequests.Create(ctx, pullRequest("pr-1", "u1", "u2", "u3")); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	update := merged(pullRequest("pr-1", "u1", "u3"))
+	update.PullRequestName = "renamed"
+	update.CreatedAt = nil
+	if err := r.PullRequests.Update(ctx, update); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	got, err := r.PullRequests.GetByID(ctx, "pr-1")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.PullRequestName != "renamed" || got.Status != "MERGED" || !slices.Equal(got.AssignedReviewers, []string{"u3"}) {
+		t.Fatalf("unexpected pr after update: %+v", got)
+	}
+	if got.CreatedAt == nil || !got.CreatedAt.Equal(createdAt) {
+		t.Fatalf("expected created_at to be kept, got %v", got.CreatedAt)
+	}
+	if got.MergedAt == nil || !got.MergedAt.Equal(createdAt.Add(time.Hour)) {
+		t.Fatalf("expected merged_at to be set, got %v", got.MergedAt)
+	}
+
+	// Время назначения сохраняется из PR: у нового ревьювера своё, у оставшегося - прежнее.
+	reassignedAt := createdAt.Add(30 * time.Minute)
+	got.AssignedReviewers = append(got.AssignedReviewers, "u2")
+	got.MarkAssigned("u2", reassignedAt)
+	if err := r.PullRequests.Update(ctx, got); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	got, err = r.PullRequests.GetByID(ctx, "pr-1")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if !got.ReviewerAssignedAt["u3"].Equal(createdAt) || !got.ReviewerAssignedAt["u2"].Equal(reassignedAt) {
+		t.Fatalf("expected u3 at %v and u2 at %v, got %v", createdAt, reassignedAt, got.ReviewerAssignedAt)
+	}
+
+	bad := pullRequest("pr-1", "u1", "missing")
+	if err := r.PullRequests.Update(ctx, bad); err == nil {
+		t.Fatalf("expected error for unknown reviewer")
+	}
+	got, err = r.PullRequests.GetByID(ctx, "pr-1")
+	if err != nil || got.PullRequestName != "renamed" || !slices.Equal(got.AssignedReviewers, []string{"u3", "u2"}) {
+		t.Fatalf("expected failed update to change nothing, got %+v, %v", got, err)
+	}
+}
+
+func testPullRequestQueries(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	seed(t, r, []string{"backend"}, user("u1", "backend", true), user("u2", "backend", true), user("u3", "backend", true))
+
+	for _, pr := range []*domain.PullRequest{
+		pullRequest("pr-2", "u1", "u2"),
+		pullRequest("pr-1", "u1", "u2", "u3"),
+		merged(pullRequest("pr-3", "u2", "u3")),
+	} {
+		if err := r.PullRequests.Create(ctx, pr); err != nil {
+			t.Fatalf("Create %s: %v", pr.PullRequestID, err)
+		}
+	}
+
+	prIDs := func(prs []domain.PullRequestShort) []string {
+		result := make([]string, 0, len(prs))
+		for _, pr := range prs {
+			result = append(result, pr.PullRequestID)
+		}
+		return result
+	}
+
+	byReviewer, err := r.PullRequests.ListByReviewer(ctx, "u3")
+	if err != nil || !slices.Equal(prIDs(byReviewer), []string{"pr-1", "pr-3"}) {
+		t.Fatalf("ListByReviewer: %v, %v", prIDs(byReviewer), err)
+	}
+	if byReviewer[1] != (domain.PullRequestShort{PullRequestID: "pr-3", PullRequestName: "pr pr-3", AuthorID: "u2", Status: "MERGED"}) {
+		t.Fatalf("unexpected short pr: %+v", byReviewer[1])
+	}
+	byReviewer, err = r.PullRequests.ListByReviewer(ctx, "u1")
+	if err != nil || len(byReviewer) != 0 {
+		t.Fatalf("ListByReviewer without reviews: %v, %v", prIDs(byReviewer), err)
+	}
+
+	byAuthor, err := r.PullRequests.ListByAuthor(ctx, "u1")
+	if err != nil || !slices.Equal(prIDs(byAuthor), []string{"pr-1", "pr-2"}) {
+		t.Fatalf("ListByAuthor: %v, %v", prIDs(byAuthor), err)
+	}
+
+	counts, err := r.PullRequests.CountOpenByReviewers(ctx, []string{"u1", "u2", "u3"})
+	if err != nil {
+		t.Fatalf("CountOpenByReviewers: %v", err)
+	}
+	if len(counts) != 2 || counts["u2"] != 2 || counts["u3"] != 1 {
+		t.Fatalf("expected u2=2, u3=1, got %v", counts)
+	}
+	counts, err = r.PullRequests.CountOpenByReviewers(ctx, nil)
+	if err != nil || counts == nil || len(counts) != 0 {
+		t.Fatalf("expected empty non-nil counts, got %#v, %v", counts, err)
+	}
+
+	stats, err := r.PullRequests.GetReviewerStats(ctx)
+	if err != nil {
+		t.Fatalf("GetReviewerStats: %v", err)
+	}
+	want := []domain.ReviewerStat{{UserID: "u2", ReviewCount: 2}, {UserID: "u3", ReviewCount: 2}}
+	if !slices.Equal(stats, want) {
+		t.Fatalf("expected %+v, got %+v", want, stats)
+	}
+}
+
+// batchSink собирает выгрузку в пакет импорта, как её потом прочитал бы ParseImport.
+// Время приводится к UTC, чтобы сравнение не зависело от часового пояса драйвера.
+type batchSink struct {
+	batch domain.ImportBatch
+	line  int
+}
+
+func (s *batchSink) next() int {
+	s.line++
+	return s.line
+}
+
+func (s *batchSink) Team(teamName string) error {
+	s.batch.Teams = append(s.batch.Teams, domain.ImportTeam{Line: s.next(), TeamName: teamName})
+	return nil
+}
+
+func (s *batchSink) User(u *domain.User) error {
+	c := *u
+	c.Skills = slices.Clone(u.Skills)
+	if u.MaxOpenReviews != nil {
+		limit := *u.MaxOpenReviews
+		c.MaxOpenReviews = &limit
+	}
+	s.batch.Users = append(s.batch.Users, domain.ImportUser{Line: s.next(), User: c})
+	return nil
+}
+
+func (s *batchSink) PullRequest(pr *domain.PullRequest) error {
+	times, err := pr.ReviewerAssignedTimes()
+	if err != nil {
+		return err
+	}
+	c := domain.PullRequest{
+		PullRequestID:     pr.PullRequestID,
+		PullRequestName:   pr.PullRequestName,
+		AuthorID:          pr.AuthorID,
+		Status:            pr.Status,
+		AssignedReviewers: slices.Clone(pr.AssignedReviewers),
+		CreatedAt:         utcPtr(pr.CreatedAt),
+		MergedAt:          utcPtr(pr.MergedAt),
+	}
+	for i, id := range c.AssignedReviewers {
+		c.MarkAssigned(id, times[i].UTC())
+	}
+	s.batch.PullRequests = append(s.batch.PullRequests, domain.ImportPullRequest{Line: s.next(), PullRequest: c})
+	return nil
+}
+
+func (s *batchSink) Escalation(prID string, escalatedAt time.Time) error {
+	s.batch.Escalations = append(s.batch.Escalations,
+		domain.ImportEscalation{Line: s.next(), PullRequestID: prID, EscalatedAt: escalatedAt.UTC()})
+	return nil
+}
+
+func (s *batchSink) ReviewSLA(sla *domain.ReviewSLA) error {
+	s.batch.ReviewSLAs = append(s.batch.ReviewSLAs, domain.ImportReviewSLA{Line: s.next(), ReviewSLA: *sla})
+	return nil
+}
+
+func (s *batchSink) CodeOwnerRule(rule *domain.CodeOwnerRule) error {
+	c := *rule
+	c.Users = slices.Clone(rule.Users)
+	c.Teams = slices.Clone(rule.Teams)
+	s.batch.CodeOwnerRules = append(s.batch.CodeOwnerRules, domain.ImportCodeOwnerRule{Line: s.next(), CodeOwnerRule: c})
+	return nil
+}
+
+func (s *batchSink) ReviewerRule(rule *domain.ReviewerRule) error {
+	c := *rule
+	c.CreatedAt = rule.CreatedAt.UTC()
+	s.batch.ReviewerRules = append(s.batch.ReviewerRules, domain.ImportReviewerRule{Line: s.next(), ReviewerRule: c})
+	return nil
+}
+
+func (s *batchSink) Unavailability(u *domain.Unavailability) error {
+	c := *u
+	c.StartsAt, c.EndsAt, c.ReleasedAt = u.StartsAt.UTC(), u.EndsAt.UTC(), utcPtr(u.ReleasedAt)
+	s.batch.Unavailability = append(s.batch.Unavailability, domain.ImportUnavailability{Line: s.next(), Unavailability: c})
+	return nil
+}
+
+func utcPtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
+
+func exportBatch(t *testing.T, r Repositories) *domain.ImportBatch {
+	t.Helper()
+	var sink batchSink
+	if err := r.Export.Export(context.Background(), &sink); err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	return &sink.batch
+}
+
+// testExportRoundTrip выгружает заполненное хранилище, загружает выгрузку в пустое и сравнивает выгрузки:
+// восстановленная копия должна подбирать ревьюверов так же, как исходная.
+func testExportRoundTrip(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+	src := newRepos(t)
+
+	limit := 3
+	lead := user("u1", "backend", true)
+	lead.Skills = []string{"go", "sql"}
+	lead.MaxOpenReviews = &limit
+	seed(t, src, []string{"backend", "frontend"},
+		lead, user("u2", "backend", true), user("u3", "frontend", false), user("u4", "", true))
+
+	open := pullRequest("pr-1", "u1", "u2", "u4")
+	open.MarkAssigned("u4", createdAt.Add(2*time.Hour))
+	for _, pr := range []*domain.PullRequest{open, merged(pullRequest("pr-2", "u2", "u1"))} {
+		if err := src.PullRequests.Create(ctx, pr); err != nil {
+			t.Fatalf("Create %s: %v", pr.PullRequestID, err)
+		}
+	}
+
+	if err := src.SLAs.Set(ctx, domain.ReviewSLA{TeamName: "backend", SLA: 24 * time.Hour, Policy: domain.EscalationReassign}); err != nil {
+		t.Fatalf("Set SLA: %v", err)
+	}
+	if err := src.SLAs.MarkEscalated(ctx, "pr-1", createdAt.Add(25*time.Hour)); err != nil {
+		t.Fatalf("MarkEscalated: %v", err)
+	}
+
+	owners := []domain.CodeOwnerRule{
+		{Position: 1, Pattern: "*.go", Users: []string{"u1"}, Teams: []string{}},
+		{Position: 3, Pattern: "/web/", Users: []string{"u3"}, Teams: []string{"frontend"}},
+		{Position: 4, Pattern: "web/vendor/", Users: []string{}, Teams: []string{}},
+	}
+	if err := src.CodeOwners.Replace(ctx, owners); err != nil {
+		t.Fatalf("Replace code owners: %v", err)
+	}
+
+	for _, rule := range []*domain.ReviewerRule{
+		{
+			ID: "rule-2", Kind: domain.RuleExclude, Description: "pair programming",
+			Author: domain.UserSelector{UserID: "u1"}, Reviewer: domain.UserSelector{UserID: "u4"},
+			CreatedAt: createdAt,
+		},
+		{
+			ID: "rule-1", Kind: domain.RuleRequire,
+			Author: domain.UserSelector{Skill: "sql"}, Reviewer: domain.UserSelector{UserID: "u2"},
+			CreatedAt: createdAt.Add(time.Minute),
+		},
+	} {
+		if err := src.Rules.Create(ctx, rule); err != nil {
+			t.Fatalf("Create rule %s: %v", rule.ID, err)
+		}
+	}
+
+	for _, u := range []*domain.Unavailability{
+		{ID: "vac-1", UserID: "u2", StartsAt: createdAt, EndsAt: createdAt.Add(72 * time.Hour), Reason: "vacation"},
+		{ID: "vac-2", UserID: "u4", StartsAt: createdAt.Add(240 * time.Hour), EndsAt: createdAt.Add(264 * time.Hour)},
+	} {
+		if err := src.Unavailability.Create(ctx, u); err != nil {
+			t.Fatalf("Create unavailability %s: %v", u.ID, err)
+		}
+	}
+	if err := src.Unavailability.MarkReleased(ctx, "vac-1", createdAt.Add(time.Minute)); err != nil {
+		t.Fatalf("MarkReleased: %v", err)
+	}
+
+	want := exportBatch(t, src)
+	if len(want.Teams) != 2 || len(want.Users) != 4 || len(want.PullRequests) != 2 || len(want.Escalations) != 1 ||
+		len(want.ReviewSLAs) != 1 || len(want.CodeOwnerRules) != 3 || len(want.ReviewerRules) != 2 || len(want.Unavailability) != 2 {
+		t.Fatalf("export is incomplete: %+v", want)
+	}
+	if want.ReviewerRules[0].ID != "rule-2" {
+		t.Fatalf("expected reviewer rules in creation order, got %+v", want.ReviewerRules)
+	}
+
+	dst := newRepos(t)
+	if err := dst.Import.Load(ctx, want); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if got := exportBatch(t, dst); !reflect.DeepEqual(got, want) {
+		t.Fatalf("restored export differs:\n got %+v\nwant %+v", got, want)
+	}
+
+	// Восстановленные правила применяются в том же порядке и с теми же владельцами.
+	rules, err := dst.Rules.List(ctx)
+	if err != nil || len(rules) != 2 || rules[0].ID != "rule-2" {
+		t.Fatalf("restored rules: %+v, %v", rules, err)
+	}
+	restoredOwners, err := dst.CodeOwners.List(ctx)
+	if err != nil || !reflect.DeepEqual(restoredOwners, owners) {
+		t.Fatalf("restored code owners: %+v, %v", restoredOwners, err)
+	}
+	if err := dst.Import.Load(ctx, &domain.ImportBatch{Escalations: want.Escalations}); !errors.Is(err, repository.ErrAlreadyExists) {
+		t.Fatalf("expected ErrAlreadyExists for a second escalation load, got %v", err)
+	}
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+// ReviewerRuleRepository хранит правила подбора ревьюверов.
+type ReviewerRuleRepository interface {
+	// Create сохраняет новое правило. ErrNotFound - пользователя из селектора нет.
+	Create(ctx context.Context, rule *domain.ReviewerRule) error
+
+	// List возвращает все правила в порядке создания.
+	List(ctx context.Context) (domain.RuleSet, error)
+
+	// Delete удаляет правило и возвращает его. ErrNotFound - правила нет.
+	Delete(ctx context.Context, ruleID string) (*domain.ReviewerRule, error)
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+// ReviewSLARepository хранит SLA ревью команд и эскалации просроченных PR.
+type ReviewSLARepository interface {
+	// Set задаёт или заменяет SLA команды. ErrNotFound - команды нет.
+	Set(ctx context.Context, sla domain.ReviewSLA) error
+
+	// Delete снимает SLA команды. Если SLA не было, ничего не делает.
+	Delete(ctx context.Context, teamName string) error
+
+	// ListOverdue возвращает открытые PR, у которых к моменту now истёк SLA команды автора,
+	// от самых старых к новым. Пустой teamName - все команды.
+	ListOverdue(ctx context.Context, teamName string, now time.Time) ([]domain.OverduePullRequest, error)
+
+	// MarkEscalated запоминает момент последней эскалации PR.
+	MarkEscalated(ctx context.Context, prID string, at time.Time) error
+}
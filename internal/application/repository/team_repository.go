@@ -12,4 +12,23 @@ type TeamRepository interface {
 
 	// GetByName возвращает команду вместе с участниками.
 	GetByName(ctx context.Context, teamName string) (*domain.Team, error)
+
+	// Rename переименовывает команду. Пользователи, SLA и владение кодом переезжают вместе с ней.
+	// ErrNotFound - команды нет, ErrAlreadyExists - новое имя занято.
+	Rename(ctx context.Context, oldName, newName string) error
+
+	// Delete удаляет команду вместе с её SLA и убирает её из владельцев кода.
+	// В команде не должно остаться участников.
+	// ErrNotFound - команды нет.
+	Delete(ctx context.Context, teamName string) error
+
+	// List возвращает страницу команд, упорядоченных по имени, и общее число команд под фильтром.
+	List(ctx context.Context, filter TeamListFilter) ([]domain.TeamSummary, int, error)
+}
+
+// TeamListFilter - параметры выборки списка команд.
+type TeamListFilter struct {
+	Prefix string // Начало имени команды, без учёта регистра; пустая строка - все команды
+	Limit  int
+	Offset int
 }
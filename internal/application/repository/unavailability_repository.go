@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+// UnavailabilityRepository хранит периоды недоступности пользователей.
+type UnavailabilityRepository interface {
+	// Create сохраняет новый период.
+	Create(ctx context.Context, u *domain.Unavailability) error
+
+	// ListByUser возвращает периоды пользователя, которые ещё не закончились к моменту now, по времени начала.
+	ListByUser(ctx context.Context, userID string, now time.Time) ([]domain.Unavailability, error)
+
+	// Delete удаляет период и возвращает его. ErrNotFound - периода нет.
+	Delete(ctx context.Context, id string) (*domain.Unavailability, error)
+
+	// ListUnreleased возвращает начавшиеся к моменту now и ещё не закончившиеся периоды,
+	// по которым пользователя ещё не снимали с ревью.
+	ListUnreleased(ctx context.Context, now time.Time) ([]domain.Unavailability, error)
+
+	// MarkReleased отмечает, что пользователь снят с ревью в момент at.
+	MarkReleased(ctx context.Context, id string, at time.Time) error
+}
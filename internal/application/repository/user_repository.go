@@ -2,6 +2,8 @@ package repository
 
 import (
 	"context"
+	"time"
+
 	"pr-reviewer-assigment-service/internal/domain"
 )
 
@@ -16,6 +18,38 @@ type UserRepository interface {
 	// SetActive обновляет флаг активности пользователя и возвращает обновлённого пользователя.
 	SetActive(ctx context.Context, userID string, active bool) (*domain.User, error)
 
+	// SetTeam переводит пользователя в команду и возвращает обновлённого пользователя.
+	// Пустой teamName выводит пользователя из команды.
+	SetTeam(ctx context.Context, userID string, teamName string) (*domain.User, error)
+
+	// SetMaxOpenReviews задаёт лимит открытых ревью и возвращает обновлённого пользователя. nil снимает лимит.
+	SetMaxOpenReviews(ctx context.Context, userID string, limit *int) (*domain.User, error)
+
+	// SetSkills заменяет теги экспертизы пользователя и возвращает обновлённого пользователя.
+	SetSkills(ctx context.Context, userID string, skills []string) (*domain.User, error)
+
+	// ListByIDs возвращает существующих пользователей из списка, порядок не гарантируется.
+	// С onlyActive - только активных и не находящихся в момент now в периоде недоступности;
+	// без onlyActive now не используется.
+	ListByIDs(ctx context.Context, userIDs []string, onlyActive bool, now time.Time) ([]domain.User, error)
+
 	// ListByTeam возвращает пользователей команды.
-	ListByTeam(ctx context.Context, teamName string, onlyActive bool) ([]domain.User, error)
+	// С onlyActive - только активных и не находящихся в момент now в периоде недоступности;
+	// без onlyActive now не используется.
+	ListByTeam(ctx context.Context, teamName string, onlyActive bool, now time.Time) ([]domain.User, error)
+
+	// GetSummary возвращает пользователя вместе с числом открытых ревью.
+	GetSummary(ctx context.Context, userID string) (*domain.UserSummary, error)
+
+	// List возвращает пользователей под фильтром, упорядоченных по user_id, вместе с числом открытых ревью.
+	List(ctx context.Context, filter UserListFilter) ([]domain.UserSummary, error)
+}
+
+// UserListFilter - параметры выборки списка пользователей. Пустые поля не фильтруют.
+type UserListFilter struct {
+	TeamName    string // Точное имя команды
+	IsActive    *bool  // Флаг активности
+	Username    string // Подстрока имени, без учёта регистра
+	AfterUserID string // Курсор: только пользователи с user_id больше этого
+	Limit       int
 }
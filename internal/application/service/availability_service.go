@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"pr-reviewer-assigment-service/internal/application/repository"
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+// AvailabilityService управляет периодами недоступности пользователей (отпуска, больничные).
+// Пока период идёт, пользователь не выбирается ревьювером, а его открытые ревью переназначаются.
+type AvailabilityService struct {
+	userRepo           repository.UserRepository
+	unavailabilityRepo repository.UnavailabilityRepository
+	releaser           ReviewerReleaser
+	clock              Clock
+	ids                IDGenerator
+}
+
+func NewAvailabilityService(
+	userRepository repository.UserRepository,
+	unavailabilityRepository repository.UnavailabilityRepository,
+	releaser ReviewerReleaser,
+	clock Clock,
+	ids IDGenerator,
+) *AvailabilityService {
+	return &AvailabilityService{
+		userRepo:           userRepository,
+		unavailabilityRepo: unavailabilityRepository,
+		releaser:           releaser,
+		clock:              clock,
+		ids:                ids,
+	}
+}
+
+// Add добавляет пользователю период недоступности.
+// Если период уже начался, открытые ревью будут переназначены при следующем запуске RunReleaser.
+func (s *AvailabilityService) Add(
+	ctx context.Context,
+	userID string,
+	startsAt, endsAt time.Time,
+	reason string,
+) (*domain.Unavailability, error) {
+	if err := s.ensureUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	u := &domain.Unavailability{
+		ID:       s.ids.NewID(),
+		UserID:   userID,
+		StartsAt: startsAt.UTC(),
+		EndsAt:   endsAt.UTC(),
+		Reason:   reason,
+	}
+
+	if err := s.unavailabilityRepo.Create(ctx, u); err != nil {
+		return nil, fmt.Errorf("unavailabilityRepo.Create: %w", err)
+	}
+	return u, nil
+}
+
+// List возвращает текущие и будущие периоды недоступности пользователя.
+func (s *AvailabilityService) List(ctx context.Context, userID string) ([]domain.Unavailability, error) {
+	if err := s.ensureUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	periods, err := s.unavailabilityRepo.ListByUser(ctx, userID, s.clock.Now())
+	if err != nil {
+		return nil, fmt.Errorf("unavailabilityRepo.ListByUser: %w", err)
+	}
+	return periods, nil
+}
+
+// Remove удаляет период недоступности и возвращает его.
+func (s *AvailabilityService) Remove(ctx context.Context, id string) (*domain.Unavailability, error) {
+	u, err := s.unavailabilityRepo.Delete(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, domain.NewError(domain.ErrorNotFound, "unavailability not found: "+id)
+		}
+		return nil, fmt.Errorf("unavailabilityRepo.Delete: %w", err)
+	}
+	return u, nil
+}
+
+// ReleaseStarted переназначает открытые ревью пользователей, у которых к моменту now начался
+// период недоступности, и отмечает такие периоды обработанными. Возвращает число обработанных периодов.
+// Ошибка по одному периоду не мешает остальным: период останется необработанным и повторится в следующий раз.
+func (s *AvailabilityService) ReleaseStarted(ctx context.Context, now time.Time) (int, error) {
+	periods, err := s.unavailabilityRepo.ListUnreleased(ctx, now)
+	if err != nil {
+		return 0, fmt.Errorf("unavailabilityRepo.ListUnreleased: %w", err)
+	}
+
+	released := 0
+	var errs []error
+	for _, p := range periods {
+		if err := s.releaser.ReleaseReviewer(ctx, p.UserID, true); err != nil {
+			errs = append(errs, fmt.Errorf("release %s: %w", p.UserID, err))
+			continue
+		}
+		if err := s.unavailabilityRepo.MarkReleased(ctx, p.ID, now); err != nil {
+			errs = append(errs, fmt.Errorf("unavailabilityRepo.MarkReleased: %w", err))
+			continue
+		}
+		released++
+	}
+
+	return released, errors.Join(errs...)
+}
+
+// RunReleaser периодически вызывает ReleaseStarted, пока не отменён ctx.
+func (s *AvailabilityService) RunReleaser(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.ReleaseStarted(ctx, s.clock.Now()); err != nil {
+				log.Printf("unavailability releaser: %v", err)
+			}
+		}
+	}
+}
+
+func (s *AvailabilityService) ensureUser(ctx context.Context, userID string) error {
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return domain.NewError(domain.ErrorNotFound, "user not found: "+userID)
+		}
+		return fmt.Errorf("userRepo.GetByID: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"pr-reviewer-assigment-service/internal/application/repository"
+	"pr-reviewer-assigment-service/internal/application/service"
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+type mockUnavailabilityRepo struct {
+	data map[string]domain.Unavailability
+}
+
+func newMockUnavailabilityRepo() *mockUnavailabilityRepo {
+	return &mockUnavailabilityRepo{
+		data: make(map[string]domain.Unavailability),
+	}
+}
+
+func (m *mockUnavailabilityRepo) Create(ctx context.Context, u *domain.Unavailability) error {
+	m.data[u.ID] = *u
+	return nil
+}
+
+func (m *mockUnavailabilityRepo) ListByUser(ctx context.Context, userID string, now time.Time) ([]domain.Unavailability, error) {
+	result := make([]domain.Unavailability, 0)
+	for _, u := range m.data {
+		if u.UserID == userID && u.EndsAt.After(now) {
+			result = append(result, u)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockUnavailabilityRepo) Delete(ctx context.Context, id string) (*domain.Unavailability, error) {
+	u, ok := m.data[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	delete(m.data, id)
+	return &u, nil
+}
+
+func (m *mockUnavailabilityRepo) ListUnreleased(ctx context.Context, now time.Time) ([]domain.Unavailability, error) {
+	result := make([]domain.Unavailability, 0)
+	for _, u := range m.data {
+		if u.ReleasedAt == nil && u.Covers(now) {
+			result = append(result, u)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockUnavailabilityRepo) MarkReleased(ctx context.Context, id string, at time.Time) error {
+	u, ok := m.data[id]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	u.ReleasedAt = &at
+	m.data[id] = u
+	return nil
+}
+
+func TestAvailabilityService_Add_UserNotFound(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newMockUserRepo()
+	prRepo := newMockPRRepo()
+	teamRepo := newMockTeamRepo()
+	svc := service.NewAvailabilityService(userRepo, newMockUnavailabilityRepo(), service.NewPullRequestService(prRepo, userRepo, teamRepo, newMockCodeOwnerRepo(), newMockRuleRepo(), newFakeClock()), newFakeClock(), &sequentialIDs{prefix: "period"})
+
+	now := newFakeClock().Now()
+	_, err := svc.Add(ctx, "nope", now, now.Add(time.Hour), "vacation")
+
+	var derr *domain.Error
+	if !errors.As(err, &derr) || derr.Code != domain.ErrorNotFound {
+		t.Fatalf("expected NOT_FOUND, got %v", err)
+	}
+}
+
+func TestAvailabilityService_ReleaseStarted(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newMockUserRepo()
+	prRepo := newMockPRRepo()
+	teamRepo := newMockTeamRepo()
+	unavailabilityRepo := newMockUnavailabilityRepo()
+	svc := service.NewAvailabilityService(userRepo, unavailabilityRepo, service.NewPullRequestService(prRepo, userRepo, teamRepo, newMockCodeOwnerRepo(), newMockRuleRepo(), newFakeClock()), newFakeClock(), &sequentialIDs{prefix: "period"})
+
+	userRepo.data["u2"] = domain.User{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true}
+	userRepo.data["u3"] = domain.User{UserID: "u3", Username: "Charlie", TeamName: "backend", IsActive: true}
+	prRepo.data["pr-1"] = domain.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u1",
+		Status:            "OPEN",
+		AssignedReviewers: []string{"u2"},
+	}
+
+	now := time.Date(2025, 7, 1, 9, 0, 0, 0, time.UTC)
+	started, err := svc.Add(ctx, "u2", now.Add(-time.Hour), now.Add(7*24*time.Hour), "vacation")
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if started.ID != "period-1" {
+		t.Fatalf("expected ID from the generator, got %s", started.ID)
+	}
+	if _, err := svc.Add(ctx, "u3", now.Add(time.Hour), now.Add(2*time.Hour), "dentist"); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	released, err := svc.ReleaseStarted(ctx, now)
+	if err != nil {
+		t.Fatalf("ReleaseStarted: %v", err)
+	}
+	if released != 1 {
+		t.Fatalf("expected 1 released period, got %d", released)
+	}
+	if got := prRepo.data["pr-1"].AssignedReviewers; len(got) != 1 || got[0] != "u3" {
+		t.Fatalf("expected pr-1 to be reassigned to u3, got %v", got)
+	}
+	if unavailabilityRepo.data[started.ID].ReleasedAt == nil {
+		t.Fatalf("expected started period to be marked released")
+	}
+
+	released, err = svc.ReleaseStarted(ctx, now)
+	if err != nil || released != 0 {
+		t.Fatalf("expected second run to do nothing, got %d, %v", released, err)
+	}
+}
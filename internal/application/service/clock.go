@@ -0,0 +1,15 @@
+package service
+
+import "time"
+
+// Clock - источник текущего времени. В тестах подменяется, чтобы управлять временем.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock - системные часы, время в UTC.
+type SystemClock struct{}
+
+func (SystemClock) Now() time.Time {
+	return time.Now().UTC()
+}
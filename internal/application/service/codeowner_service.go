@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"pr-reviewer-assigment-service/internal/application/repository"
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+// CodeOwnerService управляет правилами CODEOWNERS, по которым подбираются ревьюверы изменённых файлов.
+type CodeOwnerService struct {
+	codeOwnerRepo repository.CodeOwnerRepository
+	userRepo      repository.UserRepository
+	teamRepo      repository.TeamRepository
+}
+
+func NewCodeOwnerService(
+	codeOwnerRepository repository.CodeOwnerRepository,
+	userRepository repository.UserRepository,
+	teamRepository repository.TeamRepository,
+) *CodeOwnerService {
+	return &CodeOwnerService{
+		codeOwnerRepo: codeOwnerRepository,
+		userRepo:      userRepository,
+		teamRepo:      teamRepository,
+	}
+}
+
+// Upload разбирает файл CODEOWNERS и заменяет им текущие правила.
+// Все владельцы должны существовать, иначе INVALID_CODEOWNERS и правила не меняются.
+func (s *CodeOwnerService) Upload(ctx context.Context, content string) ([]domain.CodeOwnerRule, error) {
+	rules, err := domain.ParseCodeOwners(content)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rule := range rules {
+		for _, userID := range rule.Users {
+			if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+				if errors.Is(err, repository.ErrNotFound) {
+					return nil, domain.NewError(domain.ErrorInvalidCodeOwners,
+						fmt.Sprintf("line %d: user not found: %s", rule.Position, userID))
+				}
+				return nil, fmt.Errorf("userRepo.GetByID: %w", err)
+			}
+		}
+		for _, teamName := range rule.Teams {
+			if _, err := s.teamRepo.GetByName(ctx, teamName); err != nil {
+				if errors.Is(err, repository.ErrNotFound) {
+					return nil, domain.NewError(domain.ErrorInvalidCodeOwners,
+						fmt.Sprintf("line %d: team not found: %s", rule.Position, teamName))
+				}
+				return nil, fmt.Errorf("teamRepo.GetByName: %w", err)
+			}
+		}
+	}
+
+	if err := s.codeOwnerRepo.Replace(ctx, rules); err != nil {
+		return nil, fmt.Errorf("codeOwnerRepo.Replace: %w", err)
+	}
+	if rules == nil {
+		rules = []domain.CodeOwnerRule{}
+	}
+	return rules, nil
+}
+
+// List возвращает текущие правила в порядке файла.
+func (s *CodeOwnerService) List(ctx context.Context) ([]domain.CodeOwnerRule, error) {
+	rules, err := s.codeOwnerRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("codeOwnerRepo.List: %w", err)
+	}
+	return rules, nil
+}
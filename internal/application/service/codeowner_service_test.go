@@ -0,0 +1,142 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"pr-reviewer-assigment-service/internal/application/service"
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+type mockCodeOwnerRepo struct {
+	rules []domain.CodeOwnerRule
+}
+
+func newMockCodeOwnerRepo() *mockCodeOwnerRepo {
+	return &mockCodeOwnerRepo{}
+}
+
+func (m *mockCodeOwnerRepo) Replace(ctx context.Context, rules []domain.CodeOwnerRule) error {
+	m.rules = append([]domain.CodeOwnerRule(nil), rules...)
+	return nil
+}
+
+func (m *mockCodeOwnerRepo) List(ctx context.Context) ([]domain.CodeOwnerRule, error) {
+	return append([]domain.CodeOwnerRule(nil), m.rules...), nil
+}
+
+func TestCodeOwnerService_Upload_Success(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newMockUserRepo()
+	teamRepo := newMockTeamRepo()
+	codeOwnerRepo := newMockCodeOwnerRepo()
+
+	userRepo.data["u1"] = domain.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
+	teamRepo.data["frontend"] = domain.Team{TeamName: "frontend"}
+
+	svc := service.NewCodeOwnerService(codeOwnerRepo, userRepo, teamRepo)
+
+	rules, err := svc.Upload(ctx, "# owners\n\n*.go @u1 # backend\n/web/ @team/frontend @u1\ndocs/\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rules) != 3 || len(codeOwnerRepo.rules) != 3 {
+		t.Fatalf("expected 3 stored rules, got %+v", codeOwnerRepo.rules)
+	}
+
+	web := rules[1]
+	if web.Position != 4 || web.Pattern != "/web/" ||
+		len(web.Users) != 1 || web.Users[0] != "u1" ||
+		len(web.Teams) != 1 || web.Teams[0] != "frontend" {
+		t.Fatalf("unexpected rule: %+v", web)
+	}
+	if len(rules[2].Users) != 0 || len(rules[2].Teams) != 0 {
+		t.Fatalf("expected rule without owners, got %+v", rules[2])
+	}
+}
+
+func TestCodeOwnerService_Upload_Invalid(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newMockUserRepo()
+	teamRepo := newMockTeamRepo()
+	userRepo.data["u1"] = domain.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
+
+	cases := []struct {
+		name    string
+		content string
+	}{
+		{"owner without @", "*.go u1"},
+		{"empty team", "*.go @team/"},
+		{"negation", "!*.go @u1"},
+		{"bad glob", "[a.go @u1"},
+		{"unknown user", "*.go @ghost"},
+		{"unknown team", "*.go @team/ghosts"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			codeOwnerRepo := newMockCodeOwnerRepo()
+			codeOwnerRepo.rules = []domain.CodeOwnerRule{{Position: 1, Pattern: "*"}}
+			svc := service.NewCodeOwnerService(codeOwnerRepo, userRepo, teamRepo)
+
+			_, err := svc.Upload(ctx, "README.md @u1\n"+tc.content)
+
+			var derr *domain.Error
+			if !errors.As(err, &derr) || derr.Code != domain.ErrorInvalidCodeOwners {
+				t.Fatalf("expected INVALID_CODEOWNERS, got %v", err)
+			}
+			if len(codeOwnerRepo.rules) != 1 || codeOwnerRepo.rules[0].Pattern != "*" {
+				t.Fatalf("rules must stay unchanged, got %+v", codeOwnerRepo.rules)
+			}
+		})
+	}
+}
+
+func TestOwnerRuleFor(t *testing.T) {
+	rules, err := domain.ParseCodeOwners(`
+*            @default
+*.go         @gopher
+/build/      @builder
+docs/**/*.md @writer
+api/         @api
+internal/db  @dba
+config/*     @ops
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cases := []struct {
+		path string
+		want string
+	}{
+		{"README.md", "default"},
+		{"cmd/main.go", "gopher"},
+		{"build/out/app", "builder"},
+		{"tools/build/app", "default"},
+		{"docs/guide/intro.md", "writer"},
+		{"docs/intro.md", "writer"},
+		{"docs/intro.txt", "default"},
+		{"api/v1/spec.yml", "api"},
+		{"pkg/api/handler.go", "api"},
+		{"api", "default"},
+		{"internal/db/pool.go", "dba"},
+		{"pkg/internal/db/pool.txt", "default"},
+		{"config/app.yml", "ops"},
+		{"config/env/prod.yml", "default"},
+		{"config", "default"},
+		{"pkg/config/app.yml", "default"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.path, func(t *testing.T) {
+			rule := domain.OwnerRuleFor(rules, tc.path)
+			if rule == nil || len(rule.Users) != 1 || rule.Users[0] != tc.want {
+				t.Fatalf("expected owner %s, got %+v", tc.want, rule)
+			}
+		})
+	}
+}
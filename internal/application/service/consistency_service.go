@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"pr-reviewer-assigment-service/internal/application/repository"
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+// ConsistencyService проверяет целостность данных и исправляет то, что можно исправить без участия человека.
+type ConsistencyService struct {
+	consistencyRepo repository.ConsistencyRepository
+	releaser        ReviewerReleaser
+}
+
+func NewConsistencyService(
+	consistencyRepository repository.ConsistencyRepository,
+	releaser ReviewerReleaser,
+) *ConsistencyService {
+	return &ConsistencyService{
+		consistencyRepo: consistencyRepository,
+		releaser:        releaser,
+	}
+}
+
+// Check возвращает найденные нарушения, ничего не меняя.
+func (s *ConsistencyService) Check(ctx context.Context) (*domain.ConsistencyReport, error) {
+	issues, err := s.consistencyRepo.Check(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("consistencyRepo.Check: %w", err)
+	}
+	return &domain.ConsistencyReport{Issues: issues}, nil
+}
+
+// Repair находит нарушения и исправляет их:
+//   - ссылки на несуществующих ревьюверов удаляются, дальше их не допускает база;
+//   - неактивные ревьюверы снимаются с открытых PR с переназначением, как при выходе из команды.
+//
+// Авторов вне команд сервис не исправляет: команду выбирает человек. Такие нарушения остаются с Repaired=false.
+func (s *ConsistencyService) Repair(ctx context.Context) (*domain.ConsistencyReport, error) {
+	issues, err := s.consistencyRepo.Check(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("consistencyRepo.Check: %w", err)
+	}
+
+	if _, err := s.consistencyRepo.RemoveDanglingReviewers(ctx); err != nil {
+		return nil, fmt.Errorf("consistencyRepo.RemoveDanglingReviewers: %w", err)
+	}
+
+	released := make(map[string]struct{})
+	for i := range issues {
+		issue := &issues[i]
+		switch issue.Kind {
+		case domain.IssueDanglingReviewer:
+			issue.Repaired = true
+		case domain.IssueInactiveReviewer:
+			if _, ok := released[issue.UserID]; !ok {
+				if err := s.releaser.ReleaseReviewer(ctx, issue.UserID, true); err != nil {
+					return nil, fmt.Errorf("release inactive reviewer %s: %w", issue.UserID, err)
+				}
+				released[issue.UserID] = struct{}{}
+			}
+			issue.Repaired = true
+		}
+	}
+
+	return &domain.ConsistencyReport{Repair: true, Issues: issues}, nil
+}
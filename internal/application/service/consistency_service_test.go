@@ -0,0 +1,120 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"pr-reviewer-assigment-service/internal/application/service"
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+type mockConsistencyRepo struct {
+	issues         []domain.ConsistencyIssue
+	danglingPurged int
+}
+
+func (m *mockConsistencyRepo) Check(ctx context.Context) ([]domain.ConsistencyIssue, error) {
+	return slices.Clone(m.issues), nil
+}
+
+func (m *mockConsistencyRepo) RemoveDanglingReviewers(ctx context.Context) (int, error) {
+	m.danglingPurged++
+	return 1, nil
+}
+
+// mockReleaser запоминает, кого сняли с ревью; пользователи из fail снимаются с ошибкой.
+type mockReleaser struct {
+	released []string
+	fail     map[string]bool
+}
+
+func (m *mockReleaser) ReleaseReviewer(ctx context.Context, userID string, reassign bool) error {
+	if !reassign {
+		return errors.New("expected reassign")
+	}
+	if m.fail[userID] {
+		return errors.New("release failed")
+	}
+	m.released = append(m.released, userID)
+	return nil
+}
+
+func (m *mockReleaser) ReleaseReviewers(ctx context.Context, userIDs []string, reassign bool) error {
+	for _, userID := range userIDs {
+		if err := m.ReleaseReviewer(ctx, userID, reassign); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func consistencyFixture() []domain.ConsistencyIssue {
+	return []domain.ConsistencyIssue{
+		{Kind: domain.IssueAuthorWithoutTeam, PullRequestID: "pr-3", UserID: "u5"},
+		{Kind: domain.IssueDanglingReviewer, PullRequestID: "pr-1", UserID: "ghost"},
+		{Kind: domain.IssueInactiveReviewer, PullRequestID: "pr-1", UserID: "u2"},
+		{Kind: domain.IssueInactiveReviewer, PullRequestID: "pr-2", UserID: "u2"},
+	}
+}
+
+func TestConsistencyService_CheckChangesNothing(t *testing.T) {
+	repo := &mockConsistencyRepo{issues: consistencyFixture()}
+	releaser := &mockReleaser{}
+	svc := service.NewConsistencyService(repo, releaser)
+
+	report, err := svc.Check(context.Background())
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	if report.Repair || len(report.Issues) != 4 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	for _, issue := range report.Issues {
+		if issue.Repaired {
+			t.Fatalf("expected nothing repaired, got %+v", issue)
+		}
+	}
+	if repo.danglingPurged != 0 || len(releaser.released) != 0 {
+		t.Fatalf("expected no changes, purged=%d released=%v", repo.danglingPurged, releaser.released)
+	}
+}
+
+func TestConsistencyService_Repair(t *testing.T) {
+	repo := &mockConsistencyRepo{issues: consistencyFixture()}
+	releaser := &mockReleaser{}
+	svc := service.NewConsistencyService(repo, releaser)
+
+	report, err := svc.Repair(context.Background())
+	if err != nil {
+		t.Fatalf("Repair: %v", err)
+	}
+	if !report.Repair {
+		t.Fatalf("expected repair report")
+	}
+
+	repaired := make([]bool, 0, len(report.Issues))
+	for _, issue := range report.Issues {
+		repaired = append(repaired, issue.Repaired)
+	}
+	if !slices.Equal(repaired, []bool{false, true, true, true}) {
+		t.Fatalf("expected only author without team to stay unrepaired, got %v", repaired)
+	}
+	if repo.danglingPurged != 1 {
+		t.Fatalf("expected dangling reviewers to be purged once, got %d", repo.danglingPurged)
+	}
+	// Пользователь снимается со всех открытых ревью за раз.
+	if !slices.Equal(releaser.released, []string{"u2"}) {
+		t.Fatalf("expected u2 released once, got %v", releaser.released)
+	}
+}
+
+func TestConsistencyService_RepairFailsOnRelease(t *testing.T) {
+	repo := &mockConsistencyRepo{issues: consistencyFixture()}
+	svc := service.NewConsistencyService(repo, &mockReleaser{fail: map[string]bool{"u2": true}})
+
+	if _, err := svc.Repair(context.Background()); err == nil {
+		t.Fatalf("expected release error")
+	}
+}
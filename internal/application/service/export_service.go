@@ -0,0 +1,26 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"pr-reviewer-assigment-service/internal/application/repository"
+)
+
+// ExportService выгружает все данные в формате, который принимает ImportService.
+type ExportService struct {
+	exportRepo repository.ExportRepository
+}
+
+func NewExportService(exportRepository repository.ExportRepository) *ExportService {
+	return &ExportService{exportRepo: exportRepository}
+}
+
+// Export передаёт sink команды, пользователей, PR, а затем ссылающиеся на них записи -
+// в порядке, в котором их можно импортировать.
+func (s *ExportService) Export(ctx context.Context, sink repository.ExportSink) error {
+	if err := s.exportRepo.Export(ctx, sink); err != nil {
+		return fmt.Errorf("exportRepo.Export: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+package service
+
+import "github.com/google/uuid"
+
+// IDGenerator выдаёт идентификаторы новых сущностей. В тестах подменяется, чтобы ID были предсказуемыми.
+type IDGenerator interface {
+	NewID() string
+}
+
+// UUIDGenerator выдаёт случайные UUID.
+type UUIDGenerator struct{}
+
+func (UUIDGenerator) NewID() string {
+	return uuid.NewString()
+}
@@ -0,0 +1,286 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"pr-reviewer-assigment-service/internal/application/repository"
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+// ImportService загружает данные из файла при переезде на сервис или восстановлении из выгрузки.
+type ImportService struct {
+	importRepo repository.ImportRepository
+}
+
+func NewImportService(importRepository repository.ImportRepository) *ImportService {
+	return &ImportService{importRepo: importRepository}
+}
+
+// Import проверяет записи и загружает корректные. Записи с ошибками попадают в отчёт и пропускаются:
+//   - ключ повторяется в файле или уже есть в базе;
+//   - команда, пользователь или PR, на которые ссылается запись, не найдены ни среди корректных записей файла, ни в базе.
+//
+// С dryRun записи только проверяются. Если база изменилась между проверкой и загрузкой - IMPORT_CONFLICT.
+func (s *ImportService) Import(ctx context.Context, batch *domain.ImportBatch, dryRun bool) (*domain.ImportReport, error) {
+	existing, err := s.importRepo.Existing(ctx, importKeys(batch))
+	if err != nil {
+		return nil, fmt.Errorf("importRepo.Existing: %w", err)
+	}
+
+	valid, errs := checkImport(batch, existing)
+	report := &domain.ImportReport{
+		DryRun:         dryRun,
+		Teams:          len(valid.Teams),
+		Users:          len(valid.Users),
+		PullRequests:   len(valid.PullRequests),
+		Escalations:    len(valid.Escalations),
+		ReviewSLAs:     len(valid.ReviewSLAs),
+		CodeOwnerRules: len(valid.CodeOwnerRules),
+		ReviewerRules:  len(valid.ReviewerRules),
+		Unavailability: len(valid.Unavailability),
+		Errors:         errs,
+	}
+	if dryRun || valid.Len() == 0 {
+		return report, nil
+	}
+
+	if err := s.importRepo.Load(ctx, valid); err != nil {
+		if errors.Is(err, repository.ErrAlreadyExists) || errors.Is(err, repository.ErrNotFound) {
+			return nil, domain.NewError(domain.ErrorImportConflict,
+				"data changed while importing, nothing was loaded; retry the import")
+		}
+		return nil, fmt.Errorf("importRepo.Load: %w", err)
+	}
+	return report, nil
+}
+
+// importKeys собирает ключи записей и ссылок на команды и пользователей.
+func importKeys(batch *domain.ImportBatch) repository.ImportKeys {
+	var keys repository.ImportKeys
+	for _, t := range batch.Teams {
+		keys.Teams = append(keys.Teams, t.TeamName)
+	}
+	for _, u := range batch.Users {
+		keys.Users = append(keys.Users, u.UserID)
+		if u.TeamName != "" {
+			keys.Teams = append(keys.Teams, u.TeamName)
+		}
+	}
+	for _, pr := range batch.PullRequests {
+		keys.PullRequests = append(keys.PullRequests, pr.PullRequestID)
+		keys.Users = append(keys.Users, pr.AuthorID)
+		keys.Users = append(keys.Users, pr.AssignedReviewers...)
+	}
+	for _, e := range batch.Escalations {
+		keys.Escalations = append(keys.Escalations, e.PullRequestID)
+		keys.PullRequests = append(keys.PullRequests, e.PullRequestID)
+	}
+	for _, sla := range batch.ReviewSLAs {
+		keys.ReviewSLAs = append(keys.ReviewSLAs, sla.TeamName)
+		keys.Teams = append(keys.Teams, sla.TeamName)
+	}
+	for _, rule := range batch.CodeOwnerRules {
+		keys.CodeOwnerRules = append(keys.CodeOwnerRules, rule.Position)
+		keys.Users = append(keys.Users, rule.Users...)
+		keys.Teams = append(keys.Teams, rule.Teams...)
+	}
+	for _, rule := range batch.ReviewerRules {
+		keys.ReviewerRules = append(keys.ReviewerRules, rule.ID)
+		for _, id := range []string{rule.Author.UserID, rule.Reviewer.UserID} {
+			if id != "" {
+				keys.Users = append(keys.Users, id)
+			}
+		}
+	}
+	for _, u := range batch.Unavailability {
+		keys.Unavailability = append(keys.Unavailability, u.ID)
+		keys.Users = append(keys.Users, u.UserID)
+	}
+
+	slices.Sort(keys.Teams)
+	slices.Sort(keys.Users)
+	slices.Sort(keys.PullRequests)
+	keys.Teams = slices.Compact(keys.Teams)
+	keys.Users = slices.Compact(keys.Users)
+	keys.PullRequests = slices.Compact(keys.PullRequests)
+	return keys
+}
+
+// checkImport отбирает корректные записи. Команды проверяются раньше пользователей, пользователи - раньше PR,
+// а они - раньше ссылающихся на них записей, поэтому запись, ссылающаяся на отклонённую запись файла, тоже отклоняется.
+func checkImport(batch *domain.ImportBatch, existing repository.ImportKeys) (*domain.ImportBatch, []domain.ImportRowError) {
+	var (
+		valid domain.ImportBatch
+		errs  []domain.ImportRowError
+	)
+	reject := func(line int, field, reason string) {
+		errs = append(errs, domain.ImportRowError{Line: line, Field: field, Reason: reason})
+	}
+
+	teamsInDB := toSet(existing.Teams)
+	teams := make(map[string]int, len(batch.Teams)) // team_name -> строка первой записи
+	knownTeam := func(teamName string) bool {
+		_, inFile := teams[teamName]
+		return inFile || has(teamsInDB, teamName)
+	}
+	for _, t := range batch.Teams {
+		switch line, dup := teams[t.TeamName]; {
+		case dup:
+			reject(t.Line, "team_name", fmt.Sprintf("is duplicated (first on line %d)", line))
+		case has(teamsInDB, t.TeamName):
+			reject(t.Line, "team_name", "team already exists")
+		default:
+			teams[t.TeamName] = t.Line
+			valid.Teams = append(valid.Teams, t)
+		}
+	}
+
+	usersInDB := toSet(existing.Users)
+	users := make(map[string]int, len(batch.Users))
+	for _, u := range batch.Users {
+		switch line, dup := users[u.UserID]; {
+		case dup:
+			reject(u.Line, "user_id", fmt.Sprintf("is duplicated (first on line %d)", line))
+		case has(usersInDB, u.UserID):
+			reject(u.Line, "user_id", "user already exists")
+		case u.TeamName != "" && !knownTeam(u.TeamName):
+			reject(u.Line, "team_name", "team not found: "+u.TeamName)
+		default:
+			users[u.UserID] = u.Line
+			valid.Users = append(valid.Users, u)
+		}
+	}
+	knownUser := func(userID string) bool {
+		_, inFile := users[userID]
+		return inFile || has(usersInDB, userID)
+	}
+
+	prsInDB := toSet(existing.PullRequests)
+	prs := make(map[string]int, len(batch.PullRequests))
+	for _, pr := range batch.PullRequests {
+		unknown := slices.IndexFunc(pr.AssignedReviewers, func(id string) bool { return !knownUser(id) })
+		switch line, dup := prs[pr.PullRequestID]; {
+		case dup:
+			reject(pr.Line, "pull_request_id", fmt.Sprintf("is duplicated (first on line %d)", line))
+		case has(prsInDB, pr.PullRequestID):
+			reject(pr.Line, "pull_request_id", "pull request already exists")
+		case !knownUser(pr.AuthorID):
+			reject(pr.Line, "author_id", "user not found: "+pr.AuthorID)
+		case unknown >= 0:
+			reject(pr.Line, fmt.Sprintf("assigned_reviewers[%d]", unknown), "user not found: "+pr.AssignedReviewers[unknown])
+		default:
+			prs[pr.PullRequestID] = pr.Line
+			valid.PullRequests = append(valid.PullRequests, pr)
+		}
+	}
+
+	escalationsInDB := toSet(existing.Escalations)
+	escalations := make(map[string]int, len(batch.Escalations))
+	for _, e := range batch.Escalations {
+		_, prInFile := prs[e.PullRequestID]
+		switch line, dup := escalations[e.PullRequestID]; {
+		case dup:
+			reject(e.Line, "pull_request_id", fmt.Sprintf("is duplicated (first on line %d)", line))
+		case has(escalationsInDB, e.PullRequestID):
+			reject(e.Line, "pull_request_id", "escalation already exists")
+		case !prInFile && !has(prsInDB, e.PullRequestID):
+			reject(e.Line, "pull_request_id", "pull request not found: "+e.PullRequestID)
+		default:
+			escalations[e.PullRequestID] = e.Line
+			valid.Escalations = append(valid.Escalations, e)
+		}
+	}
+
+	slasInDB := toSet(existing.ReviewSLAs)
+	slas := make(map[string]int, len(batch.ReviewSLAs))
+	for _, sla := range batch.ReviewSLAs {
+		switch line, dup := slas[sla.TeamName]; {
+		case dup:
+			reject(sla.Line, "team_name", fmt.Sprintf("is duplicated (first on line %d)", line))
+		case has(slasInDB, sla.TeamName):
+			reject(sla.Line, "team_name", "review SLA already exists")
+		case !knownTeam(sla.TeamName):
+			reject(sla.Line, "team_name", "team not found: "+sla.TeamName)
+		default:
+			slas[sla.TeamName] = sla.Line
+			valid.ReviewSLAs = append(valid.ReviewSLAs, sla)
+		}
+	}
+
+	positionsInDB := make(map[int]struct{}, len(existing.CodeOwnerRules))
+	for _, position := range existing.CodeOwnerRules {
+		positionsInDB[position] = struct{}{}
+	}
+	positions := make(map[int]int, len(batch.CodeOwnerRules))
+	for _, rule := range batch.CodeOwnerRules {
+		_, inDB := positionsInDB[rule.Position]
+		unknownUser := slices.IndexFunc(rule.Users, func(id string) bool { return !knownUser(id) })
+		unknownTeam := slices.IndexFunc(rule.Teams, func(name string) bool { return !knownTeam(name) })
+		switch line, dup := positions[rule.Position]; {
+		case dup:
+			reject(rule.Line, "position", fmt.Sprintf("is duplicated (first on line %d)", line))
+		case inDB:
+			reject(rule.Line, "position", "code owner rule already exists")
+		case unknownUser >= 0:
+			reject(rule.Line, fmt.Sprintf("owner_users[%d]", unknownUser), "user not found: "+rule.Users[unknownUser])
+		case unknownTeam >= 0:
+			reject(rule.Line, fmt.Sprintf("owner_teams[%d]", unknownTeam), "team not found: "+rule.Teams[unknownTeam])
+		default:
+			positions[rule.Position] = rule.Line
+			valid.CodeOwnerRules = append(valid.CodeOwnerRules, rule)
+		}
+	}
+
+	rulesInDB := toSet(existing.ReviewerRules)
+	rules := make(map[string]int, len(batch.ReviewerRules))
+	for _, rule := range batch.ReviewerRules {
+		switch line, dup := rules[rule.ID]; {
+		case dup:
+			reject(rule.Line, "rule_id", fmt.Sprintf("is duplicated (first on line %d)", line))
+		case has(rulesInDB, rule.ID):
+			reject(rule.Line, "rule_id", "reviewer rule already exists")
+		case rule.Author.UserID != "" && !knownUser(rule.Author.UserID):
+			reject(rule.Line, "author_user_id", "user not found: "+rule.Author.UserID)
+		case rule.Reviewer.UserID != "" && !knownUser(rule.Reviewer.UserID):
+			reject(rule.Line, "reviewer_user_id", "user not found: "+rule.Reviewer.UserID)
+		default:
+			rules[rule.ID] = rule.Line
+			valid.ReviewerRules = append(valid.ReviewerRules, rule)
+		}
+	}
+
+	periodsInDB := toSet(existing.Unavailability)
+	periods := make(map[string]int, len(batch.Unavailability))
+	for _, u := range batch.Unavailability {
+		switch line, dup := periods[u.ID]; {
+		case dup:
+			reject(u.Line, "unavailability_id", fmt.Sprintf("is duplicated (first on line %d)", line))
+		case has(periodsInDB, u.ID):
+			reject(u.Line, "unavailability_id", "unavailability already exists")
+		case !knownUser(u.UserID):
+			reject(u.Line, "user_id", "user not found: "+u.UserID)
+		default:
+			periods[u.ID] = u.Line
+			valid.Unavailability = append(valid.Unavailability, u)
+		}
+	}
+
+	slices.SortStableFunc(errs, func(a, b domain.ImportRowError) int { return a.Line - b.Line })
+	return &valid, errs
+}
+
+func toSet(keys []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(keys))
+	for _, k := range keys {
+		set[k] = struct{}{}
+	}
+	return set
+}
+
+func has(set map[string]struct{}, key string) bool {
+	_, ok := set[key]
+	return ok
+}
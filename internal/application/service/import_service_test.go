@@ -0,0 +1,229 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"pr-reviewer-assigment-service/internal/application/repository"
+	"pr-reviewer-assigment-service/internal/application/service"
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+// mockImportRepo считает существующими ключи из teams, users, prs, rules и positions и запоминает загруженные пакеты.
+type mockImportRepo struct {
+	teams, users, prs, rules []string
+	positions                []int
+	loaded                   []*domain.ImportBatch
+	loadErr                  error
+}
+
+func (m *mockImportRepo) Existing(ctx context.Context, keys repository.ImportKeys) (repository.ImportKeys, error) {
+	var found repository.ImportKeys
+	for _, k := range keys.Teams {
+		if slices.Contains(m.teams, k) {
+			found.Teams = append(found.Teams, k)
+		}
+	}
+	for _, k := range keys.Users {
+		if slices.Contains(m.users, k) {
+			found.Users = append(found.Users, k)
+		}
+	}
+	for _, k := range keys.PullRequests {
+		if slices.Contains(m.prs, k) {
+			found.PullRequests = append(found.PullRequests, k)
+		}
+	}
+	for _, k := range keys.ReviewerRules {
+		if slices.Contains(m.rules, k) {
+			found.ReviewerRules = append(found.ReviewerRules, k)
+		}
+	}
+	for _, k := range keys.CodeOwnerRules {
+		if slices.Contains(m.positions, k) {
+			found.CodeOwnerRules = append(found.CodeOwnerRules, k)
+		}
+	}
+	return found, nil
+}
+
+func (m *mockImportRepo) Load(ctx context.Context, batch *domain.ImportBatch) error {
+	if m.loadErr != nil {
+		return m.loadErr
+	}
+	m.loaded = append(m.loaded, batch)
+	return nil
+}
+
+func importFixture() *domain.ImportBatch {
+	created := time.Date(2025, 9, 1, 9, 0, 0, 0, time.UTC)
+	user := func(line int, id, team string) domain.ImportUser {
+		return domain.ImportUser{Line: line, User: domain.User{UserID: id, Username: id, TeamName: team, IsActive: true}}
+	}
+	pr := func(line int, id, author string, reviewers ...string) domain.ImportPullRequest {
+		return domain.ImportPullRequest{Line: line, PullRequest: domain.PullRequest{
+			PullRequestID: id, PullRequestName: id, AuthorID: author, Status: "OPEN", AssignedReviewers: reviewers, CreatedAt: &created,
+		}}
+	}
+
+	return &domain.ImportBatch{
+		Teams: []domain.ImportTeam{
+			{Line: 1, TeamName: "payments"},
+			{Line: 2, TeamName: "backend"},  // уже есть в базе
+			{Line: 3, TeamName: "payments"}, // повтор
+		},
+		Users: []domain.ImportUser{
+			user(4, "p1", "payments"),
+			user(5, "p2", "backend"), // команда из базы
+			user(6, "u1", "backend"), // уже есть в базе
+			user(7, "x1", "ghost"),
+			user(12, "p3", ""), // вне команд
+		},
+		PullRequests: []domain.ImportPullRequest{
+			pr(8, "pr-1", "p1", "p2", "u1"), // ревьювер из базы
+			pr(9, "pr-2", "x1"),             // автор отклонён выше
+			pr(10, "pr-3", "p1", "p2", "nobody"),
+			pr(11, "pr-old", "u1"), // уже есть в базе
+		},
+	}
+}
+
+func TestImportService_Import_ReportsRowErrorsAndLoadsValidRows(t *testing.T) {
+	ctx := context.Background()
+
+	repo := &mockImportRepo{teams: []string{"backend"}, users: []string{"u1"}, prs: []string{"pr-old"}}
+	svc := service.NewImportService(repo)
+
+	report, err := svc.Import(ctx, importFixture(), false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if report.DryRun || report.Teams != 1 || report.Users != 3 || report.PullRequests != 1 {
+		t.Fatalf("unexpected counts: %+v", report)
+	}
+	want := []domain.ImportRowError{
+		{Line: 2, Field: "team_name", Reason: "team already exists"},
+		{Line: 3, Field: "team_name", Reason: "is duplicated (first on line 1)"},
+		{Line: 6, Field: "user_id", Reason: "user already exists"},
+		{Line: 7, Field: "team_name", Reason: "team not found: ghost"},
+		{Line: 9, Field: "author_id", Reason: "user not found: x1"},
+		{Line: 10, Field: "assigned_reviewers[1]", Reason: "user not found: nobody"},
+		{Line: 11, Field: "pull_request_id", Reason: "pull request already exists"},
+	}
+	if !slices.Equal(report.Errors, want) {
+		t.Fatalf("unexpected errors:\n got %+v\nwant %+v", report.Errors, want)
+	}
+
+	if len(repo.loaded) != 1 {
+		t.Fatalf("expected one load, got %d", len(repo.loaded))
+	}
+	loaded := repo.loaded[0]
+	if loaded.Teams[0].TeamName != "payments" || loaded.Users[0].UserID != "p1" || loaded.Users[1].UserID != "p2" || loaded.Users[2].UserID != "p3" ||
+		loaded.PullRequests[0].PullRequestID != "pr-1" {
+		t.Fatalf("unexpected loaded batch: %+v", loaded)
+	}
+}
+
+func TestImportService_Import_DryRunLoadsNothing(t *testing.T) {
+	ctx := context.Background()
+
+	repo := &mockImportRepo{teams: []string{"backend"}, users: []string{"u1"}, prs: []string{"pr-old"}}
+	svc := service.NewImportService(repo)
+
+	report, err := svc.Import(ctx, importFixture(), true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !report.DryRun || report.Teams != 1 || report.Users != 3 || report.PullRequests != 1 || len(report.Errors) != 7 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	if len(repo.loaded) != 0 {
+		t.Fatalf("dry run must not load anything")
+	}
+}
+
+func TestImportService_Import_Conflict(t *testing.T) {
+	ctx := context.Background()
+
+	repo := &mockImportRepo{teams: []string{"backend"}, users: []string{"u1"}, loadErr: repository.ErrAlreadyExists}
+	svc := service.NewImportService(repo)
+
+	_, err := svc.Import(ctx, importFixture(), false)
+
+	var derr *domain.Error
+	if !errors.As(err, &derr) || derr.Code != domain.ErrorImportConflict {
+		t.Fatalf("expected IMPORT_CONFLICT, got %v", err)
+	}
+}
+
+func TestImportService_Import_ChecksReferencesOfRulesAndSLAs(t *testing.T) {
+	ctx := context.Background()
+
+	repo := &mockImportRepo{
+		teams: []string{"backend"}, users: []string{"u1"}, prs: []string{"pr-old"},
+		rules: []string{"rule-old"}, positions: []int{5},
+	}
+	svc := service.NewImportService(repo)
+
+	escalatedAt := time.Date(2025, 9, 2, 9, 0, 0, 0, time.UTC)
+	rule := func(line int, id, authorID, reviewerSkill string) domain.ImportReviewerRule {
+		return domain.ImportReviewerRule{Line: line, ReviewerRule: domain.ReviewerRule{
+			ID: id, Kind: domain.RuleExclude,
+			Author: domain.UserSelector{UserID: authorID}, Reviewer: domain.UserSelector{Skill: reviewerSkill},
+		}}
+	}
+	batch := importFixture()
+	batch.Escalations = []domain.ImportEscalation{
+		{Line: 13, PullRequestID: "pr-old", EscalatedAt: escalatedAt},
+		{Line: 14, PullRequestID: "pr-3", EscalatedAt: escalatedAt}, // PR отклонён выше
+	}
+	batch.ReviewSLAs = []domain.ImportReviewSLA{
+		{Line: 15, ReviewSLA: domain.ReviewSLA{TeamName: "payments", SLA: time.Hour, Policy: domain.EscalationNotify}},
+		{Line: 16, ReviewSLA: domain.ReviewSLA{TeamName: "ghost", SLA: time.Hour, Policy: domain.EscalationNotify}},
+	}
+	batch.CodeOwnerRules = []domain.ImportCodeOwnerRule{
+		{Line: 17, CodeOwnerRule: domain.CodeOwnerRule{Position: 1, Pattern: "*.go", Users: []string{"u1"}, Teams: []string{"backend"}}},
+		{Line: 18, CodeOwnerRule: domain.CodeOwnerRule{Position: 5, Pattern: "docs/"}},
+		{Line: 19, CodeOwnerRule: domain.CodeOwnerRule{Position: 6, Pattern: "web/", Teams: []string{"payments", "ghost"}}},
+	}
+	batch.ReviewerRules = []domain.ImportReviewerRule{
+		rule(20, "rule-1", "p1", "go"),
+		rule(21, "rule-old", "u1", "go"),
+		rule(22, "rule-2", "x1", "go"),
+	}
+	batch.Unavailability = []domain.ImportUnavailability{
+		{Line: 23, Unavailability: domain.Unavailability{ID: "vac-1", UserID: "p2", StartsAt: escalatedAt, EndsAt: escalatedAt.Add(time.Hour)}},
+		{Line: 24, Unavailability: domain.Unavailability{ID: "vac-1", UserID: "p2", StartsAt: escalatedAt, EndsAt: escalatedAt.Add(time.Hour)}},
+	}
+
+	report, err := svc.Import(ctx, batch, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if report.Escalations != 1 || report.ReviewSLAs != 1 || report.CodeOwnerRules != 1 || report.ReviewerRules != 1 || report.Unavailability != 1 {
+		t.Fatalf("unexpected counts: %+v", report)
+	}
+	want := []domain.ImportRowError{
+		{Line: 14, Field: "pull_request_id", Reason: "pull request not found: pr-3"},
+		{Line: 16, Field: "team_name", Reason: "team not found: ghost"},
+		{Line: 18, Field: "position", Reason: "code owner rule already exists"},
+		{Line: 19, Field: "owner_teams[1]", Reason: "team not found: ghost"},
+		{Line: 21, Field: "rule_id", Reason: "reviewer rule already exists"},
+		{Line: 22, Field: "author_user_id", Reason: "user not found: x1"},
+		{Line: 24, Field: "unavailability_id", Reason: "is duplicated (first on line 23)"},
+	}
+	if !slices.Equal(report.Errors[7:], want) {
+		t.Fatalf("unexpected errors:\n got %+v\nwant %+v", report.Errors[7:], want)
+	}
+
+	loaded := repo.loaded[0]
+	if loaded.Escalations[0].PullRequestID != "pr-old" || loaded.ReviewSLAs[0].TeamName != "payments" ||
+		loaded.CodeOwnerRules[0].Position != 1 || loaded.ReviewerRules[0].ID != "rule-1" || loaded.Unavailability[0].ID != "vac-1" {
+		t.Fatalf("unexpected loaded batch: %+v", loaded)
+	}
+}
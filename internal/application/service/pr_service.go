@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"pr-reviewer-assigment-service/internal/application/repository"
@@ -11,30 +13,101 @@ import (
 )
 
 type PullRequestService struct {
-	prRepo   repository.PullRequestRepository
-	userRepo repository.UserRepository
-	teamRepo repository.TeamRepository
+	prRepo        repository.PullRequestRepository
+	userRepo      repository.UserRepository
+	teamRepo      repository.TeamRepository
+	codeOwnerRepo repository.CodeOwnerRepository
+	ruleRepo      repository.ReviewerRuleRepository
+	clock         Clock
 }
 
 func NewPullRequestService(
 	prRepository repository.PullRequestRepository,
 	userRepository repository.UserRepository,
 	teamRepository repository.TeamRepository,
+	codeOwnerRepository repository.CodeOwnerRepository,
+	ruleRepository repository.ReviewerRuleRepository,
+	clock Clock,
 ) *PullRequestService {
 	return &PullRequestService{
-		prRepo:   prRepository,
-		userRepo: userRepository,
-		teamRepo: teamRepository,
+		prRepo:        prRepository,
+		userRepo:      userRepository,
+		teamRepo:      teamRepository,
+		codeOwnerRepo: codeOwnerRepository,
+		ruleRepo:      ruleRepository,
+		clock:         clock,
 	}
 }
 
 // Create создаёт PR и назначает до двух активных ревьюверов из команды автора (исключая самого автора).
+// Участники, у которых исчерпан лимит открытых ревью, пропускаются.
 func (s *PullRequestService) Create(
 	ctx context.Context,
 	prID string,
 	prName string,
 	authorID string,
 ) (*domain.PullRequest, error) {
+	return s.CreateWithHints(ctx, prID, prName, authorID, domain.ReviewHints{})
+}
+
+// CreateWithHints создаёт PR, подбирая ревьюверов с учётом подсказок (см. selectReviewers).
+func (s *PullRequestService) CreateWithHints(
+	ctx context.Context,
+	prID string,
+	prName string,
+	authorID string,
+	hints domain.ReviewHints,
+) (*domain.PullRequest, error) {
+	author, err := s.getAuthor(ctx, authorID)
+	if err != nil {
+		return nil, err
+	}
+
+	selection, err := s.selectReviewers(ctx, author, hints)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+
+	pr := &domain.PullRequest{
+		PullRequestID:     prID,
+		PullRequestName:   prName,
+		AuthorID:          authorID,
+		Status:            "OPEN",
+		AssignedReviewers: selection.ReviewerIDs(),
+		CreatedAt:         &now,
+	}
+	for _, id := range pr.AssignedReviewers {
+		pr.MarkAssigned(id, now)
+	}
+
+	if err := s.prRepo.Create(ctx, pr); err != nil {
+		if errors.Is(err, repository.ErrAlreadyExists) {
+			return nil, domain.NewError(domain.ErrorPRExists, "pull request already exists: "+prID)
+		}
+		return nil, fmt.Errorf("prRepo.Create: %w", err)
+	}
+
+	return pr, nil
+}
+
+// PreviewAssignment подбирает ревьюверов так же, как CreateWithHints, но ничего не сохраняет.
+// Кроме выбранных ревьюверов возвращает отклонённых кандидатов с причинами.
+func (s *PullRequestService) PreviewAssignment(
+	ctx context.Context,
+	authorID string,
+	hints domain.ReviewHints,
+) (*domain.ReviewerSelection, error) {
+	author, err := s.getAuthor(ctx, authorID)
+	if err != nil {
+		return nil, err
+	}
+	return s.selectReviewers(ctx, author, hints)
+}
+
+// getAuthor возвращает автора PR, проверяя, что он и его команда существуют.
+func (s *PullRequestService) getAuthor(ctx context.Context, authorID string) (*domain.User, error) {
 	author, err := s.userRepo.GetByID(ctx, authorID)
 	if err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
@@ -43,50 +116,310 @@ func (s *PullRequestService) Create(
 		return nil, fmt.Errorf("userRepo.GetByID: %w", err)
 	}
 
-	team, err := s.teamRepo.GetByName(ctx, author.TeamName)
-	if err != nil {
+	if _, err := s.teamRepo.GetByName(ctx, author.TeamName); err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
 			return nil, domain.NewError(domain.ErrorNotFound, "team not found: "+author.TeamName)
 		}
 		return nil, fmt.Errorf("teamRepo.GetByName: %w", err)
 	}
 
-	_ = team
+	return author, nil
+}
+
+// selectReviewers подбирает до MaxReviewersPerPR ревьюверов PR автора author.
+// Запрошенные автором ревьюверы выбираются первыми и проверяются как при ручном назначении (см. checkReviewer).
+// Оставшиеся места заполняются автоматически: владельцы изменённых файлов по CODEOWNERS (из любой команды),
+// затем участники команды автора с навыками из меток PR, затем остальные участники команды.
+// Автор, исключённые в подсказках, неактивные, недоступные и исчерпавшие лимит открытых ревью пользователи
+// пропускаются. Правила подбора: исключённые для автора пользователи не назначаются (запрошенный - RULE_VIOLATION),
+// а под правила REQUIRE в первую очередь подбираются подходящие кандидаты.
+func (s *PullRequestService) selectReviewers(
+	ctx context.Context,
+	author *domain.User,
+	hints domain.ReviewHints,
+) (*domain.ReviewerSelection, error) {
+	if len(hints.RequestedReviewers) > domain.MaxReviewersPerPR {
+		return nil, domain.NewError(domain.ErrorReviewerLimit,
+			fmt.Sprintf("at most %d reviewers can be requested", domain.MaxReviewersPerPR))
+	}
+
+	rules, err := s.ruleRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ruleRepo.List: %w", err)
+	}
+
+	requested := make([]domain.User, 0, domain.MaxReviewersPerPR)
+	seen := make(map[string]struct{}, domain.MaxReviewersPerPR)
+	for _, id := range hints.RequestedReviewers {
+		reviewer, err := s.checkReviewer(ctx, rules, author, id)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		requested = append(requested, *reviewer)
+	}
+
+	users, err := s.autoCandidates(ctx, author, hints)
+	if err != nil {
+		return nil, err
+	}
+	users = slices.DeleteFunc(users, func(u domain.User) bool {
+		_, ok := seen[u.UserID]
+		return ok
+	})
 
-	users, err := s.userRepo.ListByTeam(ctx, author.TeamName, true)
+	candidates, rejected, err := s.screenCandidates(ctx, author, rules, hints.ExcludedUsers, users)
+	if err != nil {
+		return nil, err
+	}
+
+	picked := rules.PickReviewers(author, requested, candidates, domain.MaxReviewersPerPR-len(requested))
+	for _, u := range picked {
+		seen[u.UserID] = struct{}{}
+	}
+	for _, u := range candidates {
+		if _, ok := seen[u.UserID]; !ok {
+			rejected = append(rejected, domain.RejectedCandidate{UserID: u.UserID, Reason: domain.RejectedSlotsFilled})
+		}
+	}
+
+	return &domain.ReviewerSelection{
+		Reviewers: append(requested, picked...),
+		Rejected:  rejected,
+	}, nil
+}
+
+// autoCandidates возвращает кандидатов в ревьюверы в порядке предпочтения:
+// владельцы изменённых файлов, участники команды автора с навыками из меток, остальные участники команды.
+// Список без повторов; автор, неактивные и недоступные пользователи не отсеиваются (см. screenCandidates).
+func (s *PullRequestService) autoCandidates(
+	ctx context.Context,
+	author *domain.User,
+	hints domain.ReviewHints,
+) ([]domain.User, error) {
+	owners, err := s.codeOwners(ctx, hints.ChangedFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := s.userRepo.ListByTeam(ctx, author.TeamName, false, time.Time{})
 	if err != nil {
 		return nil, fmt.Errorf("userRepo.ListByTeam: %w", err)
 	}
 
-	reviewerIDs := make([]string, 0, 2)
+	skilled := make([]domain.User, 0, len(users))
+	others := make([]domain.User, 0, len(users))
+	for _, u := range users {
+		if len(hints.Labels) > 0 && u.HasSkill(hints.Labels) {
+			skilled = append(skilled, u)
+		} else {
+			others = append(others, u)
+		}
+	}
+
+	candidates := make([]domain.User, 0, len(owners)+len(users))
+	seen := make(map[string]struct{}, len(owners)+len(users))
+	for _, group := range [][]domain.User{owners, skilled, others} {
+		for _, u := range group {
+			if _, ok := seen[u.UserID]; ok {
+				continue
+			}
+			seen[u.UserID] = struct{}{}
+			candidates = append(candidates, u)
+		}
+	}
+
+	return candidates, nil
+}
+
+// screenCandidates отсеивает кандидатов, которых нельзя назначить ревьюверами PR автора author,
+// и объясняет каждый отказ. Порядок оставшихся кандидатов сохраняется.
+func (s *PullRequestService) screenCandidates(
+	ctx context.Context,
+	author *domain.User,
+	rules domain.RuleSet,
+	excluded []string,
+	users []domain.User,
+) ([]domain.User, []domain.RejectedCandidate, error) {
+	ids := make([]string, 0, len(users))
+	for _, u := range users {
+		ids = append(ids, u.UserID)
+	}
+	available, err := s.userRepo.ListByIDs(ctx, ids, true, s.clock.Now())
+	if err != nil {
+		return nil, nil, fmt.Errorf("userRepo.ListByIDs: %w", err)
+	}
+	isAvailable := make(map[string]struct{}, len(available))
+	for _, u := range available {
+		isAvailable[u.UserID] = struct{}{}
+	}
+
+	rejected := make([]domain.RejectedCandidate, 0)
+	reject := func(u domain.User, reason domain.RejectionReason, detail string) {
+		rejected = append(rejected, domain.RejectedCandidate{UserID: u.UserID, Reason: reason, Detail: detail})
+	}
+
+	fit := make([]domain.User, 0, len(users))
 	for _, u := range users {
-		if u.UserID == authorID {
+		_, available := isAvailable[u.UserID]
+		switch {
+		case u.UserID == author.UserID:
+			reject(u, domain.RejectedIsAuthor, "")
+		case slices.Contains(excluded, u.UserID):
+			reject(u, domain.RejectedExcluded, "")
+		case !u.IsActive:
+			reject(u, domain.RejectedInactive, "")
+		case !available:
+			reject(u, domain.RejectedUnavailable, "")
+		default:
+			if rule := rules.Exclusion(author, &u); rule != nil {
+				reject(u, domain.RejectedByRule, "rule "+rule.ID)
+				continue
+			}
+			fit = append(fit, u)
+		}
+	}
+
+	counts, err := s.openReviewCounts(ctx, fit)
+	if err != nil {
+		return nil, nil, err
+	}
+	candidates := make([]domain.User, 0, len(fit))
+	for _, u := range fit {
+		if u.HasCapacity(counts[u.UserID]) {
+			candidates = append(candidates, u)
 			continue
 		}
-		reviewerIDs = append(reviewerIDs, u.UserID)
-		if len(reviewerIDs) == 2 {
-			break
+		reject(u, domain.RejectedAtCapacity, fmt.Sprintf("%d of %d open reviews", counts[u.UserID], *u.MaxOpenReviews))
+	}
+
+	return candidates, rejected, nil
+}
+
+// checkReviewer проверяет, что пользователя можно вручную назначить ревьювером PR автора author,
+// и возвращает его: он существует (NOT_FOUND), активен и не является автором (INVALID_REVIEWER),
+// сейчас не в периоде недоступности (REVIEWER_UNAVAILABLE), не исчерпал лимит открытых ревью (REVIEWER_AT_CAPACITY)
+// и правила исключения не запрещают ему ревьюить PR автора (RULE_VIOLATION).
+func (s *PullRequestService) checkReviewer(
+	ctx context.Context,
+	rules domain.RuleSet,
+	author *domain.User,
+	userID string,
+) (*domain.User, error) {
+	if userID == author.UserID {
+		return nil, domain.NewError(domain.ErrorInvalidReviewer, "author cannot review own pull request: "+userID)
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, domain.NewError(domain.ErrorNotFound, "reviewer not found: "+userID)
 		}
+		return nil, fmt.Errorf("userRepo.GetByID: %w", err)
 	}
+	if !user.IsActive {
+		return nil, domain.NewError(domain.ErrorInvalidReviewer, "reviewer is not active: "+userID)
+	}
+	available, err := s.userRepo.ListByIDs(ctx, []string{userID}, true, s.clock.Now())
+	if err != nil {
+		return nil, fmt.Errorf("userRepo.ListByIDs: %w", err)
+	}
+	if len(available) == 0 {
+		return nil, domain.NewError(domain.ErrorReviewerUnavailable, "reviewer is unavailable now: "+userID)
+	}
+	counts, err := s.openReviewCounts(ctx, []domain.User{*user})
+	if err != nil {
+		return nil, err
+	}
+	if !user.HasCapacity(counts[userID]) {
+		return nil, domain.NewError(domain.ErrorReviewerAtCapacity,
+			fmt.Sprintf("reviewer %s has %d of %d open reviews", userID, counts[userID], *user.MaxOpenReviews))
+	}
+	if rule := rules.Exclusion(author, user); rule != nil {
+		return nil, domain.NewError(domain.ErrorRuleViolation,
+			fmt.Sprintf("reviewer %s is excluded for author %s by rule %s", userID, author.UserID, rule.ID))
+	}
+	return user, nil
+}
 
-	now := time.Now().UTC()
+// AddReviewer вручную назначает ревьювера на открытый PR, если на нём меньше MaxReviewersPerPR ревьюверов.
+// Ревьювер проверяется checkReviewer.
+func (s *PullRequestService) AddReviewer(ctx context.Context, prID string, userID string) (*domain.PullRequest, error) {
+	pr, err := s.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, domain.NewError(domain.ErrorNotFound, "pull request not found: "+prID)
+		}
+		return nil, fmt.Errorf("prRepo.GetByID: %w", err)
+	}
 
-	pr := &domain.PullRequest{
-		PullRequestID:     prID,
-		PullRequestName:   prName,
-		AuthorID:          authorID,
-		Status:            "OPEN",
-		AssignedReviewers: reviewerIDs,
-		CreatedAt:         &now,
+	if pr.Status == string(domain.StatusMerged) {
+		return nil, domain.NewError(domain.ErrorPRMerged, "cannot change reviewers on merged PR")
+	}
+	if slices.Contains(pr.AssignedReviewers, userID) {
+		return nil, domain.NewError(domain.ErrorAlreadyAssigned, "user is already a reviewer of this PR: "+userID)
+	}
+	if len(pr.AssignedReviewers) >= domain.MaxReviewersPerPR {
+		return nil, domain.NewError(domain.ErrorReviewerLimit,
+			fmt.Sprintf("pull request already has %d reviewers", domain.MaxReviewersPerPR))
 	}
 
-	if err := s.prRepo.Create(ctx, pr); err != nil {
-		if errors.Is(err, repository.ErrAlreadyExists) {
-			return nil, domain.NewError(domain.ErrorPRExists, "pull request already exists: "+prID)
+	author, err := s.userRepo.GetByID(ctx, pr.AuthorID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, domain.NewError(domain.ErrorNotFound, "author not found: "+pr.AuthorID)
 		}
-		return nil, fmt.Errorf("prRepo.Create: %w", err)
+		return nil, fmt.Errorf("userRepo.GetByID: %w", err)
+	}
+	rules, err := s.ruleRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ruleRepo.List: %w", err)
+	}
+	if _, err := s.checkReviewer(ctx, rules, author, userID); err != nil {
+		return nil, err
 	}
 
+	pr.AssignedReviewers = append(pr.AssignedReviewers, userID)
+	pr.MarkAssigned(userID, s.clock.Now())
+
+	if err := s.prRepo.Update(ctx, pr); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, domain.NewError(domain.ErrorNotFound, "pull request not found on update: "+prID)
+		}
+		return nil, fmt.Errorf("prRepo.Update: %w", err)
+	}
+	return pr, nil
+}
+
+// RemoveReviewer снимает ревьювера с открытого PR без замены.
+// Если пользователь не назначен - NOT_ASSIGNED.
+func (s *PullRequestService) RemoveReviewer(ctx context.Context, prID string, userID string) (*domain.PullRequest, error) {
+	pr, err := s.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, domain.NewError(domain.ErrorNotFound, "pull request not found: "+prID)
+		}
+		return nil, fmt.Errorf("prRepo.GetByID: %w", err)
+	}
+
+	if pr.Status == string(domain.StatusMerged) {
+		return nil, domain.NewError(domain.ErrorPRMerged, "cannot change reviewers on merged PR")
+	}
+	if !slices.Contains(pr.AssignedReviewers, userID) {
+		return nil, domain.NewError(domain.ErrorNotAssigned, "user is not assigned as reviewer on this PR")
+	}
+
+	pr.AssignedReviewers = slices.DeleteFunc(pr.AssignedReviewers, func(r string) bool { return r == userID })
+
+	if err := s.prRepo.Update(ctx, pr); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, domain.NewError(domain.ErrorNotFound, "pull request not found on update: "+prID)
+		}
+		return nil, fmt.Errorf("prRepo.Update: %w", err)
+	}
 	return pr, nil
 }
 
@@ -102,7 +435,7 @@ func (s *PullRequestService) Merge(ctx context.Context, prID string) (*domain.Pu
 
 	if pr.Status == "MERGED" {
 		if pr.MergedAt == nil {
-			now := time.Now().UTC()
+			now := s.clock.Now()
 			pr.MergedAt = &now
 			if err := s.prRepo.Update(ctx, pr); err != nil {
 				if errors.Is(err, repository.ErrNotFound) {
@@ -114,7 +447,7 @@ func (s *PullRequestService) Merge(ctx context.Context, prID string) (*domain.Pu
 		return pr, nil
 	}
 
-	now := time.Now().UTC()
+	now := s.clock.Now()
 	pr.Status = "MERGED"
 	pr.MergedAt = &now
 
@@ -127,14 +460,57 @@ func (s *PullRequestService) Merge(ctx context.Context, prID string) (*domain.Pu
 	return pr, nil
 }
 
+// TeamOf возвращает команду автора PR - по ней проверяется доступ team-lead к ревьюверам PR.
+// Автор вне команды даёт пустую строку.
+func (s *PullRequestService) TeamOf(ctx context.Context, prID string) (string, error) {
+	pr, err := s.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return "", domain.NewError(domain.ErrorNotFound, "pull request not found: "+prID)
+		}
+		return "", fmt.Errorf("prRepo.GetByID: %w", err)
+	}
+
+	author, err := s.userRepo.GetByID(ctx, pr.AuthorID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return "", domain.NewError(domain.ErrorNotFound, "author not found: "+pr.AuthorID)
+		}
+		return "", fmt.Errorf("userRepo.GetByID: %w", err)
+	}
+	return author.TeamName, nil
+}
+
 // Reassign переносит одного ревьювера на другого из его команды.
 // После MERGED менять ревьюверов нельзя.
 // Если ревьювер не назначен - NOT_ASSIGNED.
-// Если нет доступных кандидатов - NO_CANDIDATE.
+// Если нет доступных кандидатов (в том числе из-за лимита открытых ревью и правил исключения) - NO_CANDIDATE.
+// Если без заменяемого ревьювера нарушается правило REQUIRE, предпочитается подходящий под него кандидат.
 func (s *PullRequestService) Reassign(
 	ctx context.Context,
 	prID string,
 	oldUserID string,
+) (*domain.PullRequest, string, error) {
+	return s.ReassignExcluding(ctx, prID, oldUserID, nil)
+}
+
+// ReassignExcluding работает как Reassign, но не выбирает на замену пользователей из exclude.
+func (s *PullRequestService) ReassignExcluding(
+	ctx context.Context,
+	prID string,
+	oldUserID string,
+	exclude []string,
+) (*domain.PullRequest, string, error) {
+	return s.reassign(ctx, prID, oldUserID, exclude, false)
+}
+
+// reassign заменяет ревьювера кандидатом из его команды, а с fromAuthorTeam - из команды автора PR.
+func (s *PullRequestService) reassign(
+	ctx context.Context,
+	prID string,
+	oldUserID string,
+	exclude []string,
+	fromAuthorTeam bool,
 ) (*domain.PullRequest, string, error) {
 	pr, err := s.prRepo.GetByID(ctx, prID)
 	if err != nil {
@@ -166,9 +542,24 @@ func (s *PullRequestService) Reassign(
 		return nil, "", fmt.Errorf("userRepo.GetByID: %w", err)
 	}
 
-	users, err := s.userRepo.ListByTeam(ctx, oldReviewer.TeamName, true)
-	if err != nil {
-		return nil, "", fmt.Errorf("userRepo.ListByTeam: %w", err)
+	teamName := oldReviewer.TeamName
+	if fromAuthorTeam {
+		teamName = ""
+		author, err := s.userRepo.GetByID(ctx, pr.AuthorID)
+		switch {
+		case err == nil:
+			teamName = author.TeamName
+		case !errors.Is(err, repository.ErrNotFound):
+			return nil, "", fmt.Errorf("userRepo.GetByID: %w", err)
+		}
+	}
+
+	var users []domain.User
+	if teamName != "" { // Пользователи вне команд друг другу не коллеги
+		users, err = s.userRepo.ListByTeam(ctx, teamName, true, s.clock.Now())
+		if err != nil {
+			return nil, "", fmt.Errorf("userRepo.ListByTeam: %w", err)
+		}
 	}
 
 	candidates := make([]domain.User, 0, len(users))
@@ -176,7 +567,7 @@ func (s *PullRequestService) Reassign(
 		if u.UserID == oldUserID {
 			continue
 		}
-		if u.UserID == pr.AuthorID {
+		if u.UserID == pr.AuthorID || slices.Contains(exclude, u.UserID) {
 			continue
 		}
 		alreadyAssigned := false
@@ -192,11 +583,38 @@ func (s *PullRequestService) Reassign(
 		candidates = append(candidates, u)
 	}
 
-	if len(candidates) == 0 {
-		return nil, "", domain.NewError(domain.ErrorNoCandidate, "no active replacement candidate in team")
+	candidates, err = s.withCapacity(ctx, candidates)
+	if err != nil {
+		return nil, "", err
 	}
 
-	newReviewer := candidates[0].UserID
+	rules, err := s.ruleRepo.List(ctx)
+	if err != nil {
+		return nil, "", fmt.Errorf("ruleRepo.List: %w", err)
+	}
+
+	// Без правил автор и оставшиеся ревьюверы не нужны: PickReviewers берёт первого кандидата.
+	var author *domain.User
+	var remaining []domain.User
+	if len(rules) > 0 {
+		author, err = s.userRepo.GetByID(ctx, pr.AuthorID)
+		if err != nil {
+			return nil, "", fmt.Errorf("userRepo.GetByID: %w", err)
+		}
+		others := slices.DeleteFunc(slices.Clone(pr.AssignedReviewers), func(r string) bool { return r == oldUserID })
+		remaining, err = s.userRepo.ListByIDs(ctx, others, false, time.Time{})
+		if err != nil {
+			return nil, "", fmt.Errorf("userRepo.ListByIDs: %w", err)
+		}
+	}
+
+	picked := rules.PickReviewers(author, remaining, candidates, 1)
+	if len(picked) == 0 {
+		return nil, "", domain.NewError(domain.ErrorNoCandidate,
+			"no active replacement candidate with free review capacity allowed by reviewer rules in team")
+	}
+
+	newReviewer := picked[0].UserID
 
 	for i, r := range pr.AssignedReviewers {
 		if r == oldUserID {
@@ -204,6 +622,7 @@ func (s *PullRequestService) Reassign(
 			break
 		}
 	}
+	pr.MarkAssigned(newReviewer, s.clock.Now())
 
 	if err := s.prRepo.Update(ctx, pr); err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
@@ -214,3 +633,169 @@ func (s *PullRequestService) Reassign(
 
 	return pr, newReviewer, nil
 }
+
+// ReleaseReviewer снимает пользователя со всех открытых PR перед выходом из команды,
+// чтобы ушедший участник не оставался ревьювером PR команды.
+// Без reassign при наличии открытых ревью возвращает HAS_OPEN_REVIEWS и ничего не меняет.
+// С reassign каждое ревью переназначается на другого участника команды, если там кандидатов нет -
+// на участника команды автора PR, а если нет и там - пользователь просто убирается из ревьюверов PR.
+func (s *PullRequestService) ReleaseReviewer(ctx context.Context, userID string, reassign bool) error {
+	return s.ReleaseReviewers(ctx, []string{userID}, reassign)
+}
+
+// ReleaseReviewers снимает с открытых PR сразу нескольких пользователей, как ReleaseReviewer.
+// Уходящие пользователи не выбираются на замену друг другу.
+// Без reassign HAS_OPEN_REVIEWS возвращается до любых изменений.
+func (s *PullRequestService) ReleaseReviewers(ctx context.Context, userIDs []string, reassign bool) error {
+	openIDs := make([][]string, len(userIDs))
+	for i, userID := range userIDs {
+		prs, err := s.prRepo.ListByReviewer(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("prRepo.ListByReviewer: %w", err)
+		}
+
+		for _, pr := range prs {
+			if pr.Status == string(domain.StatusOpen) {
+				openIDs[i] = append(openIDs[i], pr.PullRequestID)
+			}
+		}
+		if len(openIDs[i]) > 0 && !reassign {
+			return domain.NewError(domain.ErrorHasOpenReviews,
+				fmt.Sprintf("user %s is a reviewer of open pull requests: %s", userID, strings.Join(openIDs[i], ", ")))
+		}
+	}
+
+	for i, userID := range userIDs {
+		for _, prID := range openIDs[i] {
+			_, _, err := s.reassign(ctx, prID, userID, userIDs, false)
+			if isNoCandidate(err) {
+				// Например, вся команда ревьювера уходит разом.
+				_, _, err = s.reassign(ctx, prID, userID, userIDs, true)
+			}
+			if isNoCandidate(err) {
+				err = s.unassign(ctx, prID, userID)
+			}
+			if err != nil {
+				return fmt.Errorf("release reviewer %s from %s: %w", userID, prID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// isNoCandidate сообщает, что замены ревьюверу не нашлось.
+func isNoCandidate(err error) bool {
+	var dErr *domain.Error
+	return errors.As(err, &dErr) && dErr.Code == domain.ErrorNoCandidate
+}
+
+// codeOwners возвращает владельцев файлов по CODEOWNERS в порядке файлов и правил:
+// сначала пользователи, указанные явно, затем участники команд-владельцев. Возможны повторы.
+func (s *PullRequestService) codeOwners(ctx context.Context, files []string) ([]domain.User, error) {
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	rules, err := s.codeOwnerRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("codeOwnerRepo.List: %w", err)
+	}
+
+	var userIDs, teamNames []string
+	for _, file := range files {
+		rule := domain.OwnerRuleFor(rules, file)
+		if rule == nil {
+			continue
+		}
+		userIDs = append(userIDs, rule.Users...)
+		teamNames = append(teamNames, rule.Teams...)
+	}
+
+	found, err := s.userRepo.ListByIDs(ctx, userIDs, false, time.Time{})
+	if err != nil {
+		return nil, fmt.Errorf("userRepo.ListByIDs: %w", err)
+	}
+	byID := make(map[string]domain.User, len(found))
+	for _, u := range found {
+		byID[u.UserID] = u
+	}
+
+	owners := make([]domain.User, 0, len(found))
+	for _, id := range userIDs {
+		if u, ok := byID[id]; ok {
+			owners = append(owners, u)
+		}
+	}
+
+	queried := make(map[string]struct{}, len(teamNames))
+	for _, teamName := range teamNames {
+		if _, ok := queried[teamName]; ok {
+			continue
+		}
+		queried[teamName] = struct{}{}
+		members, err := s.userRepo.ListByTeam(ctx, teamName, false, time.Time{})
+		if err != nil {
+			return nil, fmt.Errorf("userRepo.ListByTeam: %w", err)
+		}
+		owners = append(owners, members...)
+	}
+
+	return owners, nil
+}
+
+// withCapacity оставляет только пользователей, которым можно назначить ещё одно ревью.
+func (s *PullRequestService) withCapacity(ctx context.Context, users []domain.User) ([]domain.User, error) {
+	counts, err := s.openReviewCounts(ctx, users)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.User, 0, len(users))
+	for _, u := range users {
+		if u.HasCapacity(counts[u.UserID]) {
+			result = append(result, u)
+		}
+	}
+	return result, nil
+}
+
+// openReviewCounts возвращает число открытых ревью пользователей.
+// Открытые ревью считаются только у пользователей с лимитом.
+func (s *PullRequestService) openReviewCounts(ctx context.Context, users []domain.User) (map[string]int, error) {
+	limited := make([]string, 0, len(users))
+	for _, u := range users {
+		if u.MaxOpenReviews != nil {
+			limited = append(limited, u.UserID)
+		}
+	}
+	if len(limited) == 0 {
+		return nil, nil
+	}
+
+	counts, err := s.prRepo.CountOpenByReviewers(ctx, limited)
+	if err != nil {
+		return nil, fmt.Errorf("prRepo.CountOpenByReviewers: %w", err)
+	}
+	return counts, nil
+}
+
+// unassign убирает ревьювера из PR без замены.
+func (s *PullRequestService) unassign(ctx context.Context, prID string, userID string) error {
+	pr, err := s.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		return fmt.Errorf("prRepo.GetByID: %w", err)
+	}
+
+	reviewers := make([]string, 0, len(pr.AssignedReviewers))
+	for _, r := range pr.AssignedReviewers {
+		if r != userID {
+			reviewers = append(reviewers, r)
+		}
+	}
+	pr.AssignedReviewers = reviewers
+
+	if err := s.prRepo.Update(ctx, pr); err != nil {
+		return fmt.Errorf("prRepo.Update: %w", err)
+	}
+	return nil
+}
@@ -3,6 +3,9 @@ package service_test
 import (
 	"context"
 	"errors"
+	"fmt"
+	"maps"
+	"slices"
 	"testing"
 	"time"
 
@@ -11,6 +14,23 @@ import (
 	"pr-reviewer-assigment-service/internal/domain"
 )
 
+// fakeClock - управляемые часы для тестов: время идёт только через Advance.
+type fakeClock struct {
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2025, 10, 20, 9, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
 type mockPRRepo struct {
 	data map[string]domain.PullRequest
 }
@@ -40,6 +60,7 @@ func (m *mockPRRepo) GetByID(ctx context.Context, id string) (*domain.PullReques
 		return nil, repository.ErrNotFound
 	}
 	cp := pr
+	cp.ReviewerAssignedAt = maps.Clone(pr.ReviewerAssignedAt)
 	return &cp, nil
 }
 
@@ -73,6 +94,43 @@ func (m *mockPRRepo) ListByReviewer(ctx context.Context, reviewerID string) ([]d
 	return result, nil
 }
 
+func (m *mockPRRepo) ListByAuthor(ctx context.Context, authorID string) ([]domain.PullRequestShort, error) {
+	result := []domain.PullRequestShort{}
+
+	for _, pr := range m.data {
+		if pr.AuthorID == authorID {
+			result = append(result, domain.PullRequestShort{
+				PullRequestID:   pr.PullRequestID,
+				PullRequestName: pr.PullRequestName,
+				AuthorID:        pr.AuthorID,
+				Status:          pr.Status,
+			})
+		}
+	}
+
+	return result, nil
+}
+
+func (m *mockPRRepo) CountOpenByReviewers(ctx context.Context, reviewerIDs []string) (map[string]int, error) {
+	wanted := make(map[string]bool, len(reviewerIDs))
+	for _, id := range reviewerIDs {
+		wanted[id] = true
+	}
+
+	counts := make(map[string]int)
+	for _, pr := range m.data {
+		if pr.Status != string(domain.StatusOpen) {
+			continue
+		}
+		for _, r := range pr.AssignedReviewers {
+			if wanted[r] {
+				counts[r]++
+			}
+		}
+	}
+	return counts, nil
+}
+
 func TestPullRequestService_Create_SuccessTwoReviewers(t *testing.T) {
 	ctx := context.Background()
 
@@ -85,7 +143,8 @@ func TestPullRequestService_Create_SuccessTwoReviewers(t *testing.T) {
 	userRepo.data["u3"] = domain.User{UserID: "u3", Username: "Charlie", TeamName: "backend", IsActive: true}
 	teamRepo.data["backend"] = domain.Team{TeamName: "backend"}
 
-	svc := service.NewPullRequestService(prRepo, userRepo, teamRepo)
+	clock := newFakeClock()
+	svc := service.NewPullRequestService(prRepo, userRepo, teamRepo, newMockCodeOwnerRepo(), newMockRuleRepo(), clock)
 
 	pr, err := svc.Create(ctx, "pr-1", "Add feature", "u1")
 	if err != nil {
@@ -106,8 +165,8 @@ func TestPullRequestService_Create_SuccessTwoReviewers(t *testing.T) {
 			t.Fatalf("author was assigned as reviewer")
 		}
 	}
-	if pr.CreatedAt == nil {
-		t.Fatalf("expected CreatedAt to be set")
+	if pr.CreatedAt == nil || !pr.CreatedAt.Equal(clock.Now()) {
+		t.Fatalf("expected CreatedAt %v, got %v", clock.Now(), pr.CreatedAt)
 	}
 }
 
@@ -122,7 +181,7 @@ func TestPullRequestService_Create_SuccessOneReviewer(t *testing.T) {
 	userRepo.data["u2"] = domain.User{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true}
 	teamRepo.data["backend"] = domain.Team{TeamName: "backend"}
 
-	svc := service.NewPullRequestService(prRepo, userRepo, teamRepo)
+	svc := service.NewPullRequestService(prRepo, userRepo, teamRepo, newMockCodeOwnerRepo(), newMockRuleRepo(), newFakeClock())
 
 	pr, err := svc.Create(ctx, "pr-2", "Fix bug", "u1")
 	if err != nil {
@@ -147,7 +206,7 @@ func TestPullRequestService_Create_NoReviewers(t *testing.T) {
 	userRepo.data["u1"] = domain.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
 	teamRepo.data["backend"] = domain.Team{TeamName: "backend"}
 
-	svc := service.NewPullRequestService(prRepo, userRepo, teamRepo)
+	svc := service.NewPullRequestService(prRepo, userRepo, teamRepo, newMockCodeOwnerRepo(), newMockRuleRepo(), newFakeClock())
 
 	pr, err := svc.Create(ctx, "pr-3", "Doc change", "u1")
 	if err != nil {
@@ -166,7 +225,7 @@ func TestPullRequestService_Create_AuthorNotFound(t *testing.T) {
 	teamRepo := newMockTeamRepo()
 	prRepo := newMockPRRepo()
 
-	svc := service.NewPullRequestService(prRepo, userRepo, teamRepo)
+	svc := service.NewPullRequestService(prRepo, userRepo, teamRepo, newMockCodeOwnerRepo(), newMockRuleRepo(), newFakeClock())
 
 	_, err := svc.Create(ctx, "pr-4", "Add feature", "u-missing")
 	if err == nil {
@@ -191,7 +250,7 @@ func TestPullRequestService_Create_TeamNotFound(t *testing.T) {
 
 	userRepo.data["u1"] = domain.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
 
-	svc := service.NewPullRequestService(prRepo, userRepo, teamRepo)
+	svc := service.NewPullRequestService(prRepo, userRepo, teamRepo, newMockCodeOwnerRepo(), newMockRuleRepo(), newFakeClock())
 
 	_, err := svc.Create(ctx, "pr-5", "Add feature", "u1")
 	if err == nil {
@@ -225,7 +284,7 @@ func TestPullRequestService_Create_PRAlreadyExists(t *testing.T) {
 		Status:          "OPEN",
 	}
 
-	svc := service.NewPullRequestService(prRepo, userRepo, teamRepo)
+	svc := service.NewPullRequestService(prRepo, userRepo, teamRepo, newMockCodeOwnerRepo(), newMockRuleRepo(), newFakeClock())
 
 	_, err := svc.Create(ctx, "pr-6", "Duplicate", "u1")
 	if err == nil {
@@ -248,16 +307,18 @@ func TestPullRequestService_Merge_Success(t *testing.T) {
 	teamRepo := newMockTeamRepo()
 	prRepo := newMockPRRepo()
 
-	now := time.Now().UTC()
+	clock := newFakeClock()
+	createdAt := clock.Now()
 	prRepo.data["pr-7"] = domain.PullRequest{
 		PullRequestID:   "pr-7",
 		PullRequestName: "To merge",
 		AuthorID:        "u1",
 		Status:          "OPEN",
-		CreatedAt:       &now,
+		CreatedAt:       &createdAt,
 	}
 
-	svc := service.NewPullRequestService(prRepo, userRepo, teamRepo)
+	svc := service.NewPullRequestService(prRepo, userRepo, teamRepo, newMockCodeOwnerRepo(), newMockRuleRepo(), clock)
+	clock.Advance(3 * time.Hour)
 
 	pr, err := svc.Merge(ctx, "pr-7")
 	if err != nil {
@@ -267,8 +328,11 @@ func TestPullRequestService_Merge_Success(t *testing.T) {
 	if pr.Status != "MERGED" {
 		t.Fatalf("expected MERGED, got %s", pr.Status)
 	}
-	if pr.MergedAt == nil {
-		t.Fatalf("expected MergedAt to be set")
+	if pr.MergedAt == nil || !pr.MergedAt.Equal(clock.Now()) {
+		t.Fatalf("expected MergedAt %v, got %v", clock.Now(), pr.MergedAt)
+	}
+	if !pr.CreatedAt.Equal(createdAt) {
+		t.Fatalf("CreatedAt changed on merge: %v", pr.CreatedAt)
 	}
 	stored, _ := prRepo.GetByID(ctx, "pr-7")
 	if stored.Status != pr.Status {
@@ -283,7 +347,7 @@ func TestPullRequestService_Merge_NotFound(t *testing.T) {
 	teamRepo := newMockTeamRepo()
 	prRepo := newMockPRRepo()
 
-	svc := service.NewPullRequestService(prRepo, userRepo, teamRepo)
+	svc := service.NewPullRequestService(prRepo, userRepo, teamRepo, newMockCodeOwnerRepo(), newMockRuleRepo(), newFakeClock())
 
 	_, err := svc.Merge(ctx, "no-pr")
 	if err == nil {
@@ -319,7 +383,7 @@ func TestPullRequestService_Reassign_Success(t *testing.T) {
 		AssignedReviewers: []string{"u2", "u3"},
 	}
 
-	svc := service.NewPullRequestService(prRepo, userRepo, teamRepo)
+	svc := service.NewPullRequestService(prRepo, userRepo, teamRepo, newMockCodeOwnerRepo(), newMockRuleRepo(), newFakeClock())
 
 	pr, replacedBy, err := svc.Reassign(ctx, "pr-8", "u2")
 	if err != nil {
@@ -350,6 +414,58 @@ func TestPullRequestService_Reassign_Success(t *testing.T) {
 	}
 }
 
+func TestPullRequestService_AssignmentTimesFromClock(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newMockUserRepo()
+	teamRepo := newMockTeamRepo()
+	prRepo := newMockPRRepo()
+
+	userRepo.data["u1"] = domain.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
+	userRepo.data["u2"] = domain.User{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true}
+	userRepo.data["u3"] = domain.User{UserID: "u3", Username: "Charlie", TeamName: "backend", IsActive: true}
+	userRepo.data["u4"] = domain.User{UserID: "u4", Username: "Dave", TeamName: "backend", IsActive: true}
+	teamRepo.data["backend"] = domain.Team{TeamName: "backend"}
+
+	clock := newFakeClock()
+	svc := service.NewPullRequestService(prRepo, userRepo, teamRepo, newMockCodeOwnerRepo(), newMockRuleRepo(), clock)
+
+	created := clock.Now()
+	pr, err := svc.CreateWithHints(ctx, "pr-1", "Add feature", "u1", domain.ReviewHints{RequestedReviewers: []string{"u2", "u3"}})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	for _, id := range pr.AssignedReviewers {
+		if at := pr.ReviewerAssignedAt[id]; !at.Equal(created) {
+			t.Fatalf("expected %s assigned at creation %v, got %v", id, created, at)
+		}
+	}
+
+	clock.Advance(time.Hour)
+	if _, err := svc.RemoveReviewer(ctx, "pr-1", "u2"); err != nil {
+		t.Fatalf("RemoveReviewer: %v", err)
+	}
+	pr, err = svc.AddReviewer(ctx, "pr-1", "u4")
+	if err != nil {
+		t.Fatalf("AddReviewer: %v", err)
+	}
+	if at := pr.ReviewerAssignedAt["u4"]; !at.Equal(clock.Now()) {
+		t.Fatalf("expected u4 assigned at %v, got %v", clock.Now(), at)
+	}
+
+	clock.Advance(time.Hour)
+	pr, replacedBy, err := svc.Reassign(ctx, "pr-1", "u3")
+	if err != nil {
+		t.Fatalf("Reassign: %v", err)
+	}
+	if replacedBy != "u2" || !pr.ReviewerAssignedAt["u2"].Equal(clock.Now()) {
+		t.Fatalf("expected u2 assigned at %v, got %s at %v", clock.Now(), replacedBy, pr.ReviewerAssignedAt["u2"])
+	}
+	if at := prRepo.data["pr-1"].ReviewerAssignedAt["u4"]; !at.Equal(created.Add(time.Hour)) {
+		t.Fatalf("expected u4 to keep its assignment time, got %v", at)
+	}
+}
+
 func TestPullRequestService_Reassign_PRNotFound(t *testing.T) {
 	ctx := context.Background()
 
@@ -357,7 +473,7 @@ func TestPullRequestService_Reassign_PRNotFound(t *testing.T) {
 	teamRepo := newMockTeamRepo()
 	prRepo := newMockPRRepo()
 
-	svc := service.NewPullRequestService(prRepo, userRepo, teamRepo)
+	svc := service.NewPullRequestService(prRepo, userRepo, teamRepo, newMockCodeOwnerRepo(), newMockRuleRepo(), newFakeClock())
 
 	_, _, err := svc.Reassign(ctx, "no-pr", "u2")
 	if err == nil {
@@ -388,7 +504,7 @@ func TestPullRequestService_Reassign_PRMerged(t *testing.T) {
 		AssignedReviewers: []string{"u2"},
 	}
 
-	svc := service.NewPullRequestService(prRepo, userRepo, teamRepo)
+	svc := service.NewPullRequestService(prRepo, userRepo, teamRepo, newMockCodeOwnerRepo(), newMockRuleRepo(), newFakeClock())
 
 	_, _, err := svc.Reassign(ctx, "pr-9", "u2")
 	if err == nil {
@@ -419,7 +535,7 @@ func TestPullRequestService_Reassign_OldUserNotAssigned(t *testing.T) {
 		AssignedReviewers: []string{"u3"},
 	}
 
-	svc := service.NewPullRequestService(prRepo, userRepo, teamRepo)
+	svc := service.NewPullRequestService(prRepo, userRepo, teamRepo, newMockCodeOwnerRepo(), newMockRuleRepo(), newFakeClock())
 
 	_, _, err := svc.Reassign(ctx, "pr-10", "u2")
 	if err == nil {
@@ -450,7 +566,7 @@ func TestPullRequestService_Reassign_ReviewerNotFound(t *testing.T) {
 		AssignedReviewers: []string{"u2"},
 	}
 
-	svc := service.NewPullRequestService(prRepo, userRepo, teamRepo)
+	svc := service.NewPullRequestService(prRepo, userRepo, teamRepo, newMockCodeOwnerRepo(), newMockRuleRepo(), newFakeClock())
 
 	_, _, err := svc.Reassign(ctx, "pr-11", "u2")
 	if err == nil {
@@ -484,7 +600,7 @@ func TestPullRequestService_Reassign_NoCandidate(t *testing.T) {
 		AssignedReviewers: []string{"u2"},
 	}
 
-	svc := service.NewPullRequestService(prRepo, userRepo, teamRepo)
+	svc := service.NewPullRequestService(prRepo, userRepo, teamRepo, newMockCodeOwnerRepo(), newMockRuleRepo(), newFakeClock())
 
 	_, _, err := svc.Reassign(ctx, "pr-12", "u2")
 	if err == nil {
@@ -499,3 +615,554 @@ func TestPullRequestService_Reassign_NoCandidate(t *testing.T) {
 		t.Fatalf("expected NO_CANDIDATE, got %s", dErr.Code)
 	}
 }
+
+func TestPullRequestService_Create_SkipsReviewersAtCapacity(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newMockUserRepo()
+	teamRepo := newMockTeamRepo()
+	prRepo := newMockPRRepo()
+
+	one := 1
+	userRepo.data["u1"] = domain.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
+	userRepo.data["u2"] = domain.User{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true, MaxOpenReviews: &one}
+	userRepo.data["u3"] = domain.User{UserID: "u3", Username: "Charlie", TeamName: "backend", IsActive: true}
+	teamRepo.data["backend"] = domain.Team{TeamName: "backend"}
+	prRepo.data["pr-0"] = domain.PullRequest{PullRequestID: "pr-0", AuthorID: "u3", Status: "OPEN", AssignedReviewers: []string{"u2"}}
+
+	svc := service.NewPullRequestService(prRepo, userRepo, teamRepo, newMockCodeOwnerRepo(), newMockRuleRepo(), newFakeClock())
+
+	pr, err := svc.Create(ctx, "pr-1", "Add feature", "u1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(pr.AssignedReviewers) != 1 || pr.AssignedReviewers[0] != "u3" {
+		t.Fatalf("expected only u3 to be assigned, got %v", pr.AssignedReviewers)
+	}
+	if pr.UnfilledReviewerSlots() != 1 {
+		t.Fatalf("expected 1 unfilled slot, got %d", pr.UnfilledReviewerSlots())
+	}
+}
+
+func TestPullRequestService_Create_ChecksUnavailabilityAtClockTime(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newMockUserRepo()
+	teamRepo := newMockTeamRepo()
+	prRepo := newMockPRRepo()
+
+	userRepo.data["u1"] = domain.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
+	userRepo.data["u2"] = domain.User{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true}
+	userRepo.data["u3"] = domain.User{UserID: "u3", Username: "Charlie", TeamName: "backend", IsActive: true}
+	teamRepo.data["backend"] = domain.Team{TeamName: "backend"}
+
+	clock := newFakeClock()
+	userRepo.unavailable = []domain.Unavailability{{
+		ID:       "ua-1",
+		UserID:   "u2",
+		StartsAt: clock.Now().Add(time.Hour),
+		EndsAt:   clock.Now().Add(3 * time.Hour),
+	}}
+	svc := service.NewPullRequestService(prRepo, userRepo, teamRepo, newMockCodeOwnerRepo(), newMockRuleRepo(), clock)
+
+	create := func(prID string) []string {
+		t.Helper()
+		pr, err := svc.Create(ctx, prID, "Change "+prID, "u1")
+		if err != nil {
+			t.Fatalf("Create %s: %v", prID, err)
+		}
+		slices.Sort(pr.AssignedReviewers)
+		return pr.AssignedReviewers
+	}
+
+	if got := create("pr-1"); !slices.Equal(got, []string{"u2", "u3"}) {
+		t.Fatalf("before the period: expected [u2 u3], got %v", got)
+	}
+	clock.Advance(2 * time.Hour)
+	if got := create("pr-2"); !slices.Equal(got, []string{"u3"}) {
+		t.Fatalf("during the period: expected [u3], got %v", got)
+	}
+	clock.Advance(time.Hour) // Конец периода не входит в него
+	if got := create("pr-3"); !slices.Equal(got, []string{"u2", "u3"}) {
+		t.Fatalf("after the period: expected [u2 u3], got %v", got)
+	}
+}
+
+func TestPullRequestService_Reassign_NoCandidateWithCapacity(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newMockUserRepo()
+	teamRepo := newMockTeamRepo()
+	prRepo := newMockPRRepo()
+
+	zero := 0
+	userRepo.data["u2"] = domain.User{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true}
+	userRepo.data["u3"] = domain.User{UserID: "u3", Username: "Charlie", TeamName: "backend", IsActive: true, MaxOpenReviews: &zero}
+	teamRepo.data["backend"] = domain.Team{TeamName: "backend"}
+	prRepo.data["pr-1"] = domain.PullRequest{PullRequestID: "pr-1", AuthorID: "u1", Status: "OPEN", AssignedReviewers: []string{"u2"}}
+
+	svc := service.NewPullRequestService(prRepo, userRepo, teamRepo, newMockCodeOwnerRepo(), newMockRuleRepo(), newFakeClock())
+
+	_, _, err := svc.Reassign(ctx, "pr-1", "u2")
+
+	var dErr *domain.Error
+	if !errors.As(err, &dErr) || dErr.Code != domain.ErrorNoCandidate {
+		t.Fatalf("expected NO_CANDIDATE, got %v", err)
+	}
+}
+
+func TestPullRequestService_Create_PrefersCodeOwners(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newMockUserRepo()
+	teamRepo := newMockTeamRepo()
+	prRepo := newMockPRRepo()
+	codeOwnerRepo := newMockCodeOwnerRepo()
+
+	userRepo.data["u1"] = domain.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
+	userRepo.data["u2"] = domain.User{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true}
+	userRepo.data["u3"] = domain.User{UserID: "u3", Username: "Charlie", TeamName: "backend", IsActive: true}
+	userRepo.data["u4"] = domain.User{UserID: "u4", Username: "Dave", TeamName: "platform", IsActive: true}
+	userRepo.data["u5"] = domain.User{UserID: "u5", Username: "Eve", TeamName: "backend", IsActive: false}
+	teamRepo.data["backend"] = domain.Team{TeamName: "backend"}
+	codeOwnerRepo.rules = []domain.CodeOwnerRule{
+		{Position: 1, Pattern: "*", Users: []string{"u2"}},
+		{Position: 2, Pattern: "/deploy/", Users: []string{"u5", "u4"}},
+	}
+
+	svc := service.NewPullRequestService(prRepo, userRepo, teamRepo, codeOwnerRepo, newMockRuleRepo(), newFakeClock())
+
+	pr, err := svc.CreateWithHints(ctx, "pr-1", "Deploy", "u1", domain.ReviewHints{
+		ChangedFiles: []string{"deploy/app.yml"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// u5 неактивен, поэтому первым идёт владелец из другой команды, вторым - обычный участник команды.
+	if len(pr.AssignedReviewers) != 2 || pr.AssignedReviewers[0] != "u4" {
+		t.Fatalf("expected code owner u4 first, got %v", pr.AssignedReviewers)
+	}
+	if r := pr.AssignedReviewers[1]; r != "u2" && r != "u3" {
+		t.Fatalf("expected teammate as second reviewer, got %s", r)
+	}
+}
+
+func TestPullRequestService_Create_PrefersSkilledTeammates(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newMockUserRepo()
+	teamRepo := newMockTeamRepo()
+	prRepo := newMockPRRepo()
+
+	userRepo.data["u1"] = domain.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
+	userRepo.data["u2"] = domain.User{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true}
+	userRepo.data["u3"] = domain.User{UserID: "u3", Username: "Charlie", TeamName: "backend", IsActive: true, Skills: []string{"postgres"}}
+	userRepo.data["u4"] = domain.User{UserID: "u4", Username: "Dave", TeamName: "backend", IsActive: true}
+	userRepo.data["u5"] = domain.User{UserID: "u5", Username: "Eve", TeamName: "backend", IsActive: true, Skills: []string{"go"}}
+	teamRepo.data["backend"] = domain.Team{TeamName: "backend"}
+
+	svc := service.NewPullRequestService(prRepo, userRepo, teamRepo, newMockCodeOwnerRepo(), newMockRuleRepo(), newFakeClock())
+
+	pr, err := svc.CreateWithHints(ctx, "pr-1", "Add index", "u1", domain.ReviewHints{
+		Labels: []string{"Postgres", "Go"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := map[string]bool{}
+	for _, r := range pr.AssignedReviewers {
+		got[r] = true
+	}
+	if len(got) != 2 || !got["u3"] || !got["u5"] {
+		t.Fatalf("expected skilled reviewers u3 and u5, got %v", pr.AssignedReviewers)
+	}
+}
+
+func TestPullRequestService_Create_RequestedReviewersFirst(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newMockUserRepo()
+	teamRepo := newMockTeamRepo()
+	prRepo := newMockPRRepo()
+
+	userRepo.data["u1"] = domain.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
+	userRepo.data["u2"] = domain.User{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true}
+	userRepo.data["u3"] = domain.User{UserID: "u3", Username: "Charlie", TeamName: "backend", IsActive: true}
+	userRepo.data["u4"] = domain.User{UserID: "u4", Username: "Dave", TeamName: "platform", IsActive: true}
+	teamRepo.data["backend"] = domain.Team{TeamName: "backend"}
+
+	svc := service.NewPullRequestService(prRepo, userRepo, teamRepo, newMockCodeOwnerRepo(), newMockRuleRepo(), newFakeClock())
+
+	pr, err := svc.CreateWithHints(ctx, "pr-1", "Add feature", "u1", domain.ReviewHints{
+		RequestedReviewers: []string{"u4"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Запрошенный ревьювер назначается, даже если он из другой команды.
+	if len(pr.AssignedReviewers) != 2 || pr.AssignedReviewers[0] != "u4" {
+		t.Fatalf("expected requested u4 first, got %v", pr.AssignedReviewers)
+	}
+	if r := pr.AssignedReviewers[1]; r != "u2" && r != "u3" {
+		t.Fatalf("expected teammate in the remaining slot, got %s", r)
+	}
+}
+
+func TestPullRequestService_Create_InvalidRequestedReviewer(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newMockUserRepo()
+	teamRepo := newMockTeamRepo()
+	prRepo := newMockPRRepo()
+
+	userRepo.data["u1"] = domain.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
+	userRepo.data["u2"] = domain.User{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: false}
+	teamRepo.data["backend"] = domain.Team{TeamName: "backend"}
+
+	svc := service.NewPullRequestService(prRepo, userRepo, teamRepo, newMockCodeOwnerRepo(), newMockRuleRepo(), newFakeClock())
+
+	cases := []struct {
+		name      string
+		requested []string
+		want      domain.ErrorCode
+	}{
+		{"inactive", []string{"u2"}, domain.ErrorInvalidReviewer},
+		{"author", []string{"u1"}, domain.ErrorInvalidReviewer},
+		{"unknown", []string{"ghost"}, domain.ErrorNotFound},
+		{"too many", []string{"u2", "u3", "u4"}, domain.ErrorReviewerLimit},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := svc.CreateWithHints(ctx, "pr-1", "Add feature", "u1", domain.ReviewHints{RequestedReviewers: tc.requested})
+
+			var derr *domain.Error
+			if !errors.As(err, &derr) || derr.Code != tc.want {
+				t.Fatalf("expected %s, got %v", tc.want, err)
+			}
+			if _, ok := prRepo.data["pr-1"]; ok {
+				t.Fatalf("pull request must not be created")
+			}
+		})
+	}
+}
+
+func TestPullRequestService_ManualReviewerUnavailable(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newMockUserRepo()
+	teamRepo := newMockTeamRepo()
+	prRepo := newMockPRRepo()
+
+	userRepo.data["u1"] = domain.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
+	userRepo.data["u2"] = domain.User{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true}
+	teamRepo.data["backend"] = domain.Team{TeamName: "backend"}
+	prRepo.data["pr-1"] = domain.PullRequest{PullRequestID: "pr-1", PullRequestName: "Add", AuthorID: "u1", Status: "OPEN"}
+
+	clock := newFakeClock()
+	userRepo.unavailable = []domain.Unavailability{{
+		ID:       "ua-1",
+		UserID:   "u2",
+		StartsAt: clock.Now().Add(-time.Hour),
+		EndsAt:   clock.Now().Add(time.Hour),
+	}}
+	svc := service.NewPullRequestService(prRepo, userRepo, teamRepo, newMockCodeOwnerRepo(), newMockRuleRepo(), clock)
+
+	expectUnavailable := func(err error) {
+		t.Helper()
+		var derr *domain.Error
+		if !errors.As(err, &derr) || derr.Code != domain.ErrorReviewerUnavailable {
+			t.Fatalf("expected REVIEWER_UNAVAILABLE, got %v", err)
+		}
+	}
+
+	_, err := svc.CreateWithHints(ctx, "pr-2", "Fix", "u1", domain.ReviewHints{RequestedReviewers: []string{"u2"}})
+	expectUnavailable(err)
+	if _, ok := prRepo.data["pr-2"]; ok {
+		t.Fatalf("pull request must not be created")
+	}
+	_, err = svc.AddReviewer(ctx, "pr-1", "u2")
+	expectUnavailable(err)
+	if got := prRepo.data["pr-1"].AssignedReviewers; len(got) != 0 {
+		t.Fatalf("expected no reviewers, got %v", got)
+	}
+
+	clock.Advance(time.Hour) // Конец периода не входит в него
+	if _, err := svc.AddReviewer(ctx, "pr-1", "u2"); err != nil {
+		t.Fatalf("after the period: unexpected error: %v", err)
+	}
+}
+
+func TestPullRequestService_ManualReviewerAtCapacity(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newMockUserRepo()
+	teamRepo := newMockTeamRepo()
+	prRepo := newMockPRRepo()
+
+	one := 1
+	userRepo.data["u1"] = domain.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
+	userRepo.data["u2"] = domain.User{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true, MaxOpenReviews: &one}
+	teamRepo.data["backend"] = domain.Team{TeamName: "backend"}
+	prRepo.data["pr-0"] = domain.PullRequest{
+		PullRequestID: "pr-0", PullRequestName: "Old", AuthorID: "u1", Status: "OPEN",
+		AssignedReviewers: []string{"u2"},
+	}
+	prRepo.data["pr-1"] = domain.PullRequest{PullRequestID: "pr-1", PullRequestName: "Add", AuthorID: "u1", Status: "OPEN"}
+
+	svc := service.NewPullRequestService(prRepo, userRepo, teamRepo, newMockCodeOwnerRepo(), newMockRuleRepo(), newFakeClock())
+
+	expectAtCapacity := func(err error) {
+		t.Helper()
+		var derr *domain.Error
+		if !errors.As(err, &derr) || derr.Code != domain.ErrorReviewerAtCapacity {
+			t.Fatalf("expected REVIEWER_AT_CAPACITY, got %v", err)
+		}
+	}
+
+	_, err := svc.CreateWithHints(ctx, "pr-2", "Fix", "u1", domain.ReviewHints{RequestedReviewers: []string{"u2"}})
+	expectAtCapacity(err)
+	if _, ok := prRepo.data["pr-2"]; ok {
+		t.Fatalf("pull request must not be created")
+	}
+	_, err = svc.AddReviewer(ctx, "pr-1", "u2")
+	expectAtCapacity(err)
+
+	if _, err := svc.Merge(ctx, "pr-0"); err != nil {
+		t.Fatalf("Merge: %v", err)
+	}
+	if _, err := svc.AddReviewer(ctx, "pr-1", "u2"); err != nil {
+		t.Fatalf("after merge: unexpected error: %v", err)
+	}
+}
+
+func TestPullRequestService_AddReviewer_RemoveReviewer(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newMockUserRepo()
+	teamRepo := newMockTeamRepo()
+	prRepo := newMockPRRepo()
+
+	userRepo.data["u1"] = domain.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
+	userRepo.data["u2"] = domain.User{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true}
+	userRepo.data["u3"] = domain.User{UserID: "u3", Username: "Charlie", TeamName: "backend", IsActive: true}
+	userRepo.data["u4"] = domain.User{UserID: "u4", Username: "Dave", TeamName: "backend", IsActive: true}
+	prRepo.data["pr-1"] = domain.PullRequest{
+		PullRequestID: "pr-1", PullRequestName: "Add", AuthorID: "u1", Status: "OPEN",
+		AssignedReviewers: []string{"u2"},
+	}
+
+	svc := service.NewPullRequestService(prRepo, userRepo, teamRepo, newMockCodeOwnerRepo(), newMockRuleRepo(), newFakeClock())
+
+	expectCode := func(err error, want domain.ErrorCode) {
+		t.Helper()
+		var derr *domain.Error
+		if !errors.As(err, &derr) || derr.Code != want {
+			t.Fatalf("expected %s, got %v", want, err)
+		}
+	}
+
+	_, err := svc.AddReviewer(ctx, "pr-1", "u2")
+	expectCode(err, domain.ErrorAlreadyAssigned)
+	_, err = svc.AddReviewer(ctx, "pr-1", "u1")
+	expectCode(err, domain.ErrorInvalidReviewer)
+
+	pr, err := svc.AddReviewer(ctx, "pr-1", "u3")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pr.AssignedReviewers) != 2 || pr.AssignedReviewers[1] != "u3" {
+		t.Fatalf("expected [u2 u3], got %v", pr.AssignedReviewers)
+	}
+
+	_, err = svc.AddReviewer(ctx, "pr-1", "u4")
+	expectCode(err, domain.ErrorReviewerLimit)
+
+	pr, err = svc.RemoveReviewer(ctx, "pr-1", "u2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pr.AssignedReviewers) != 1 || pr.AssignedReviewers[0] != "u3" {
+		t.Fatalf("expected [u3], got %v", pr.AssignedReviewers)
+	}
+	if got := prRepo.data["pr-1"].AssignedReviewers; len(got) != 1 || got[0] != "u3" {
+		t.Fatalf("expected stored [u3], got %v", got)
+	}
+
+	_, err = svc.RemoveReviewer(ctx, "pr-1", "u2")
+	expectCode(err, domain.ErrorNotAssigned)
+
+	merged := prRepo.data["pr-1"]
+	merged.Status = "MERGED"
+	prRepo.data["pr-1"] = merged
+	_, err = svc.AddReviewer(ctx, "pr-1", "u4")
+	expectCode(err, domain.ErrorPRMerged)
+	_, err = svc.RemoveReviewer(ctx, "pr-1", "u3")
+	expectCode(err, domain.ErrorPRMerged)
+}
+
+func TestPullRequestService_Create_AppliesReviewerRules(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newMockUserRepo()
+	teamRepo := newMockTeamRepo()
+	prRepo := newMockPRRepo()
+	ruleRepo := newMockRuleRepo()
+
+	userRepo.data["u1"] = domain.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true, Skills: []string{"junior"}}
+	userRepo.data["u2"] = domain.User{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true}
+	userRepo.data["u3"] = domain.User{UserID: "u3", Username: "Charlie", TeamName: "backend", IsActive: true}
+	userRepo.data["u4"] = domain.User{UserID: "u4", Username: "Dave", TeamName: "backend", IsActive: true, Skills: []string{"senior"}}
+	teamRepo.data["backend"] = domain.Team{TeamName: "backend"}
+	ruleRepo.rules = domain.RuleSet{
+		{ID: "r1", Kind: domain.RuleExclude, Author: domain.UserSelector{UserID: "u1"}, Reviewer: domain.UserSelector{UserID: "u2"}},
+		{ID: "r2", Kind: domain.RuleRequire, Author: domain.UserSelector{Skill: "junior"}, Reviewer: domain.UserSelector{Skill: "senior"}},
+	}
+
+	svc := service.NewPullRequestService(prRepo, userRepo, teamRepo, newMockCodeOwnerRepo(), ruleRepo, newFakeClock())
+
+	for i := 0; i < 5; i++ {
+		prID := fmt.Sprintf("pr-%d", i)
+		pr, err := svc.Create(ctx, prID, "Add feature", "u1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		// u2 исключён для u1, а джуну нужен senior: остаются ровно u3 и u4.
+		got := map[string]bool{}
+		for _, r := range pr.AssignedReviewers {
+			got[r] = true
+		}
+		if len(got) != 2 || !got["u3"] || !got["u4"] {
+			t.Fatalf("expected reviewers u3 and u4, got %v", pr.AssignedReviewers)
+		}
+	}
+
+	_, err := svc.CreateWithHints(ctx, "pr-x", "Add feature", "u1", domain.ReviewHints{RequestedReviewers: []string{"u2"}})
+	var derr *domain.Error
+	if !errors.As(err, &derr) || derr.Code != domain.ErrorRuleViolation {
+		t.Fatalf("expected RULE_VIOLATION, got %v", err)
+	}
+	_, err = svc.AddReviewer(ctx, "pr-0", "u2")
+	if !errors.As(err, &derr) || derr.Code != domain.ErrorRuleViolation && derr.Code != domain.ErrorReviewerLimit {
+		t.Fatalf("expected rule or limit error, got %v", err)
+	}
+}
+
+func TestPullRequestService_Reassign_KeepsRequiredReviewer(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newMockUserRepo()
+	teamRepo := newMockTeamRepo()
+	prRepo := newMockPRRepo()
+	ruleRepo := newMockRuleRepo()
+
+	userRepo.data["u1"] = domain.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true, Skills: []string{"junior"}}
+	userRepo.data["u2"] = domain.User{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true}
+	userRepo.data["u3"] = domain.User{UserID: "u3", Username: "Charlie", TeamName: "backend", IsActive: true, Skills: []string{"senior"}}
+	userRepo.data["u4"] = domain.User{UserID: "u4", Username: "Dave", TeamName: "backend", IsActive: true}
+	userRepo.data["u5"] = domain.User{UserID: "u5", Username: "Eve", TeamName: "backend", IsActive: true, Skills: []string{"senior"}}
+	userRepo.data["u6"] = domain.User{UserID: "u6", Username: "Frank", TeamName: "backend", IsActive: true}
+	ruleRepo.rules = domain.RuleSet{
+		{ID: "r1", Kind: domain.RuleRequire, Author: domain.UserSelector{Skill: "junior"}, Reviewer: domain.UserSelector{Skill: "senior"}},
+		{ID: "r2", Kind: domain.RuleExclude, Author: domain.UserSelector{UserID: "u1"}, Reviewer: domain.UserSelector{UserID: "u6"}},
+	}
+	prRepo.data["pr-1"] = domain.PullRequest{
+		PullRequestID: "pr-1", PullRequestName: "Add", AuthorID: "u1", Status: "OPEN",
+		AssignedReviewers: []string{"u2", "u3"},
+	}
+
+	svc := service.NewPullRequestService(prRepo, userRepo, teamRepo, newMockCodeOwnerRepo(), ruleRepo, newFakeClock())
+
+	// Уходит единственный senior - замена тоже должна быть senior.
+	_, replacedBy, err := svc.Reassign(ctx, "pr-1", "u3")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if replacedBy != "u5" {
+		t.Fatalf("expected senior u5, got %s", replacedBy)
+	}
+
+	// Исключённый для u1 пользователь u6 не назначается никогда.
+	for i := 0; i < 3; i++ {
+		pr, replacedBy, err := svc.Reassign(ctx, "pr-1", prRepo.data["pr-1"].AssignedReviewers[0])
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if replacedBy == "u6" || slices.Contains(pr.AssignedReviewers, "u6") {
+			t.Fatalf("excluded reviewer u6 was assigned: %v", pr.AssignedReviewers)
+		}
+	}
+}
+
+func TestPullRequestService_PreviewAssignment_ExplainsRejections(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newMockUserRepo()
+	teamRepo := newMockTeamRepo()
+	prRepo := newMockPRRepo()
+	ruleRepo := newMockRuleRepo()
+
+	zero := 0
+	userRepo.data["u1"] = domain.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
+	userRepo.data["u2"] = domain.User{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: false}
+	userRepo.data["u3"] = domain.User{UserID: "u3", Username: "Charlie", TeamName: "backend", IsActive: true, MaxOpenReviews: &zero}
+	userRepo.data["u4"] = domain.User{UserID: "u4", Username: "Dave", TeamName: "backend", IsActive: true}
+	userRepo.data["u5"] = domain.User{UserID: "u5", Username: "Eve", TeamName: "backend", IsActive: true}
+	userRepo.data["u6"] = domain.User{UserID: "u6", Username: "Frank", TeamName: "backend", IsActive: true}
+	userRepo.data["u7"] = domain.User{UserID: "u7", Username: "Grace", TeamName: "backend", IsActive: true}
+	userRepo.data["u8"] = domain.User{UserID: "u8", Username: "Heidi", TeamName: "backend", IsActive: true}
+	teamRepo.data["backend"] = domain.Team{TeamName: "backend"}
+	ruleRepo.rules = domain.RuleSet{
+		{ID: "r1", Kind: domain.RuleExclude, Author: domain.UserSelector{UserID: "u1"}, Reviewer: domain.UserSelector{UserID: "u5"}},
+	}
+
+	svc := service.NewPullRequestService(prRepo, userRepo, teamRepo, newMockCodeOwnerRepo(), ruleRepo, newFakeClock())
+
+	selection, err := svc.PreviewAssignment(ctx, "u1", domain.ReviewHints{ExcludedUsers: []string{"u4"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(selection.Reviewers) != 2 || selection.UnfilledReviewerSlots() != 0 {
+		t.Fatalf("expected 2 reviewers, got %v", selection.ReviewerIDs())
+	}
+	for _, id := range selection.ReviewerIDs() {
+		if id != "u6" && id != "u7" && id != "u8" {
+			t.Fatalf("unexpected reviewer %s", id)
+		}
+	}
+
+	reasons := map[string]domain.RejectionReason{}
+	for _, c := range selection.Rejected {
+		reasons[c.UserID] = c.Reason
+	}
+	want := map[string]domain.RejectionReason{
+		"u1": domain.RejectedIsAuthor,
+		"u2": domain.RejectedInactive,
+		"u3": domain.RejectedAtCapacity,
+		"u4": domain.RejectedExcluded,
+		"u5": domain.RejectedByRule,
+	}
+	for id, reason := range want {
+		if reasons[id] != reason {
+			t.Fatalf("expected %s to be rejected as %s, got %v", id, reason, selection.Rejected)
+		}
+	}
+	if len(selection.Rejected) != len(want)+1 {
+		t.Fatalf("expected one SLOTS_FILLED candidate besides %v, got %v", want, selection.Rejected)
+	}
+
+	if len(prRepo.data) != 0 {
+		t.Fatalf("preview must not create pull requests, got %v", prRepo.data)
+	}
+
+	_, err = svc.PreviewAssignment(ctx, "u1", domain.ReviewHints{RequestedReviewers: []string{"u5"}})
+	var derr *domain.Error
+	if !errors.As(err, &derr) || derr.Code != domain.ErrorRuleViolation {
+		t.Fatalf("expected RULE_VIOLATION, got %v", err)
+	}
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"pr-reviewer-assigment-service/internal/application/repository"
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+// RuleService управляет правилами подбора ревьюверов и объясняет их нарушения.
+type RuleService struct {
+	ruleRepo repository.ReviewerRuleRepository
+	userRepo repository.UserRepository
+	prRepo   repository.PullRequestRepository
+	clock    Clock
+	ids      IDGenerator
+}
+
+func NewRuleService(
+	ruleRepository repository.ReviewerRuleRepository,
+	userRepository repository.UserRepository,
+	prRepository repository.PullRequestRepository,
+	clock Clock,
+	ids IDGenerator,
+) *RuleService {
+	return &RuleService{
+		ruleRepo: ruleRepository,
+		userRepo: userRepository,
+		prRepo:   prRepository,
+		clock:    clock,
+		ids:      ids,
+	}
+}
+
+// Add сохраняет новое правило. Пользователь из селектора должен существовать.
+func (s *RuleService) Add(ctx context.Context, rule domain.ReviewerRule) (*domain.ReviewerRule, error) {
+	rule.ID = s.ids.NewID()
+	rule.CreatedAt = s.clock.Now()
+	if err := s.ruleRepo.Create(ctx, &rule); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, domain.NewError(domain.ErrorNotFound, "rule refers to unknown user")
+		}
+		return nil, fmt.Errorf("ruleRepo.Create: %w", err)
+	}
+	return &rule, nil
+}
+
+// List возвращает все правила.
+func (s *RuleService) List(ctx context.Context) (domain.RuleSet, error) {
+	rules, err := s.ruleRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ruleRepo.List: %w", err)
+	}
+	return rules, nil
+}
+
+// Delete удаляет правило и возвращает его.
+func (s *RuleService) Delete(ctx context.Context, ruleID string) (*domain.ReviewerRule, error) {
+	rule, err := s.ruleRepo.Delete(ctx, ruleID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, domain.NewError(domain.ErrorNotFound, "rule not found: "+ruleID)
+		}
+		return nil, fmt.Errorf("ruleRepo.Delete: %w", err)
+	}
+	return rule, nil
+}
+
+// Evaluate проверяет по правилам набор ревьюверов PR автора authorID без изменений (dry-run).
+func (s *RuleService) Evaluate(ctx context.Context, authorID string, reviewerIDs []string) ([]domain.RuleViolation, error) {
+	author, err := s.getUser(ctx, authorID, "author")
+	if err != nil {
+		return nil, err
+	}
+
+	reviewers := make([]domain.User, 0, len(reviewerIDs))
+	for _, id := range reviewerIDs {
+		reviewer, err := s.getUser(ctx, id, "reviewer")
+		if err != nil {
+			return nil, err
+		}
+		reviewers = append(reviewers, *reviewer)
+	}
+
+	rules, err := s.ruleRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ruleRepo.List: %w", err)
+	}
+	return rules.Evaluate(author, reviewers), nil
+}
+
+// EvaluatePullRequest проверяет по правилам текущих ревьюверов PR.
+func (s *RuleService) EvaluatePullRequest(ctx context.Context, prID string) ([]domain.RuleViolation, error) {
+	pr, err := s.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, domain.NewError(domain.ErrorNotFound, "pull request not found: "+prID)
+		}
+		return nil, fmt.Errorf("prRepo.GetByID: %w", err)
+	}
+	return s.Evaluate(ctx, pr.AuthorID, pr.AssignedReviewers)
+}
+
+func (s *RuleService) getUser(ctx context.Context, userID string, role string) (*domain.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, domain.NewError(domain.ErrorNotFound, role+" not found: "+userID)
+		}
+		return nil, fmt.Errorf("userRepo.GetByID: %w", err)
+	}
+	return user, nil
+}
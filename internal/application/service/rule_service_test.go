@@ -0,0 +1,122 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"pr-reviewer-assigment-service/internal/application/repository"
+	"pr-reviewer-assigment-service/internal/application/service"
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+type mockRuleRepo struct {
+	rules domain.RuleSet
+}
+
+func newMockRuleRepo() *mockRuleRepo {
+	return &mockRuleRepo{}
+}
+
+func (m *mockRuleRepo) Create(ctx context.Context, rule *domain.ReviewerRule) error {
+	m.rules = append(m.rules, *rule)
+	return nil
+}
+
+func (m *mockRuleRepo) List(ctx context.Context) (domain.RuleSet, error) {
+	return append(domain.RuleSet(nil), m.rules...), nil
+}
+
+func (m *mockRuleRepo) Delete(ctx context.Context, ruleID string) (*domain.ReviewerRule, error) {
+	for i, rule := range m.rules {
+		if rule.ID == ruleID {
+			m.rules = append(m.rules[:i], m.rules[i+1:]...)
+			return &rule, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+// sequentialIDs выдаёт предсказуемые ID: <prefix>-1, <prefix>-2, ...
+type sequentialIDs struct {
+	prefix string
+	n      int
+}
+
+func (g *sequentialIDs) NewID() string {
+	g.n++
+	return fmt.Sprintf("%s-%d", g.prefix, g.n)
+}
+
+func TestRuleService_Evaluate(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newMockUserRepo()
+	prRepo := newMockPRRepo()
+	ruleRepo := newMockRuleRepo()
+
+	userRepo.data["u1"] = domain.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true, Skills: []string{"junior"}}
+	userRepo.data["u2"] = domain.User{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true}
+	userRepo.data["u3"] = domain.User{UserID: "u3", Username: "Charlie", TeamName: "backend", IsActive: true, Skills: []string{"senior"}}
+	prRepo.data["pr-1"] = domain.PullRequest{PullRequestID: "pr-1", AuthorID: "u1", Status: "OPEN", AssignedReviewers: []string{"u2"}}
+
+	clock := newFakeClock()
+	svc := service.NewRuleService(ruleRepo, userRepo, prRepo, clock, &sequentialIDs{prefix: "rule"})
+
+	exclude, err := svc.Add(ctx, domain.ReviewerRule{
+		Kind:     domain.RuleExclude,
+		Author:   domain.UserSelector{UserID: "u1"},
+		Reviewer: domain.UserSelector{UserID: "u2"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !exclude.CreatedAt.Equal(clock.Now()) {
+		t.Fatalf("expected created_at %v from the clock, got %v", clock.Now(), exclude.CreatedAt)
+	}
+	clock.Advance(time.Minute)
+	require, err := svc.Add(ctx, domain.ReviewerRule{
+		Kind:     domain.RuleRequire,
+		Author:   domain.UserSelector{Skill: "junior"},
+		Reviewer: domain.UserSelector{Skill: "senior"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	violations, err := svc.EvaluatePullRequest(ctx, "pr-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if exclude.ID != "rule-1" || require.ID != "rule-2" {
+		t.Fatalf("expected IDs from the generator, got %s and %s", exclude.ID, require.ID)
+	}
+	if len(violations) != 2 ||
+		violations[0].RuleID != exclude.ID || violations[0].ReviewerID != "u2" ||
+		violations[1].RuleID != require.ID {
+		t.Fatalf("expected exclusion of u2 and missing senior, got %+v", violations)
+	}
+
+	violations, err = svc.Evaluate(ctx, "u1", []string{"u3"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(violations) != 0 {
+		t.Fatalf("expected no violations, got %+v", violations)
+	}
+
+	_, err = svc.Evaluate(ctx, "u1", []string{"ghost"})
+	var derr *domain.Error
+	if !errors.As(err, &derr) || derr.Code != domain.ErrorNotFound {
+		t.Fatalf("expected NOT_FOUND, got %v", err)
+	}
+
+	if _, err := svc.Delete(ctx, exclude.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := svc.Delete(ctx, exclude.ID); !errors.As(err, &derr) || derr.Code != domain.ErrorNotFound {
+		t.Fatalf("expected NOT_FOUND, got %v", err)
+	}
+}
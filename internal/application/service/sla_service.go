@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"pr-reviewer-assigment-service/internal/application/repository"
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+// ReviewerReassigner переназначает ревьювера PR на другого участника его команды,
+// не выбирая пользователей из exclude. Реализуется PullRequestService.
+type ReviewerReassigner interface {
+	ReassignExcluding(ctx context.Context, prID string, oldUserID string, exclude []string) (*domain.PullRequest, string, error)
+}
+
+// EscalationPublisher доставляет события эскалации просроченных PR (лог, чат, webhook).
+type EscalationPublisher interface {
+	PublishEscalation(ctx context.Context, e domain.Escalation) error
+}
+
+// SLAService следит за SLA ревью команд: находит открытые PR, просрочившие SLA команды автора,
+// и эскалирует их по политике команды.
+type SLAService struct {
+	slaRepo    repository.ReviewSLARepository
+	teamRepo   repository.TeamRepository
+	reassigner ReviewerReassigner
+	publisher  EscalationPublisher
+	clock      Clock
+}
+
+func NewSLAService(
+	slaRepository repository.ReviewSLARepository,
+	teamRepository repository.TeamRepository,
+	reassigner ReviewerReassigner,
+	publisher EscalationPublisher,
+	clock Clock,
+) *SLAService {
+	return &SLAService{
+		slaRepo:    slaRepository,
+		teamRepo:   teamRepository,
+		reassigner: reassigner,
+		publisher:  publisher,
+		clock:      clock,
+	}
+}
+
+// SetTeamSLA задаёт SLA ревью команды. nil в sla снимает SLA.
+func (s *SLAService) SetTeamSLA(
+	ctx context.Context,
+	teamName string,
+	sla *time.Duration,
+	policy domain.EscalationPolicy,
+) (*domain.ReviewSLA, error) {
+	if _, err := s.teamRepo.GetByName(ctx, teamName); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, domain.NewError(domain.ErrorNotFound, "team not found: "+teamName)
+		}
+		return nil, fmt.Errorf("teamRepo.GetByName: %w", err)
+	}
+
+	if sla == nil {
+		if err := s.slaRepo.Delete(ctx, teamName); err != nil {
+			return nil, fmt.Errorf("slaRepo.Delete: %w", err)
+		}
+		return nil, nil
+	}
+
+	result := domain.ReviewSLA{TeamName: teamName, SLA: *sla, Policy: policy}
+	if err := s.slaRepo.Set(ctx, result); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, domain.NewError(domain.ErrorNotFound, "team not found: "+teamName)
+		}
+		return nil, fmt.Errorf("slaRepo.Set: %w", err)
+	}
+	return &result, nil
+}
+
+// Overdue возвращает открытые PR, просрочившие SLA, от самых старых. Пустой teamName - все команды.
+func (s *SLAService) Overdue(ctx context.Context, teamName string) ([]domain.OverduePullRequest, time.Time, error) {
+	now := s.clock.Now()
+	overdue, err := s.slaRepo.ListOverdue(ctx, teamName, now)
+	if err != nil {
+		return nil, now, fmt.Errorf("slaRepo.ListOverdue: %w", err)
+	}
+	return overdue, now, nil
+}
+
+// EscalateOverdue эскалирует просроченные PR, которым пора: ещё не эскалированные
+// или эскалированные не меньше SLA назад. По политике REASSIGN ревьюверы переназначаются;
+// ревьювер, которого некем заменить, остаётся. Возвращает число эскалированных PR.
+// Ошибка по одному PR не мешает остальным. PR отмечается эскалированным до переназначения,
+// поэтому сбой посреди эскалации не ротирует ревьюверов при следующем запуске: PR эскалируется снова
+// не раньше чем через SLA. Событие доставляется по возможности: ошибка публикации только логируется.
+func (s *SLAService) EscalateOverdue(ctx context.Context) (int, error) {
+	now := s.clock.Now()
+	overdue, err := s.slaRepo.ListOverdue(ctx, "", now)
+	if err != nil {
+		return 0, fmt.Errorf("slaRepo.ListOverdue: %w", err)
+	}
+
+	escalated := 0
+	var errs []error
+	for _, pr := range overdue {
+		if !pr.EscalationDue(now) {
+			continue
+		}
+		if err := s.escalate(ctx, pr, now); err != nil {
+			errs = append(errs, fmt.Errorf("escalate %s: %w", pr.PullRequestID, err))
+			continue
+		}
+		escalated++
+	}
+
+	return escalated, errors.Join(errs...)
+}
+
+func (s *SLAService) escalate(ctx context.Context, pr domain.OverduePullRequest, now time.Time) error {
+	e := domain.Escalation{
+		PullRequestID: pr.PullRequestID,
+		TeamName:      pr.TeamName,
+		Policy:        pr.Policy,
+		Reviewers:     pr.AssignedReviewers,
+		Reassigned:    make(map[string]string),
+		OverdueBy:     pr.OverdueBy(now),
+		EscalatedAt:   now,
+	}
+
+	if err := s.slaRepo.MarkEscalated(ctx, pr.PullRequestID, now); err != nil {
+		return fmt.Errorf("slaRepo.MarkEscalated: %w", err)
+	}
+
+	var reassignErr error
+	if pr.Policy == domain.EscalationReassign {
+		// Снятые за эскалацию ревьюверы не должны вернуться на замену следующим.
+		original := slices.Clone(pr.AssignedReviewers)
+		for _, reviewer := range original {
+			_, replacedBy, err := s.reassigner.ReassignExcluding(ctx, pr.PullRequestID, reviewer, original)
+			var dErr *domain.Error
+			if errors.As(err, &dErr) && dErr.Code == domain.ErrorNoCandidate {
+				continue
+			}
+			if err != nil {
+				reassignErr = fmt.Errorf("reassign %s: %w", reviewer, err)
+				break
+			}
+			e.Reassigned[reviewer] = replacedBy
+		}
+	}
+
+	// О сделанных заменах сообщается и при сбое переназначения.
+	if err := s.publisher.PublishEscalation(ctx, e); err != nil {
+		log.Printf("sla escalator: publish escalation %s: %v", pr.PullRequestID, err)
+	}
+	return reassignErr
+}
+
+// RunEscalator периодически вызывает EscalateOverdue, пока не отменён ctx.
+func (s *SLAService) RunEscalator(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.EscalateOverdue(ctx); err != nil {
+				log.Printf("sla escalator: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,373 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"testing"
+	"time"
+
+	"pr-reviewer-assigment-service/internal/application/service"
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+// mockSLARepo считает просроченные PR по данным моков PR и пользователей, как это делает SQL-запрос.
+type mockSLARepo struct {
+	slas      map[string]domain.ReviewSLA
+	escalated map[string]time.Time
+	prRepo    *mockPRRepo
+	userRepo  *mockUserRepo
+}
+
+func newMockSLARepo(prRepo *mockPRRepo, userRepo *mockUserRepo) *mockSLARepo {
+	return &mockSLARepo{
+		slas:      make(map[string]domain.ReviewSLA),
+		escalated: make(map[string]time.Time),
+		prRepo:    prRepo,
+		userRepo:  userRepo,
+	}
+}
+
+func (m *mockSLARepo) Set(ctx context.Context, sla domain.ReviewSLA) error {
+	m.slas[sla.TeamName] = sla
+	return nil
+}
+
+func (m *mockSLARepo) Delete(ctx context.Context, teamName string) error {
+	delete(m.slas, teamName)
+	return nil
+}
+
+func (m *mockSLARepo) ListOverdue(ctx context.Context, teamName string, now time.Time) ([]domain.OverduePullRequest, error) {
+	result := make([]domain.OverduePullRequest, 0)
+	for _, pr := range m.prRepo.data {
+		author := m.userRepo.data[pr.AuthorID]
+		sla, ok := m.slas[author.TeamName]
+		if !ok || pr.Status != string(domain.StatusOpen) || (teamName != "" && teamName != author.TeamName) {
+			continue
+		}
+		if pr.CreatedAt.Add(sla.SLA).After(now) {
+			continue
+		}
+		o := domain.OverduePullRequest{
+			PullRequestID:     pr.PullRequestID,
+			PullRequestName:   pr.PullRequestName,
+			AuthorID:          pr.AuthorID,
+			TeamName:          author.TeamName,
+			AssignedReviewers: append([]string(nil), pr.AssignedReviewers...),
+			CreatedAt:         *pr.CreatedAt,
+			SLA:               sla.SLA,
+			Policy:            sla.Policy,
+		}
+		if at, ok := m.escalated[pr.PullRequestID]; ok {
+			o.EscalatedAt = &at
+		}
+		result = append(result, o)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].CreatedAt.Before(result[j].CreatedAt) })
+	return result, nil
+}
+
+func (m *mockSLARepo) MarkEscalated(ctx context.Context, prID string, at time.Time) error {
+	m.escalated[prID] = at
+	return nil
+}
+
+type recordingPublisher struct {
+	events []domain.Escalation
+	err    error
+}
+
+func (p *recordingPublisher) PublishEscalation(ctx context.Context, e domain.Escalation) error {
+	p.events = append(p.events, e)
+	return p.err
+}
+
+type slaFixture struct {
+	clock     *fakeClock
+	userRepo  *mockUserRepo
+	teamRepo  *mockTeamRepo
+	prRepo    *mockPRRepo
+	slaRepo   *mockSLARepo
+	publisher *recordingPublisher
+	svc       *service.SLAService
+}
+
+func newSLAFixture() *slaFixture {
+	f := &slaFixture{
+		clock:     newFakeClock(),
+		userRepo:  newMockUserRepo(),
+		teamRepo:  newMockTeamRepo(),
+		prRepo:    newMockPRRepo(),
+		publisher: &recordingPublisher{},
+	}
+	f.slaRepo = newMockSLARepo(f.prRepo, f.userRepo)
+
+	f.teamRepo.data["backend"] = domain.Team{TeamName: "backend"}
+	f.teamRepo.data["payments"] = domain.Team{TeamName: "payments"}
+	for _, u := range []domain.User{
+		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+		{UserID: "u3", Username: "Charlie", TeamName: "backend", IsActive: true},
+		{UserID: "u4", Username: "Dave", TeamName: "backend", IsActive: true},
+		{UserID: "u5", Username: "Eve", TeamName: "payments", IsActive: true},
+		{UserID: "u6", Username: "Frank", TeamName: "payments", IsActive: true},
+	} {
+		f.userRepo.data[u.UserID] = u
+	}
+
+	prService := service.NewPullRequestService(f.prRepo, f.userRepo, f.teamRepo, newMockCodeOwnerRepo(), newMockRuleRepo(), f.clock)
+	f.svc = service.NewSLAService(f.slaRepo, f.teamRepo, prService, f.publisher, f.clock)
+	return f
+}
+
+func (f *slaFixture) addPR(id, authorID string, reviewers ...string) {
+	createdAt := f.clock.Now()
+	f.prRepo.data[id] = domain.PullRequest{
+		PullRequestID:     id,
+		PullRequestName:   "PR " + id,
+		AuthorID:          authorID,
+		Status:            string(domain.StatusOpen),
+		AssignedReviewers: reviewers,
+		CreatedAt:         &createdAt,
+	}
+}
+
+func TestSLAService_SetTeamSLA(t *testing.T) {
+	ctx := context.Background()
+	f := newSLAFixture()
+
+	day := 24 * time.Hour
+	_, err := f.svc.SetTeamSLA(ctx, "ghost", &day, domain.EscalationNotify)
+	var derr *domain.Error
+	if !errors.As(err, &derr) || derr.Code != domain.ErrorNotFound {
+		t.Fatalf("expected NOT_FOUND, got %v", err)
+	}
+
+	sla, err := f.svc.SetTeamSLA(ctx, "backend", &day, domain.EscalationReassign)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sla.SLA != day || sla.Policy != domain.EscalationReassign || f.slaRepo.slas["backend"] != *sla {
+		t.Fatalf("unexpected sla: %+v", sla)
+	}
+
+	sla, err = f.svc.SetTeamSLA(ctx, "backend", nil, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sla != nil || len(f.slaRepo.slas) != 0 {
+		t.Fatalf("expected sla to be removed, got %+v", f.slaRepo.slas)
+	}
+}
+
+func TestSLAService_Overdue(t *testing.T) {
+	ctx := context.Background()
+	f := newSLAFixture()
+
+	f.slaRepo.slas["backend"] = domain.ReviewSLA{TeamName: "backend", SLA: 24 * time.Hour, Policy: domain.EscalationNotify}
+	f.slaRepo.slas["payments"] = domain.ReviewSLA{TeamName: "payments", SLA: 48 * time.Hour, Policy: domain.EscalationNotify}
+
+	f.addPR("pr-1", "u1", "u2")
+	f.addPR("pr-2", "u5")
+	f.clock.Advance(time.Hour)
+	f.addPR("pr-3", "u1", "u3")
+	f.addPR("pr-merged", "u1", "u2")
+	merged := f.prRepo.data["pr-merged"]
+	merged.Status = string(domain.StatusMerged)
+	f.prRepo.data["pr-merged"] = merged
+
+	f.clock.Advance(23*time.Hour + 30*time.Minute)
+
+	overdue, now, err := f.svc.Overdue(ctx, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(overdue) != 1 || overdue[0].PullRequestID != "pr-1" {
+		t.Fatalf("expected only pr-1 to be overdue, got %+v", overdue)
+	}
+	if got := overdue[0].OverdueBy(now); got != 30*time.Minute {
+		t.Fatalf("expected pr-1 to be overdue by 30m, got %s", got)
+	}
+
+	f.clock.Advance(25 * time.Hour)
+	overdue, _, err = f.svc.Overdue(ctx, "backend")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(overdue) != 2 || overdue[0].PullRequestID != "pr-1" || overdue[1].PullRequestID != "pr-3" {
+		t.Fatalf("expected pr-1 and pr-3, got %+v", overdue)
+	}
+}
+
+func TestSLAService_EscalateOverdue_Notify(t *testing.T) {
+	ctx := context.Background()
+	f := newSLAFixture()
+
+	f.slaRepo.slas["backend"] = domain.ReviewSLA{TeamName: "backend", SLA: 24 * time.Hour, Policy: domain.EscalationNotify}
+	f.addPR("pr-1", "u1", "u2", "u3")
+
+	f.clock.Advance(23 * time.Hour)
+	if n, err := f.svc.EscalateOverdue(ctx); err != nil || n != 0 {
+		t.Fatalf("expected nothing to escalate before SLA, got %d, %v", n, err)
+	}
+
+	f.clock.Advance(2 * time.Hour)
+	if n, err := f.svc.EscalateOverdue(ctx); err != nil || n != 1 {
+		t.Fatalf("expected 1 escalation, got %d, %v", n, err)
+	}
+	if len(f.publisher.events) != 1 {
+		t.Fatalf("expected 1 event, got %+v", f.publisher.events)
+	}
+	e := f.publisher.events[0]
+	if e.PullRequestID != "pr-1" || e.Policy != domain.EscalationNotify || e.OverdueBy != time.Hour ||
+		len(e.Reviewers) != 2 || len(e.Reassigned) != 0 || !e.EscalatedAt.Equal(f.clock.Now()) {
+		t.Fatalf("unexpected escalation: %+v", e)
+	}
+	if got := f.prRepo.data["pr-1"].AssignedReviewers; len(got) != 2 || got[0] != "u2" || got[1] != "u3" {
+		t.Fatalf("NOTIFY must not change reviewers, got %v", got)
+	}
+
+	// Повторная эскалация - только через SLA после предыдущей.
+	f.clock.Advance(12 * time.Hour)
+	if n, err := f.svc.EscalateOverdue(ctx); err != nil || n != 0 {
+		t.Fatalf("expected no repeated escalation, got %d, %v", n, err)
+	}
+	f.clock.Advance(12 * time.Hour)
+	if n, err := f.svc.EscalateOverdue(ctx); err != nil || n != 1 {
+		t.Fatalf("expected second escalation, got %d, %v", n, err)
+	}
+}
+
+func TestSLAService_EscalateOverdue_Reassign(t *testing.T) {
+	ctx := context.Background()
+	f := newSLAFixture()
+
+	f.slaRepo.slas["backend"] = domain.ReviewSLA{TeamName: "backend", SLA: 24 * time.Hour, Policy: domain.EscalationReassign}
+	f.slaRepo.slas["payments"] = domain.ReviewSLA{TeamName: "payments", SLA: 24 * time.Hour, Policy: domain.EscalationReassign}
+	f.addPR("pr-1", "u1", "u2")
+	// В payments некем заменить ревьювера: PR всё равно эскалируется, ревьювер остаётся.
+	f.addPR("pr-2", "u5", "u6")
+
+	f.clock.Advance(25 * time.Hour)
+	if n, err := f.svc.EscalateOverdue(ctx); err != nil || n != 2 {
+		t.Fatalf("expected 2 escalations, got %d, %v", n, err)
+	}
+
+	byPR := map[string]domain.Escalation{}
+	for _, e := range f.publisher.events {
+		byPR[e.PullRequestID] = e
+	}
+
+	replacedBy := byPR["pr-1"].Reassigned["u2"]
+	if replacedBy != "u3" && replacedBy != "u4" {
+		t.Fatalf("expected u2 to be replaced by a teammate, got %+v", byPR["pr-1"])
+	}
+	if got := f.prRepo.data["pr-1"].AssignedReviewers; len(got) != 1 || got[0] != replacedBy {
+		t.Fatalf("expected reviewers [%s], got %v", replacedBy, got)
+	}
+
+	if len(byPR["pr-2"].Reassigned) != 0 {
+		t.Fatalf("expected nothing reassigned for pr-2, got %+v", byPR["pr-2"])
+	}
+	if got := f.prRepo.data["pr-2"].AssignedReviewers; len(got) != 1 || got[0] != "u6" {
+		t.Fatalf("expected reviewer u6 to stay, got %v", got)
+	}
+}
+
+func TestSLAService_EscalateOverdue_ReassignSkipsReplacedReviewers(t *testing.T) {
+	ctx := context.Background()
+	f := newSLAFixture()
+
+	f.slaRepo.slas["backend"] = domain.ReviewSLA{TeamName: "backend", SLA: 24 * time.Hour, Policy: domain.EscalationReassign}
+	// Свободен только u4: после замены u2 на u4 освободившийся u2 не должен заменить u3.
+	f.addPR("pr-1", "u1", "u2", "u3")
+
+	f.clock.Advance(25 * time.Hour)
+	if n, err := f.svc.EscalateOverdue(ctx); err != nil || n != 1 {
+		t.Fatalf("expected 1 escalation, got %d, %v", n, err)
+	}
+
+	if got := f.prRepo.data["pr-1"].AssignedReviewers; !slices.Equal(got, []string{"u4", "u3"}) {
+		t.Fatalf("expected reviewers [u4 u3], got %v", got)
+	}
+	e := f.publisher.events[0]
+	if len(e.Reassigned) != 1 || e.Reassigned["u2"] != "u4" {
+		t.Fatalf("expected only u2 to be replaced by u4, got %+v", e.Reassigned)
+	}
+}
+
+func TestSLAService_EscalateOverdue_PublishFailureKeepsEscalation(t *testing.T) {
+	ctx := context.Background()
+	f := newSLAFixture()
+	f.publisher.err = errors.New("webhook is down")
+
+	f.slaRepo.slas["backend"] = domain.ReviewSLA{TeamName: "backend", SLA: 24 * time.Hour, Policy: domain.EscalationReassign}
+	f.addPR("pr-1", "u1", "u2")
+
+	f.clock.Advance(25 * time.Hour)
+	if n, err := f.svc.EscalateOverdue(ctx); err != nil || n != 1 {
+		t.Fatalf("expected 1 escalation despite publish failure, got %d, %v", n, err)
+	}
+	reviewers := slices.Clone(f.prRepo.data["pr-1"].AssignedReviewers)
+	if _, ok := f.slaRepo.escalated["pr-1"]; !ok {
+		t.Fatalf("expected pr-1 marked escalated")
+	}
+
+	// Следующий запуск до истечения SLA не ротирует ревьюверов снова.
+	f.clock.Advance(5 * time.Minute)
+	if n, err := f.svc.EscalateOverdue(ctx); err != nil || n != 0 {
+		t.Fatalf("expected no escalations on the next run, got %d, %v", n, err)
+	}
+	if got := f.prRepo.data["pr-1"].AssignedReviewers; !slices.Equal(got, reviewers) {
+		t.Fatalf("expected reviewers %v to stay, got %v", reviewers, got)
+	}
+}
+
+// failingReassigner переназначает первого ревьювера и падает на следующих.
+type failingReassigner struct {
+	next  service.ReviewerReassigner
+	calls int
+}
+
+func (r *failingReassigner) ReassignExcluding(ctx context.Context, prID string, oldUserID string, exclude []string) (*domain.PullRequest, string, error) {
+	r.calls++
+	if r.calls > 1 {
+		return nil, "", errors.New("connection reset")
+	}
+	return r.next.ReassignExcluding(ctx, prID, oldUserID, exclude)
+}
+
+func TestSLAService_EscalateOverdue_ReassignFailureDoesNotRotate(t *testing.T) {
+	ctx := context.Background()
+	f := newSLAFixture()
+	reassigner := &failingReassigner{
+		next: service.NewPullRequestService(f.prRepo, f.userRepo, f.teamRepo, newMockCodeOwnerRepo(), newMockRuleRepo(), f.clock),
+	}
+	svc := service.NewSLAService(f.slaRepo, f.teamRepo, reassigner, f.publisher, f.clock)
+
+	f.slaRepo.slas["backend"] = domain.ReviewSLA{TeamName: "backend", SLA: 24 * time.Hour, Policy: domain.EscalationReassign}
+	f.addPR("pr-1", "u1", "u2", "u3")
+
+	f.clock.Advance(25 * time.Hour)
+	if _, err := svc.EscalateOverdue(ctx); err == nil {
+		t.Fatalf("expected reassign failure")
+	}
+	reviewers := slices.Clone(f.prRepo.data["pr-1"].AssignedReviewers)
+	if !slices.Equal(reviewers, []string{"u4", "u3"}) {
+		t.Fatalf("expected u2 replaced by u4 before the failure, got %v", reviewers)
+	}
+	// О сделанной замене всё равно сообщается.
+	if len(f.publisher.events) != 1 || f.publisher.events[0].Reassigned["u2"] != "u4" {
+		t.Fatalf("expected escalation event with u2 -> u4, got %+v", f.publisher.events)
+	}
+
+	f.clock.Advance(5 * time.Minute)
+	if n, err := svc.EscalateOverdue(ctx); err != nil || n != 0 {
+		t.Fatalf("expected no escalations on the next run, got %d, %v", n, err)
+	}
+	if got := f.prRepo.data["pr-1"].AssignedReviewers; !slices.Equal(got, reviewers) {
+		t.Fatalf("expected reviewers %v to stay, got %v", reviewers, got)
+	}
+}
@@ -4,17 +4,38 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+
 	"pr-reviewer-assigment-service/internal/application/repository"
 	"pr-reviewer-assigment-service/internal/domain"
 )
 
+// ReviewerReleaser снимает пользователей с открытых ревью перед выходом из команды.
+// Реализуется PullRequestService.
+type ReviewerReleaser interface {
+	ReleaseReviewer(ctx context.Context, userID string, reassign bool) error
+	ReleaseReviewers(ctx context.Context, userIDs []string, reassign bool) error
+}
+
 type TeamService struct {
 	userRepo repository.UserRepository
 	teamRepo repository.TeamRepository
+	prRepo   repository.PullRequestRepository
+	releaser ReviewerReleaser
 }
 
-func NewTeamService(userRepository repository.UserRepository, teamRepository repository.TeamRepository) *TeamService {
-	return &TeamService{userRepo: userRepository, teamRepo: teamRepository}
+func NewTeamService(
+	userRepository repository.UserRepository,
+	teamRepository repository.TeamRepository,
+	prRepository repository.PullRequestRepository,
+	releaser ReviewerReleaser,
+) *TeamService {
+	return &TeamService{
+		userRepo: userRepository,
+		teamRepo: teamRepository,
+		prRepo:   prRepository,
+		releaser: releaser,
+	}
 }
 
 func (t *TeamService) Add(ctx context.Context, teamName string, members []domain.TeamMember) (*domain.Team, error) {
@@ -65,12 +86,27 @@ func (t *TeamService) Get(ctx context.Context, teamName string) (*domain.Team, e
 	return team, nil
 }
 
+// List возвращает страницу команд с количеством участников и общее число найденных команд.
+func (t *TeamService) List(ctx context.Context, prefix string, limit, offset int) ([]domain.TeamSummary, int, error) {
+	teams, total, err := t.teamRepo.List(ctx, repository.TeamListFilter{
+		Prefix: prefix,
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("teamRepo.List: %w", err)
+	}
+
+	return teams, total, nil
+}
+
 func (t *TeamService) DeactivateMembers(ctx context.Context, teamName string) error {
 	team, err := t.teamRepo.GetByName(ctx, teamName)
 	if err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
 			return domain.NewError(domain.ErrorNotFound, "team not found: "+teamName)
 		}
+		return fmt.Errorf("teamRepo.GetByName: %w", err)
 	}
 	for _, member := range team.Members {
 		_, err := t.userRepo.SetActive(ctx, member.UserID, false)
@@ -83,3 +119,211 @@ func (t *TeamService) DeactivateMembers(ctx context.Context, teamName string) er
 	}
 	return nil
 }
+
+// AddMembers добавляет в существующую команду новых пользователей или обновляет её участников.
+// Пользователя из другой команды добавить нельзя (USER_IN_OTHER_TEAM) - для этого есть перевод.
+func (t *TeamService) AddMembers(ctx context.Context, teamName string, members []domain.TeamMember) (*domain.Team, error) {
+	if _, err := t.Get(ctx, teamName); err != nil {
+		return nil, err
+	}
+
+	users := make([]domain.User, 0, len(members))
+	for _, m := range members {
+		existing, err := t.userRepo.GetByID(ctx, m.UserID)
+		if err != nil && !errors.Is(err, repository.ErrNotFound) {
+			return nil, fmt.Errorf("userRepo.GetByID: %w", err)
+		}
+		if existing != nil && existing.TeamName != "" && existing.TeamName != teamName {
+			return nil, domain.NewError(domain.ErrorUserInOtherTeam,
+				fmt.Sprintf("user %s is a member of team %s", m.UserID, existing.TeamName))
+		}
+		users = append(users, domain.User{
+			UserID:   m.UserID,
+			Username: m.Username,
+			TeamName: teamName,
+			IsActive: m.IsActive,
+		})
+	}
+
+	if err := t.userRepo.BulkUpsert(ctx, users); err != nil {
+		return nil, fmt.Errorf("userRepo.BulkUpsert: %w", err)
+	}
+	return t.Get(ctx, teamName)
+}
+
+// RemoveMembers выводит пользователей из команды. Пользователи остаются в системе без команды.
+// Участник открытых ревью удаляется только с reassign: его ревью переназначаются
+// (см. PullRequestService.ReleaseReviewer), иначе - HAS_OPEN_REVIEWS.
+func (t *TeamService) RemoveMembers(ctx context.Context, teamName string, userIDs []string, reassign bool) (*domain.Team, error) {
+	team, err := t.Get(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make(map[string]struct{}, len(team.Members))
+	for _, m := range team.Members {
+		members[m.UserID] = struct{}{}
+	}
+	for _, id := range userIDs {
+		if _, ok := members[id]; !ok {
+			return nil, domain.NewError(domain.ErrorNotFound,
+				fmt.Sprintf("user %s is not a member of team %s", id, teamName))
+		}
+	}
+
+	// Все удаляемые освобождаются вместе, чтобы их ревью не переназначались друг на друга.
+	if err := t.releaser.ReleaseReviewers(ctx, userIDs, reassign); err != nil {
+		return nil, err
+	}
+
+	for _, id := range userIDs {
+		if _, err := t.userRepo.SetTeam(ctx, id, ""); err != nil {
+			return nil, fmt.Errorf("userRepo.SetTeam: %w", err)
+		}
+	}
+
+	return t.Get(ctx, teamName)
+}
+
+// Rename переименовывает команду. Участники остаются в ней.
+func (t *TeamService) Rename(ctx context.Context, oldName, newName string) (*domain.Team, error) {
+	if err := t.teamRepo.Rename(ctx, oldName, newName); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			return nil, domain.NewError(domain.ErrorNotFound, "team not found: "+oldName)
+		case errors.Is(err, repository.ErrAlreadyExists):
+			return nil, domain.NewError(domain.ErrorTeamExists, fmt.Sprintf("team %s already exists", newName))
+		}
+		return nil, fmt.Errorf("teamRepo.Rename: %w", err)
+	}
+	return t.Get(ctx, newName)
+}
+
+// Delete удаляет команду и возвращает её состав на момент удаления.
+// Участники обрабатываются по стратегии:
+//   - move: переводятся в targetTeam вместе со своими открытыми PR;
+//   - deactivate: деактивируются и остаются без команды, их открытые PR остаются открытыми.
+//
+// Пока участники - авторы или ревьюверы открытых PR, удаление отклоняется (TEAM_HAS_OPEN_PRS).
+// С force ревью участников переназначаются (см. PullRequestService.ReleaseReviewers):
+// при deactivate - до деактивации, пока участники ещё активны, при move - после перевода,
+// на участников targetTeam.
+//
+// Удаление не транзакционно, но шаги упорядочены так, чтобы повтор после сбоя довершил его:
+// проверки ничего не меняют, повторное освобождение ревью и перевод участника ничего не делают,
+// а команда удаляется последней, когда в ней никого не осталось.
+// Если при move сбой случился между переводом и переназначением, повтор уже не видит участников:
+// их ревью остаются за ними, активными участниками targetTeam.
+func (t *TeamService) Delete(
+	ctx context.Context,
+	teamName string,
+	strategy domain.TeamDeleteStrategy,
+	targetTeam string,
+	force bool,
+) (*domain.Team, error) {
+	team, err := t.Get(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	switch strategy {
+	case domain.TeamDeleteMove:
+		if _, err := t.teamRepo.GetByName(ctx, targetTeam); err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return nil, domain.NewError(domain.ErrorNotFound, "target team not found: "+targetTeam)
+			}
+			return nil, fmt.Errorf("teamRepo.GetByName: %w", err)
+		}
+	case domain.TeamDeleteDeactivate:
+	default:
+		return nil, domain.NewError(domain.ErrorValidation,
+			fmt.Sprintf("unknown team delete strategy %q: must be one of: move, deactivate", strategy))
+	}
+
+	authored, reviewed, err := t.openPullRequests(ctx, team.Members)
+	if err != nil {
+		return nil, err
+	}
+	if !force && (len(authored) > 0 || len(reviewed) > 0) {
+		return nil, domain.NewError(domain.ErrorTeamHasOpenPRs,
+			fmt.Sprintf("team %s members author or review open pull requests: %s",
+				teamName, strings.Join(append(authored, reviewed...), ", ")))
+	}
+	memberIDs := make([]string, 0, len(team.Members))
+	for _, m := range team.Members {
+		memberIDs = append(memberIDs, m.UserID)
+	}
+
+	// До деактивации: неактивных ревьюверов уже не заменить, их ревью просто пропали бы.
+	if strategy == domain.TeamDeleteDeactivate && force {
+		if err := t.releaser.ReleaseReviewers(ctx, memberIDs, true); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, id := range memberIDs {
+		switch strategy {
+		case domain.TeamDeleteMove:
+			if _, err := t.userRepo.SetTeam(ctx, id, targetTeam); err != nil {
+				return nil, fmt.Errorf("userRepo.SetTeam: %w", err)
+			}
+		case domain.TeamDeleteDeactivate:
+			if _, err := t.userRepo.SetActive(ctx, id, false); err != nil {
+				return nil, fmt.Errorf("userRepo.SetActive: %w", err)
+			}
+			if _, err := t.userRepo.SetTeam(ctx, id, ""); err != nil {
+				return nil, fmt.Errorf("userRepo.SetTeam: %w", err)
+			}
+		}
+	}
+
+	// После перевода: замену ищут уже в targetTeam, не среди переводимых.
+	if strategy == domain.TeamDeleteMove && force {
+		if err := t.releaser.ReleaseReviewers(ctx, memberIDs, true); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := t.teamRepo.Delete(ctx, teamName); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, domain.NewError(domain.ErrorNotFound, "team not found: "+teamName)
+		}
+		return nil, fmt.Errorf("teamRepo.Delete: %w", err)
+	}
+	return team, nil
+}
+
+// openPullRequests возвращает ID открытых PR, где участники - авторы и где они ревьюверы.
+// PR, где участники и авторы, и ревьюверы, попадает только в authored.
+func (t *TeamService) openPullRequests(ctx context.Context, members []domain.TeamMember) (authored, reviewed []string, err error) {
+	seen := make(map[string]struct{})
+	add := func(ids []string, prs []domain.PullRequestShort) []string {
+		for _, pr := range prs {
+			if pr.Status != string(domain.StatusOpen) {
+				continue
+			}
+			if _, ok := seen[pr.PullRequestID]; ok {
+				continue
+			}
+			seen[pr.PullRequestID] = struct{}{}
+			ids = append(ids, pr.PullRequestID)
+		}
+		return ids
+	}
+
+	for _, m := range members {
+		prs, err := t.prRepo.ListByAuthor(ctx, m.UserID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("prRepo.ListByAuthor: %w", err)
+		}
+		authored = add(authored, prs)
+	}
+	for _, m := range members {
+		prs, err := t.prRepo.ListByReviewer(ctx, m.UserID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("prRepo.ListByReviewer: %w", err)
+		}
+		reviewed = add(reviewed, prs)
+	}
+	return authored, reviewed, nil
+}
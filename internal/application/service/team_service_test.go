@@ -3,6 +3,9 @@ package service_test
 import (
 	"context"
 	"errors"
+	"slices"
+	"sort"
+	"strings"
 	"testing"
 
 	"pr-reviewer-assigment-service/internal/application/repository"
@@ -48,13 +51,63 @@ func (m *mockTeamRepo) GetByName(ctx context.Context, teamName string) (*domain.
 	return &cp, nil
 }
 
+func (m *mockTeamRepo) Rename(ctx context.Context, oldName, newName string) error {
+	t, ok := m.data[oldName]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	if _, ok := m.data[newName]; ok {
+		return repository.ErrAlreadyExists
+	}
+	delete(m.data, oldName)
+	t.TeamName = newName
+	m.data[newName] = t
+	return nil
+}
+
+func (m *mockTeamRepo) Delete(ctx context.Context, teamName string) error {
+	if _, ok := m.data[teamName]; !ok {
+		return repository.ErrNotFound
+	}
+	delete(m.data, teamName)
+	return nil
+}
+
+func (m *mockTeamRepo) List(ctx context.Context, filter repository.TeamListFilter) ([]domain.TeamSummary, int, error) {
+	names := make([]string, 0, len(m.data))
+	for name := range m.data {
+		if strings.HasPrefix(strings.ToLower(name), strings.ToLower(filter.Prefix)) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	res := make([]domain.TeamSummary, 0)
+	for i := filter.Offset; i < len(names) && len(res) < filter.Limit; i++ {
+		t := m.data[names[i]]
+		s := domain.TeamSummary{TeamName: t.TeamName, MemberCount: len(t.Members)}
+		for _, member := range t.Members {
+			if member.IsActive {
+				s.ActiveMemberCount++
+			}
+		}
+		res = append(res, s)
+	}
+
+	return res, len(names), nil
+}
+
+func newTeamService(userRepo *mockUserRepo, teamRepo *mockTeamRepo, prRepo *mockPRRepo) *service.TeamService {
+	return service.NewTeamService(userRepo, teamRepo, prRepo, service.NewPullRequestService(prRepo, userRepo, teamRepo, newMockCodeOwnerRepo(), newMockRuleRepo(), newFakeClock()))
+}
+
 func TestTeamService_Add_Success(t *testing.T) {
 	ctx := context.Background()
 
 	userRepo := newMockUserRepo()
 	teamRepo := newMockTeamRepo()
 
-	svc := service.NewTeamService(userRepo, teamRepo)
+	svc := newTeamService(userRepo, teamRepo, newMockPRRepo())
 
 	members := []domain.TeamMember{
 		{UserID: "u1", Username: "Alice", IsActive: true},
@@ -116,7 +169,7 @@ func TestTeamService_Add_TeamAlreadyExists(t *testing.T) {
 		},
 	}
 
-	svc := service.NewTeamService(userRepo, teamRepo)
+	svc := newTeamService(userRepo, teamRepo, newMockPRRepo())
 
 	members := []domain.TeamMember{
 		{UserID: "u2", Username: "Bob", IsActive: true},
@@ -155,7 +208,7 @@ func TestTeamService_Get_Success(t *testing.T) {
 		},
 	}
 
-	svc := service.NewTeamService(userRepo, teamRepo)
+	svc := newTeamService(userRepo, teamRepo, newMockPRRepo())
 
 	team, err := svc.Get(ctx, "backend")
 	if err != nil {
@@ -181,7 +234,7 @@ func TestTeamService_Get_NotFound(t *testing.T) {
 	userRepo := newMockUserRepo()
 	teamRepo := newMockTeamRepo()
 
-	svc := service.NewTeamService(userRepo, teamRepo)
+	svc := newTeamService(userRepo, teamRepo, newMockPRRepo())
 
 	_, err := svc.Get(ctx, "unknown")
 	if err == nil {
@@ -197,3 +250,530 @@ func TestTeamService_Get_NotFound(t *testing.T) {
 		t.Fatalf("expected error code NOT_FOUND, got %s", dErr.Code)
 	}
 }
+
+func TestTeamService_List(t *testing.T) {
+	ctx := context.Background()
+
+	teamRepo := newMockTeamRepo()
+	teamRepo.data["backend"] = domain.Team{
+		TeamName: "backend",
+		Members: []domain.TeamMember{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: false},
+		},
+	}
+	teamRepo.data["billing"] = domain.Team{TeamName: "billing"}
+	teamRepo.data["frontend"] = domain.Team{TeamName: "frontend"}
+
+	svc := newTeamService(newMockUserRepo(), teamRepo, newMockPRRepo())
+
+	teams, total, err := svc.List(ctx, "B", 1, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if total != 2 {
+		t.Fatalf("expected total 2, got %d", total)
+	}
+
+	want := domain.TeamSummary{TeamName: "backend", MemberCount: 2, ActiveMemberCount: 1}
+	if len(teams) != 1 || teams[0] != want {
+		t.Fatalf("expected [%+v], got %+v", want, teams)
+	}
+}
+
+// failingTeamRepo возвращает ошибку хранилища при чтении команды.
+type failingTeamRepo struct {
+	*mockTeamRepo
+	err error
+}
+
+func (m *failingTeamRepo) GetByName(ctx context.Context, teamName string) (*domain.Team, error) {
+	return nil, m.err
+}
+
+func TestTeamService_DeactivateMembers_LookupError(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newMockUserRepo()
+	prRepo := newMockPRRepo()
+	teamRepo := &failingTeamRepo{mockTeamRepo: newMockTeamRepo(), err: errors.New("connection reset")}
+
+	svc := service.NewTeamService(userRepo, teamRepo, prRepo,
+		service.NewPullRequestService(prRepo, userRepo, teamRepo, newMockCodeOwnerRepo(), newMockRuleRepo(), newFakeClock()))
+
+	err := svc.DeactivateMembers(ctx, "backend")
+	if !errors.Is(err, teamRepo.err) {
+		t.Fatalf("expected storage error, got %v", err)
+	}
+}
+
+func TestTeamService_AddMembers_UserInOtherTeam(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newMockUserRepo()
+	teamRepo := newMockTeamRepo()
+
+	teamRepo.data["backend"] = domain.Team{TeamName: "backend"}
+	userRepo.data["u1"] = domain.User{UserID: "u1", Username: "Alice", TeamName: "frontend", IsActive: true}
+
+	svc := newTeamService(userRepo, teamRepo, newMockPRRepo())
+
+	_, err := svc.AddMembers(ctx, "backend", []domain.TeamMember{
+		{UserID: "u2", Username: "Bob", IsActive: true},
+		{UserID: "u1", Username: "Alice", IsActive: true},
+	})
+
+	var derr *domain.Error
+	if !errors.As(err, &derr) || derr.Code != domain.ErrorUserInOtherTeam {
+		t.Fatalf("expected USER_IN_OTHER_TEAM, got %v", err)
+	}
+	if _, ok := userRepo.data["u2"]; ok {
+		t.Fatalf("expected no users to be added")
+	}
+}
+
+func TestTeamService_RemoveMembers_HasOpenReviews(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newMockUserRepo()
+	teamRepo := newMockTeamRepo()
+	prRepo := newMockPRRepo()
+
+	userRepo.data["u2"] = domain.User{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true}
+	teamRepo.data["backend"] = domain.Team{
+		TeamName: "backend",
+		Members:  []domain.TeamMember{{UserID: "u2", Username: "Bob", IsActive: true}},
+	}
+	prRepo.data["pr-1"] = domain.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u1",
+		Status:            "OPEN",
+		AssignedReviewers: []string{"u2"},
+	}
+
+	svc := newTeamService(userRepo, teamRepo, prRepo)
+
+	_, err := svc.RemoveMembers(ctx, "backend", []string{"u2"}, false)
+
+	var derr *domain.Error
+	if !errors.As(err, &derr) || derr.Code != domain.ErrorHasOpenReviews {
+		t.Fatalf("expected HAS_OPEN_REVIEWS, got %v", err)
+	}
+	if userRepo.data["u2"].TeamName != "backend" {
+		t.Fatalf("expected u2 to stay in backend")
+	}
+}
+
+func TestTeamService_RemoveMembers_Reassign(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newMockUserRepo()
+	teamRepo := newMockTeamRepo()
+	prRepo := newMockPRRepo()
+
+	userRepo.data["u2"] = domain.User{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true}
+	userRepo.data["u3"] = domain.User{UserID: "u3", Username: "Charlie", TeamName: "backend", IsActive: true}
+	teamRepo.data["backend"] = domain.Team{
+		TeamName: "backend",
+		Members: []domain.TeamMember{
+			{UserID: "u2", Username: "Bob", IsActive: true},
+			{UserID: "u3", Username: "Charlie", IsActive: true},
+		},
+	}
+	prRepo.data["pr-1"] = domain.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u1",
+		Status:            "OPEN",
+		AssignedReviewers: []string{"u2"},
+	}
+	prRepo.data["pr-2"] = domain.PullRequest{
+		PullRequestID:     "pr-2",
+		AuthorID:          "u1",
+		Status:            "MERGED",
+		AssignedReviewers: []string{"u2"},
+	}
+
+	svc := newTeamService(userRepo, teamRepo, prRepo)
+
+	if _, err := svc.RemoveMembers(ctx, "backend", []string{"u2"}, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if userRepo.data["u2"].TeamName != "" {
+		t.Fatalf("expected u2 to leave the team, got %s", userRepo.data["u2"].TeamName)
+	}
+	if got := prRepo.data["pr-1"].AssignedReviewers; len(got) != 1 || got[0] != "u3" {
+		t.Fatalf("expected open review reassigned to u3, got %v", got)
+	}
+	if got := prRepo.data["pr-2"].AssignedReviewers; len(got) != 1 || got[0] != "u2" {
+		t.Fatalf("expected merged PR untouched, got %v", got)
+	}
+}
+
+// historyPRRepo запоминает ревьюверов PR после каждого Update.
+type historyPRRepo struct {
+	*mockPRRepo
+	assigned [][]string
+}
+
+func (m *historyPRRepo) Update(ctx context.Context, pr *domain.PullRequest) error {
+	m.assigned = append(m.assigned, slices.Clone(pr.AssignedReviewers))
+	return m.mockPRRepo.Update(ctx, pr)
+}
+
+func TestTeamService_RemoveMembers_ReassignSkipsRemovedUsers(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newMockUserRepo()
+	teamRepo := newMockTeamRepo()
+	prRepo := &historyPRRepo{mockPRRepo: newMockPRRepo()}
+
+	for _, id := range []string{"u2", "u3", "u4"} {
+		userRepo.data[id] = domain.User{UserID: id, Username: id, TeamName: "backend", IsActive: true}
+	}
+	teamRepo.data["backend"] = domain.Team{
+		TeamName: "backend",
+		Members: []domain.TeamMember{
+			{UserID: "u2", Username: "u2", IsActive: true},
+			{UserID: "u3", Username: "u3", IsActive: true},
+			{UserID: "u4", Username: "u4", IsActive: true},
+		},
+	}
+	prRepo.data["pr-1"] = domain.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u1",
+		Status:            "OPEN",
+		AssignedReviewers: []string{"u2"},
+	}
+
+	svc := service.NewTeamService(userRepo, teamRepo, prRepo,
+		service.NewPullRequestService(prRepo, userRepo, teamRepo, newMockCodeOwnerRepo(), newMockRuleRepo(), newFakeClock()))
+
+	if _, err := svc.RemoveMembers(ctx, "backend", []string{"u2", "u3"}, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// u3 уходит вместе с u2 и не должен получить его ревью даже на время.
+	want := [][]string{{"u4"}}
+	if !slices.EqualFunc(prRepo.assigned, want, slices.Equal) {
+		t.Fatalf("expected single reassignment to u4, got %v", prRepo.assigned)
+	}
+}
+
+func TestTeamService_RemoveMembers_NotMember(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newMockUserRepo()
+	teamRepo := newMockTeamRepo()
+	teamRepo.data["backend"] = domain.Team{TeamName: "backend"}
+
+	svc := newTeamService(userRepo, teamRepo, newMockPRRepo())
+
+	_, err := svc.RemoveMembers(ctx, "backend", []string{"u9"}, true)
+
+	var derr *domain.Error
+	if !errors.As(err, &derr) || derr.Code != domain.ErrorNotFound {
+		t.Fatalf("expected NOT_FOUND, got %v", err)
+	}
+}
+
+func TestTeamService_Rename(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newMockUserRepo()
+	teamRepo := newMockTeamRepo()
+	teamRepo.data["backend"] = domain.Team{TeamName: "backend"}
+	teamRepo.data["payments"] = domain.Team{TeamName: "payments"}
+
+	svc := newTeamService(userRepo, teamRepo, newMockPRRepo())
+
+	var derr *domain.Error
+	if _, err := svc.Rename(ctx, "backend", "payments"); !errors.As(err, &derr) || derr.Code != domain.ErrorTeamExists {
+		t.Fatalf("expected TEAM_EXISTS, got %v", err)
+	}
+	if _, err := svc.Rename(ctx, "missing", "core"); !errors.As(err, &derr) || derr.Code != domain.ErrorNotFound {
+		t.Fatalf("expected NOT_FOUND, got %v", err)
+	}
+
+	team, err := svc.Rename(ctx, "backend", "core")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if team.TeamName != "core" {
+		t.Fatalf("expected core, got %s", team.TeamName)
+	}
+}
+
+func TestTeamService_Delete_RefusesWithOpenPRs(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newMockUserRepo()
+	teamRepo := newMockTeamRepo()
+	prRepo := newMockPRRepo()
+
+	userRepo.data["u1"] = domain.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
+	teamRepo.data["backend"] = domain.Team{
+		TeamName: "backend",
+		Members:  []domain.TeamMember{{UserID: "u1", Username: "Alice", IsActive: true}},
+	}
+	prRepo.data["pr-1"] = domain.PullRequest{PullRequestID: "pr-1", AuthorID: "u1", Status: "OPEN"}
+
+	svc := newTeamService(userRepo, teamRepo, prRepo)
+
+	_, err := svc.Delete(ctx, "backend", domain.TeamDeleteDeactivate, "", false)
+
+	var derr *domain.Error
+	if !errors.As(err, &derr) || derr.Code != domain.ErrorTeamHasOpenPRs {
+		t.Fatalf("expected TEAM_HAS_OPEN_PRS, got %v", err)
+	}
+	if _, ok := teamRepo.data["backend"]; !ok {
+		t.Fatalf("expected team to remain")
+	}
+	if !userRepo.data["u1"].IsActive {
+		t.Fatalf("expected members untouched")
+	}
+}
+
+func TestTeamService_Delete_ForceDeactivate(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newMockUserRepo()
+	teamRepo := newMockTeamRepo()
+	prRepo := newMockPRRepo()
+
+	userRepo.data["u1"] = domain.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
+	userRepo.data["u2"] = domain.User{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true}
+	userRepo.data["u3"] = domain.User{UserID: "u3", Username: "Carol", TeamName: "frontend", IsActive: true}
+	userRepo.data["u4"] = domain.User{UserID: "u4", Username: "Dave", TeamName: "frontend", IsActive: true}
+	teamRepo.data["backend"] = domain.Team{
+		TeamName: "backend",
+		Members: []domain.TeamMember{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: true},
+		},
+	}
+	teamRepo.data["frontend"] = domain.Team{TeamName: "frontend"}
+	// Участники удаляемой команды ревьюят PR другой команды (например, как владельцы кода).
+	prRepo.data["pr-1"] = domain.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u3",
+		Status:            "OPEN",
+		AssignedReviewers: []string{"u1", "u2"},
+	}
+
+	svc := newTeamService(userRepo, teamRepo, prRepo)
+
+	if _, err := svc.Delete(ctx, "backend", domain.TeamDeleteDeactivate, "", true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, ok := teamRepo.data["backend"]; ok {
+		t.Fatalf("expected team to be deleted")
+	}
+	for _, id := range []string{"u1", "u2"} {
+		if u := userRepo.data[id]; u.IsActive || u.TeamName != "" {
+			t.Fatalf("expected %s deactivated without team, got %+v", id, u)
+		}
+	}
+	// Одно ревью уходит u4 из команды автора, на второе кандидатов больше нет.
+	if got := prRepo.data["pr-1"].AssignedReviewers; !slices.Equal(got, []string{"u4"}) {
+		t.Fatalf("expected reviews reassigned to u4, got %v", got)
+	}
+}
+
+func TestTeamService_Delete_ForceDeactivateWithAuthoredPRs(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newMockUserRepo()
+	teamRepo := newMockTeamRepo()
+	prRepo := newMockPRRepo()
+
+	userRepo.data["u1"] = domain.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
+	userRepo.data["u2"] = domain.User{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true}
+	teamRepo.data["backend"] = domain.Team{
+		TeamName: "backend",
+		Members: []domain.TeamMember{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: true},
+		},
+	}
+	prRepo.data["pr-1"] = domain.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u1",
+		Status:            "OPEN",
+		AssignedReviewers: []string{"u2"},
+	}
+
+	svc := newTeamService(userRepo, teamRepo, prRepo)
+
+	if _, err := svc.Delete(ctx, "backend", domain.TeamDeleteDeactivate, "", true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, ok := teamRepo.data["backend"]; ok {
+		t.Fatalf("expected team to be deleted")
+	}
+	for _, id := range []string{"u1", "u2"} {
+		if u := userRepo.data[id]; u.IsActive || u.TeamName != "" {
+			t.Fatalf("expected %s deactivated without team, got %+v", id, u)
+		}
+	}
+	// PR автора остаётся открытым, а ревью снимается: замены нет ни в одной команде.
+	pr := prRepo.data["pr-1"]
+	if pr.Status != "OPEN" || len(pr.AssignedReviewers) != 0 {
+		t.Fatalf("expected open PR without reviewers, got %+v", pr)
+	}
+}
+
+func TestTeamService_Delete_ForceMoveReassignsReviews(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newMockUserRepo()
+	teamRepo := newMockTeamRepo()
+	prRepo := newMockPRRepo()
+
+	userRepo.data["u1"] = domain.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
+	userRepo.data["u2"] = domain.User{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true}
+	userRepo.data["u3"] = domain.User{UserID: "u3", Username: "Carol", TeamName: "payments", IsActive: true}
+	teamRepo.data["backend"] = domain.Team{
+		TeamName: "backend",
+		Members: []domain.TeamMember{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: true},
+		},
+	}
+	teamRepo.data["payments"] = domain.Team{TeamName: "payments"}
+	prRepo.data["pr-1"] = domain.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u1",
+		Status:            "OPEN",
+		AssignedReviewers: []string{"u2"},
+	}
+
+	svc := newTeamService(userRepo, teamRepo, prRepo)
+
+	if _, err := svc.Delete(ctx, "backend", domain.TeamDeleteMove, "payments", true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, id := range []string{"u1", "u2"} {
+		if u := userRepo.data[id]; !u.IsActive || u.TeamName != "payments" {
+			t.Fatalf("expected %s moved to payments, got %+v", id, u)
+		}
+	}
+	// Замена берётся из targetTeam, но не из переводимых участников.
+	if got := prRepo.data["pr-1"].AssignedReviewers; !slices.Equal(got, []string{"u3"}) {
+		t.Fatalf("expected review reassigned to u3, got %v", got)
+	}
+}
+
+func TestTeamService_Delete_UnknownStrategy(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newMockUserRepo()
+	teamRepo := newMockTeamRepo()
+
+	userRepo.data["u1"] = domain.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
+	teamRepo.data["backend"] = domain.Team{
+		TeamName: "backend",
+		Members:  []domain.TeamMember{{UserID: "u1", Username: "Alice", IsActive: true}},
+	}
+
+	svc := newTeamService(userRepo, teamRepo, newMockPRRepo())
+
+	_, err := svc.Delete(ctx, "backend", domain.TeamDeleteStrategy("archive"), "", true)
+
+	var derr *domain.Error
+	if !errors.As(err, &derr) || derr.Code != domain.ErrorValidation {
+		t.Fatalf("expected VALIDATION_ERROR, got %v", err)
+	}
+	if _, ok := teamRepo.data["backend"]; !ok {
+		t.Fatalf("expected team to remain")
+	}
+}
+
+// flakyTeamRepo один раз не удаляет команду, как при обрыве соединения.
+type flakyTeamRepo struct {
+	*mockTeamRepo
+	failed bool
+}
+
+func (m *flakyTeamRepo) Delete(ctx context.Context, teamName string) error {
+	if !m.failed {
+		m.failed = true
+		return errors.New("connection reset")
+	}
+	return m.mockTeamRepo.Delete(ctx, teamName)
+}
+
+func TestTeamService_Delete_RetryAfterFailure(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newMockUserRepo()
+	teamRepo := &flakyTeamRepo{mockTeamRepo: newMockTeamRepo()}
+	prRepo := newMockPRRepo()
+
+	userRepo.data["u1"] = domain.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
+	teamRepo.data["backend"] = domain.Team{
+		TeamName: "backend",
+		Members:  []domain.TeamMember{{UserID: "u1", Username: "Alice", IsActive: true}},
+	}
+	prRepo.data["pr-1"] = domain.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u9",
+		Status:            "OPEN",
+		AssignedReviewers: []string{"u1"},
+	}
+
+	svc := service.NewTeamService(userRepo, teamRepo, prRepo,
+		service.NewPullRequestService(prRepo, userRepo, teamRepo, newMockCodeOwnerRepo(), newMockRuleRepo(), newFakeClock()))
+
+	if _, err := svc.Delete(ctx, "backend", domain.TeamDeleteDeactivate, "", true); err == nil {
+		t.Fatalf("expected first attempt to fail")
+	}
+	// Повтор без force проходит: ревью уже освобождены, участник уже деактивирован.
+	if _, err := svc.Delete(ctx, "backend", domain.TeamDeleteDeactivate, "", false); err != nil {
+		t.Fatalf("unexpected error on retry: %v", err)
+	}
+
+	if _, ok := teamRepo.data["backend"]; ok {
+		t.Fatalf("expected team to be deleted on retry")
+	}
+	if got := prRepo.data["pr-1"].AssignedReviewers; len(got) != 0 {
+		t.Fatalf("expected review released, got %v", got)
+	}
+}
+
+func TestTeamService_Delete_MoveToTargetTeam(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newMockUserRepo()
+	teamRepo := newMockTeamRepo()
+	prRepo := newMockPRRepo()
+
+	userRepo.data["u1"] = domain.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
+	teamRepo.data["backend"] = domain.Team{
+		TeamName: "backend",
+		Members:  []domain.TeamMember{{UserID: "u1", Username: "Alice", IsActive: true}},
+	}
+	teamRepo.data["payments"] = domain.Team{TeamName: "payments"}
+
+	svc := newTeamService(userRepo, teamRepo, prRepo)
+
+	var derr *domain.Error
+	if _, err := svc.Delete(ctx, "backend", domain.TeamDeleteMove, "missing", false); !errors.As(err, &derr) || derr.Code != domain.ErrorNotFound {
+		t.Fatalf("expected NOT_FOUND for missing target, got %v", err)
+	}
+
+	team, err := svc.Delete(ctx, "backend", domain.TeamDeleteMove, "payments", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(team.Members) != 1 {
+		t.Fatalf("expected deleted team snapshot with 1 member, got %+v", team)
+	}
+	if u := userRepo.data["u1"]; u.TeamName != "payments" || !u.IsActive {
+		t.Fatalf("expected u1 moved to payments, got %+v", u)
+	}
+}
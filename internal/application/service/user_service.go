@@ -4,22 +4,33 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+
 	"pr-reviewer-assigment-service/internal/application/repository"
 	"pr-reviewer-assigment-service/internal/domain"
 )
 
+// UserListFilter - параметры выборки списка пользователей.
+type UserListFilter = repository.UserListFilter
+
 type UserService struct {
 	userRepo repository.UserRepository
 	prRepo   repository.PullRequestRepository
+	teamRepo repository.TeamRepository
+	releaser ReviewerReleaser
 }
 
 func NewUserService(
 	userRepository repository.UserRepository,
 	prRepository repository.PullRequestRepository,
+	teamRepository repository.TeamRepository,
+	releaser ReviewerReleaser,
 ) *UserService {
 	return &UserService{
 		userRepo: userRepository,
 		prRepo:   prRepository,
+		teamRepo: teamRepository,
+		releaser: releaser,
 	}
 }
 
@@ -35,6 +46,89 @@ func (s *UserService) SetIsActive(ctx context.Context, userID string, isActive b
 	return user, nil
 }
 
+// Get возвращает пользователя вместе с числом открытых ревью.
+func (s *UserService) Get(ctx context.Context, userID string) (*domain.UserSummary, error) {
+	user, err := s.userRepo.GetSummary(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, domain.NewError(domain.ErrorNotFound, "user not found: "+userID)
+		}
+		return nil, fmt.Errorf("userRepo.GetSummary: %w", err)
+	}
+	return user, nil
+}
+
+// TeamOf возвращает текущую команду пользователя - по ней определяется, какой командой руководит team-lead.
+// Пользователь вне команды даёт пустую строку.
+func (s *UserService) TeamOf(ctx context.Context, userID string) (string, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return "", domain.NewError(domain.ErrorNotFound, "user not found: "+userID)
+		}
+		return "", fmt.Errorf("userRepo.GetByID: %w", err)
+	}
+	return user.TeamName, nil
+}
+
+// List возвращает страницу пользователей и user_id, после которого начинается следующая страница.
+// Пустой next означает, что страница последняя. Limit меньше 1 - ошибка VALIDATION_ERROR.
+func (s *UserService) List(ctx context.Context, filter UserListFilter) ([]domain.UserSummary, string, error) {
+	limit := filter.Limit
+	if limit < 1 {
+		return nil, "", domain.NewError(domain.ErrorValidation, fmt.Sprintf("limit must be positive, got %d", limit))
+	}
+	filter.Limit = limit + 1 // лишняя запись показывает, есть ли следующая страница
+
+	users, err := s.userRepo.List(ctx, filter)
+	if err != nil {
+		return nil, "", fmt.Errorf("userRepo.List: %w", err)
+	}
+
+	if len(users) <= limit {
+		return users, "", nil
+	}
+	users = users[:limit]
+	return users, users[limit-1].UserID, nil
+}
+
+// SetMaxOpenReviews задаёт лимит открытых ревью пользователя. nil снимает лимит.
+// Уже назначенные ревью сверх нового лимита не снимаются.
+func (s *UserService) SetMaxOpenReviews(ctx context.Context, userID string, limit *int) (*domain.User, error) {
+	user, err := s.userRepo.SetMaxOpenReviews(ctx, userID, limit)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, domain.NewError(domain.ErrorNotFound, "user not found: "+userID)
+		}
+		return nil, fmt.Errorf("userRepo.SetMaxOpenReviews: %w", err)
+	}
+	return user, nil
+}
+
+// SetSkills заменяет теги экспертизы пользователя.
+// Теги приводятся к нижнему регистру, повторы отбрасываются; пустой список очищает навыки.
+func (s *UserService) SetSkills(ctx context.Context, userID string, skills []string) (*domain.User, error) {
+	normalized := make([]string, 0, len(skills))
+	seen := make(map[string]struct{}, len(skills))
+	for _, skill := range skills {
+		skill = strings.ToLower(strings.TrimSpace(skill))
+		if _, ok := seen[skill]; ok || skill == "" {
+			continue
+		}
+		seen[skill] = struct{}{}
+		normalized = append(normalized, skill)
+	}
+
+	user, err := s.userRepo.SetSkills(ctx, userID, normalized)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, domain.NewError(domain.ErrorNotFound, "user not found: "+userID)
+		}
+		return nil, fmt.Errorf("userRepo.SetSkills: %w", err)
+	}
+	return user, nil
+}
+
 // GetReview возвращает список PR'ов, где пользователь назначен ревьювером.
 func (s *UserService) GetReview(ctx context.Context, userID string) (string, []domain.PullRequestShort, error) {
 	_, err := s.userRepo.GetByID(ctx, userID)
@@ -50,3 +144,39 @@ func (s *UserService) GetReview(ctx context.Context, userID string) (string, []d
 	}
 	return userID, prs, nil
 }
+
+// MoveTeam переводит пользователя в другую команду.
+// Открытые ревью в старой команде переназначаются при reassign, иначе перевод отклоняется (HAS_OPEN_REVIEWS).
+func (s *UserService) MoveTeam(ctx context.Context, userID string, teamName string, reassign bool) (*domain.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, domain.NewError(domain.ErrorNotFound, "user not found: "+userID)
+		}
+		return nil, fmt.Errorf("userRepo.GetByID: %w", err)
+	}
+
+	if _, err := s.teamRepo.GetByName(ctx, teamName); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, domain.NewError(domain.ErrorNotFound, "team not found: "+teamName)
+		}
+		return nil, fmt.Errorf("teamRepo.GetByName: %w", err)
+	}
+
+	if user.TeamName == teamName {
+		return user, nil
+	}
+
+	if err := s.releaser.ReleaseReviewer(ctx, userID, reassign); err != nil {
+		return nil, err
+	}
+
+	moved, err := s.userRepo.SetTeam(ctx, userID, teamName)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, domain.NewError(domain.ErrorNotFound, "user not found: "+userID)
+		}
+		return nil, fmt.Errorf("userRepo.SetTeam: %w", err)
+	}
+	return moved, nil
+}
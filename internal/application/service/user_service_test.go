@@ -3,7 +3,10 @@ package service_test
 import (
 	"context"
 	"errors"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 
 	"pr-reviewer-assigment-service/internal/application/repository"
 	"pr-reviewer-assigment-service/internal/application/service"
@@ -11,7 +14,8 @@ import (
 )
 
 type mockUserRepo struct {
-	data map[string]domain.User
+	data        map[string]domain.User
+	unavailable []domain.Unavailability
 }
 
 func newMockUserRepo() *mockUserRepo {
@@ -44,23 +48,108 @@ func (m *mockUserRepo) SetActive(ctx context.Context, userID string, active bool
 	return &user, nil
 }
 
-func (m *mockUserRepo) ListByTeam(ctx context.Context, teamName string, onlyActive bool) ([]domain.User, error) {
+func (m *mockUserRepo) SetTeam(ctx context.Context, userID string, teamName string) (*domain.User, error) {
+	user, ok := m.data[userID]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	user.TeamName = teamName
+	m.data[userID] = user
+	return &user, nil
+}
+
+func (m *mockUserRepo) SetMaxOpenReviews(ctx context.Context, userID string, limit *int) (*domain.User, error) {
+	user, ok := m.data[userID]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	user.MaxOpenReviews = limit
+	m.data[userID] = user
+	return &user, nil
+}
+
+func (m *mockUserRepo) SetSkills(ctx context.Context, userID string, skills []string) (*domain.User, error) {
+	user, ok := m.data[userID]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	user.Skills = skills
+	m.data[userID] = user
+	return &user, nil
+}
+
+func (m *mockUserRepo) ListByIDs(ctx context.Context, userIDs []string, onlyActive bool, now time.Time) ([]domain.User, error) {
+	users := make([]domain.User, 0, len(userIDs))
+	for _, id := range userIDs {
+		if user, ok := m.data[id]; ok && (!onlyActive || m.available(user, now)) {
+			users = append(users, user)
+		}
+	}
+	return users, nil
+}
+
+func (m *mockUserRepo) ListByTeam(ctx context.Context, teamName string, onlyActive bool, now time.Time) ([]domain.User, error) {
 	users := make([]domain.User, 0)
 	for _, user := range m.data {
-		if user.TeamName == teamName {
+		if user.TeamName == teamName && (!onlyActive || m.available(user, now)) {
 			users = append(users, user)
 		}
 	}
 	return users, nil
 }
 
+// available повторяет условие доступности репозиториев: активен и в момент now не в периоде недоступности.
+func (m *mockUserRepo) available(user domain.User, now time.Time) bool {
+	if !user.IsActive {
+		return false
+	}
+	for _, period := range m.unavailable {
+		if period.UserID == user.UserID && period.Covers(now) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *mockUserRepo) GetSummary(ctx context.Context, userID string) (*domain.UserSummary, error) {
+	user, ok := m.data[userID]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &domain.UserSummary{User: user}, nil
+}
+
+func (m *mockUserRepo) List(ctx context.Context, filter repository.UserListFilter) ([]domain.UserSummary, error) {
+	ids := make([]string, 0, len(m.data))
+	for id, user := range m.data {
+		if id <= filter.AfterUserID ||
+			(filter.TeamName != "" && user.TeamName != filter.TeamName) ||
+			(filter.IsActive != nil && user.IsActive != *filter.IsActive) ||
+			!strings.Contains(strings.ToLower(user.Username), strings.ToLower(filter.Username)) {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	result := make([]domain.UserSummary, 0)
+	for _, id := range ids {
+		if len(result) == filter.Limit {
+			break
+		}
+		result = append(result, domain.UserSummary{User: m.data[id]})
+	}
+	return result, nil
+}
+
 func TestUserService_SetIsActive_UserNotFound(t *testing.T) {
 	ctx := context.Background()
 
 	userRepo := newMockUserRepo()
 	prRepo := newMockPRRepo()
+	teamRepo := newMockTeamRepo()
 
-	svc := service.NewUserService(userRepo, prRepo)
+	svc := service.NewUserService(userRepo, prRepo, teamRepo, service.NewPullRequestService(prRepo, userRepo, teamRepo, newMockCodeOwnerRepo(), newMockRuleRepo(), newFakeClock()))
 
 	_, err := svc.SetIsActive(ctx, "nope", false)
 	if err == nil {
@@ -82,7 +171,8 @@ func TestUserService_SetIsActive_Success(t *testing.T) {
 
 	userRepo := newMockUserRepo()
 	prRepo := newMockPRRepo()
-	svc := service.NewUserService(userRepo, prRepo)
+	teamRepo := newMockTeamRepo()
+	svc := service.NewUserService(userRepo, prRepo, teamRepo, service.NewPullRequestService(prRepo, userRepo, teamRepo, newMockCodeOwnerRepo(), newMockRuleRepo(), newFakeClock()))
 
 	userRepo.data["u1"] = domain.User{
 		UserID:   "u1",
@@ -110,8 +200,9 @@ func TestUserService_GetReview_UserNotFound(t *testing.T) {
 
 	userRepo := newMockUserRepo()
 	prRepo := newMockPRRepo()
+	teamRepo := newMockTeamRepo()
 
-	svc := service.NewUserService(userRepo, prRepo)
+	svc := service.NewUserService(userRepo, prRepo, teamRepo, service.NewPullRequestService(prRepo, userRepo, teamRepo, newMockCodeOwnerRepo(), newMockRuleRepo(), newFakeClock()))
 
 	_, _, err := svc.GetReview(ctx, "ghost")
 	if err == nil {
@@ -133,8 +224,9 @@ func TestUserService_GetReview_Success(t *testing.T) {
 
 	userRepo := newMockUserRepo()
 	prRepo := newMockPRRepo()
+	teamRepo := newMockTeamRepo()
 
-	svc := service.NewUserService(userRepo, prRepo)
+	svc := service.NewUserService(userRepo, prRepo, teamRepo, service.NewPullRequestService(prRepo, userRepo, teamRepo, newMockCodeOwnerRepo(), newMockRuleRepo(), newFakeClock()))
 
 	userRepo.data["u1"] = domain.User{
 		UserID:   "u1",
@@ -172,3 +264,141 @@ func TestUserService_GetReview_Success(t *testing.T) {
 		t.Fatalf("expected 2 PRs, got %d", len(prs))
 	}
 }
+
+func TestUserService_MoveTeam_ReassignsOpenReviews(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newMockUserRepo()
+	prRepo := newMockPRRepo()
+	teamRepo := newMockTeamRepo()
+
+	teamRepo.data["backend"] = domain.Team{TeamName: "backend"}
+	teamRepo.data["frontend"] = domain.Team{TeamName: "frontend"}
+	userRepo.data["u2"] = domain.User{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true}
+	prRepo.data["pr-1"] = domain.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u1",
+		Status:            "OPEN",
+		AssignedReviewers: []string{"u2", "u3"},
+	}
+
+	svc := service.NewUserService(userRepo, prRepo, teamRepo, service.NewPullRequestService(prRepo, userRepo, teamRepo, newMockCodeOwnerRepo(), newMockRuleRepo(), newFakeClock()))
+
+	if _, err := svc.MoveTeam(ctx, "u2", "frontend", false); err == nil {
+		t.Fatalf("expected HAS_OPEN_REVIEWS without reassign")
+	}
+
+	user, err := svc.MoveTeam(ctx, "u2", "frontend", true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user.TeamName != "frontend" {
+		t.Fatalf("expected frontend, got %s", user.TeamName)
+	}
+	// В backend нет других кандидатов - u2 просто снимается с ревью.
+	if got := prRepo.data["pr-1"].AssignedReviewers; len(got) != 1 || got[0] != "u3" {
+		t.Fatalf("expected only u3 to stay assigned, got %v", got)
+	}
+}
+
+func TestUserService_MoveTeam_TeamNotFound(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newMockUserRepo()
+	prRepo := newMockPRRepo()
+	teamRepo := newMockTeamRepo()
+
+	userRepo.data["u2"] = domain.User{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true}
+
+	svc := service.NewUserService(userRepo, prRepo, teamRepo, service.NewPullRequestService(prRepo, userRepo, teamRepo, newMockCodeOwnerRepo(), newMockRuleRepo(), newFakeClock()))
+
+	_, err := svc.MoveTeam(ctx, "u2", "missing", true)
+
+	var derr *domain.Error
+	if !errors.As(err, &derr) || derr.Code != domain.ErrorNotFound {
+		t.Fatalf("expected NOT_FOUND, got %v", err)
+	}
+}
+
+func TestUserService_List_Pagination(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newMockUserRepo()
+	prRepo := newMockPRRepo()
+	teamRepo := newMockTeamRepo()
+	svc := service.NewUserService(userRepo, prRepo, teamRepo, service.NewPullRequestService(prRepo, userRepo, teamRepo, newMockCodeOwnerRepo(), newMockRuleRepo(), newFakeClock()))
+
+	for _, u := range []domain.User{
+		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: false},
+		{UserID: "u3", Username: "Carol", TeamName: "backend", IsActive: true},
+		{UserID: "u4", Username: "Dave", TeamName: "frontend", IsActive: true},
+	} {
+		userRepo.data[u.UserID] = u
+	}
+
+	active := true
+	filter := service.UserListFilter{TeamName: "backend", IsActive: &active, Limit: 1}
+
+	page, next, err := svc.List(ctx, filter)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page) != 1 || page[0].UserID != "u1" || next != "u1" {
+		t.Fatalf("expected [u1] with next u1, got %+v next %q", page, next)
+	}
+
+	filter.AfterUserID = next
+	page, next, err = svc.List(ctx, filter)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page) != 1 || page[0].UserID != "u3" || next != "" {
+		t.Fatalf("expected last page [u3], got %+v next %q", page, next)
+	}
+}
+
+func TestUserService_List_RejectsNonPositiveLimit(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newMockUserRepo()
+	prRepo := newMockPRRepo()
+	teamRepo := newMockTeamRepo()
+	svc := service.NewUserService(userRepo, prRepo, teamRepo, service.NewPullRequestService(prRepo, userRepo, teamRepo, newMockCodeOwnerRepo(), newMockRuleRepo(), newFakeClock()))
+
+	userRepo.data["u1"] = domain.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
+
+	for _, limit := range []int{0, -1} {
+		_, _, err := svc.List(ctx, service.UserListFilter{Limit: limit})
+
+		var derr *domain.Error
+		if !errors.As(err, &derr) || derr.Code != domain.ErrorValidation {
+			t.Fatalf("limit %d: expected VALIDATION_ERROR, got %v", limit, err)
+		}
+	}
+}
+
+func TestUserService_SetSkills_Normalizes(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newMockUserRepo()
+	prRepo := newMockPRRepo()
+	teamRepo := newMockTeamRepo()
+	svc := service.NewUserService(userRepo, prRepo, teamRepo, service.NewPullRequestService(prRepo, userRepo, teamRepo, newMockCodeOwnerRepo(), newMockRuleRepo(), newFakeClock()))
+
+	userRepo.data["u1"] = domain.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
+
+	user, err := svc.SetSkills(ctx, "u1", []string{"Go", "postgres", "go", " Kafka "})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(user.Skills, ",") != "go,postgres,kafka" {
+		t.Fatalf("unexpected skills: %v", user.Skills)
+	}
+
+	_, err = svc.SetSkills(ctx, "nope", nil)
+	var derr *domain.Error
+	if !errors.As(err, &derr) || derr.Code != domain.ErrorNotFound {
+		t.Fatalf("expected NOT_FOUND, got %v", err)
+	}
+}
@@ -3,13 +3,58 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"time"
+)
+
+// Хранилища данных, из которых выбирает STORAGE.
+const (
+	StoragePostgres = "postgres" // PostgreSQL по DATABASE_URL
+	StorageMemory   = "memory"   // Память процесса: без базы, данные теряются при остановке
+	StorageSQLite   = "sqlite"   // Файл SQLite по SQLITE_PATH
 )
 
 // Config содержит все конфигурационные параметры приложения
 type Config struct {
-	HttpPort    string // Порт для HTTP сервера
-	DatabaseURL string // URL для подключения к базе данных
+	HttpPort      string      // Порт для HTTP сервера
+	Storage       string      // Хранилище данных: postgres, memory или sqlite
+	DatabaseURL   string      // URL для подключения к базе данных (для STORAGE=postgres)
+	SQLitePath    string      // Путь к файлу базы (для STORAGE=sqlite)
+	AuthTokens    []AuthToken // Статические API-токены
+	AuthJWTSecret string      // Секрет для проверки HMAC-подписи JWT
+	AuthDisabled  bool        // Явно разрешает работу без аутентификации
+
+	RateLimitIP      RateLimit            // Лимит запросов с одного IP-адреса, проверяется до аутентификации
+	RateLimitDefault RateLimit            // Лимит запросов по умолчанию
+	RateLimitRoutes  map[string]RateLimit // Лимиты для отдельных маршрутов ("METHOD /path")
+
+	IdempotencyTTL time.Duration // Сколько хранится ответ для Idempotency-Key
+
+	UnavailabilityCheckInterval time.Duration // Как часто переназначать ревью пользователей, ушедших в недоступность
+	SLACheckInterval            time.Duration // Как часто искать и эскалировать PR, просрочившие SLA ревью
+
+	OpenAPIValidation string // Проверка запросов и ответов по OpenAPI: off, log или strict
+}
+
+// RateLimit - лимит запросов клиента: RPS запросов в секунду с запасом Burst.
+// Задаётся в виде "rps:burst", нулевой RPS - без ограничения.
+type RateLimit struct {
+	RPS   float64
+	Burst int
+}
+
+// AuthToken описывает статический API-токен.
+// Задаётся в AUTH_TOKENS в виде "subject:token:role" через запятую.
+type AuthToken struct {
+	Subject string // Имя клиента; для team-lead - user_id, по нему определяется его команда
+	Token   string // Значение токена
+	Role    string // Роль: admin, team-lead или bot
+}
+
+// AuthEnabled сообщает, настроен ли хотя бы один способ аутентификации.
+func (c *Config) AuthEnabled() bool {
+	return len(c.AuthTokens) > 0 || c.AuthJWTSecret != ""
 }
 
 // mustGetEnv получает значение обязательной переменной окружения или возвращает ошибку если она пустая
@@ -21,6 +66,74 @@ func mustGetEnv(key string) (string, error) {
 	return value, nil
 }
 
+// getDurationEnv читает длительность вида "24h" или возвращает значение по умолчанию.
+func getDurationEnv(key string, def time.Duration) (time.Duration, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return def, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("%s: invalid duration %q", key, value)
+	}
+	return d, nil
+}
+
+// parseAuthTokens разбирает значение AUTH_TOKENS.
+func parseAuthTokens(value string) ([]AuthToken, error) {
+	tokens := make([]AuthToken, 0)
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		parts := strings.Split(item, ":")
+		if len(parts) != 3 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("AUTH_TOKENS: entry %q must be subject:token:role", parts[0])
+		}
+		tokens = append(tokens, AuthToken{Subject: parts[0], Token: parts[1], Role: parts[2]})
+	}
+	return tokens, nil
+}
+
+// parseRateLimit разбирает лимит вида "rps:burst". Если burst не указан, он равен rps.
+func parseRateLimit(value string) (RateLimit, error) {
+	rpsStr, burstStr, hasBurst := strings.Cut(strings.TrimSpace(value), ":")
+	rps, err := strconv.ParseFloat(rpsStr, 64)
+	if err != nil || rps < 0 {
+		return RateLimit{}, fmt.Errorf("invalid rate limit %q: expected rps:burst", value)
+	}
+	burst := int(rps)
+	if hasBurst {
+		burst, err = strconv.Atoi(burstStr)
+		if err != nil || burst < 0 {
+			return RateLimit{}, fmt.Errorf("invalid rate limit %q: expected rps:burst", value)
+		}
+	}
+	return RateLimit{RPS: rps, Burst: burst}, nil
+}
+
+// parseRateLimitRoutes разбирает RATE_LIMIT_ROUTES: "METHOD /path=rps:burst" через запятую.
+func parseRateLimitRoutes(value string) (map[string]RateLimit, error) {
+	routes := make(map[string]RateLimit)
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		route, limitStr, ok := strings.Cut(item, "=")
+		if !ok {
+			return nil, fmt.Errorf("RATE_LIMIT_ROUTES: entry %q must be \"METHOD /path=rps:burst\"", item)
+		}
+		limit, err := parseRateLimit(limitStr)
+		if err != nil {
+			return nil, fmt.Errorf("RATE_LIMIT_ROUTES: %w", err)
+		}
+		routes[strings.Join(strings.Fields(route), " ")] = limit
+	}
+	return routes, nil
+}
+
 // LoadConfig загружает конфигурацию из переменных окружения и возвращает Config
 // Возвращает ошибку если какие-то обязательные переменные не установлены
 func LoadConfig() (*Config, error) {
@@ -31,7 +144,82 @@ func LoadConfig() (*Config, error) {
 		errs = append(errs, err.Error())
 	}
 
-	db, err := mustGetEnv("DATABASE_URL")
+	storage := os.Getenv("STORAGE")
+	if storage == "" {
+		storage = StoragePostgres
+	}
+	var db, sqlitePath string
+	switch storage {
+	case StoragePostgres:
+		db, err = mustGetEnv("DATABASE_URL")
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+	case StorageSQLite:
+		sqlitePath, err = mustGetEnv("SQLITE_PATH")
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+	case StorageMemory:
+	default:
+		errs = append(errs, fmt.Sprintf("STORAGE: must be %s, %s or %s, got %q",
+			StoragePostgres, StorageSQLite, StorageMemory, storage))
+	}
+
+	authTokens, err := parseAuthTokens(os.Getenv("AUTH_TOKENS"))
+	if err != nil {
+		errs = append(errs, err.Error())
+	}
+	authJWTSecret := os.Getenv("AUTH_JWT_SECRET")
+
+	// Без аутентификации любой клиент получает права admin, поэтому это нужно разрешить явно.
+	var authDisabled bool
+	if v := os.Getenv("AUTH_DISABLED"); v != "" {
+		authDisabled, err = strconv.ParseBool(v)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("AUTH_DISABLED: invalid boolean %q", v))
+		}
+	}
+	authConfigured := len(authTokens) > 0 || authJWTSecret != ""
+	switch {
+	case authDisabled && authConfigured:
+		errs = append(errs, "AUTH_DISABLED: must not be true when AUTH_TOKENS or AUTH_JWT_SECRET is set")
+	case !authDisabled && !authConfigured:
+		errs = append(errs, "AUTH_TOKENS or AUTH_JWT_SECRET is required (set AUTH_DISABLED=true to run without authentication)")
+	}
+
+	rateLimitIP := RateLimit{RPS: 100, Burst: 200}
+	if v := os.Getenv("RATE_LIMIT_IP"); v != "" {
+		rateLimitIP, err = parseRateLimit(v)
+		if err != nil {
+			errs = append(errs, "RATE_LIMIT_IP: "+err.Error())
+		}
+	}
+
+	var rateLimitDefault RateLimit
+	if v := os.Getenv("RATE_LIMIT_DEFAULT"); v != "" {
+		rateLimitDefault, err = parseRateLimit(v)
+		if err != nil {
+			errs = append(errs, "RATE_LIMIT_DEFAULT: "+err.Error())
+		}
+	}
+
+	rateLimitRoutes, err := parseRateLimitRoutes(os.Getenv("RATE_LIMIT_ROUTES"))
+	if err != nil {
+		errs = append(errs, err.Error())
+	}
+
+	idempotencyTTL, err := getDurationEnv("IDEMPOTENCY_TTL", 24*time.Hour)
+	if err != nil {
+		errs = append(errs, err.Error())
+	}
+
+	unavailabilityCheckInterval, err := getDurationEnv("UNAVAILABILITY_CHECK_INTERVAL", time.Minute)
+	if err != nil {
+		errs = append(errs, err.Error())
+	}
+
+	slaCheckInterval, err := getDurationEnv("SLA_CHECK_INTERVAL", 5*time.Minute)
 	if err != nil {
 		errs = append(errs, err.Error())
 	}
@@ -40,7 +228,23 @@ func LoadConfig() (*Config, error) {
 		return nil, fmt.Errorf("config validation failed:\n  %s", strings.Join(errs, "\n  "))
 	}
 	return &Config{
-		HttpPort:    httpPort,
-		DatabaseURL: db,
+		HttpPort:      httpPort,
+		Storage:       storage,
+		DatabaseURL:   db,
+		SQLitePath:    sqlitePath,
+		AuthTokens:    authTokens,
+		AuthJWTSecret: authJWTSecret,
+		AuthDisabled:  authDisabled,
+
+		RateLimitIP:      rateLimitIP,
+		RateLimitDefault: rateLimitDefault,
+		RateLimitRoutes:  rateLimitRoutes,
+
+		IdempotencyTTL: idempotencyTTL,
+
+		UnavailabilityCheckInterval: unavailabilityCheckInterval,
+		SLACheckInterval:            slaCheckInterval,
+
+		OpenAPIValidation: os.Getenv("OPENAPI_VALIDATION"),
 	}, nil
 }
@@ -0,0 +1,148 @@
+package domain
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// teamOwnerPrefix - префикс владельца-команды в CODEOWNERS: @team/backend.
+const teamOwnerPrefix = "team/"
+
+// CodeOwnerRule - строка CODEOWNERS: шаблон пути и владельцы совпавших файлов.
+// Правило без владельцев снимает владение, заданное предыдущими правилами.
+type CodeOwnerRule struct {
+	Position int      `json:"position"` // Номер строки в исходном файле
+	Pattern  string   `json:"pattern"`  // Шаблон пути в синтаксисе gitignore
+	Users    []string `json:"users"`    // user_id владельцев (@alice)
+	Teams    []string `json:"teams"`    // Команды-владельцы (@team/backend)
+}
+
+// ParseCodeOwners разбирает файл в формате CODEOWNERS.
+// Пустые строки и строки, начинающиеся с #, пропускаются; # после пробела начинает комментарий.
+// Владелец - @user_id или @team/<имя команды>. Ошибки содержат номер строки.
+func ParseCodeOwners(content string) ([]CodeOwnerRule, error) {
+	var rules []CodeOwnerRule
+
+	for i, line := range strings.Split(content, "\n") {
+		lineNo := i + 1
+
+		fields := strings.Fields(line)
+		for j, f := range fields {
+			if strings.HasPrefix(f, "#") {
+				fields = fields[:j]
+				break
+			}
+		}
+		if len(fields) == 0 {
+			continue
+		}
+
+		pattern := fields[0]
+		if err := ValidateOwnerPattern(pattern); err != nil {
+			return nil, NewError(ErrorInvalidCodeOwners, fmt.Sprintf("line %d: %v", lineNo, err))
+		}
+
+		rule := CodeOwnerRule{Position: lineNo, Pattern: pattern, Users: []string{}, Teams: []string{}}
+		for _, owner := range fields[1:] {
+			name, ok := strings.CutPrefix(owner, "@")
+			if !ok || name == "" {
+				return nil, NewError(ErrorInvalidCodeOwners,
+					fmt.Sprintf("line %d: owner %q must be @user_id or @team/<name>", lineNo, owner))
+			}
+			if team, isTeam := strings.CutPrefix(name, teamOwnerPrefix); isTeam {
+				if team == "" {
+					return nil, NewError(ErrorInvalidCodeOwners, fmt.Sprintf("line %d: empty team name in %q", lineNo, owner))
+				}
+				rule.Teams = append(rule.Teams, team)
+				continue
+			}
+			rule.Users = append(rule.Users, name)
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// ValidateOwnerPattern проверяет шаблон пути правила: отрицание и пустые сегменты не поддерживаются.
+func ValidateOwnerPattern(pattern string) error {
+	if strings.HasPrefix(pattern, "!") {
+		return fmt.Errorf("negated pattern %q is not supported", pattern)
+	}
+	trimmed := strings.Trim(pattern, "/")
+	if trimmed == "" {
+		return fmt.Errorf("pattern %q matches nothing", pattern)
+	}
+	for _, seg := range strings.Split(trimmed, "/") {
+		if seg == "" {
+			return fmt.Errorf("pattern %q has an empty path segment", pattern)
+		}
+		if _, err := path.Match(seg, ""); err != nil {
+			return fmt.Errorf("bad pattern %q: %v", pattern, err)
+		}
+	}
+	return nil
+}
+
+// Matches сообщает, подпадает ли файл под шаблон правила.
+// Шаблон с / в начале или середине привязан к корню, иначе совпадает на любой глубине.
+// / в конце - только каталоги, ** - любое число сегментов. Совпавший каталог владеет всем содержимым,
+// но * последним сегментом совпадает только с прямыми потомками: docs/* не владеет docs/guide/intro.md.
+func (r *CodeOwnerRule) Matches(filePath string) bool {
+	pattern := r.Pattern
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+	anchored := strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	patSegs := strings.Split(pattern, "/")
+	pathSegs := strings.Split(strings.Trim(filePath, "/"), "/")
+
+	if anchored {
+		return matchSegments(patSegs, pathSegs, dirOnly)
+	}
+	for i := range pathSegs {
+		if matchSegments(patSegs, pathSegs[i:], dirOnly) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchSegments проверяет, что шаблон совпадает с началом пути.
+// Если путь не исчерпан, совпал каталог, содержащий файл. Исключение - * последним сегментом шаблона:
+// после него путь должен закончиться.
+func matchSegments(pat, segs []string, dirOnly bool) bool {
+	if len(pat) == 0 {
+		return len(segs) > 0 || !dirOnly
+	}
+	if len(pat) == 1 && pat[0] == "*" && !dirOnly {
+		return len(segs) == 1
+	}
+	if pat[0] == "**" {
+		for i := 0; i <= len(segs); i++ {
+			if matchSegments(pat[1:], segs[i:], dirOnly) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segs) == 0 {
+		return false
+	}
+	ok, _ := path.Match(pat[0], segs[0])
+	return ok && matchSegments(pat[1:], segs[1:], dirOnly)
+}
+
+// OwnerRuleFor возвращает правило, определяющее владельцев файла, - последнее совпавшее.
+// rules должны идти в порядке файла. nil - у файла нет владельцев.
+func OwnerRuleFor(rules []CodeOwnerRule, filePath string) *CodeOwnerRule {
+	for i := len(rules) - 1; i >= 0; i-- {
+		if rules[i].Matches(filePath) {
+			return &rules[i]
+		}
+	}
+	return nil
+}
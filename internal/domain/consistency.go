@@ -0,0 +1,27 @@
+package domain
+
+// ConsistencyIssueKind - вид нарушения целостности данных.
+type ConsistencyIssueKind string
+
+const (
+	// IssueDanglingReviewer - ревьювер PR не найден среди пользователей.
+	IssueDanglingReviewer ConsistencyIssueKind = "DANGLING_REVIEWER"
+	// IssueInactiveReviewer - неактивный пользователь остаётся ревьювером открытого PR.
+	IssueInactiveReviewer ConsistencyIssueKind = "INACTIVE_REVIEWER"
+	// IssueAuthorWithoutTeam - автор открытого PR не состоит в команде, ревьюверов ему не подобрать.
+	IssueAuthorWithoutTeam ConsistencyIssueKind = "AUTHOR_WITHOUT_TEAM"
+)
+
+// ConsistencyIssue - одно нарушение целостности.
+type ConsistencyIssue struct {
+	Kind          ConsistencyIssueKind
+	PullRequestID string
+	UserID        string // Ревьювер или автор, к которому относится нарушение
+	Repaired      bool   // Нарушение исправлено в режиме исправления
+}
+
+// ConsistencyReport - итог проверки целостности.
+type ConsistencyReport struct {
+	Repair bool // Найденные нарушения исправлялись
+	Issues []ConsistencyIssue
+}
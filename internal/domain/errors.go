@@ -10,6 +10,23 @@ const (
 	ErrorNotAssigned ErrorCode = "NOT_ASSIGNED"
 	ErrorNoCandidate ErrorCode = "NO_CANDIDATE"
 	ErrorNotFound    ErrorCode = "NOT_FOUND"
+
+	ErrorHasOpenReviews  ErrorCode = "HAS_OPEN_REVIEWS"
+	ErrorUserInOtherTeam ErrorCode = "USER_IN_OTHER_TEAM"
+	ErrorTeamHasOpenPRs  ErrorCode = "TEAM_HAS_OPEN_PRS"
+
+	ErrorInvalidCodeOwners ErrorCode = "INVALID_CODEOWNERS"
+
+	ErrorInvalidReviewer     ErrorCode = "INVALID_REVIEWER"
+	ErrorAlreadyAssigned     ErrorCode = "ALREADY_ASSIGNED"
+	ErrorReviewerLimit       ErrorCode = "REVIEWER_LIMIT"
+	ErrorRuleViolation       ErrorCode = "RULE_VIOLATION"
+	ErrorReviewerUnavailable ErrorCode = "REVIEWER_UNAVAILABLE"
+	ErrorReviewerAtCapacity  ErrorCode = "REVIEWER_AT_CAPACITY"
+
+	ErrorImportConflict ErrorCode = "IMPORT_CONFLICT"
+
+	ErrorValidation ErrorCode = "VALIDATION_ERROR"
 )
 
 // Error структура для проброса ошибок из домена.
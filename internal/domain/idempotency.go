@@ -0,0 +1,19 @@
+package domain
+
+import "time"
+
+// IdempotencyRecord - сохранённый результат запроса с заголовком Idempotency-Key.
+type IdempotencyRecord struct {
+	Key         string    // Ключ (с учётом клиента)
+	RequestHash string    // Хеш метода, пути и тела исходного запроса
+	StatusCode  int       // HTTP-статус ответа (0 - запрос ещё выполняется)
+	ContentType string    // Content-Type ответа
+	Body        []byte    // Тело ответа
+	CreatedAt   time.Time // Время первого запроса
+	ExpiresAt   time.Time // Время, после которого ключ можно использовать заново
+}
+
+// Completed сообщает, сохранён ли уже ответ.
+func (r *IdempotencyRecord) Completed() bool {
+	return r.StatusCode != 0
+}
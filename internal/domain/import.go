@@ -0,0 +1,92 @@
+package domain
+
+import "time"
+
+// ImportBatch - записи файла импорта, разобранные по видам.
+// Line в каждой записи - номер строки файла, на него ссылаются ошибки отчёта.
+type ImportBatch struct {
+	Teams          []ImportTeam
+	Users          []ImportUser
+	PullRequests   []ImportPullRequest
+	Escalations    []ImportEscalation
+	ReviewSLAs     []ImportReviewSLA
+	CodeOwnerRules []ImportCodeOwnerRule
+	ReviewerRules  []ImportReviewerRule
+	Unavailability []ImportUnavailability
+}
+
+// ImportTeam - команда из файла импорта (без участников: они приходят отдельными записями).
+type ImportTeam struct {
+	Line     int
+	TeamName string
+}
+
+// ImportUser - пользователь из файла импорта.
+type ImportUser struct {
+	Line int
+	User
+}
+
+// ImportPullRequest - PR из файла импорта, в том числе уже слитый.
+type ImportPullRequest struct {
+	Line int
+	PullRequest
+}
+
+// ImportEscalation - последняя эскалация просроченного PR.
+type ImportEscalation struct {
+	Line          int
+	PullRequestID string
+	EscalatedAt   time.Time
+}
+
+// ImportReviewSLA - SLA ревью команды.
+type ImportReviewSLA struct {
+	Line int
+	ReviewSLA
+}
+
+// ImportCodeOwnerRule - правило CODEOWNERS; Position задаёт его место среди правил.
+type ImportCodeOwnerRule struct {
+	Line int
+	CodeOwnerRule
+}
+
+// ImportReviewerRule - правило подбора ревьюверов вместе со временем создания.
+type ImportReviewerRule struct {
+	Line int
+	ReviewerRule
+}
+
+// ImportUnavailability - период недоступности пользователя.
+type ImportUnavailability struct {
+	Line int
+	Unavailability
+}
+
+// Len возвращает общее число записей.
+func (b *ImportBatch) Len() int {
+	return len(b.Teams) + len(b.Users) + len(b.PullRequests) + len(b.Escalations) +
+		len(b.ReviewSLAs) + len(b.CodeOwnerRules) + len(b.ReviewerRules) + len(b.Unavailability)
+}
+
+// ImportRowError - ошибка в строке файла импорта.
+type ImportRowError struct {
+	Line   int    // Номер строки файла
+	Field  string // Поле записи, пустое - ошибка относится ко всей записи
+	Reason string
+}
+
+// ImportReport - итог импорта. Записи с ошибками пропускаются, остальные загружаются.
+type ImportReport struct {
+	DryRun         bool             // Файл только проверен, в базу ничего не записано
+	Teams          int              // Сколько команд загружено (в dry-run - было бы загружено)
+	Users          int              // Сколько пользователей загружено
+	PullRequests   int              // Сколько PR загружено
+	Escalations    int              // Сколько эскалаций PR загружено
+	ReviewSLAs     int              // Сколько SLA команд загружено
+	CodeOwnerRules int              // Сколько правил CODEOWNERS загружено
+	ReviewerRules  int              // Сколько правил подбора загружено
+	Unavailability int              // Сколько периодов недоступности загружено
+	Errors         []ImportRowError // Ошибки по строкам в порядке файла
+}
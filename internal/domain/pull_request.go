@@ -1,6 +1,9 @@
 package domain
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 // PullRequestStatus описывает статус PR: OPEN или MERGED.
 type PullRequestStatus string
@@ -10,6 +13,9 @@ const (
 	StatusMerged PullRequestStatus = "MERGED"
 )
 
+// MaxReviewersPerPR - сколько ревьюверов назначается на PR.
+const MaxReviewersPerPR = 2
+
 // PullRequest описывает полный PR.
 type PullRequest struct {
 	PullRequestID     string     `json:"pull_request_id"`    // ID PR
@@ -19,6 +25,83 @@ type PullRequest struct {
 	AssignedReviewers []string   `json:"assigned_reviewers"` // Список user_id назначенных ревьюверов (0-2)
 	CreatedAt         *time.Time `json:"created_at"`         // Время создания PR
 	MergedAt          *time.Time `json:"merged_at"`          // Время слияния PR
+
+	ReviewerAssignedAt map[string]time.Time `json:"-"` // Время назначения ревьюверов по user_id
+}
+
+// UnfilledReviewerSlots возвращает, скольких ревьюверов не хватает до MaxReviewersPerPR.
+func (pr *PullRequest) UnfilledReviewerSlots() int {
+	return max(MaxReviewersPerPR-len(pr.AssignedReviewers), 0)
+}
+
+// MarkAssigned запоминает, что ревьювер userID назначен в момент at.
+func (pr *PullRequest) MarkAssigned(userID string, at time.Time) {
+	if pr.ReviewerAssignedAt == nil {
+		pr.ReviewerAssignedAt = make(map[string]time.Time, len(pr.AssignedReviewers))
+	}
+	pr.ReviewerAssignedAt[userID] = at
+}
+
+// ReviewerAssignedTimes возвращает время назначения ревьюверов в порядке AssignedReviewers.
+// Время выбирает сервис, а не хранилище, поэтому ревьювер без него - ошибка.
+func (pr *PullRequest) ReviewerAssignedTimes() ([]time.Time, error) {
+	times := make([]time.Time, 0, len(pr.AssignedReviewers))
+	for _, userID := range pr.AssignedReviewers {
+		at, ok := pr.ReviewerAssignedAt[userID]
+		if !ok {
+			return nil, fmt.Errorf("pull_request %s: assigned_at of reviewer %s is not set", pr.PullRequestID, userID)
+		}
+		times = append(times, at)
+	}
+	return times, nil
+}
+
+// ReviewHints - необязательные подсказки для подбора ревьюверов при создании PR.
+type ReviewHints struct {
+	RequestedReviewers []string // Ревьюверы, выбранные автором; назначаются первыми, остальные места заполняются автоматически
+	ChangedFiles       []string // Пути изменённых файлов от корня репозитория, сопоставляются с CODEOWNERS
+	Labels             []string // Метки PR, сопоставляются с навыками пользователей
+	ExcludedUsers      []string // Пользователи, которых не назначать автоматически
+}
+
+// RejectionReason - почему кандидат не выбран ревьювером.
+type RejectionReason string
+
+const (
+	RejectedIsAuthor    RejectionReason = "IS_AUTHOR"
+	RejectedInactive    RejectionReason = "INACTIVE"
+	RejectedUnavailable RejectionReason = "UNAVAILABLE"   // Действует период недоступности
+	RejectedAtCapacity  RejectionReason = "AT_CAPACITY"   // Исчерпан лимит открытых ревью
+	RejectedExcluded    RejectionReason = "EXCLUDED"      // Исключён в запросе
+	RejectedByRule      RejectionReason = "RULE_EXCLUDED" // Исключён правилом подбора EXCLUDE
+	RejectedSlotsFilled RejectionReason = "SLOTS_FILLED"  // Подходит, но места заняты более приоритетными кандидатами
+)
+
+// RejectedCandidate - кандидат, которого подбор ревьюверов пропустил.
+type RejectedCandidate struct {
+	UserID string          `json:"user_id"`
+	Reason RejectionReason `json:"reason"`
+	Detail string          `json:"detail,omitempty"`
+}
+
+// ReviewerSelection - результат подбора ревьюверов: выбранные в порядке назначения и отклонённые кандидаты.
+type ReviewerSelection struct {
+	Reviewers []User
+	Rejected  []RejectedCandidate
+}
+
+// ReviewerIDs возвращает user_id выбранных ревьюверов.
+func (s *ReviewerSelection) ReviewerIDs() []string {
+	ids := make([]string, 0, len(s.Reviewers))
+	for _, u := range s.Reviewers {
+		ids = append(ids, u.UserID)
+	}
+	return ids
+}
+
+// UnfilledReviewerSlots возвращает, скольких ревьюверов не хватает до MaxReviewersPerPR.
+func (s *ReviewerSelection) UnfilledReviewerSlots() int {
+	return max(MaxReviewersPerPR-len(s.Reviewers), 0)
 }
 
 // PullRequestShort - сокращённая версия PR
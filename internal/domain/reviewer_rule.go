@@ -0,0 +1,166 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// ReviewerRuleKind - вид правила подбора ревьюверов.
+type ReviewerRuleKind string
+
+const (
+	// RuleExclude - пользователи под Reviewer не ревьюят PR авторов под Author (конфликт интересов).
+	RuleExclude ReviewerRuleKind = "EXCLUDE"
+	// RuleRequire - у PR авторов под Author хотя бы один ревьювер должен подпадать под Reviewer.
+	RuleRequire ReviewerRuleKind = "REQUIRE"
+)
+
+// UserSelector выбирает пользователей по user_id или по навыку. Задано ровно одно поле.
+type UserSelector struct {
+	UserID string `json:"user_id,omitempty"`
+	Skill  string `json:"skill,omitempty"`
+}
+
+// Matches сообщает, подпадает ли пользователь под селектор.
+func (s UserSelector) Matches(u *User) bool {
+	if u == nil {
+		return false
+	}
+	if s.UserID != "" {
+		return u.UserID == s.UserID
+	}
+	return u.HasSkill([]string{s.Skill})
+}
+
+func (s UserSelector) String() string {
+	if s.UserID != "" {
+		return "user " + s.UserID
+	}
+	return "skill " + s.Skill
+}
+
+// ReviewerRule - правило исключения или обязательной пары при подборе ревьюверов.
+type ReviewerRule struct {
+	ID          string           `json:"rule_id"`
+	Kind        ReviewerRuleKind `json:"kind"`
+	Author      UserSelector     `json:"author"`
+	Reviewer    UserSelector     `json:"reviewer"`
+	Description string           `json:"description"`
+	CreatedAt   time.Time        `json:"-"` // Время создания, задаёт сервис; правила применяются в порядке создания
+}
+
+// RuleViolation - нарушение правила набором ревьюверов PR.
+type RuleViolation struct {
+	RuleID     string           `json:"rule_id"`
+	Kind       ReviewerRuleKind `json:"kind"`
+	ReviewerID string           `json:"reviewer_id,omitempty"` // Для EXCLUDE - исключённый ревьювер
+	Message    string           `json:"message"`
+}
+
+// RuleSet - все правила подбора ревьюверов.
+type RuleSet []ReviewerRule
+
+// Exclusion возвращает правило, запрещающее reviewer ревьюить PR автора author, или nil.
+func (rs RuleSet) Exclusion(author, reviewer *User) *ReviewerRule {
+	for i, rule := range rs {
+		if rule.Kind == RuleExclude && rule.Author.Matches(author) && rule.Reviewer.Matches(reviewer) {
+			return &rs[i]
+		}
+	}
+	return nil
+}
+
+// Requirements возвращает правила REQUIRE, действующие для PR автора author.
+func (rs RuleSet) Requirements(author *User) []ReviewerRule {
+	var result []ReviewerRule
+	for _, rule := range rs {
+		if rule.Kind == RuleRequire && rule.Author.Matches(author) {
+			result = append(result, rule)
+		}
+	}
+	return result
+}
+
+// Evaluate проверяет набор ревьюверов PR автора author и объясняет каждое нарушение.
+func (rs RuleSet) Evaluate(author *User, reviewers []User) []RuleViolation {
+	violations := make([]RuleViolation, 0)
+
+	for i := range reviewers {
+		if rule := rs.Exclusion(author, &reviewers[i]); rule != nil {
+			violations = append(violations, RuleViolation{
+				RuleID:     rule.ID,
+				Kind:       rule.Kind,
+				ReviewerID: reviewers[i].UserID,
+				Message: fmt.Sprintf("reviewer %s is excluded for author %s: reviewer matches %s, author matches %s",
+					reviewers[i].UserID, author.UserID, rule.Reviewer, rule.Author),
+			})
+		}
+	}
+
+	for _, rule := range rs.Requirements(author) {
+		if !anyMatches(rule.Reviewer, reviewers) {
+			violations = append(violations, RuleViolation{
+				RuleID: rule.ID,
+				Kind:   rule.Kind,
+				Message: fmt.Sprintf("pull requests of %s need at least one reviewer matching %s (author matches %s)",
+					author.UserID, rule.Reviewer, rule.Author),
+			})
+		}
+	}
+
+	return violations
+}
+
+// PickReviewers выбирает из candidates до n новых ревьюверов в дополнение к уже назначенным selected.
+// Сначала закрываются невыполненные правила REQUIRE, затем места заполняются кандидатами по порядку.
+// Кандидаты, запрещённые правилами EXCLUDE, пропускаются.
+func (rs RuleSet) PickReviewers(author *User, selected, candidates []User, n int) []User {
+	allowed := make([]User, 0, len(candidates))
+	for i := range candidates {
+		if rs.Exclusion(author, &candidates[i]) == nil {
+			allowed = append(allowed, candidates[i])
+		}
+	}
+
+	picked := make([]User, 0, n)
+	taken := make(map[string]struct{}, n)
+	take := func(u User) {
+		picked = append(picked, u)
+		taken[u.UserID] = struct{}{}
+	}
+
+	for _, rule := range rs.Requirements(author) {
+		if len(picked) == n {
+			break
+		}
+		if anyMatches(rule.Reviewer, selected) || anyMatches(rule.Reviewer, picked) {
+			continue
+		}
+		for i := range allowed {
+			if _, ok := taken[allowed[i].UserID]; !ok && rule.Reviewer.Matches(&allowed[i]) {
+				take(allowed[i])
+				break
+			}
+		}
+	}
+
+	for _, u := range allowed {
+		if len(picked) == n {
+			break
+		}
+		if _, ok := taken[u.UserID]; !ok {
+			take(u)
+		}
+	}
+
+	return picked
+}
+
+func anyMatches(s UserSelector, users []User) bool {
+	for i := range users {
+		if s.Matches(&users[i]) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+package domain
+
+import "time"
+
+// EscalationPolicy - что делать с PR, который дольше SLA остаётся OPEN.
+type EscalationPolicy string
+
+const (
+	// EscalationNotify - только отправить событие эскалации.
+	EscalationNotify EscalationPolicy = "NOTIFY"
+	// EscalationReassign - переназначить ревьюверов PR и отправить событие эскалации.
+	EscalationReassign EscalationPolicy = "REASSIGN"
+)
+
+// ReviewSLA - SLA ревью PR авторов из команды.
+type ReviewSLA struct {
+	TeamName string
+	SLA      time.Duration    // Сколько PR может оставаться OPEN с момента создания
+	Policy   EscalationPolicy // Что делать после
+}
+
+// OverduePullRequest - открытый PR, просрочивший SLA команды автора.
+type OverduePullRequest struct {
+	PullRequestID     string
+	PullRequestName   string
+	AuthorID          string
+	TeamName          string // Команда автора, чей SLA нарушен
+	AssignedReviewers []string
+	CreatedAt         time.Time
+	SLA               time.Duration
+	Policy            EscalationPolicy
+	EscalatedAt       *time.Time // Последняя эскалация; nil - ещё не было
+}
+
+// OverdueBy возвращает, насколько к моменту now просрочен SLA.
+func (o *OverduePullRequest) OverdueBy(now time.Time) time.Duration {
+	return now.Sub(o.CreatedAt.Add(o.SLA))
+}
+
+// EscalationDue сообщает, пора ли эскалировать PR в момент now:
+// эскалаций ещё не было или с последней прошло не меньше SLA.
+func (o *OverduePullRequest) EscalationDue(now time.Time) bool {
+	return o.EscalatedAt == nil || !now.Before(o.EscalatedAt.Add(o.SLA))
+}
+
+// Escalation - событие эскалации просроченного PR.
+type Escalation struct {
+	PullRequestID string
+	TeamName      string
+	Policy        EscalationPolicy
+	Reviewers     []string          // Ревьюверы на момент просрочки
+	Reassigned    map[string]string // Старый ревьювер -> новый (для REASSIGN)
+	OverdueBy     time.Duration
+	EscalatedAt   time.Time
+}
@@ -12,3 +12,20 @@ type Team struct {
 	TeamName string       `json:"team_name"` // Уникальное имя команды
 	Members  []TeamMember `json:"members"`   // Участники команды
 }
+
+// TeamDeleteStrategy - что делать с участниками удаляемой команды.
+type TeamDeleteStrategy string
+
+const (
+	// TeamDeleteMove - перевести участников в другую команду.
+	TeamDeleteMove TeamDeleteStrategy = "move"
+	// TeamDeleteDeactivate - деактивировать участников и оставить их без команды.
+	TeamDeleteDeactivate TeamDeleteStrategy = "deactivate"
+)
+
+// TeamSummary - краткие сведения о команде для списка команд.
+type TeamSummary struct {
+	TeamName          string // Имя команды
+	MemberCount       int    // Всего участников
+	ActiveMemberCount int    // Активных участников
+}
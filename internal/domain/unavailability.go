@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+// Unavailability - период, когда пользователь не может ревьюить (отпуск, больничный и т.п.).
+type Unavailability struct {
+	ID         string     // Идентификатор периода
+	UserID     string     // Пользователь
+	StartsAt   time.Time  // Начало периода, включительно
+	EndsAt     time.Time  // Конец периода, не включительно
+	Reason     string     // Причина, произвольный текст
+	ReleasedAt *time.Time // Когда пользователя сняли с открытых ревью; nil - ещё не снимали
+}
+
+// Covers сообщает, попадает ли момент t в период.
+func (u *Unavailability) Covers(t time.Time) bool {
+	return !t.Before(u.StartsAt) && t.Before(u.EndsAt)
+}
@@ -1,8 +1,36 @@
 package domain
 
+import "strings"
+
 type User struct {
 	UserID   string `json:"user_id"`   // Идентификатор пользователя
 	Username string `json:"username"`  // Имя пользователя
-	TeamName string `json:"team_name"` // Название команды
+	TeamName string `json:"team_name"` // Название команды, пустое - пользователь выведен из всех команд
 	IsActive bool   `json:"is_active"` // Статус активности пользователя
+
+	MaxOpenReviews *int     `json:"max_open_reviews,omitempty"` // Сколько открытых ревью можно назначить одновременно, nil - без лимита
+	Skills         []string `json:"skills,omitempty"`           // Теги экспертизы, сопоставляются с метками PR
+}
+
+// HasSkill сообщает, есть ли у пользователя хотя бы один из тегов (без учёта регистра).
+func (u *User) HasSkill(tags []string) bool {
+	for _, skill := range u.Skills {
+		for _, tag := range tags {
+			if strings.EqualFold(skill, tag) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// HasCapacity сообщает, можно ли назначить пользователю ещё одно ревью при openReviews уже открытых.
+func (u *User) HasCapacity(openReviews int) bool {
+	return u.MaxOpenReviews == nil || openReviews < *u.MaxOpenReviews
+}
+
+// UserSummary - пользователь вместе с текущей нагрузкой ревью.
+type UserSummary struct {
+	User
+	OpenReviewCount int // Число открытых PR, где пользователь назначен ревьювером
 }
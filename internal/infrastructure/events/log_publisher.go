@@ -0,0 +1,51 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+// LogPublisher пишет события эскалации в лог одной JSON-строкой.
+type LogPublisher struct {
+	logger *log.Logger
+}
+
+// NewLogPublisher создаёт публикатор; nil logger - стандартный логгер.
+func NewLogPublisher(logger *log.Logger) *LogPublisher {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return &LogPublisher{logger: logger}
+}
+
+type escalationEvent struct {
+	PullRequestID  string            `json:"pull_request_id"`
+	TeamName       string            `json:"team_name"`
+	Policy         string            `json:"policy"`
+	Reviewers      []string          `json:"reviewers"`
+	Reassigned     map[string]string `json:"reassigned"`
+	OverdueSeconds int64             `json:"overdue_seconds"`
+	EscalatedAt    time.Time         `json:"escalated_at"`
+}
+
+func (p *LogPublisher) PublishEscalation(ctx context.Context, e domain.Escalation) error {
+	body, err := json.Marshal(escalationEvent{
+		PullRequestID:  e.PullRequestID,
+		TeamName:       e.TeamName,
+		Policy:         string(e.Policy),
+		Reviewers:      e.Reviewers,
+		Reassigned:     e.Reassigned,
+		OverdueSeconds: int64(e.OverdueBy / time.Second),
+		EscalatedAt:    e.EscalatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal escalation: %w", err)
+	}
+	p.logger.Printf("review escalation: %s", body)
+	return nil
+}
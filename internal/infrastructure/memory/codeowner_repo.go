@@ -0,0 +1,46 @@
+package memory
+
+import (
+	"context"
+	"slices"
+
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+type CodeOwnerRepo struct {
+	store *Store
+}
+
+func NewCodeOwnerRepo(store *Store) *CodeOwnerRepo {
+	return &CodeOwnerRepo{store: store}
+}
+
+// Replace заменяет все правила новым набором.
+func (r *CodeOwnerRepo) Replace(ctx context.Context, rules []domain.CodeOwnerRule) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.codeOwners = cloneCodeOwnerRules(rules)
+	slices.SortStableFunc(s.codeOwners, func(a, b domain.CodeOwnerRule) int { return a.Position - b.Position })
+	return nil
+}
+
+// List возвращает правила в порядке файла.
+func (r *CodeOwnerRepo) List(ctx context.Context) ([]domain.CodeOwnerRule, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return cloneCodeOwnerRules(s.codeOwners), nil
+}
+
+func cloneCodeOwnerRules(rules []domain.CodeOwnerRule) []domain.CodeOwnerRule {
+	var result []domain.CodeOwnerRule
+	for _, rule := range rules {
+		rule.Users = cloneStrings(rule.Users)
+		rule.Teams = cloneStrings(rule.Teams)
+		result = append(result, rule)
+	}
+	return result
+}
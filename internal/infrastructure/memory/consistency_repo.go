@@ -0,0 +1,75 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+type ConsistencyRepo struct {
+	store *Store
+}
+
+func NewConsistencyRepo(store *Store) *ConsistencyRepo {
+	return &ConsistencyRepo{store: store}
+}
+
+// Check ищет ссылки на несуществующих ревьюверов, неактивных ревьюверов открытых PR и авторов открытых PR вне команд.
+// Новые ссылки на несуществующих пользователей хранилище не допускает, но проверка их всё равно ищет.
+func (r *ConsistencyRepo) Check(ctx context.Context) ([]domain.ConsistencyIssue, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	issues := make([]domain.ConsistencyIssue, 0)
+	add := func(kind domain.ConsistencyIssueKind, prID, userID string) {
+		issues = append(issues, domain.ConsistencyIssue{Kind: kind, PullRequestID: prID, UserID: userID})
+	}
+
+	for _, pr := range s.prs {
+		open := pr.Status == string(domain.StatusOpen)
+		for _, id := range pr.AssignedReviewers {
+			reviewer, ok := s.users[id]
+			switch {
+			case !ok:
+				add(domain.IssueDanglingReviewer, pr.PullRequestID, id)
+			case open && !reviewer.IsActive:
+				add(domain.IssueInactiveReviewer, pr.PullRequestID, id)
+			}
+		}
+		if author, ok := s.users[pr.AuthorID]; ok && open && author.TeamName == "" {
+			add(domain.IssueAuthorWithoutTeam, pr.PullRequestID, pr.AuthorID)
+		}
+	}
+
+	slices.SortFunc(issues, func(a, b domain.ConsistencyIssue) int {
+		if c := strings.Compare(string(a.Kind), string(b.Kind)); c != 0 {
+			return c
+		}
+		if c := strings.Compare(a.PullRequestID, b.PullRequestID); c != 0 {
+			return c
+		}
+		return strings.Compare(a.UserID, b.UserID)
+	})
+	return issues, nil
+}
+
+// RemoveDanglingReviewers снимает с PR ревьюверов, которых нет среди пользователей.
+func (r *ConsistencyRepo) RemoveDanglingReviewers(ctx context.Context) (int, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	removed := 0
+	for _, pr := range s.prs {
+		before := len(pr.AssignedReviewers)
+		pr.AssignedReviewers = slices.DeleteFunc(pr.AssignedReviewers, func(id string) bool {
+			_, ok := s.users[id]
+			return !ok
+		})
+		removed += before - len(pr.AssignedReviewers)
+	}
+	return removed, nil
+}
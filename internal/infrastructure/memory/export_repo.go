@@ -0,0 +1,131 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"pr-reviewer-assigment-service/internal/application/repository"
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+type ExportRepo struct {
+	store *Store
+}
+
+func NewExportRepo(store *Store) *ExportRepo {
+	return &ExportRepo{store: store}
+}
+
+// Export копирует данные под блокировкой и передаёт их sink уже без неё,
+// чтобы медленный получатель не задерживал запись.
+func (r *ExportRepo) Export(ctx context.Context, sink repository.ExportSink) error {
+	snap := r.snapshot()
+
+	for _, name := range snap.teams {
+		if err := sink.Team(name); err != nil {
+			return fmt.Errorf("export teams: %w", err)
+		}
+	}
+	for i := range snap.users {
+		if err := sink.User(&snap.users[i]); err != nil {
+			return fmt.Errorf("export users: %w", err)
+		}
+	}
+	for _, pr := range snap.prs {
+		if err := sink.PullRequest(pr); err != nil {
+			return fmt.Errorf("export pull_requests: %w", err)
+		}
+	}
+	for _, prID := range sortedKeys(snap.escalations) {
+		if err := sink.Escalation(prID, snap.escalations[prID]); err != nil {
+			return fmt.Errorf("export review_escalations: %w", err)
+		}
+	}
+	for i := range snap.slas {
+		if err := sink.ReviewSLA(&snap.slas[i]); err != nil {
+			return fmt.Errorf("export review_slas: %w", err)
+		}
+	}
+	for i := range snap.codeOwners {
+		if err := sink.CodeOwnerRule(&snap.codeOwners[i]); err != nil {
+			return fmt.Errorf("export code_owner_rules: %w", err)
+		}
+	}
+	for i := range snap.rules {
+		if err := sink.ReviewerRule(&snap.rules[i]); err != nil {
+			return fmt.Errorf("export reviewer_rules: %w", err)
+		}
+	}
+	for i := range snap.unavailability {
+		if err := sink.Unavailability(&snap.unavailability[i]); err != nil {
+			return fmt.Errorf("export user_unavailability: %w", err)
+		}
+	}
+	return nil
+}
+
+// exportSnapshot - копия данных для выгрузки в её порядке.
+type exportSnapshot struct {
+	teams          []string
+	users          []domain.User
+	prs            []*domain.PullRequest
+	escalations    map[string]time.Time
+	slas           []domain.ReviewSLA
+	codeOwners     []domain.CodeOwnerRule
+	rules          []domain.ReviewerRule
+	unavailability []domain.Unavailability
+}
+
+// snapshot упорядочивает данные как Postgres-выгрузка: команды по имени, пользователей по user_id,
+// PR по времени создания, правила CODEOWNERS по позиции, правила подбора в порядке создания,
+// периоды недоступности по началу.
+func (r *ExportRepo) snapshot() exportSnapshot {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	snap := exportSnapshot{
+		teams:       sortedKeys(s.teams),
+		escalations: make(map[string]time.Time, len(s.escalations)),
+		codeOwners:  cloneCodeOwnerRules(s.codeOwners),
+		rules:       slices.Clone(s.rules),
+	}
+
+	snap.users = make([]domain.User, 0, len(s.users))
+	for _, id := range sortedKeys(s.users) {
+		snap.users = append(snap.users, cloneUser(s.users[id]))
+	}
+
+	snap.prs = make([]*domain.PullRequest, 0, len(s.prs))
+	for _, pr := range s.prs {
+		snap.prs = append(snap.prs, clonePullRequest(pr))
+	}
+	slices.SortFunc(snap.prs, func(a, b *domain.PullRequest) int {
+		if c := a.CreatedAt.Compare(*b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.PullRequestID, b.PullRequestID)
+	})
+
+	for prID, at := range s.escalations {
+		snap.escalations[prID] = at
+	}
+	for _, teamName := range sortedKeys(s.slas) {
+		snap.slas = append(snap.slas, s.slas[teamName])
+	}
+
+	for _, u := range s.unavailability {
+		snap.unavailability = append(snap.unavailability, *cloneUnavailability(u))
+	}
+	slices.SortFunc(snap.unavailability, func(a, b domain.Unavailability) int {
+		if c := a.StartsAt.Compare(b.StartsAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	return snap
+}
@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"pr-reviewer-assigment-service/internal/application/repository"
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+type IdempotencyRepo struct {
+	store *Store
+}
+
+func NewIdempotencyRepo(store *Store) *IdempotencyRepo {
+	return &IdempotencyRepo{store: store}
+}
+
+// Reserve занимает ключ под новый запрос.
+// Если ключ уже занят действующей записью - возвращает её и repository.ErrAlreadyExists.
+func (r *IdempotencyRepo) Reserve(
+	ctx context.Context,
+	rec *domain.IdempotencyRecord,
+	staleBefore time.Time,
+) (*domain.IdempotencyRecord, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, ok := s.idempotency[rec.Key]; ok {
+		expired := !existing.ExpiresAt.After(rec.CreatedAt)
+		stale := !existing.Completed() && existing.CreatedAt.Before(staleBefore)
+		if !expired && !stale {
+			c := cloneIdempotencyRecord(existing)
+			return &c, repository.ErrAlreadyExists
+		}
+	}
+
+	s.idempotency[rec.Key] = &domain.IdempotencyRecord{
+		Key:         rec.Key,
+		RequestHash: rec.RequestHash,
+		CreatedAt:   dbTime(rec.CreatedAt),
+		ExpiresAt:   dbTime(rec.ExpiresAt),
+	}
+	return nil, nil
+}
+
+// Complete сохраняет ответ для занятого ключа.
+// Если ключ не найден - возвращает repository.ErrNotFound.
+func (r *IdempotencyRepo) Complete(ctx context.Context, key string, statusCode int, contentType string, body []byte) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rec, ok := s.idempotency[key]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	rec.StatusCode = statusCode
+	rec.ContentType = contentType
+	rec.Body = slices.Clone(body)
+	return nil
+}
+
+// Release освобождает незавершённый ключ.
+func (r *IdempotencyRepo) Release(ctx context.Context, key string) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if rec, ok := s.idempotency[key]; ok && !rec.Completed() {
+		delete(s.idempotency, key)
+	}
+	return nil
+}
+
+// DeleteExpired удаляет просроченные записи и возвращает их количество.
+func (r *IdempotencyRepo) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var deleted int64
+	for key, rec := range s.idempotency {
+		if !rec.ExpiresAt.After(now) {
+			delete(s.idempotency, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func cloneIdempotencyRecord(rec *domain.IdempotencyRecord) domain.IdempotencyRecord {
+	c := *rec
+	c.Body = slices.Clone(rec.Body)
+	return c
+}
@@ -0,0 +1,224 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"pr-reviewer-assigment-service/internal/application/repository"
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+type ImportRepo struct {
+	store *Store
+}
+
+func NewImportRepo(store *Store) *ImportRepo {
+	return &ImportRepo{store: store}
+}
+
+// Existing возвращает, какие из перечисленных ключей уже есть.
+func (r *ImportRepo) Existing(ctx context.Context, keys repository.ImportKeys) (repository.ImportKeys, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	found := repository.ImportKeys{
+		Teams:          existing(s.teams, keys.Teams),
+		Users:          existing(s.users, keys.Users),
+		PullRequests:   existing(s.prs, keys.PullRequests),
+		Escalations:    existing(s.escalations, keys.Escalations),
+		ReviewSLAs:     existing(s.slas, keys.ReviewSLAs),
+		Unavailability: existing(s.unavailability, keys.Unavailability),
+	}
+	for _, position := range keys.CodeOwnerRules {
+		if s.hasCodeOwnerRule(position) {
+			found.CodeOwnerRules = append(found.CodeOwnerRules, position)
+		}
+	}
+	for _, id := range keys.ReviewerRules {
+		if s.hasReviewerRule(id) {
+			found.ReviewerRules = append(found.ReviewerRules, id)
+		}
+	}
+	return found, nil
+}
+
+func existing[V any](m map[string]V, keys []string) []string {
+	var found []string
+	for _, k := range keys {
+		if _, ok := m[k]; ok {
+			found = append(found, k)
+		}
+	}
+	return found
+}
+
+// Load загружает записи целиком или не загружает ничего: сначала проверяется весь пакет.
+func (r *ImportRepo) Load(ctx context.Context, batch *domain.ImportBatch) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	teams := make(map[string]struct{}, len(batch.Teams))
+	for _, t := range batch.Teams {
+		if _, ok := s.teams[t.TeamName]; ok {
+			return repository.ErrAlreadyExists
+		}
+		if _, ok := teams[t.TeamName]; ok {
+			return repository.ErrAlreadyExists
+		}
+		teams[t.TeamName] = struct{}{}
+	}
+
+	users := make(map[string]struct{}, len(batch.Users))
+	for _, u := range batch.Users {
+		if _, ok := s.users[u.UserID]; ok {
+			return repository.ErrAlreadyExists
+		}
+		if _, ok := users[u.UserID]; ok {
+			return repository.ErrAlreadyExists
+		}
+		if _, inBatch := teams[u.TeamName]; !inBatch && !s.hasTeam(u.TeamName) {
+			return repository.ErrNotFound
+		}
+		users[u.UserID] = struct{}{}
+	}
+	knownUser := func(userID string) bool {
+		_, inBatch := users[userID]
+		_, inStore := s.users[userID]
+		return inBatch || inStore
+	}
+
+	prs := make(map[string]struct{}, len(batch.PullRequests))
+	for _, pr := range batch.PullRequests {
+		if _, ok := s.prs[pr.PullRequestID]; ok {
+			return repository.ErrAlreadyExists
+		}
+		if _, ok := prs[pr.PullRequestID]; ok {
+			return repository.ErrAlreadyExists
+		}
+		if !knownUser(pr.AuthorID) {
+			return repository.ErrNotFound
+		}
+		for _, id := range pr.AssignedReviewers {
+			if !knownUser(id) {
+				return repository.ErrNotFound
+			}
+		}
+		if _, err := pr.ReviewerAssignedTimes(); err != nil {
+			return err
+		}
+		prs[pr.PullRequestID] = struct{}{}
+	}
+
+	escalations := make(map[string]struct{}, len(batch.Escalations))
+	for _, e := range batch.Escalations {
+		if _, ok := s.escalations[e.PullRequestID]; ok {
+			return repository.ErrAlreadyExists
+		}
+		if _, ok := escalations[e.PullRequestID]; ok {
+			return repository.ErrAlreadyExists
+		}
+		_, prInBatch := prs[e.PullRequestID]
+		if _, prInStore := s.prs[e.PullRequestID]; !prInBatch && !prInStore {
+			return repository.ErrNotFound
+		}
+		escalations[e.PullRequestID] = struct{}{}
+	}
+
+	slas := make(map[string]struct{}, len(batch.ReviewSLAs))
+	for _, sla := range batch.ReviewSLAs {
+		if _, ok := s.slas[sla.TeamName]; ok {
+			return repository.ErrAlreadyExists
+		}
+		if _, ok := slas[sla.TeamName]; ok {
+			return repository.ErrAlreadyExists
+		}
+		if _, teamInBatch := teams[sla.TeamName]; !teamInBatch && !s.hasTeam(sla.TeamName) {
+			return repository.ErrNotFound
+		}
+		slas[sla.TeamName] = struct{}{}
+	}
+
+	positions := make(map[int]struct{}, len(batch.CodeOwnerRules))
+	for _, rule := range batch.CodeOwnerRules {
+		if _, ok := positions[rule.Position]; ok || s.hasCodeOwnerRule(rule.Position) {
+			return repository.ErrAlreadyExists
+		}
+		positions[rule.Position] = struct{}{}
+	}
+
+	rules := make(map[string]struct{}, len(batch.ReviewerRules))
+	for _, rule := range batch.ReviewerRules {
+		if _, ok := rules[rule.ID]; ok || s.hasReviewerRule(rule.ID) {
+			return repository.ErrAlreadyExists
+		}
+		for _, userID := range []string{rule.Author.UserID, rule.Reviewer.UserID} {
+			if userID != "" && !knownUser(userID) {
+				return repository.ErrNotFound
+			}
+		}
+		rules[rule.ID] = struct{}{}
+	}
+
+	periods := make(map[string]struct{}, len(batch.Unavailability))
+	for _, u := range batch.Unavailability {
+		if _, ok := s.unavailability[u.ID]; ok {
+			return repository.ErrAlreadyExists
+		}
+		if _, ok := periods[u.ID]; ok {
+			return repository.ErrAlreadyExists
+		}
+		if !knownUser(u.UserID) {
+			return repository.ErrNotFound
+		}
+		if !u.EndsAt.After(u.StartsAt) {
+			return fmt.Errorf("insert unavailability for %s: ends_at must be after starts_at", u.UserID)
+		}
+		periods[u.ID] = struct{}{}
+	}
+
+	for name := range teams {
+		s.teams[name] = struct{}{}
+	}
+	for _, u := range batch.Users {
+		c := cloneUser(&u.User)
+		s.users[u.UserID] = &c
+	}
+	for _, pr := range batch.PullRequests {
+		s.prs[pr.PullRequestID] = clonePullRequest(&pr.PullRequest)
+	}
+	for _, e := range batch.Escalations {
+		s.escalations[e.PullRequestID] = dbTime(e.EscalatedAt)
+	}
+	for _, sla := range batch.ReviewSLAs {
+		sla.SLA = sla.SLA.Truncate(time.Second)
+		s.slas[sla.TeamName] = sla.ReviewSLA
+	}
+
+	for _, rule := range batch.CodeOwnerRules {
+		s.codeOwners = append(s.codeOwners, rule.CodeOwnerRule)
+	}
+	s.codeOwners = cloneCodeOwnerRules(s.codeOwners)
+	slices.SortFunc(s.codeOwners, func(a, b domain.CodeOwnerRule) int { return a.Position - b.Position })
+
+	// Правила применяются в порядке создания: загруженные встают среди существующих по created_at, как в Postgres.
+	for _, rule := range batch.ReviewerRules {
+		rule.CreatedAt = dbTime(rule.CreatedAt)
+		s.rules = append(s.rules, rule.ReviewerRule)
+	}
+	slices.SortStableFunc(s.rules, func(a, b domain.ReviewerRule) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	for _, u := range batch.Unavailability {
+		s.unavailability[u.ID] = cloneUnavailability(&u.Unavailability)
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package memory_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"pr-reviewer-assigment-service/internal/application/repository/repositorytest"
+	"pr-reviewer-assigment-service/internal/domain"
+	"pr-reviewer-assigment-service/internal/infrastructure/memory"
+)
+
+func newRepos(t *testing.T) repositorytest.Repositories {
+	store := memory.NewStore()
+	return repositorytest.Repositories{
+		Users:          memory.NewUserRepo(store),
+		Teams:          memory.NewTeamRepo(store),
+		PullRequests:   memory.NewPullRequestRepo(store),
+		CodeOwners:     memory.NewCodeOwnerRepo(store),
+		SLAs:           memory.NewReviewSLARepo(store),
+		Rules:          memory.NewReviewerRuleRepo(store),
+		Unavailability: memory.NewUnavailabilityRepo(store),
+		Export:         memory.NewExportRepo(store),
+		Import:         memory.NewImportRepo(store),
+	}
+}
+
+func TestContract(t *testing.T) {
+	repositorytest.Run(t, newRepos)
+}
+
+func TestUserRepo_OnlyActive_SkipsUnavailable(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	teams := memory.NewTeamRepo(store)
+	users := memory.NewUserRepo(store)
+	periods := memory.NewUnavailabilityRepo(store)
+
+	if err := teams.Create(ctx, &domain.Team{TeamName: "backend"}); err != nil {
+		t.Fatalf("create team: %v", err)
+	}
+	if err := users.BulkUpsert(ctx, []domain.User{
+		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+	}); err != nil {
+		t.Fatalf("BulkUpsert: %v", err)
+	}
+
+	now := time.Now()
+	if err := periods.Create(ctx, &domain.Unavailability{
+		UserID:   "u2",
+		StartsAt: now.Add(-time.Hour),
+		EndsAt:   now.Add(time.Hour),
+	}); err != nil {
+		t.Fatalf("create unavailability: %v", err)
+	}
+
+	active, err := users.ListByTeam(ctx, "backend", true, now)
+	if err != nil {
+		t.Fatalf("ListByTeam: %v", err)
+	}
+	if len(active) != 1 || active[0].UserID != "u1" {
+		t.Fatalf("expected only u1, got %+v", active)
+	}
+
+	active, err = users.ListByTeam(ctx, "backend", true, now.Add(2*time.Hour))
+	if err != nil {
+		t.Fatalf("ListByTeam: %v", err)
+	}
+	if len(active) != 2 {
+		t.Fatalf("expected u2 back after the period, got %+v", active)
+	}
+}
+
+func TestStore_ConcurrentAccess(t *testing.T) {
+	ctx := context.Background()
+	repos := newRepos(t)
+
+	if err := repos.Teams.Create(ctx, &domain.Team{TeamName: "backend"}); err != nil {
+		t.Fatalf("create team: %v", err)
+	}
+	if err := repos.Users.BulkUpsert(ctx, []domain.User{
+		{UserID: "author", Username: "Author", TeamName: "backend", IsActive: true},
+		{UserID: "reviewer", Username: "Reviewer", TeamName: "backend", IsActive: true},
+	}); err != nil {
+		t.Fatalf("BulkUpsert: %v", err)
+	}
+
+	const workers = 8
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for i := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			created := time.Now()
+			id := fmt.Sprintf("pr-%d", i)
+			pr := &domain.PullRequest{
+				PullRequestID:     id,
+				PullRequestName:   id,
+				AuthorID:          "author",
+				Status:            string(domain.StatusOpen),
+				AssignedReviewers: []string{"reviewer"},
+				CreatedAt:         &created,
+			}
+			pr.MarkAssigned("reviewer", created)
+			if err := repos.PullRequests.Create(ctx, pr); err != nil {
+				errs <- err
+				return
+			}
+			if _, err := repos.Users.SetActive(ctx, "reviewer", i%2 == 0); err != nil {
+				errs <- err
+				return
+			}
+			if _, err := repos.Users.GetSummary(ctx, "reviewer"); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatalf("concurrent call: %v", err)
+	}
+
+	counts, err := repos.PullRequests.CountOpenByReviewers(ctx, []string{"reviewer"})
+	if err != nil {
+		t.Fatalf("CountOpenByReviewers: %v", err)
+	}
+	if counts["reviewer"] != workers {
+		t.Fatalf("expected %d open reviews, got %d", workers, counts["reviewer"])
+	}
+}
@@ -0,0 +1,172 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"pr-reviewer-assigment-service/internal/application/repository"
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+type PullRequestRepo struct {
+	store *Store
+}
+
+func NewPullRequestRepo(store *Store) *PullRequestRepo {
+	return &PullRequestRepo{store: store}
+}
+
+// checkPullRequest проверяет то, что в Postgres проверяют ограничения таблиц pull_requests и pull_request_reviewers.
+func (s *Store) checkPullRequest(pr *domain.PullRequest) error {
+	if pr.Status != string(domain.StatusOpen) && pr.Status != string(domain.StatusMerged) {
+		return fmt.Errorf("pull_request %s: invalid status %q", pr.PullRequestID, pr.Status)
+	}
+	if len(pr.AssignedReviewers) > domain.MaxReviewersPerPR {
+		return fmt.Errorf("pull_request %s: more than %d reviewers", pr.PullRequestID, domain.MaxReviewersPerPR)
+	}
+	for i, id := range pr.AssignedReviewers {
+		if slices.Contains(pr.AssignedReviewers[:i], id) {
+			return fmt.Errorf("pull_request %s: reviewer %s is assigned twice", pr.PullRequestID, id)
+		}
+	}
+	if _, err := pr.ReviewerAssignedTimes(); err != nil {
+		return err
+	}
+	if err := s.checkUsers("pull_request "+pr.PullRequestID+" author", pr.AuthorID); err != nil {
+		return err
+	}
+	return s.checkUsers("pull_request "+pr.PullRequestID+" reviewer", pr.AssignedReviewers...)
+}
+
+// Create создаёт новый PR. Время создания и назначения ревьюверов задаёт сервис, репозиторий его не выбирает.
+// Если PR с таким ID уже существует - возвращает repository.ErrAlreadyExists.
+func (r *PullRequestRepo) Create(ctx context.Context, pr *domain.PullRequest) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if pr.CreatedAt == nil {
+		return fmt.Errorf("insert pull_request %s: created_at is not set", pr.PullRequestID)
+	}
+	if _, ok := s.prs[pr.PullRequestID]; ok {
+		return repository.ErrAlreadyExists
+	}
+	if err := s.checkPullRequest(pr); err != nil {
+		return fmt.Errorf("insert %w", err)
+	}
+
+	s.prs[pr.PullRequestID] = clonePullRequest(pr)
+	return nil
+}
+
+// GetByID возвращает PR по ID.
+// Если не найден - возвращает repository.ErrNotFound.
+func (r *PullRequestRepo) GetByID(ctx context.Context, id string) (*domain.PullRequest, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	pr, ok := s.prs[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return clonePullRequest(pr), nil
+}
+
+// Update обновляет существующий PR (например, после merge или reassignment).
+// Если CreatedAt не задан, сохранённое время создания не меняется.
+// Время назначения ревьюверов берётся из pr.ReviewerAssignedAt, как и при создании.
+// Если PR не найден - возвращает repository.ErrNotFound.
+func (r *PullRequestRepo) Update(ctx context.Context, pr *domain.PullRequest) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.prs[pr.PullRequestID]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	if err := s.checkPullRequest(pr); err != nil {
+		return fmt.Errorf("update %w", err)
+	}
+
+	updated := clonePullRequest(pr)
+	if updated.CreatedAt == nil {
+		updated.CreatedAt = existing.CreatedAt
+	}
+	s.prs[pr.PullRequestID] = updated
+	return nil
+}
+
+// ListByReviewer возвращает список PR'ов, где пользователь назначен ревьювером.
+func (r *PullRequestRepo) ListByReviewer(ctx context.Context, reviewerID string) ([]domain.PullRequestShort, error) {
+	return r.listShort(func(pr *domain.PullRequest) bool { return slices.Contains(pr.AssignedReviewers, reviewerID) })
+}
+
+// ListByAuthor возвращает список PR'ов автора.
+func (r *PullRequestRepo) ListByAuthor(ctx context.Context, authorID string) ([]domain.PullRequestShort, error) {
+	return r.listShort(func(pr *domain.PullRequest) bool { return pr.AuthorID == authorID })
+}
+
+// listShort возвращает PR под условием match по возрастанию pull_request_id.
+func (r *PullRequestRepo) listShort(match func(pr *domain.PullRequest) bool) ([]domain.PullRequestShort, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var result []domain.PullRequestShort
+	for _, id := range sortedKeys(s.prs) {
+		pr := s.prs[id]
+		if match(pr) {
+			result = append(result, domain.PullRequestShort{
+				PullRequestID:   pr.PullRequestID,
+				PullRequestName: pr.PullRequestName,
+				AuthorID:        pr.AuthorID,
+				Status:          pr.Status,
+			})
+		}
+	}
+	return result, nil
+}
+
+// CountOpenByReviewers возвращает число открытых PR у каждого из ревьюверов.
+// Ревьюверов без открытых PR в результате нет.
+func (r *PullRequestRepo) CountOpenByReviewers(ctx context.Context, reviewerIDs []string) (map[string]int, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	counts := make(map[string]int, len(reviewerIDs))
+	for _, pr := range s.prs {
+		if pr.Status != string(domain.StatusOpen) {
+			continue
+		}
+		for _, id := range pr.AssignedReviewers {
+			if slices.Contains(reviewerIDs, id) {
+				counts[id]++
+			}
+		}
+	}
+	return counts, nil
+}
+
+// GetReviewerStats получает статистику назначений по ревьюверам.
+func (r *PullRequestRepo) GetReviewerStats(ctx context.Context) ([]domain.ReviewerStat, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	counts := make(map[string]int)
+	for _, pr := range s.prs {
+		for _, id := range pr.AssignedReviewers {
+			counts[id]++
+		}
+	}
+
+	stats := make([]domain.ReviewerStat, 0, len(counts))
+	for _, id := range sortedKeys(counts) {
+		stats = append(stats, domain.ReviewerStat{UserID: id, ReviewCount: counts[id]})
+	}
+	return stats, nil
+}
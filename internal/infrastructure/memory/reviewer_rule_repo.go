@@ -0,0 +1,64 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"pr-reviewer-assigment-service/internal/application/repository"
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+type ReviewerRuleRepo struct {
+	store *Store
+}
+
+func NewReviewerRuleRepo(store *Store) *ReviewerRuleRepo {
+	return &ReviewerRuleRepo{store: store}
+}
+
+// Create сохраняет правило. Если пользователя из селектора нет - repository.ErrNotFound.
+func (r *ReviewerRuleRepo) Create(ctx context.Context, rule *domain.ReviewerRule) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if slices.ContainsFunc(s.rules, func(existing domain.ReviewerRule) bool { return existing.ID == rule.ID }) {
+		return fmt.Errorf("insert reviewer rule %s: id is taken", rule.ID)
+	}
+	for _, userID := range []string{rule.Author.UserID, rule.Reviewer.UserID} {
+		if _, ok := s.users[userID]; userID != "" && !ok {
+			return repository.ErrNotFound
+		}
+	}
+
+	s.rules = append(s.rules, *rule)
+	return nil
+}
+
+// List возвращает все правила в порядке создания.
+func (r *ReviewerRuleRepo) List(ctx context.Context) (domain.RuleSet, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(s.rules) == 0 {
+		return nil, nil
+	}
+	return slices.Clone(domain.RuleSet(s.rules)), nil
+}
+
+// Delete удаляет правило и возвращает его. Если правила нет - repository.ErrNotFound.
+func (r *ReviewerRuleRepo) Delete(ctx context.Context, ruleID string) (*domain.ReviewerRule, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := slices.IndexFunc(s.rules, func(rule domain.ReviewerRule) bool { return rule.ID == ruleID })
+	if i < 0 {
+		return nil, repository.ErrNotFound
+	}
+	rule := s.rules[i]
+	s.rules = slices.Delete(s.rules, i, i+1)
+	return &rule, nil
+}
@@ -0,0 +1,105 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"pr-reviewer-assigment-service/internal/application/repository"
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+type ReviewSLARepo struct {
+	store *Store
+}
+
+func NewReviewSLARepo(store *Store) *ReviewSLARepo {
+	return &ReviewSLARepo{store: store}
+}
+
+// Set задаёт или заменяет SLA команды. Если команды нет - repository.ErrNotFound.
+func (r *ReviewSLARepo) Set(ctx context.Context, sla domain.ReviewSLA) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.teams[sla.TeamName]; !ok {
+		return repository.ErrNotFound
+	}
+	// SLA хранится с точностью до секунды, как sla_seconds в Postgres.
+	sla.SLA = sla.SLA.Truncate(time.Second)
+	s.slas[sla.TeamName] = sla
+	return nil
+}
+
+// Delete снимает SLA команды.
+func (r *ReviewSLARepo) Delete(ctx context.Context, teamName string) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.slas, teamName)
+	return nil
+}
+
+// ListOverdue возвращает открытые PR, у которых к моменту now истёк SLA команды автора.
+// Пустой teamName - все команды.
+func (r *ReviewSLARepo) ListOverdue(ctx context.Context, teamName string, now time.Time) ([]domain.OverduePullRequest, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]domain.OverduePullRequest, 0)
+	for _, pr := range s.prs {
+		if pr.Status != string(domain.StatusOpen) {
+			continue
+		}
+		author := s.users[pr.AuthorID]
+		sla, ok := s.slas[author.TeamName]
+		if !ok || (teamName != "" && sla.TeamName != teamName) {
+			continue
+		}
+		createdAt := dbTime(*pr.CreatedAt)
+		if createdAt.Add(sla.SLA).After(now) {
+			continue
+		}
+
+		o := domain.OverduePullRequest{
+			PullRequestID:     pr.PullRequestID,
+			PullRequestName:   pr.PullRequestName,
+			AuthorID:          pr.AuthorID,
+			TeamName:          sla.TeamName,
+			AssignedReviewers: cloneStrings(pr.AssignedReviewers),
+			CreatedAt:         createdAt,
+			SLA:               sla.SLA,
+			Policy:            sla.Policy,
+		}
+		if at, ok := s.escalations[pr.PullRequestID]; ok {
+			o.EscalatedAt = &at
+		}
+		result = append(result, o)
+	}
+
+	slices.SortFunc(result, func(a, b domain.OverduePullRequest) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.PullRequestID, b.PullRequestID)
+	})
+	return result, nil
+}
+
+// MarkEscalated запоминает момент последней эскалации PR.
+func (r *ReviewSLARepo) MarkEscalated(ctx context.Context, prID string, at time.Time) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.prs[prID]; !ok {
+		return fmt.Errorf("mark pull_request %s escalated: pull request does not exist", prID)
+	}
+	s.escalations[prID] = dbTime(at)
+	return nil
+}
@@ -0,0 +1,152 @@
+// Package memory хранит данные сервиса в памяти процесса: для быстрых тестов и демо-режима (STORAGE=memory).
+// Репозитории повторяют поведение Postgres-реализаций, включая repository.ErrNotFound и repository.ErrAlreadyExists
+// и проверки, которые в базе делают внешние ключи. Строки сравниваются побайтно, а не по правилам сортировки базы.
+// Данные теряются при остановке процесса.
+package memory
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+// Store - общее состояние всех репозиториев. Репозитории одного Store видят данные друг друга,
+// как таблицы одной базы. Все методы потокобезопасны.
+type Store struct {
+	mu sync.RWMutex
+
+	teams          map[string]struct{}
+	users          map[string]*domain.User
+	prs            map[string]*domain.PullRequest
+	unavailability map[string]*domain.Unavailability
+	codeOwners     []domain.CodeOwnerRule
+	rules          []domain.ReviewerRule // В порядке создания
+	slas           map[string]domain.ReviewSLA
+	escalations    map[string]time.Time
+	idempotency    map[string]*domain.IdempotencyRecord
+}
+
+func NewStore() *Store {
+	return &Store{
+		teams:          make(map[string]struct{}),
+		users:          make(map[string]*domain.User),
+		prs:            make(map[string]*domain.PullRequest),
+		unavailability: make(map[string]*domain.Unavailability),
+		slas:           make(map[string]domain.ReviewSLA),
+		escalations:    make(map[string]time.Time),
+		idempotency:    make(map[string]*domain.IdempotencyRecord),
+	}
+}
+
+// hasTeam сообщает, есть ли команда. Пустое имя - "вне команд", такая ссылка всегда допустима.
+func (s *Store) hasTeam(teamName string) bool {
+	if teamName == "" {
+		return true
+	}
+	_, ok := s.teams[teamName]
+	return ok
+}
+
+// available сообщает, активен ли пользователь и не находится ли в момент now в периоде недоступности.
+func (s *Store) available(u *domain.User, now time.Time) bool {
+	if !u.IsActive {
+		return false
+	}
+	for _, period := range s.unavailability {
+		if period.UserID == u.UserID && period.Covers(now) {
+			return false
+		}
+	}
+	return true
+}
+
+// openReviewCount считает открытые PR, где пользователь назначен ревьювером.
+func (s *Store) openReviewCount(userID string) int {
+	count := 0
+	for _, pr := range s.prs {
+		if pr.Status == string(domain.StatusOpen) && slices.Contains(pr.AssignedReviewers, userID) {
+			count++
+		}
+	}
+	return count
+}
+
+// checkUsers проверяет, что все пользователи существуют, как внешний ключ на users.
+func (s *Store) checkUsers(what string, userIDs ...string) error {
+	for _, id := range userIDs {
+		if _, ok := s.users[id]; !ok {
+			return fmt.Errorf("%s: user %s does not exist", what, id)
+		}
+	}
+	return nil
+}
+
+// hasCodeOwnerRule сообщает, занята ли позиция правилом CODEOWNERS.
+func (s *Store) hasCodeOwnerRule(position int) bool {
+	return slices.ContainsFunc(s.codeOwners, func(rule domain.CodeOwnerRule) bool { return rule.Position == position })
+}
+
+// hasReviewerRule сообщает, есть ли правило подбора с таким rule_id.
+func (s *Store) hasReviewerRule(ruleID string) bool {
+	return slices.ContainsFunc(s.rules, func(rule domain.ReviewerRule) bool { return rule.ID == ruleID })
+}
+
+// sortedKeys возвращает ключи map по возрастанию.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// dbTime округляет время до микросекунд, с которыми его хранит Postgres.
+func dbTime(t time.Time) time.Time {
+	return t.Round(time.Microsecond)
+}
+
+// dbTimePtr - dbTime для необязательного времени.
+func dbTimePtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	rounded := dbTime(*t)
+	return &rounded
+}
+
+// cloneStrings копирует список; nil превращается в пустой список, как TEXT[] NOT NULL DEFAULT '{}'.
+func cloneStrings(items []string) []string {
+	if items == nil {
+		return []string{}
+	}
+	return slices.Clone(items)
+}
+
+func cloneUser(u *domain.User) domain.User {
+	c := *u
+	c.Skills = cloneStrings(u.Skills)
+	if u.MaxOpenReviews != nil {
+		limit := *u.MaxOpenReviews
+		c.MaxOpenReviews = &limit
+	}
+	return c
+}
+
+func clonePullRequest(pr *domain.PullRequest) *domain.PullRequest {
+	c := *pr
+	c.AssignedReviewers = cloneStrings(pr.AssignedReviewers)
+	c.CreatedAt = dbTimePtr(pr.CreatedAt)
+	c.MergedAt = dbTimePtr(pr.MergedAt)
+	// Как в pull_request_reviewers: время хранится только у назначенных ревьюверов.
+	c.ReviewerAssignedAt = make(map[string]time.Time, len(pr.AssignedReviewers))
+	for _, id := range pr.AssignedReviewers {
+		if at, ok := pr.ReviewerAssignedAt[id]; ok {
+			c.ReviewerAssignedAt[id] = dbTime(at)
+		}
+	}
+	return &c
+}
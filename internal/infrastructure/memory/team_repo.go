@@ -0,0 +1,156 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"pr-reviewer-assigment-service/internal/application/repository"
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+type TeamRepo struct {
+	store *Store
+}
+
+func NewTeamRepo(store *Store) *TeamRepo {
+	return &TeamRepo{store: store}
+}
+
+// Create создаёт новую команду.
+// Если команда с таким именем уже существует - возвращает repository.ErrAlreadyExists.
+func (r *TeamRepo) Create(ctx context.Context, team *domain.Team) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.teams[team.TeamName]; ok {
+		return repository.ErrAlreadyExists
+	}
+	s.teams[team.TeamName] = struct{}{}
+	return nil
+}
+
+// GetByName возвращает команду вместе с участниками, упорядоченными по user_id.
+// Если команда не найдена - возвращает repository.ErrNotFound.
+func (r *TeamRepo) GetByName(ctx context.Context, teamName string) (*domain.Team, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.teams[teamName]; !ok {
+		return nil, repository.ErrNotFound
+	}
+
+	members := make([]domain.TeamMember, 0)
+	for _, id := range sortedKeys(s.users) {
+		u := s.users[id]
+		if u.TeamName == teamName {
+			members = append(members, domain.TeamMember{UserID: u.UserID, Username: u.Username, IsActive: u.IsActive})
+		}
+	}
+
+	return &domain.Team{TeamName: teamName, Members: members}, nil
+}
+
+// Rename переименовывает команду. Участники, SLA и владение кодом переезжают вместе с ней.
+// Если команды нет - repository.ErrNotFound, если новое имя занято - repository.ErrAlreadyExists.
+func (r *TeamRepo) Rename(ctx context.Context, oldName, newName string) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.teams[oldName]; !ok {
+		return repository.ErrNotFound
+	}
+	if oldName == newName {
+		return nil
+	}
+	if _, ok := s.teams[newName]; ok {
+		return repository.ErrAlreadyExists
+	}
+
+	delete(s.teams, oldName)
+	s.teams[newName] = struct{}{}
+	for _, u := range s.users {
+		if u.TeamName == oldName {
+			u.TeamName = newName
+		}
+	}
+	if sla, ok := s.slas[oldName]; ok {
+		delete(s.slas, oldName)
+		sla.TeamName = newName
+		s.slas[newName] = sla
+	}
+	for i := range s.codeOwners {
+		for j, team := range s.codeOwners[i].Teams {
+			if team == oldName {
+				s.codeOwners[i].Teams[j] = newName
+			}
+		}
+	}
+	return nil
+}
+
+// Delete удаляет команду вместе с её SLA и убирает её из владельцев кода. Если команды нет - repository.ErrNotFound.
+// Пока в команде есть участники, удаление запрещено.
+func (r *TeamRepo) Delete(ctx context.Context, teamName string) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.teams[teamName]; !ok {
+		return repository.ErrNotFound
+	}
+	for _, u := range s.users {
+		if u.TeamName == teamName {
+			return fmt.Errorf("delete team %s: user %s is still a member", teamName, u.UserID)
+		}
+	}
+
+	delete(s.teams, teamName)
+	delete(s.slas, teamName)
+	for i := range s.codeOwners {
+		s.codeOwners[i].Teams = slices.DeleteFunc(s.codeOwners[i].Teams, func(team string) bool {
+			return team == teamName
+		})
+	}
+	return nil
+}
+
+// List возвращает страницу команд, упорядоченных по имени, и общее число команд под фильтром.
+// Префикс сравнивается без учёта регистра.
+func (r *TeamRepo) List(ctx context.Context, filter repository.TeamListFilter) ([]domain.TeamSummary, int, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	prefix := strings.ToLower(filter.Prefix)
+	var names []string
+	for _, name := range sortedKeys(s.teams) {
+		if strings.HasPrefix(strings.ToLower(name), prefix) {
+			names = append(names, name)
+		}
+	}
+	total := len(names)
+
+	start := min(max(filter.Offset, 0), total)
+	end := min(start+max(filter.Limit, 0), total)
+
+	teams := make([]domain.TeamSummary, 0, end-start)
+	for _, name := range names[start:end] {
+		t := domain.TeamSummary{TeamName: name}
+		for _, u := range s.users {
+			if u.TeamName == name {
+				t.MemberCount++
+				if u.IsActive {
+					t.ActiveMemberCount++
+				}
+			}
+		}
+		teams = append(teams, t)
+	}
+
+	return teams, total, nil
+}
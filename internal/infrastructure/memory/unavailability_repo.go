@@ -0,0 +1,111 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"pr-reviewer-assigment-service/internal/application/repository"
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+type UnavailabilityRepo struct {
+	store *Store
+}
+
+func NewUnavailabilityRepo(store *Store) *UnavailabilityRepo {
+	return &UnavailabilityRepo{store: store}
+}
+
+// Create сохраняет новый период недоступности.
+func (r *UnavailabilityRepo) Create(ctx context.Context, u *domain.Unavailability) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.unavailability[u.ID]; ok {
+		return fmt.Errorf("insert unavailability for %s: id %s is taken", u.UserID, u.ID)
+	}
+	if err := s.checkUsers("insert unavailability", u.UserID); err != nil {
+		return err
+	}
+	if !u.EndsAt.After(u.StartsAt) {
+		return fmt.Errorf("insert unavailability for %s: ends_at must be after starts_at", u.UserID)
+	}
+
+	s.unavailability[u.ID] = cloneUnavailability(u)
+	return nil
+}
+
+// ListByUser возвращает незакончившиеся периоды пользователя по времени начала.
+func (r *UnavailabilityRepo) ListByUser(ctx context.Context, userID string, now time.Time) ([]domain.Unavailability, error) {
+	return r.list(func(u *domain.Unavailability) bool {
+		return u.UserID == userID && u.EndsAt.After(now)
+	})
+}
+
+// Delete удаляет период и возвращает его. Если периода нет - repository.ErrNotFound.
+func (r *UnavailabilityRepo) Delete(ctx context.Context, id string) (*domain.Unavailability, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, ok := s.unavailability[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	delete(s.unavailability, id)
+	return u, nil
+}
+
+// ListUnreleased возвращает идущие сейчас периоды, по которым пользователя ещё не снимали с ревью.
+func (r *UnavailabilityRepo) ListUnreleased(ctx context.Context, now time.Time) ([]domain.Unavailability, error) {
+	return r.list(func(u *domain.Unavailability) bool {
+		return u.ReleasedAt == nil && u.Covers(now)
+	})
+}
+
+// MarkReleased отмечает, что пользователь снят с ревью.
+func (r *UnavailabilityRepo) MarkReleased(ctx context.Context, id string, at time.Time) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, ok := s.unavailability[id]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	u.ReleasedAt = dbTimePtr(&at)
+	return nil
+}
+
+// list возвращает периоды под условием match по времени начала.
+func (r *UnavailabilityRepo) list(match func(u *domain.Unavailability) bool) ([]domain.Unavailability, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]domain.Unavailability, 0)
+	for _, u := range s.unavailability {
+		if match(u) {
+			result = append(result, *cloneUnavailability(u))
+		}
+	}
+	slices.SortFunc(result, func(a, b domain.Unavailability) int {
+		if c := a.StartsAt.Compare(b.StartsAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return result, nil
+}
+
+func cloneUnavailability(u *domain.Unavailability) *domain.Unavailability {
+	c := *u
+	c.StartsAt = dbTime(u.StartsAt)
+	c.EndsAt = dbTime(u.EndsAt)
+	c.ReleasedAt = dbTimePtr(u.ReleasedAt)
+	return &c
+}
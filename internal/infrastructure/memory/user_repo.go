@@ -0,0 +1,196 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"pr-reviewer-assigment-service/internal/application/repository"
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+type UserRepo struct {
+	store *Store
+}
+
+func NewUserRepo(store *Store) *UserRepo {
+	return &UserRepo{store: store}
+}
+
+// BulkUpsert создаёт или обновляет нескольких пользователей.
+// Лимит открытых ревью и навыки у существующих пользователей не меняются.
+// Если команда какого-то пользователя не существует, ничего не меняется.
+func (r *UserRepo) BulkUpsert(ctx context.Context, users []domain.User) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, u := range users {
+		if !s.hasTeam(u.TeamName) {
+			return fmt.Errorf("bulk upsert users: team %s does not exist", u.TeamName)
+		}
+	}
+
+	for _, u := range users {
+		if existing, ok := s.users[u.UserID]; ok {
+			existing.Username = u.Username
+			existing.TeamName = u.TeamName
+			existing.IsActive = u.IsActive
+			continue
+		}
+		s.users[u.UserID] = &domain.User{
+			UserID:   u.UserID,
+			Username: u.Username,
+			TeamName: u.TeamName,
+			IsActive: u.IsActive,
+			Skills:   []string{},
+		}
+	}
+	return nil
+}
+
+// GetByID возвращает пользователя по user_id.
+func (r *UserRepo) GetByID(ctx context.Context, userID string) (*domain.User, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	u, ok := s.users[userID]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	c := cloneUser(u)
+	return &c, nil
+}
+
+// update применяет change к пользователю и возвращает обновлённого пользователя.
+// Ошибка change отменяет изменение.
+func (r *UserRepo) update(userID string, change func(u *domain.User) error) (*domain.User, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, ok := s.users[userID]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	if err := change(u); err != nil {
+		return nil, err
+	}
+	c := cloneUser(u)
+	return &c, nil
+}
+
+// SetActive обновляет флаг активности пользователя и возвращает обновлённого пользователя.
+func (r *UserRepo) SetActive(ctx context.Context, userID string, active bool) (*domain.User, error) {
+	return r.update(userID, func(u *domain.User) error {
+		u.IsActive = active
+		return nil
+	})
+}
+
+// SetTeam переводит пользователя в команду и возвращает обновлённого пользователя.
+// Пустой teamName выводит пользователя из команды.
+func (r *UserRepo) SetTeam(ctx context.Context, userID string, teamName string) (*domain.User, error) {
+	return r.update(userID, func(u *domain.User) error {
+		if !r.store.hasTeam(teamName) {
+			return fmt.Errorf("update user team_name: team %s does not exist", teamName)
+		}
+		u.TeamName = teamName
+		return nil
+	})
+}
+
+// SetMaxOpenReviews задаёт лимит открытых ревью пользователя и возвращает обновлённого пользователя.
+// nil снимает лимит.
+func (r *UserRepo) SetMaxOpenReviews(ctx context.Context, userID string, limit *int) (*domain.User, error) {
+	return r.update(userID, func(u *domain.User) error {
+		if limit != nil && *limit < 0 {
+			return fmt.Errorf("update user max_open_reviews: limit must not be negative")
+		}
+		u.MaxOpenReviews = nil
+		if limit != nil {
+			l := *limit
+			u.MaxOpenReviews = &l
+		}
+		return nil
+	})
+}
+
+// SetSkills заменяет теги экспертизы пользователя и возвращает обновлённого пользователя.
+func (r *UserRepo) SetSkills(ctx context.Context, userID string, skills []string) (*domain.User, error) {
+	return r.update(userID, func(u *domain.User) error {
+		u.Skills = cloneStrings(skills)
+		return nil
+	})
+}
+
+// ListByIDs возвращает существующих пользователей из списка.
+// Если onlyActive == true, возвращаются только активные пользователи, которые в момент now не в периоде недоступности.
+func (r *UserRepo) ListByIDs(ctx context.Context, userIDs []string, onlyActive bool, now time.Time) ([]domain.User, error) {
+	return r.list(onlyActive, now, func(u *domain.User) bool { return slices.Contains(userIDs, u.UserID) })
+}
+
+// ListByTeam возвращает пользователей команды.
+// Если onlyActive == true, возвращаются только активные пользователи, которые в момент now не в периоде недоступности.
+func (r *UserRepo) ListByTeam(ctx context.Context, teamName string, onlyActive bool, now time.Time) ([]domain.User, error) {
+	return r.list(onlyActive, now, func(u *domain.User) bool { return teamName != "" && u.TeamName == teamName })
+}
+
+// list возвращает пользователей под условием match по возрастанию user_id.
+func (r *UserRepo) list(onlyActive bool, now time.Time, match func(u *domain.User) bool) ([]domain.User, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var result []domain.User
+	for _, id := range sortedKeys(s.users) {
+		u := s.users[id]
+		if match(u) && (!onlyActive || s.available(u, now)) {
+			result = append(result, cloneUser(u))
+		}
+	}
+	return result, nil
+}
+
+// GetSummary возвращает пользователя вместе с числом открытых ревью.
+// Если пользователь не найден - возвращает repository.ErrNotFound.
+func (r *UserRepo) GetSummary(ctx context.Context, userID string) (*domain.UserSummary, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	u, ok := s.users[userID]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &domain.UserSummary{User: cloneUser(u), OpenReviewCount: s.openReviewCount(userID)}, nil
+}
+
+// List возвращает пользователей под фильтром, упорядоченных по user_id.
+// Пагинация по ключу: следующая страница начинается после filter.AfterUserID.
+func (r *UserRepo) List(ctx context.Context, filter repository.UserListFilter) ([]domain.UserSummary, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	username := strings.ToLower(filter.Username)
+	result := make([]domain.UserSummary, 0, max(filter.Limit, 0))
+	for _, id := range sortedKeys(s.users) {
+		if len(result) >= filter.Limit {
+			break
+		}
+		u := s.users[id]
+		switch {
+		case u.UserID <= filter.AfterUserID:
+		case filter.TeamName != "" && u.TeamName != filter.TeamName:
+		case filter.IsActive != nil && u.IsActive != *filter.IsActive:
+		case !strings.Contains(strings.ToLower(u.Username), username):
+		default:
+			result = append(result, domain.UserSummary{User: cloneUser(u), OpenReviewCount: s.openReviewCount(u.UserID)})
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+type CodeOwnerDb struct {
+	pool *pgxpool.Pool
+}
+
+func NewCodeOwnerDb(pool *pgxpool.Pool) *CodeOwnerDb {
+	return &CodeOwnerDb{pool: pool}
+}
+
+// Replace удаляет старые правила и сохраняет новые в одной транзакции.
+func (r *CodeOwnerDb) Replace(ctx context.Context, rules []domain.CodeOwnerRule) (err error) {
+	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+			return
+		}
+		err = tx.Commit(ctx)
+	}()
+
+	if _, err = tx.Exec(ctx, `DELETE FROM code_owner_rules`); err != nil {
+		return fmt.Errorf("delete code owner rules: %w", err)
+	}
+
+	const query = `
+		INSERT INTO code_owner_rules (position, pattern, owner_users, owner_teams)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	for _, rule := range rules {
+		users, teams := rule.Users, rule.Teams
+		if users == nil {
+			users = []string{}
+		}
+		if teams == nil {
+			teams = []string{}
+		}
+		if _, err = tx.Exec(ctx, query, rule.Position, rule.Pattern, users, teams); err != nil {
+			return fmt.Errorf("insert code owner rule at line %d: %w", rule.Position, err)
+		}
+	}
+
+	return nil
+}
+
+// List возвращает правила в порядке файла.
+func (r *CodeOwnerDb) List(ctx context.Context) ([]domain.CodeOwnerRule, error) {
+	const query = `
+		SELECT position, pattern, owner_users, owner_teams
+		FROM code_owner_rules
+		ORDER BY position
+	`
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("list code owner rules: %w", err)
+	}
+	defer rows.Close()
+
+	var result []domain.CodeOwnerRule
+	for rows.Next() {
+		var rule domain.CodeOwnerRule
+		if err := rows.Scan(&rule.Position, &rule.Pattern, &rule.Users, &rule.Teams); err != nil {
+			return nil, fmt.Errorf("scan code owner rule: %w", err)
+		}
+		result = append(result, rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate code owner rules: %w", err)
+	}
+
+	return result, nil
+}
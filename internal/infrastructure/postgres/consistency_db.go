@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+type ConsistencyDb struct {
+	pool *pgxpool.Pool
+}
+
+func NewConsistencyDb(pool *pgxpool.Pool) *ConsistencyDb {
+	return &ConsistencyDb{pool: pool}
+}
+
+// Check ищет ссылки на несуществующих ревьюверов, неактивных ревьюверов открытых PR и авторов открытых PR вне команд.
+func (r *ConsistencyDb) Check(ctx context.Context) ([]domain.ConsistencyIssue, error) {
+	const query = `
+		SELECT 'DANGLING_REVIEWER', prr.pull_request_id, prr.user_id
+		FROM pull_request_reviewers prr
+		WHERE NOT EXISTS (SELECT 1 FROM users u WHERE u.user_id = prr.user_id)
+
+		UNION ALL
+
+		SELECT 'INACTIVE_REVIEWER', prr.pull_request_id, prr.user_id
+		FROM pull_request_reviewers prr
+		JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id
+		JOIN users u ON u.user_id = prr.user_id
+		WHERE pr.status = 'OPEN' AND NOT u.is_active
+
+		UNION ALL
+
+		SELECT 'AUTHOR_WITHOUT_TEAM', pr.pull_request_id, pr.author_id
+		FROM pull_requests pr
+		JOIN users u ON u.user_id = pr.author_id
+		WHERE pr.status = 'OPEN' AND u.team_name IS NULL
+
+		ORDER BY 1, 2, 3
+	`
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("query consistency issues: %w", err)
+	}
+
+	issues, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.ConsistencyIssue, error) {
+		var issue domain.ConsistencyIssue
+		err := row.Scan(&issue.Kind, &issue.PullRequestID, &issue.UserID)
+		return issue, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan consistency issues: %w", err)
+	}
+	return issues, nil
+}
+
+// RemoveDanglingReviewers удаляет назначения несуществующих пользователей.
+// Схему не меняет: внешний ключ fk_pull_request_reviewers_user проверяется миграцией 015.
+func (r *ConsistencyDb) RemoveDanglingReviewers(ctx context.Context) (int, error) {
+	cmdTag, err := r.pool.Exec(ctx, `
+		DELETE FROM pull_request_reviewers prr
+		WHERE NOT EXISTS (SELECT 1 FROM users u WHERE u.user_id = prr.user_id)
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("delete dangling reviewers: %w", err)
+	}
+	return int(cmdTag.RowsAffected()), nil
+}
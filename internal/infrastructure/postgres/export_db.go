@@ -0,0 +1,144 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"pr-reviewer-assigment-service/internal/application/repository"
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+type ExportDb struct {
+	pool *pgxpool.Pool
+}
+
+func NewExportDb(pool *pgxpool.Pool) *ExportDb {
+	return &ExportDb{pool: pool}
+}
+
+// Export читает все таблицы в одной транзакции REPEATABLE READ, чтобы выгрузка была согласованной.
+// Строки передаются sink по мере получения от сервера.
+func (r *ExportDb) Export(ctx context.Context, sink repository.ExportSink) error {
+	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var teamName string
+	err = forEachRow(ctx, tx, `SELECT team_name FROM teams ORDER BY team_name`,
+		[]any{&teamName}, func() error { return sink.Team(teamName) })
+	if err != nil {
+		return fmt.Errorf("export teams: %w", err)
+	}
+
+	var u domain.User
+	err = forEachRow(ctx, tx, `SELECT `+userColumns+` FROM users ORDER BY user_id`,
+		userScanTargets(&u), func() error { return sink.User(&u) })
+	if err != nil {
+		return fmt.Errorf("export users: %w", err)
+	}
+
+	prQuery := `
+		SELECT
+			pr.pull_request_id,
+			pr.pull_request_name,
+			pr.author_id,
+			pr.status,
+			` + reviewersColumn + `,
+			` + reviewerTimesColumn + `,
+			pr.created_at,
+			pr.merged_at
+		FROM pull_requests pr
+		ORDER BY pr.created_at, pr.pull_request_id
+	`
+	var (
+		pr            domain.PullRequest
+		reviewerTimes []time.Time
+		createdAt     pgtype.Timestamptz
+		mergedAt      pgtype.Timestamptz
+	)
+	err = forEachRow(ctx, tx, prQuery,
+		[]any{&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &pr.AssignedReviewers, &reviewerTimes, &createdAt, &mergedAt},
+		func() error {
+			setReviewerTimes(&pr, reviewerTimes)
+			pr.CreatedAt, pr.MergedAt = nil, nil
+			if createdAt.Valid {
+				t := createdAt.Time
+				pr.CreatedAt = &t
+			}
+			if mergedAt.Valid {
+				t := mergedAt.Time
+				pr.MergedAt = &t
+			}
+			return sink.PullRequest(&pr)
+		})
+	if err != nil {
+		return fmt.Errorf("export pull_requests: %w", err)
+	}
+
+	var (
+		prID        string
+		escalatedAt time.Time
+	)
+	err = forEachRow(ctx, tx, `SELECT pull_request_id, escalated_at FROM review_escalations ORDER BY pull_request_id`,
+		[]any{&prID, &escalatedAt}, func() error { return sink.Escalation(prID, escalatedAt) })
+	if err != nil {
+		return fmt.Errorf("export review_escalations: %w", err)
+	}
+
+	var (
+		sla        domain.ReviewSLA
+		slaSeconds int64
+	)
+	err = forEachRow(ctx, tx, `SELECT team_name, sla_seconds, policy FROM review_slas ORDER BY team_name`,
+		[]any{&sla.TeamName, &slaSeconds, &sla.Policy}, func() error {
+			sla.SLA = time.Duration(slaSeconds) * time.Second
+			return sink.ReviewSLA(&sla)
+		})
+	if err != nil {
+		return fmt.Errorf("export review_slas: %w", err)
+	}
+
+	var owners domain.CodeOwnerRule
+	err = forEachRow(ctx, tx, `SELECT position, pattern, owner_users, owner_teams FROM code_owner_rules ORDER BY position`,
+		[]any{&owners.Position, &owners.Pattern, &owners.Users, &owners.Teams}, func() error { return sink.CodeOwnerRule(&owners) })
+	if err != nil {
+		return fmt.Errorf("export code_owner_rules: %w", err)
+	}
+
+	var rule domain.ReviewerRule
+	err = forEachRow(ctx, tx, `SELECT `+reviewerRuleColumns+` FROM reviewer_rules ORDER BY created_at, rule_id`,
+		[]any{
+			&rule.ID, &rule.Kind, &rule.Author.UserID, &rule.Author.Skill,
+			&rule.Reviewer.UserID, &rule.Reviewer.Skill, &rule.Description, &rule.CreatedAt,
+		}, func() error { return sink.ReviewerRule(&rule) })
+	if err != nil {
+		return fmt.Errorf("export reviewer_rules: %w", err)
+	}
+
+	var period domain.Unavailability
+	err = forEachRow(ctx, tx, `SELECT `+unavailabilityColumns+` FROM user_unavailability ORDER BY starts_at, unavailability_id`,
+		[]any{&period.ID, &period.UserID, &period.StartsAt, &period.EndsAt, &period.Reason, &period.ReleasedAt},
+		func() error { return sink.Unavailability(&period) })
+	if err != nil {
+		return fmt.Errorf("export user_unavailability: %w", err)
+	}
+
+	return nil
+}
+
+// forEachRow выполняет запрос и для каждой строки сканирует её в dest и вызывает fn.
+func forEachRow(ctx context.Context, tx pgx.Tx, query string, dest []any, fn func() error) error {
+	rows, err := tx.Query(ctx, query)
+	if err != nil {
+		return err
+	}
+	_, err = pgx.ForEachRow(rows, dest, fn)
+	return err
+}
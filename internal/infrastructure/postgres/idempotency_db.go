@@ -0,0 +1,160 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"pr-reviewer-assigment-service/internal/application/repository"
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+// reserveAttempts - сколько раз Reserve пробует занять ключ, который параллельно освобождают.
+const reserveAttempts = 3
+
+type IdempotencyDb struct {
+	pool *pgxpool.Pool
+}
+
+func NewIdempotencyDb(pool *pgxpool.Pool) *IdempotencyDb {
+	return &IdempotencyDb{pool: pool}
+}
+
+// Reserve занимает ключ под новый запрос.
+// Если ключ уже занят действующей записью - возвращает её и repository.ErrAlreadyExists.
+func (r *IdempotencyDb) Reserve(
+	ctx context.Context,
+	rec *domain.IdempotencyRecord,
+	staleBefore time.Time,
+) (*domain.IdempotencyRecord, error) {
+	// Между неудачной вставкой и чтением ключ могут освободить (Release, DeleteExpired) - тогда пробуем снова.
+	for range reserveAttempts {
+		existing, err := r.reserveOnce(ctx, rec, staleBefore)
+		if !errors.Is(err, repository.ErrNotFound) {
+			return existing, err
+		}
+	}
+	return nil, repository.ErrAlreadyExists
+}
+
+// reserveOnce делает одну попытку занять ключ.
+// Если ключ занят, но исчез до чтения записи, возвращает repository.ErrNotFound.
+func (r *IdempotencyDb) reserveOnce(
+	ctx context.Context,
+	rec *domain.IdempotencyRecord,
+	staleBefore time.Time,
+) (*domain.IdempotencyRecord, error) {
+	const reserveQuery = `
+		INSERT INTO idempotency_keys (key, request_hash, created_at, expires_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (key) DO UPDATE SET
+			request_hash = EXCLUDED.request_hash,
+			status_code  = NULL,
+			content_type = NULL,
+			body         = NULL,
+			created_at   = EXCLUDED.created_at,
+			expires_at   = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= EXCLUDED.created_at
+		   OR (idempotency_keys.status_code IS NULL AND idempotency_keys.created_at < $5)
+		RETURNING key
+	`
+
+	var key string
+	err := r.pool.QueryRow(ctx, reserveQuery,
+		rec.Key,
+		rec.RequestHash,
+		rec.CreatedAt,
+		rec.ExpiresAt,
+		staleBefore,
+	).Scan(&key)
+	if err == nil {
+		return nil, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("reserve idempotency key: %w", err)
+	}
+
+	const selectQuery = `
+		SELECT key, request_hash, status_code, content_type, body, created_at, expires_at
+		FROM idempotency_keys
+		WHERE key = $1
+	`
+
+	var (
+		existing    domain.IdempotencyRecord
+		statusCode  pgtype.Int4
+		contentType pgtype.Text
+	)
+	err = r.pool.QueryRow(ctx, selectQuery, rec.Key).Scan(
+		&existing.Key,
+		&existing.RequestHash,
+		&statusCode,
+		&contentType,
+		&existing.Body,
+		&existing.CreatedAt,
+		&existing.ExpiresAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("query idempotency key: %w", err)
+	}
+	if statusCode.Valid {
+		existing.StatusCode = int(statusCode.Int32)
+	}
+	existing.ContentType = contentType.String
+
+	return &existing, repository.ErrAlreadyExists
+}
+
+// Complete сохраняет ответ для занятого ключа.
+// Если ключ не найден - возвращает repository.ErrNotFound.
+func (r *IdempotencyDb) Complete(ctx context.Context, key string, statusCode int, contentType string, body []byte) error {
+	const query = `
+		UPDATE idempotency_keys
+		SET status_code = $2, content_type = $3, body = $4
+		WHERE key = $1
+	`
+
+	cmdTag, err := r.pool.Exec(ctx, query, key, statusCode, contentType, body)
+	if err != nil {
+		return fmt.Errorf("complete idempotency key: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// Release освобождает незавершённый ключ.
+func (r *IdempotencyDb) Release(ctx context.Context, key string) error {
+	const query = `
+		DELETE FROM idempotency_keys
+		WHERE key = $1 AND status_code IS NULL
+	`
+
+	if _, err := r.pool.Exec(ctx, query, key); err != nil {
+		return fmt.Errorf("release idempotency key: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpired удаляет просроченные записи и возвращает их количество.
+func (r *IdempotencyDb) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	const query = `
+		DELETE FROM idempotency_keys
+		WHERE expires_at <= $1
+	`
+
+	cmdTag, err := r.pool.Exec(ctx, query, now)
+	if err != nil {
+		return 0, fmt.Errorf("delete expired idempotency keys: %w", err)
+	}
+	return cmdTag.RowsAffected(), nil
+}
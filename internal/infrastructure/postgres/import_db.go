@@ -0,0 +1,233 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"pr-reviewer-assigment-service/internal/application/repository"
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+// importBatchSize - сколько строк отправляется одним COPY.
+const importBatchSize = 1000
+
+type ImportDb struct {
+	pool *pgxpool.Pool
+}
+
+func NewImportDb(pool *pgxpool.Pool) *ImportDb {
+	return &ImportDb{pool: pool}
+}
+
+// Existing возвращает, какие из перечисленных ключей уже есть в базе.
+func (r *ImportDb) Existing(ctx context.Context, keys repository.ImportKeys) (repository.ImportKeys, error) {
+	var (
+		found repository.ImportKeys
+		err   error
+	)
+
+	found.Teams, err = existing(ctx, r.pool, `SELECT team_name FROM teams WHERE team_name = ANY($1)`, keys.Teams)
+	if err != nil {
+		return found, fmt.Errorf("query existing teams: %w", err)
+	}
+	found.Users, err = existing(ctx, r.pool, `SELECT user_id FROM users WHERE user_id = ANY($1)`, keys.Users)
+	if err != nil {
+		return found, fmt.Errorf("query existing users: %w", err)
+	}
+	found.PullRequests, err = existing(ctx, r.pool, `SELECT pull_request_id FROM pull_requests WHERE pull_request_id = ANY($1)`, keys.PullRequests)
+	if err != nil {
+		return found, fmt.Errorf("query existing pull_requests: %w", err)
+	}
+	found.Escalations, err = existing(ctx, r.pool, `SELECT pull_request_id FROM review_escalations WHERE pull_request_id = ANY($1)`, keys.Escalations)
+	if err != nil {
+		return found, fmt.Errorf("query existing review_escalations: %w", err)
+	}
+	found.ReviewSLAs, err = existing(ctx, r.pool, `SELECT team_name FROM review_slas WHERE team_name = ANY($1)`, keys.ReviewSLAs)
+	if err != nil {
+		return found, fmt.Errorf("query existing review_slas: %w", err)
+	}
+	found.CodeOwnerRules, err = existing(ctx, r.pool, `SELECT position FROM code_owner_rules WHERE position = ANY($1)`, keys.CodeOwnerRules)
+	if err != nil {
+		return found, fmt.Errorf("query existing code_owner_rules: %w", err)
+	}
+	found.ReviewerRules, err = existing(ctx, r.pool, `SELECT rule_id FROM reviewer_rules WHERE rule_id = ANY($1)`, keys.ReviewerRules)
+	if err != nil {
+		return found, fmt.Errorf("query existing reviewer_rules: %w", err)
+	}
+	found.Unavailability, err = existing(ctx, r.pool, `SELECT unavailability_id FROM user_unavailability WHERE unavailability_id = ANY($1)`, keys.Unavailability)
+	if err != nil {
+		return found, fmt.Errorf("query existing user_unavailability: %w", err)
+	}
+
+	return found, nil
+}
+
+func existing[K any](ctx context.Context, pool *pgxpool.Pool, query string, keys []K) ([]K, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	rows, err := pool.Query(ctx, query, keys)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[K])
+}
+
+// Load загружает записи в одной транзакции пакетами по importBatchSize строк через COPY.
+func (r *ImportDb) Load(ctx context.Context, batch *domain.ImportBatch) (err error) {
+	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+			return
+		}
+		err = tx.Commit(ctx)
+	}()
+
+	if err = copyBatches(ctx, tx, "teams", []string{"team_name"}, batch.Teams,
+		func(t domain.ImportTeam) []any {
+			return []any{t.TeamName}
+		}); err != nil {
+		return err
+	}
+
+	if err = copyBatches(ctx, tx, "users",
+		[]string{"user_id", "username", "team_name", "is_active", "max_open_reviews", "skills"}, batch.Users,
+		func(u domain.ImportUser) []any {
+			skills := u.Skills
+			if skills == nil {
+				skills = []string{}
+			}
+			var teamName any // Пользователь без команды хранится с NULL
+			if u.TeamName != "" {
+				teamName = u.TeamName
+			}
+			return []any{u.UserID, u.Username, teamName, u.IsActive, u.MaxOpenReviews, skills}
+		}); err != nil {
+		return err
+	}
+
+	if err = copyBatches(ctx, tx, "pull_requests",
+		[]string{"pull_request_id", "pull_request_name", "author_id", "status", "created_at", "merged_at"},
+		batch.PullRequests,
+		func(pr domain.ImportPullRequest) []any {
+			return []any{pr.PullRequestID, pr.PullRequestName, pr.AuthorID, pr.Status, pr.CreatedAt, pr.MergedAt}
+		}); err != nil {
+		return err
+	}
+
+	var reviewers []importReviewer
+	for _, pr := range batch.PullRequests {
+		times, err := pr.ReviewerAssignedTimes()
+		if err != nil {
+			return err
+		}
+		for i, userID := range pr.AssignedReviewers {
+			reviewers = append(reviewers, importReviewer{
+				pullRequestID: pr.PullRequestID,
+				userID:        userID,
+				position:      i,
+				assignedAt:    times[i],
+			})
+		}
+	}
+	if err = copyBatches(ctx, tx, "pull_request_reviewers",
+		[]string{"pull_request_id", "user_id", "position", "assigned_at"}, reviewers,
+		func(r importReviewer) []any {
+			return []any{r.pullRequestID, r.userID, r.position, r.assignedAt}
+		}); err != nil {
+		return err
+	}
+
+	if err = copyBatches(ctx, tx, "review_escalations", []string{"pull_request_id", "escalated_at"}, batch.Escalations,
+		func(e domain.ImportEscalation) []any {
+			return []any{e.PullRequestID, e.EscalatedAt}
+		}); err != nil {
+		return err
+	}
+
+	if err = copyBatches(ctx, tx, "review_slas", []string{"team_name", "sla_seconds", "policy"}, batch.ReviewSLAs,
+		func(sla domain.ImportReviewSLA) []any {
+			return []any{sla.TeamName, int64(sla.SLA / time.Second), string(sla.Policy)}
+		}); err != nil {
+		return err
+	}
+
+	if err = copyBatches(ctx, tx, "code_owner_rules",
+		[]string{"position", "pattern", "owner_users", "owner_teams"}, batch.CodeOwnerRules,
+		func(rule domain.ImportCodeOwnerRule) []any {
+			return []any{rule.Position, rule.Pattern, rule.Users, rule.Teams}
+		}); err != nil {
+		return err
+	}
+
+	if err = copyBatches(ctx, tx, "reviewer_rules",
+		[]string{"rule_id", "kind", "author_user_id", "author_skill", "reviewer_user_id", "reviewer_skill", "description", "created_at"},
+		batch.ReviewerRules,
+		func(rule domain.ImportReviewerRule) []any {
+			return []any{
+				rule.ID, string(rule.Kind),
+				nullIfEmpty(rule.Author.UserID), nullIfEmpty(rule.Author.Skill),
+				nullIfEmpty(rule.Reviewer.UserID), nullIfEmpty(rule.Reviewer.Skill),
+				rule.Description, rule.CreatedAt,
+			}
+		}); err != nil {
+		return err
+	}
+
+	return copyBatches(ctx, tx, "user_unavailability",
+		[]string{"unavailability_id", "user_id", "starts_at", "ends_at", "reason", "released_at"}, batch.Unavailability,
+		func(u domain.ImportUnavailability) []any {
+			return []any{u.ID, u.UserID, u.StartsAt, u.EndsAt, u.Reason, u.ReleasedAt}
+		})
+}
+
+// nullIfEmpty возвращает NULL для пустой строки: так хранится незаданная часть селектора правила.
+func nullIfEmpty(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// importReviewer - строка pull_request_reviewers для загрузки.
+type importReviewer struct {
+	pullRequestID string
+	userID        string
+	position      int
+	assignedAt    time.Time
+}
+
+// copyBatches копирует items в таблицу пакетами по importBatchSize строк.
+func copyBatches[T any](ctx context.Context, tx pgx.Tx, table string, columns []string, items []T, row func(T) []any) error {
+	for start := 0; start < len(items); start += importBatchSize {
+		chunk := items[start:min(start+importBatchSize, len(items))]
+		_, err := tx.CopyFrom(ctx, pgx.Identifier{table}, columns,
+			pgx.CopyFromSlice(len(chunk), func(i int) ([]any, error) {
+				return row(chunk[i]), nil
+			}))
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) {
+				switch pgErr.Code {
+				case "23505": // unique_violation
+					return repository.ErrAlreadyExists
+				case "23503": // foreign_key_violation
+					return repository.ErrNotFound
+				}
+			}
+			return fmt.Errorf("copy into %s: %w", table, err)
+		}
+	}
+	return nil
+}
@@ -15,6 +15,32 @@ import (
 	"pr-reviewer-assigment-service/internal/domain"
 )
 
+// reviewersColumn собирает ревьюверов PR pr из pull_request_reviewers в порядке назначения.
+const reviewersColumn = `ARRAY(
+	SELECT prr.user_id
+	FROM pull_request_reviewers prr
+	WHERE prr.pull_request_id = pr.pull_request_id
+	ORDER BY prr.position
+)`
+
+// reviewerTimesColumn собирает время назначения ревьюверов PR pr в том же порядке, что и reviewersColumn.
+const reviewerTimesColumn = `ARRAY(
+	SELECT prr.assigned_at
+	FROM pull_request_reviewers prr
+	WHERE prr.pull_request_id = pr.pull_request_id
+	ORDER BY prr.position
+)`
+
+// setReviewerTimes заполняет pr.ReviewerAssignedAt по времени назначения из reviewerTimesColumn.
+func setReviewerTimes(pr *domain.PullRequest, times []time.Time) {
+	pr.ReviewerAssignedAt = make(map[string]time.Time, len(times))
+	for i, at := range times {
+		if i < len(pr.AssignedReviewers) {
+			pr.ReviewerAssignedAt[pr.AssignedReviewers[i]] = at
+		}
+	}
+}
+
 type PullRequestDb struct {
 	pool *pgxpool.Pool
 }
@@ -23,42 +49,45 @@ func NewPullRequestDb(pool *pgxpool.Pool) *PullRequestDb {
 	return &PullRequestDb{pool: pool}
 }
 
-// Create создаёт новый PR.
+// Create создаёт новый PR вместе с назначениями ревьюверов. Время создания и назначения задаёт сервис,
+// репозиторий его не выбирает.
 // Если PR с таким ID уже существует - возвращает repository.ErrAlreadyExists.
-func (r *PullRequestDb) Create(ctx context.Context, pr *domain.PullRequest) error {
+func (r *PullRequestDb) Create(ctx context.Context, pr *domain.PullRequest) (err error) {
 	const query = `
 		INSERT INTO pull_requests (
 			pull_request_id,
 			pull_request_name,
 			author_id,
 			status,
-			assigned_reviewers,
 			created_at,
 			merged_at
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		VALUES ($1, $2, $3, $4, $5, $6)
 	`
 
-	createdAt := time.Now().UTC()
-	if pr.CreatedAt != nil {
-		createdAt = *pr.CreatedAt
+	if pr.CreatedAt == nil {
+		return fmt.Errorf("insert pull_request %s: created_at is not set", pr.PullRequestID)
 	}
 
-	var mergedAt any
-	if pr.MergedAt != nil {
-		mergedAt = *pr.MergedAt
-	} else {
-		mergedAt = nil
+	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
 	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+			return
+		}
+		err = tx.Commit(ctx)
+	}()
 
-	_, err := r.pool.Exec(ctx, query,
+	_, err = tx.Exec(ctx, query,
 		pr.PullRequestID,
 		pr.PullRequestName,
 		pr.AuthorID,
 		pr.Status,
-		pr.AssignedReviewers,
-		createdAt,
-		mergedAt,
+		*pr.CreatedAt,
+		pr.MergedAt,
 	)
 	if err != nil {
 		var pgErr *pgconn.PgError
@@ -68,29 +97,31 @@ func (r *PullRequestDb) Create(ctx context.Context, pr *domain.PullRequest) erro
 		return fmt.Errorf("insert pull_request %s: %w", pr.PullRequestID, err)
 	}
 
-	return nil
+	return saveReviewers(ctx, tx, pr)
 }
 
 // GetByID возвращает PR по ID.
 // Если не найден - возвращает repository.ErrNotFound.
 func (r *PullRequestDb) GetByID(ctx context.Context, id string) (*domain.PullRequest, error) {
-	const query = `
+	query := `
 		SELECT
-			pull_request_id,
-			pull_request_name,
-			author_id,
-			status,
-			assigned_reviewers,
-			created_at,
-			merged_at
-		FROM pull_requests
-		WHERE pull_request_id = $1
+			pr.pull_request_id,
+			pr.pull_request_name,
+			pr.author_id,
+			pr.status,
+			` + reviewersColumn + `,
+			` + reviewerTimesColumn + `,
+			pr.created_at,
+			pr.merged_at
+		FROM pull_requests pr
+		WHERE pr.pull_request_id = $1
 	`
 
 	var (
-		pr        domain.PullRequest
-		createdAt pgtype.Timestamptz
-		mergedAt  pgtype.Timestamptz
+		pr            domain.PullRequest
+		reviewerTimes []time.Time
+		createdAt     pgtype.Timestamptz
+		mergedAt      pgtype.Timestamptz
 	)
 
 	err := r.pool.QueryRow(ctx, query, id).Scan(
@@ -99,6 +130,7 @@ func (r *PullRequestDb) GetByID(ctx context.Context, id string) (*domain.PullReq
 		&pr.AuthorID,
 		&pr.Status,
 		&pr.AssignedReviewers,
+		&reviewerTimes,
 		&createdAt,
 		&mergedAt,
 	)
@@ -109,6 +141,7 @@ func (r *PullRequestDb) GetByID(ctx context.Context, id string) (*domain.PullReq
 		return nil, fmt.Errorf("query pull_request by id: %w", err)
 	}
 
+	setReviewerTimes(&pr, reviewerTimes)
 	if createdAt.Valid {
 		t := createdAt.Time
 		pr.CreatedAt = &t
@@ -122,40 +155,40 @@ func (r *PullRequestDb) GetByID(ctx context.Context, id string) (*domain.PullReq
 }
 
 // Update обновляет существующий PR (например, после merge или reassignment).
+// Если CreatedAt не задан, сохранённое время создания не меняется.
+// Время назначения ревьюверов берётся из pr.ReviewerAssignedAt, как и при создании.
 // Если PR не найден - возвращает repository.ErrNotFound.
-func (r *PullRequestDb) Update(ctx context.Context, pr *domain.PullRequest) error {
+func (r *PullRequestDb) Update(ctx context.Context, pr *domain.PullRequest) (err error) {
 	const query = `
 		UPDATE pull_requests
 		SET
 			pull_request_name   = $2,
 			author_id           = $3,
 			status              = $4,
-			assigned_reviewers  = $5,
-			created_at          = $6,
-			merged_at           = $7
+			created_at          = COALESCE($5, created_at),
+			merged_at           = $6
 		WHERE pull_request_id = $1
 	`
 
-	createdAt := time.Now().UTC()
-	if pr.CreatedAt != nil {
-		createdAt = *pr.CreatedAt
-	}
-
-	var mergedAt any
-	if pr.MergedAt != nil {
-		mergedAt = *pr.MergedAt
-	} else {
-		mergedAt = nil
+	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
 	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+			return
+		}
+		err = tx.Commit(ctx)
+	}()
 
-	cmdTag, err := r.pool.Exec(ctx, query,
+	cmdTag, err := tx.Exec(ctx, query,
 		pr.PullRequestID,
 		pr.PullRequestName,
 		pr.AuthorID,
 		pr.Status,
-		pr.AssignedReviewers,
-		createdAt,
-		mergedAt,
+		pr.CreatedAt,
+		pr.MergedAt,
 	)
 	if err != nil {
 		return fmt.Errorf("update pull_request %s: %w", pr.PullRequestID, err)
@@ -165,11 +198,68 @@ func (r *PullRequestDb) Update(ctx context.Context, pr *domain.PullRequest) erro
 		return repository.ErrNotFound
 	}
 
+	return saveReviewers(ctx, tx, pr)
+}
+
+// saveReviewers приводит назначения PR к pr.AssignedReviewers с временем назначения из pr.ReviewerAssignedAt.
+func saveReviewers(ctx context.Context, tx pgx.Tx, pr *domain.PullRequest) error {
+	reviewers := pr.AssignedReviewers
+	if reviewers == nil {
+		reviewers = []string{}
+	}
+	times, err := pr.ReviewerAssignedTimes()
+	if err != nil {
+		return fmt.Errorf("save reviewers: %w", err)
+	}
+
+	const deleteQuery = `
+		DELETE FROM pull_request_reviewers
+		WHERE pull_request_id = $1 AND user_id <> ALL($2::text[])
+	`
+	if _, err := tx.Exec(ctx, deleteQuery, pr.PullRequestID, reviewers); err != nil {
+		return fmt.Errorf("delete reviewers of pull_request %s: %w", pr.PullRequestID, err)
+	}
+	if len(reviewers) == 0 {
+		return nil
+	}
+
+	const upsertQuery = `
+		INSERT INTO pull_request_reviewers (pull_request_id, user_id, position, assigned_at)
+		SELECT $1, r.user_id, r.ord - 1, r.assigned_at
+		FROM unnest($2::text[], $3::timestamptz[]) WITH ORDINALITY AS r(user_id, assigned_at, ord)
+		ON CONFLICT (pull_request_id, user_id) DO UPDATE SET
+			position    = EXCLUDED.position,
+			assigned_at = EXCLUDED.assigned_at
+	`
+	if _, err := tx.Exec(ctx, upsertQuery, pr.PullRequestID, reviewers, times); err != nil {
+		return fmt.Errorf("save reviewers of pull_request %s: %w", pr.PullRequestID, err)
+	}
 	return nil
 }
 
 // ListByReviewer возвращает список PR'ов, где пользователь назначен ревьювером.
 func (r *PullRequestDb) ListByReviewer(ctx context.Context, reviewerID string) ([]domain.PullRequestShort, error) {
+	const query = `
+		SELECT
+			pr.pull_request_id,
+			pr.pull_request_name,
+			pr.author_id,
+			pr.status
+		FROM pull_request_reviewers prr
+		JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id
+		WHERE prr.user_id = $1
+		ORDER BY pr.pull_request_id
+	`
+
+	result, err := r.listShort(ctx, query, reviewerID)
+	if err != nil {
+		return nil, fmt.Errorf("list pull_requests by reviewer: %w", err)
+	}
+	return result, nil
+}
+
+// ListByAuthor возвращает список PR'ов автора.
+func (r *PullRequestDb) ListByAuthor(ctx context.Context, authorID string) ([]domain.PullRequestShort, error) {
 	const query = `
 		SELECT
 			pull_request_id,
@@ -177,13 +267,21 @@ func (r *PullRequestDb) ListByReviewer(ctx context.Context, reviewerID string) (
 			author_id,
 			status
 		FROM pull_requests
-		WHERE $1 = ANY(assigned_reviewers)
+		WHERE author_id = $1
 		ORDER BY pull_request_id
 	`
 
-	rows, err := r.pool.Query(ctx, query, reviewerID)
+	result, err := r.listShort(ctx, query, authorID)
 	if err != nil {
-		return nil, fmt.Errorf("list pull_requests by reviewer: %w", err)
+		return nil, fmt.Errorf("list pull_requests by author: %w", err)
+	}
+	return result, nil
+}
+
+func (r *PullRequestDb) listShort(ctx context.Context, query string, args ...any) ([]domain.PullRequestShort, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
 	}
 	defer rows.Close()
 
@@ -208,16 +306,54 @@ func (r *PullRequestDb) ListByReviewer(ctx context.Context, reviewerID string) (
 	return result, nil
 }
 
+// CountOpenByReviewers возвращает число открытых PR у каждого из ревьюверов.
+// Ревьюверов без открытых PR в результате нет.
+func (r *PullRequestDb) CountOpenByReviewers(ctx context.Context, reviewerIDs []string) (map[string]int, error) {
+	const query = `
+		SELECT prr.user_id, COUNT(*)
+		FROM pull_request_reviewers prr
+		JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id
+		WHERE prr.user_id = ANY($1::text[])
+		  AND pr.status = 'OPEN'
+		GROUP BY prr.user_id
+	`
+
+	counts := make(map[string]int, len(reviewerIDs))
+	if len(reviewerIDs) == 0 {
+		return counts, nil
+	}
+
+	rows, err := r.pool.Query(ctx, query, reviewerIDs)
+	if err != nil {
+		return nil, fmt.Errorf("count open reviews: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id    string
+			count int
+		)
+		if err := rows.Scan(&id, &count); err != nil {
+			return nil, fmt.Errorf("scan open review count: %w", err)
+		}
+		counts[id] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate open review counts: %w", err)
+	}
+
+	return counts, nil
+}
+
 // GetReviewerStats получает статистику назначений по ревьюверам.
 func (r *PullRequestDb) GetReviewerStats(ctx context.Context) ([]domain.ReviewerStat, error) {
 	const query = `
-        SELECT reviewer_id, COUNT(*) AS review_count
-        FROM (
-            SELECT unnest(assigned_reviewers) AS reviewer_id
-            FROM pull_requests
-        ) AS t
-        GROUP BY reviewer_id
-        ORDER BY reviewer_id
+        SELECT user_id, COUNT(*) AS review_count
+        FROM pull_request_reviewers
+        GROUP BY user_id
+        ORDER BY user_id
     `
 
 	rows, err := r.pool.Query(ctx, query)
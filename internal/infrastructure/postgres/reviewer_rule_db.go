@@ -0,0 +1,122 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"pr-reviewer-assigment-service/internal/application/repository"
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+type ReviewerRuleDb struct {
+	pool *pgxpool.Pool
+}
+
+func NewReviewerRuleDb(pool *pgxpool.Pool) *ReviewerRuleDb {
+	return &ReviewerRuleDb{pool: pool}
+}
+
+// Create сохраняет правило. Если пользователя из селектора нет - repository.ErrNotFound.
+func (r *ReviewerRuleDb) Create(ctx context.Context, rule *domain.ReviewerRule) error {
+	const query = `
+		INSERT INTO reviewer_rules (
+			rule_id, kind, author_user_id, author_skill, reviewer_user_id, reviewer_skill, description, created_at
+		)
+		VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), NULLIF($5, ''), NULLIF($6, ''), $7, $8)
+	`
+
+	_, err := r.pool.Exec(ctx, query,
+		rule.ID,
+		rule.Kind,
+		rule.Author.UserID,
+		rule.Author.Skill,
+		rule.Reviewer.UserID,
+		rule.Reviewer.Skill,
+		rule.Description,
+		rule.CreatedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" { // foreign_key_violation
+			return repository.ErrNotFound
+		}
+		return fmt.Errorf("insert reviewer rule %s: %w", rule.ID, err)
+	}
+
+	return nil
+}
+
+const reviewerRuleColumns = `rule_id, kind,
+	COALESCE(author_user_id, ''), COALESCE(author_skill, ''),
+	COALESCE(reviewer_user_id, ''), COALESCE(reviewer_skill, ''),
+	description, created_at`
+
+func scanReviewerRule(row pgx.Row) (*domain.ReviewerRule, error) {
+	var rule domain.ReviewerRule
+	err := row.Scan(
+		&rule.ID,
+		&rule.Kind,
+		&rule.Author.UserID,
+		&rule.Author.Skill,
+		&rule.Reviewer.UserID,
+		&rule.Reviewer.Skill,
+		&rule.Description,
+		&rule.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// List возвращает все правила в порядке создания.
+func (r *ReviewerRuleDb) List(ctx context.Context) (domain.RuleSet, error) {
+	const query = `
+		SELECT ` + reviewerRuleColumns + `
+		FROM reviewer_rules
+		ORDER BY created_at, rule_id
+	`
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("list reviewer rules: %w", err)
+	}
+	defer rows.Close()
+
+	var result domain.RuleSet
+	for rows.Next() {
+		rule, err := scanReviewerRule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan reviewer rule: %w", err)
+		}
+		result = append(result, *rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate reviewer rules: %w", err)
+	}
+
+	return result, nil
+}
+
+// Delete удаляет правило и возвращает его. Если правила нет - repository.ErrNotFound.
+func (r *ReviewerRuleDb) Delete(ctx context.Context, ruleID string) (*domain.ReviewerRule, error) {
+	const query = `
+		DELETE FROM reviewer_rules
+		WHERE rule_id = $1
+		RETURNING ` + reviewerRuleColumns
+
+	rule, err := scanReviewerRule(r.pool.QueryRow(ctx, query, ruleID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("delete reviewer rule %s: %w", ruleID, err)
+	}
+	return rule, nil
+}
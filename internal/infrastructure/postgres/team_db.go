@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -92,3 +93,150 @@ func (r *TeamDb) GetByName(ctx context.Context, teamName string) (*domain.Team,
 
 	return team, nil
 }
+
+// Rename переименовывает команду. Участники и SLA переезжают благодаря ON UPDATE CASCADE,
+// владельцы кода переименовываются в той же транзакции.
+// Если команды нет - repository.ErrNotFound, если новое имя занято - repository.ErrAlreadyExists.
+func (r *TeamDb) Rename(ctx context.Context, oldName, newName string) (err error) {
+	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+			return
+		}
+		err = tx.Commit(ctx)
+	}()
+
+	const query = `
+		UPDATE teams
+		SET team_name = $2
+		WHERE team_name = $1
+	`
+
+	cmdTag, err := tx.Exec(ctx, query, oldName, newName)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" { // unique_violation
+			return repository.ErrAlreadyExists
+		}
+		return fmt.Errorf("rename team %s: %w", oldName, err)
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return repository.ErrNotFound
+	}
+
+	const ownersQuery = `
+		UPDATE code_owner_rules
+		SET owner_teams = array_replace(owner_teams, $1, $2)
+		WHERE $1 = ANY(owner_teams)
+	`
+
+	if _, err = tx.Exec(ctx, ownersQuery, oldName, newName); err != nil {
+		return fmt.Errorf("rename team %s in code owners: %w", oldName, err)
+	}
+
+	return nil
+}
+
+// Delete удаляет команду вместе с её SLA (ON DELETE CASCADE) и убирает её из владельцев кода.
+// Если команды нет - repository.ErrNotFound.
+// Пока в команде есть участники, удаление запрещено внешним ключом fk_users_team.
+func (r *TeamDb) Delete(ctx context.Context, teamName string) (err error) {
+	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+			return
+		}
+		err = tx.Commit(ctx)
+	}()
+
+	const query = `
+		DELETE FROM teams
+		WHERE team_name = $1
+	`
+
+	cmdTag, err := tx.Exec(ctx, query, teamName)
+	if err != nil {
+		return fmt.Errorf("delete team %s: %w", teamName, err)
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return repository.ErrNotFound
+	}
+
+	const ownersQuery = `
+		UPDATE code_owner_rules
+		SET owner_teams = array_remove(owner_teams, $1)
+		WHERE $1 = ANY(owner_teams)
+	`
+
+	if _, err = tx.Exec(ctx, ownersQuery, teamName); err != nil {
+		return fmt.Errorf("delete team %s from code owners: %w", teamName, err)
+	}
+
+	return nil
+}
+
+// likeEscaper экранирует спецсимволы LIKE, чтобы префикс искался буквально.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// List возвращает страницу команд с количеством участников одним запросом.
+// Общее число команд под фильтром считается оконной функцией по сгруппированным строкам.
+func (r *TeamDb) List(ctx context.Context, filter repository.TeamListFilter) ([]domain.TeamSummary, int, error) {
+	const query = `
+		SELECT t.team_name,
+		       COUNT(u.user_id)                             AS member_count,
+		       COUNT(u.user_id) FILTER (WHERE u.is_active) AS active_member_count,
+		       COUNT(*) OVER ()                             AS total
+		FROM teams t
+		LEFT JOIN users u ON u.team_name = t.team_name
+		WHERE t.team_name ILIKE $1 ESCAPE '\'
+		GROUP BY t.team_name
+		ORDER BY t.team_name
+		LIMIT $2 OFFSET $3
+	`
+
+	pattern := likeEscaper.Replace(filter.Prefix) + "%"
+
+	rows, err := r.pool.Query(ctx, query, pattern, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("query teams: %w", err)
+	}
+	defer rows.Close()
+
+	teams := make([]domain.TeamSummary, 0, filter.Limit)
+	total := 0
+	for rows.Next() {
+		var t domain.TeamSummary
+		if err := rows.Scan(&t.TeamName, &t.MemberCount, &t.ActiveMemberCount, &total); err != nil {
+			return nil, 0, fmt.Errorf("scan team summary: %w", err)
+		}
+		teams = append(teams, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("iterate teams: %w", err)
+	}
+
+	// За пределами последней страницы строк нет, и окно не даёт total - досчитываем отдельно.
+	if len(teams) == 0 && filter.Offset > 0 {
+		const countQuery = `
+			SELECT COUNT(*)
+			FROM teams
+			WHERE team_name ILIKE $1 ESCAPE '\'
+		`
+		if err := r.pool.QueryRow(ctx, countQuery, pattern).Scan(&total); err != nil {
+			return nil, 0, fmt.Errorf("count teams: %w", err)
+		}
+	}
+
+	return teams, total, nil
+}
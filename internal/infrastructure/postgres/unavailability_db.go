@@ -0,0 +1,132 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"pr-reviewer-assigment-service/internal/application/repository"
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+type UnavailabilityDb struct {
+	pool *pgxpool.Pool
+}
+
+func NewUnavailabilityDb(pool *pgxpool.Pool) *UnavailabilityDb {
+	return &UnavailabilityDb{pool: pool}
+}
+
+const unavailabilityColumns = `unavailability_id, user_id, starts_at, ends_at, reason, released_at`
+
+// Create сохраняет новый период недоступности.
+func (r *UnavailabilityDb) Create(ctx context.Context, u *domain.Unavailability) error {
+	const query = `
+		INSERT INTO user_unavailability (` + unavailabilityColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := r.pool.Exec(ctx, query, u.ID, u.UserID, u.StartsAt, u.EndsAt, u.Reason, u.ReleasedAt)
+	if err != nil {
+		return fmt.Errorf("insert unavailability for %s: %w", u.UserID, err)
+	}
+
+	return nil
+}
+
+// ListByUser возвращает незакончившиеся периоды пользователя по времени начала.
+func (r *UnavailabilityDb) ListByUser(ctx context.Context, userID string, now time.Time) ([]domain.Unavailability, error) {
+	const query = `
+		SELECT ` + unavailabilityColumns + `
+		FROM user_unavailability
+		WHERE user_id = $1 AND ends_at > $2
+		ORDER BY starts_at, unavailability_id
+	`
+
+	return r.list(ctx, query, userID, now)
+}
+
+// Delete удаляет период и возвращает его. Если периода нет - repository.ErrNotFound.
+func (r *UnavailabilityDb) Delete(ctx context.Context, id string) (*domain.Unavailability, error) {
+	const query = `
+		DELETE FROM user_unavailability
+		WHERE unavailability_id = $1
+		RETURNING ` + unavailabilityColumns
+
+	u, err := scanUnavailability(r.pool.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("delete unavailability %s: %w", id, err)
+	}
+
+	return u, nil
+}
+
+// ListUnreleased возвращает идущие сейчас периоды, по которым пользователя ещё не снимали с ревью.
+func (r *UnavailabilityDb) ListUnreleased(ctx context.Context, now time.Time) ([]domain.Unavailability, error) {
+	const query = `
+		SELECT ` + unavailabilityColumns + `
+		FROM user_unavailability
+		WHERE released_at IS NULL AND starts_at <= $1 AND ends_at > $1
+		ORDER BY starts_at, unavailability_id
+	`
+
+	return r.list(ctx, query, now)
+}
+
+// MarkReleased отмечает, что пользователь снят с ревью.
+func (r *UnavailabilityDb) MarkReleased(ctx context.Context, id string, at time.Time) error {
+	const query = `
+		UPDATE user_unavailability
+		SET released_at = $2
+		WHERE unavailability_id = $1
+	`
+
+	cmdTag, err := r.pool.Exec(ctx, query, id, at)
+	if err != nil {
+		return fmt.Errorf("mark unavailability %s released: %w", id, err)
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *UnavailabilityDb) list(ctx context.Context, query string, args ...any) ([]domain.Unavailability, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query unavailability: %w", err)
+	}
+	defer rows.Close()
+
+	result := make([]domain.Unavailability, 0)
+	for rows.Next() {
+		u, err := scanUnavailability(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan unavailability: %w", err)
+		}
+		result = append(result, *u)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate unavailability: %w", err)
+	}
+
+	return result, nil
+}
+
+func scanUnavailability(row pgx.Row) (*domain.Unavailability, error) {
+	var u domain.Unavailability
+	if err := row.Scan(&u.ID, &u.UserID, &u.StartsAt, &u.EndsAt, &u.Reason, &u.ReleasedAt); err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
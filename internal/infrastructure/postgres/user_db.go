@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -12,6 +13,22 @@ import (
 	"pr-reviewer-assigment-service/internal/domain"
 )
 
+// userColumns - колонки users в порядке userScanTargets.
+const userColumns = `user_id, username, COALESCE(team_name, ''), is_active, max_open_reviews, skills`
+
+// availableCondition - пользователь активен и в момент из параметра $2 не в периоде недоступности.
+const availableCondition = `is_active = TRUE
+	AND NOT EXISTS (
+		SELECT 1
+		FROM user_unavailability ua
+		WHERE ua.user_id = users.user_id AND ua.starts_at <= $2 AND ua.ends_at > $2
+	)`
+
+// userScanTargets возвращает поля пользователя для Scan в порядке userColumns.
+func userScanTargets(u *domain.User) []any {
+	return []any{&u.UserID, &u.Username, &u.TeamName, &u.IsActive, &u.MaxOpenReviews, &u.Skills}
+}
+
 type UserDb struct {
 	pool *pgxpool.Pool
 }
@@ -21,6 +38,7 @@ func NewUserDb(pool *pgxpool.Pool) *UserDb {
 }
 
 // BulkUpsert создаёт или обновляет нескольких пользователей.
+// Лимит открытых ревью и навыки у существующих пользователей не меняются.
 func (r *UserDb) BulkUpsert(ctx context.Context, users []domain.User) (err error) {
 	if len(users) == 0 {
 		return nil
@@ -38,24 +56,42 @@ func (r *UserDb) BulkUpsert(ctx context.Context, users []domain.User) (err error
 		err = tx.Commit(ctx)
 	}()
 
+	// Строки копируются во временную таблицу одним COPY и переносятся в users одним INSERT,
+	// вместо запроса на каждого пользователя. При повторе user_id побеждает последняя запись, как при поштучной вставке.
+	if _, err = tx.Exec(ctx, `
+		CREATE TEMP TABLE users_upsert (
+			position  INTEGER NOT NULL,
+			user_id   TEXT    NOT NULL,
+			username  TEXT    NOT NULL,
+			team_name TEXT    NOT NULL,
+			is_active BOOLEAN NOT NULL
+		) ON COMMIT DROP
+	`); err != nil {
+		return fmt.Errorf("create temp table: %w", err)
+	}
+
+	if _, err = tx.CopyFrom(ctx, pgx.Identifier{"users_upsert"},
+		[]string{"position", "user_id", "username", "team_name", "is_active"},
+		pgx.CopyFromSlice(len(users), func(i int) ([]any, error) {
+			u := users[i]
+			return []any{i, u.UserID, u.Username, u.TeamName, u.IsActive}, nil
+		}),
+	); err != nil {
+		return fmt.Errorf("copy users: %w", err)
+	}
+
 	const query = `
 		INSERT INTO users (user_id, username, team_name, is_active)
-		VALUES ($1, $2, $3, $4)
+		SELECT DISTINCT ON (user_id) user_id, username, NULLIF(team_name, ''), is_active
+		FROM users_upsert
+		ORDER BY user_id, position DESC
 		ON CONFLICT (user_id) DO UPDATE SET
 			username  = EXCLUDED.username,
 			team_name = EXCLUDED.team_name,
 			is_active = EXCLUDED.is_active
 	`
-
-	for _, u := range users {
-		if _, err = tx.Exec(ctx, query,
-			u.UserID,
-			u.Username,
-			u.TeamName,
-			u.IsActive,
-		); err != nil {
-			return fmt.Errorf("bulk upsert user %s: %w", u.UserID, err)
-		}
+	if _, err = tx.Exec(ctx, query); err != nil {
+		return fmt.Errorf("bulk upsert users: %w", err)
 	}
 
 	return nil
@@ -64,18 +100,13 @@ func (r *UserDb) BulkUpsert(ctx context.Context, users []domain.User) (err error
 // GetByID возвращает пользователя по user_id.
 func (r *UserDb) GetByID(ctx context.Context, userID string) (*domain.User, error) {
 	const query = `
-		SELECT user_id, username, team_name, is_active
+		SELECT ` + userColumns + `
 		FROM users
 		WHERE user_id = $1
 	`
 
 	var u domain.User
-	err := r.pool.QueryRow(ctx, query, userID).Scan(
-		&u.UserID,
-		&u.Username,
-		&u.TeamName,
-		&u.IsActive,
-	)
+	err := r.pool.QueryRow(ctx, query, userID).Scan(userScanTargets(&u)...)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, repository.ErrNotFound
@@ -92,16 +123,11 @@ func (r *UserDb) SetActive(ctx context.Context, userID string, active bool) (*do
 		UPDATE users
 		SET is_active = $1
 		WHERE user_id = $2
-		RETURNING user_id, username, team_name, is_active
+		RETURNING ` + userColumns + `
 	`
 
 	var u domain.User
-	err := r.pool.QueryRow(ctx, query, active, userID).Scan(
-		&u.UserID,
-		&u.Username,
-		&u.TeamName,
-		&u.IsActive,
-	)
+	err := r.pool.QueryRow(ctx, query, active, userID).Scan(userScanTargets(&u)...)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, repository.ErrNotFound
@@ -112,35 +138,127 @@ func (r *UserDb) SetActive(ctx context.Context, userID string, active bool) (*do
 	return &u, nil
 }
 
+// SetTeam переводит пользователя в команду и возвращает обновлённого пользователя.
+// Пустой teamName выводит пользователя из команды (team_name = NULL).
+func (r *UserDb) SetTeam(ctx context.Context, userID string, teamName string) (*domain.User, error) {
+	const query = `
+		UPDATE users
+		SET team_name = NULLIF($1, '')
+		WHERE user_id = $2
+		RETURNING ` + userColumns + `
+	`
+
+	var u domain.User
+	err := r.pool.QueryRow(ctx, query, teamName, userID).Scan(userScanTargets(&u)...)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("update user team_name: %w", err)
+	}
+
+	return &u, nil
+}
+
+// SetMaxOpenReviews задаёт лимит открытых ревью пользователя и возвращает обновлённого пользователя.
+// nil снимает лимит.
+func (r *UserDb) SetMaxOpenReviews(ctx context.Context, userID string, limit *int) (*domain.User, error) {
+	const query = `
+		UPDATE users
+		SET max_open_reviews = $1
+		WHERE user_id = $2
+		RETURNING ` + userColumns + `
+	`
+
+	var u domain.User
+	err := r.pool.QueryRow(ctx, query, limit, userID).Scan(userScanTargets(&u)...)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("update user max_open_reviews: %w", err)
+	}
+
+	return &u, nil
+}
+
+// SetSkills заменяет теги экспертизы пользователя и возвращает обновлённого пользователя.
+func (r *UserDb) SetSkills(ctx context.Context, userID string, skills []string) (*domain.User, error) {
+	const query = `
+		UPDATE users
+		SET skills = $1
+		WHERE user_id = $2
+		RETURNING ` + userColumns + `
+	`
+
+	if skills == nil {
+		skills = []string{}
+	}
+
+	var u domain.User
+	err := r.pool.QueryRow(ctx, query, skills, userID).Scan(userScanTargets(&u)...)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("update user skills: %w", err)
+	}
+
+	return &u, nil
+}
+
+// ListByIDs возвращает существующих пользователей из списка.
+// Если onlyActive == true, возвращаются только активные пользователи, которые в момент now не в периоде недоступности.
+func (r *UserDb) ListByIDs(ctx context.Context, userIDs []string, onlyActive bool, now time.Time) ([]domain.User, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		SELECT ` + userColumns + `
+		FROM users
+		WHERE user_id = ANY($1)
+	`
+	args := []any{userIDs}
+
+	if onlyActive {
+		query += " AND " + availableCondition
+		args = append(args, now)
+	}
+
+	return r.list(ctx, query, args...)
+}
+
 // ListByTeam возвращает пользователей команды.
-// Если onlyActive == true, возвращаются только активные пользователи.
-func (r *UserDb) ListByTeam(ctx context.Context, teamName string, onlyActive bool) ([]domain.User, error) {
+// Если onlyActive == true, возвращаются только активные пользователи, которые в момент now не в периоде недоступности.
+func (r *UserDb) ListByTeam(ctx context.Context, teamName string, onlyActive bool, now time.Time) ([]domain.User, error) {
 	query := `
-		SELECT user_id, username, team_name, is_active
+		SELECT ` + userColumns + `
 		FROM users
 		WHERE team_name = $1
 	`
 	args := []any{teamName}
 
 	if onlyActive {
-		query += " AND is_active = TRUE"
+		query += " AND " + availableCondition
+		args = append(args, now)
 	}
 
+	return r.list(ctx, query, args...)
+}
+
+// list выполняет запрос, выбирающий userColumns, и собирает пользователей.
+func (r *UserDb) list(ctx context.Context, query string, args ...any) ([]domain.User, error) {
 	rows, err := r.pool.Query(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("list users by team: %w", err)
+		return nil, fmt.Errorf("list users: %w", err)
 	}
 	defer rows.Close()
 
 	var result []domain.User
 	for rows.Next() {
 		var u domain.User
-		if err := rows.Scan(
-			&u.UserID,
-			&u.Username,
-			&u.TeamName,
-			&u.IsActive,
-		); err != nil {
+		if err := rows.Scan(userScanTargets(&u)...); err != nil {
 			return nil, fmt.Errorf("scan user row: %w", err)
 		}
 		result = append(result, u)
@@ -152,3 +270,81 @@ func (r *UserDb) ListByTeam(ctx context.Context, teamName string, onlyActive boo
 
 	return result, nil
 }
+
+// openReviewCountColumn считает открытые PR, где пользователь u назначен ревьювером.
+// Назначения ищутся по индексу idx_pull_request_reviewers_user.
+const openReviewCountColumn = `(
+	SELECT COUNT(*)
+	FROM pull_request_reviewers prr
+	JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id
+	WHERE prr.user_id = u.user_id AND pr.status = 'OPEN'
+)`
+
+// GetSummary возвращает пользователя вместе с числом открытых ревью.
+// Если пользователь не найден - возвращает repository.ErrNotFound.
+func (r *UserDb) GetSummary(ctx context.Context, userID string) (*domain.UserSummary, error) {
+	query := `
+		SELECT ` + userColumns + `, ` + openReviewCountColumn + `
+		FROM users u
+		WHERE u.user_id = $1
+	`
+
+	var s domain.UserSummary
+	err := r.pool.QueryRow(ctx, query, userID).Scan(append(userScanTargets(&s.User), &s.OpenReviewCount)...)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("query user summary: %w", err)
+	}
+
+	return &s, nil
+}
+
+// List возвращает пользователей под фильтром, упорядоченных по user_id.
+// Пагинация по ключу: следующая страница начинается после filter.AfterUserID.
+func (r *UserDb) List(ctx context.Context, filter repository.UserListFilter) ([]domain.UserSummary, error) {
+	query := `
+		SELECT ` + userColumns + `, ` + openReviewCountColumn + `
+		FROM users u
+		WHERE u.user_id > $1
+	`
+	args := []any{filter.AfterUserID}
+
+	if filter.TeamName != "" {
+		args = append(args, filter.TeamName)
+		query += fmt.Sprintf(" AND u.team_name = $%d", len(args))
+	}
+	if filter.IsActive != nil {
+		args = append(args, *filter.IsActive)
+		query += fmt.Sprintf(" AND u.is_active = $%d", len(args))
+	}
+	if filter.Username != "" {
+		args = append(args, "%"+likeEscaper.Replace(filter.Username)+"%")
+		query += fmt.Sprintf(` AND u.username ILIKE $%d ESCAPE '\'`, len(args))
+	}
+
+	args = append(args, filter.Limit)
+	query += fmt.Sprintf(" ORDER BY u.user_id LIMIT $%d", len(args))
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list users: %w", err)
+	}
+	defer rows.Close()
+
+	result := make([]domain.UserSummary, 0, filter.Limit)
+	for rows.Next() {
+		var s domain.UserSummary
+		if err := rows.Scan(append(userScanTargets(&s.User), &s.OpenReviewCount)...); err != nil {
+			return nil, fmt.Errorf("scan user summary: %w", err)
+		}
+		result = append(result, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate user summaries: %w", err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,83 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+type CodeOwnerDb struct {
+	db *sql.DB
+}
+
+func NewCodeOwnerDb(db *sql.DB) *CodeOwnerDb {
+	return &CodeOwnerDb{db: db}
+}
+
+// Replace удаляет старые правила и сохраняет новые в одной транзакции.
+func (r *CodeOwnerDb) Replace(ctx context.Context, rules []domain.CodeOwnerRule) (err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	if _, err = tx.ExecContext(ctx, `DELETE FROM code_owner_rules`); err != nil {
+		return fmt.Errorf("delete code owner rules: %w", err)
+	}
+
+	const query = `
+		INSERT INTO code_owner_rules (position, pattern, owner_users, owner_teams)
+		VALUES (?, ?, ?, ?)
+	`
+
+	for _, rule := range rules {
+		if _, err = tx.ExecContext(ctx, query,
+			rule.Position, rule.Pattern, stringList(rule.Users), stringList(rule.Teams),
+		); err != nil {
+			return fmt.Errorf("insert code owner rule at line %d: %w", rule.Position, err)
+		}
+	}
+
+	return nil
+}
+
+// List возвращает правила в порядке файла.
+func (r *CodeOwnerDb) List(ctx context.Context) ([]domain.CodeOwnerRule, error) {
+	const query = `
+		SELECT position, pattern, owner_users, owner_teams
+		FROM code_owner_rules
+		ORDER BY position
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("list code owner rules: %w", err)
+	}
+	defer rows.Close()
+
+	var result []domain.CodeOwnerRule
+	for rows.Next() {
+		var rule domain.CodeOwnerRule
+		if err := rows.Scan(
+			&rule.Position, &rule.Pattern, (*stringList)(&rule.Users), (*stringList)(&rule.Teams),
+		); err != nil {
+			return nil, fmt.Errorf("scan code owner rule: %w", err)
+		}
+		result = append(result, rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate code owner rules: %w", err)
+	}
+
+	return result, nil
+}
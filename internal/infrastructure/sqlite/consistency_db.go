@@ -0,0 +1,83 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+type ConsistencyDb struct {
+	db *sql.DB
+}
+
+func NewConsistencyDb(db *sql.DB) *ConsistencyDb {
+	return &ConsistencyDb{db: db}
+}
+
+// Check ищет ссылки на несуществующих ревьюверов, неактивных ревьюверов открытых PR и авторов открытых PR вне команд.
+func (r *ConsistencyDb) Check(ctx context.Context) ([]domain.ConsistencyIssue, error) {
+	const query = `
+		SELECT 'DANGLING_REVIEWER', prr.pull_request_id, prr.user_id
+		FROM pull_request_reviewers prr
+		WHERE NOT EXISTS (SELECT 1 FROM users u WHERE u.user_id = prr.user_id)
+
+		UNION ALL
+
+		SELECT 'INACTIVE_REVIEWER', prr.pull_request_id, prr.user_id
+		FROM pull_request_reviewers prr
+		JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id
+		JOIN users u ON u.user_id = prr.user_id
+		WHERE pr.status = 'OPEN' AND NOT u.is_active
+
+		UNION ALL
+
+		SELECT 'AUTHOR_WITHOUT_TEAM', pr.pull_request_id, pr.author_id
+		FROM pull_requests pr
+		JOIN users u ON u.user_id = pr.author_id
+		WHERE pr.status = 'OPEN' AND u.team_name IS NULL
+
+		ORDER BY 1, 2, 3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("query consistency issues: %w", err)
+	}
+	defer rows.Close()
+
+	var issues []domain.ConsistencyIssue
+	for rows.Next() {
+		var issue domain.ConsistencyIssue
+		if err := rows.Scan(&issue.Kind, &issue.PullRequestID, &issue.UserID); err != nil {
+			return nil, fmt.Errorf("scan consistency issue: %w", err)
+		}
+		issues = append(issues, issue)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate consistency issues: %w", err)
+	}
+
+	return issues, nil
+}
+
+// RemoveDanglingReviewers удаляет назначения несуществующих пользователей.
+// Внешний ключ fk_pull_request_reviewers_user не даёт их создать, но в файл могли писать
+// с выключенными внешними ключами (PRAGMA foreign_keys = OFF).
+func (r *ConsistencyDb) RemoveDanglingReviewers(ctx context.Context) (int, error) {
+	res, err := r.db.ExecContext(ctx, `
+		DELETE FROM pull_request_reviewers
+		WHERE NOT EXISTS (SELECT 1 FROM users u WHERE u.user_id = pull_request_reviewers.user_id)
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("delete dangling reviewers: %w", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("delete dangling reviewers: %w", err)
+	}
+	return int(n), nil
+}
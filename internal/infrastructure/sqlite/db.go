@@ -0,0 +1,220 @@
+// Package sqlite хранит данные сервиса в файле SQLite (STORAGE=sqlite): для небольших команд и локальной разработки.
+// Драйвер modernc.org/sqlite написан на Go, сборка не требует cgo. Схема создаётся встроенными миграциями при Open.
+//
+// Отличия от Postgres: время хранится текстом в UTC с точностью до микросекунд, списки - JSON-массивами,
+// а LIKE без учёта регистра работает только для латиницы.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	sqlitedrv "modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// Open открывает (или создаёт) базу в файле path и применяет недостающие миграции.
+//
+// Каждое соединение включает внешние ключи и ждёт освобождения блокировки до 5 секунд.
+// Транзакции сразу берут блокировку на запись (BEGIN IMMEDIATE): иначе две транзакции, начавшие с чтения,
+// не смогут обе перейти к записи, и одна из них упадёт с SQLITE_BUSY.
+func Open(ctx context.Context, path string) (*sql.DB, error) {
+	params := url.Values{}
+	params.Add("_pragma", "foreign_keys(1)")
+	params.Add("_pragma", "busy_timeout(5000)")
+	params.Add("_pragma", "journal_mode(WAL)")
+	params.Set("_txlock", "immediate")
+
+	db, err := sql.Open("sqlite", "file:"+path+"?"+params.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite %s: %w", path, err)
+	}
+
+	if err := migrate(ctx, db); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// migrate применяет миграции из каталога migrations по порядку имён файлов.
+// Применённые версии записываются в schema_migrations, каждая миграция - в своей транзакции.
+func migrate(ctx context.Context, db *sql.DB) error {
+	const createQuery = `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version    TEXT PRIMARY KEY,
+			applied_at TEXT NOT NULL
+		)
+	`
+	if _, err := db.ExecContext(ctx, createQuery); err != nil {
+		return fmt.Errorf("create schema_migrations: %w", err)
+	}
+
+	names, err := fs.Glob(migrations, "migrations/*.sql")
+	if err != nil {
+		return fmt.Errorf("list migrations: %w", err)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		version := strings.TrimSuffix(strings.TrimPrefix(name, "migrations/"), ".sql")
+		if err := applyMigration(ctx, db, name, version); err != nil {
+			return fmt.Errorf("migration %s: %w", version, err)
+		}
+	}
+	return nil
+}
+
+func applyMigration(ctx context.Context, db *sql.DB, name, version string) (err error) {
+	script, err := migrations.ReadFile(name)
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	// Проверка внутри транзакции: сервисы, стартующие одновременно, не применят миграцию дважды.
+	var applied bool
+	if err = tx.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = ?)`, version,
+	).Scan(&applied); err != nil {
+		return fmt.Errorf("query schema_migrations: %w", err)
+	}
+	if applied {
+		return nil
+	}
+
+	if _, err = tx.ExecContext(ctx, string(script)); err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO schema_migrations (version, applied_at) VALUES (?, ?)`, version, formatTime(time.Now()))
+	return err
+}
+
+// timeLayout - формат хранения времени. Ширина постоянная, поэтому строки сравниваются в порядке времени.
+const timeLayout = "2006-01-02T15:04:05.000000Z"
+
+// formatTime переводит время в формат хранения, округляя до микросекунд, как Postgres.
+func formatTime(t time.Time) string {
+	return t.UTC().Round(time.Microsecond).Format(timeLayout)
+}
+
+// formatTimePtr - formatTime для необязательного времени; nil сохраняется как NULL.
+func formatTimePtr(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return formatTime(*t)
+}
+
+// nullTime читает время, сохранённое formatTime. NULL даёт Valid == false.
+type nullTime struct {
+	Time  time.Time
+	Valid bool
+}
+
+func (n *nullTime) Scan(src any) error {
+	if src == nil {
+		n.Time, n.Valid = time.Time{}, false
+		return nil
+	}
+	s, ok := src.(string)
+	if !ok {
+		return fmt.Errorf("scan time: unexpected type %T", src)
+	}
+	t, err := time.Parse(timeLayout, s)
+	if err != nil {
+		return fmt.Errorf("scan time: %w", err)
+	}
+	n.Time, n.Valid = t, true
+	return nil
+}
+
+// Ptr возвращает время или nil для NULL.
+func (n nullTime) Ptr() *time.Time {
+	if !n.Valid {
+		return nil
+	}
+	t := n.Time
+	return &t
+}
+
+// stringList хранит список строк JSON-массивом, как TEXT[] в Postgres. nil сохраняется как пустой массив.
+type stringList []string
+
+func (l stringList) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal([]string(l))
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (l *stringList) Scan(src any) error {
+	var raw []byte
+	switch v := src.(type) {
+	case string:
+		raw = []byte(v)
+	case []byte:
+		raw = v
+	default:
+		return fmt.Errorf("scan string list: unexpected type %T", src)
+	}
+	items := []string{}
+	if err := json.Unmarshal(raw, &items); err != nil {
+		return fmt.Errorf("scan string list: %w", err)
+	}
+	*l = items
+	return nil
+}
+
+// errorCode возвращает расширенный код ошибки SQLite или 0, если ошибка не от SQLite.
+func errorCode(err error) int {
+	var sqliteErr *sqlitedrv.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.Code()
+	}
+	return 0
+}
+
+// isUniqueViolation сообщает, нарушен ли первичный ключ или ограничение уникальности.
+func isUniqueViolation(err error) bool {
+	code := errorCode(err)
+	return code == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY || code == sqlite3.SQLITE_CONSTRAINT_UNIQUE
+}
+
+// isForeignKeyViolation сообщает, нарушен ли внешний ключ.
+func isForeignKeyViolation(err error) bool {
+	return errorCode(err) == sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY
+}
+
+// likeEscaper экранирует спецсимволы LIKE, чтобы подстрока искалась буквально.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
@@ -0,0 +1,173 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"pr-reviewer-assigment-service/internal/application/repository"
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+type ExportDb struct {
+	db *sql.DB
+}
+
+func NewExportDb(db *sql.DB) *ExportDb {
+	return &ExportDb{db: db}
+}
+
+// Export читает все таблицы в одной читающей транзакции, чтобы выгрузка была согласованной.
+// Строки передаются sink по мере чтения.
+//
+// Транзакция открывается через BEGIN DEFERRED на отдельном соединении, а не через BeginTx:
+// транзакции BeginTx сразу берут блокировку на запись и держали бы её всю выгрузку.
+// Читающая транзакция в режиме WAL видит снимок базы и не мешает записи.
+func (r *ExportDb) Export(ctx context.Context, sink repository.ExportSink) (err error) {
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("get connection: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err = conn.ExecContext(ctx, `BEGIN DEFERRED`); err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() {
+		// Отменённый ctx не должен оставить транзакцию открытой на соединении, которое вернётся в пул.
+		if _, rollbackErr := conn.ExecContext(context.WithoutCancel(ctx), `ROLLBACK`); rollbackErr != nil && err == nil {
+			err = fmt.Errorf("end tx: %w", rollbackErr)
+		}
+	}()
+
+	var teamName string
+	err = forEachRow(ctx, conn, `SELECT team_name FROM teams ORDER BY team_name`,
+		[]any{&teamName}, func() error { return sink.Team(teamName) })
+	if err != nil {
+		return fmt.Errorf("export teams: %w", err)
+	}
+
+	var u domain.User
+	err = forEachRow(ctx, conn, `SELECT `+userColumns+` FROM users ORDER BY user_id`,
+		userScanTargets(&u), func() error { return sink.User(&u) })
+	if err != nil {
+		return fmt.Errorf("export users: %w", err)
+	}
+
+	prQuery := `
+		SELECT
+			pr.pull_request_id,
+			pr.pull_request_name,
+			pr.author_id,
+			pr.status,
+			` + reviewersColumn + `,
+			` + reviewerTimesColumn + `,
+			pr.created_at,
+			pr.merged_at
+		FROM pull_requests pr
+		ORDER BY pr.created_at, pr.pull_request_id
+	`
+	var (
+		pr            domain.PullRequest
+		reviewerTimes stringList
+		createdAt     nullTime
+		mergedAt      nullTime
+	)
+	err = forEachRow(ctx, conn, prQuery,
+		[]any{&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status,
+			(*stringList)(&pr.AssignedReviewers), &reviewerTimes, &createdAt, &mergedAt},
+		func() error {
+			if err := setReviewerTimes(&pr, reviewerTimes); err != nil {
+				return err
+			}
+			pr.CreatedAt = createdAt.Ptr()
+			pr.MergedAt = mergedAt.Ptr()
+			return sink.PullRequest(&pr)
+		})
+	if err != nil {
+		return fmt.Errorf("export pull_requests: %w", err)
+	}
+
+	var (
+		prID        string
+		escalatedAt nullTime
+	)
+	err = forEachRow(ctx, conn, `SELECT pull_request_id, escalated_at FROM review_escalations ORDER BY pull_request_id`,
+		[]any{&prID, &escalatedAt}, func() error { return sink.Escalation(prID, escalatedAt.Time) })
+	if err != nil {
+		return fmt.Errorf("export review_escalations: %w", err)
+	}
+
+	var (
+		sla        domain.ReviewSLA
+		slaSeconds int64
+	)
+	err = forEachRow(ctx, conn, `SELECT team_name, sla_seconds, policy FROM review_slas ORDER BY team_name`,
+		[]any{&sla.TeamName, &slaSeconds, &sla.Policy}, func() error {
+			sla.SLA = time.Duration(slaSeconds) * time.Second
+			return sink.ReviewSLA(&sla)
+		})
+	if err != nil {
+		return fmt.Errorf("export review_slas: %w", err)
+	}
+
+	var owners domain.CodeOwnerRule
+	err = forEachRow(ctx, conn, `SELECT position, pattern, owner_users, owner_teams FROM code_owner_rules ORDER BY position`,
+		[]any{&owners.Position, &owners.Pattern, (*stringList)(&owners.Users), (*stringList)(&owners.Teams)},
+		func() error { return sink.CodeOwnerRule(&owners) })
+	if err != nil {
+		return fmt.Errorf("export code_owner_rules: %w", err)
+	}
+
+	var (
+		rule          domain.ReviewerRule
+		ruleCreatedAt nullTime
+	)
+	err = forEachRow(ctx, conn, `SELECT `+reviewerRuleColumns+` FROM reviewer_rules ORDER BY created_at, rule_id`,
+		[]any{
+			&rule.ID, &rule.Kind, &rule.Author.UserID, &rule.Author.Skill,
+			&rule.Reviewer.UserID, &rule.Reviewer.Skill, &rule.Description, &ruleCreatedAt,
+		}, func() error {
+			rule.CreatedAt = ruleCreatedAt.Time
+			return sink.ReviewerRule(&rule)
+		})
+	if err != nil {
+		return fmt.Errorf("export reviewer_rules: %w", err)
+	}
+
+	var (
+		period                       domain.Unavailability
+		startsAt, endsAt, releasedAt nullTime
+	)
+	err = forEachRow(ctx, conn, `SELECT `+unavailabilityColumns+` FROM user_unavailability ORDER BY starts_at, unavailability_id`,
+		[]any{&period.ID, &period.UserID, &startsAt, &endsAt, &period.Reason, &releasedAt},
+		func() error {
+			period.StartsAt, period.EndsAt, period.ReleasedAt = startsAt.Time, endsAt.Time, releasedAt.Ptr()
+			return sink.Unavailability(&period)
+		})
+	if err != nil {
+		return fmt.Errorf("export user_unavailability: %w", err)
+	}
+
+	return nil
+}
+
+// forEachRow выполняет запрос и для каждой строки сканирует её в dest и вызывает fn.
+func forEachRow(ctx context.Context, conn *sql.Conn, query string, dest []any, fn func() error) error {
+	rows, err := conn.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return err
+		}
+		if err := fn(); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,160 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"pr-reviewer-assigment-service/internal/application/repository"
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+// reserveAttempts - сколько раз Reserve пробует занять ключ, который параллельно освобождают.
+const reserveAttempts = 3
+
+type IdempotencyDb struct {
+	db *sql.DB
+}
+
+func NewIdempotencyDb(db *sql.DB) *IdempotencyDb {
+	return &IdempotencyDb{db: db}
+}
+
+// Reserve занимает ключ под новый запрос.
+// Если ключ уже занят действующей записью - возвращает её и repository.ErrAlreadyExists.
+func (r *IdempotencyDb) Reserve(
+	ctx context.Context,
+	rec *domain.IdempotencyRecord,
+	staleBefore time.Time,
+) (*domain.IdempotencyRecord, error) {
+	// Между неудачной вставкой и чтением ключ могут освободить (Release, DeleteExpired) - тогда пробуем снова.
+	for range reserveAttempts {
+		existing, err := r.reserveOnce(ctx, rec, staleBefore)
+		if !errors.Is(err, repository.ErrNotFound) {
+			return existing, err
+		}
+	}
+	return nil, repository.ErrAlreadyExists
+}
+
+// reserveOnce делает одну попытку занять ключ.
+// Если ключ занят, но исчез до чтения записи, возвращает repository.ErrNotFound.
+func (r *IdempotencyDb) reserveOnce(
+	ctx context.Context,
+	rec *domain.IdempotencyRecord,
+	staleBefore time.Time,
+) (*domain.IdempotencyRecord, error) {
+	const reserveQuery = `
+		INSERT INTO idempotency_keys (key, request_hash, created_at, expires_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT (key) DO UPDATE SET
+			request_hash = excluded.request_hash,
+			status_code  = NULL,
+			content_type = NULL,
+			body         = NULL,
+			created_at   = excluded.created_at,
+			expires_at   = excluded.expires_at
+		WHERE idempotency_keys.expires_at <= excluded.created_at
+		   OR (idempotency_keys.status_code IS NULL AND idempotency_keys.created_at < ?)
+		RETURNING key
+	`
+
+	var key string
+	err := r.db.QueryRowContext(ctx, reserveQuery,
+		rec.Key,
+		rec.RequestHash,
+		formatTime(rec.CreatedAt),
+		formatTime(rec.ExpiresAt),
+		formatTime(staleBefore),
+	).Scan(&key)
+	if err == nil {
+		return nil, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("reserve idempotency key: %w", err)
+	}
+
+	const selectQuery = `
+		SELECT key, request_hash, status_code, content_type, body, created_at, expires_at
+		FROM idempotency_keys
+		WHERE key = ?
+	`
+
+	var (
+		existing    domain.IdempotencyRecord
+		statusCode  sql.NullInt64
+		contentType sql.NullString
+		createdAt   nullTime
+		expiresAt   nullTime
+	)
+	err = r.db.QueryRowContext(ctx, selectQuery, rec.Key).Scan(
+		&existing.Key,
+		&existing.RequestHash,
+		&statusCode,
+		&contentType,
+		&existing.Body,
+		&createdAt,
+		&expiresAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("query idempotency key: %w", err)
+	}
+	existing.StatusCode = int(statusCode.Int64)
+	existing.ContentType = contentType.String
+	existing.CreatedAt = createdAt.Time
+	existing.ExpiresAt = expiresAt.Time
+
+	return &existing, repository.ErrAlreadyExists
+}
+
+// Complete сохраняет ответ для занятого ключа.
+// Если ключ не найден - возвращает repository.ErrNotFound.
+func (r *IdempotencyDb) Complete(ctx context.Context, key string, statusCode int, contentType string, body []byte) error {
+	const query = `
+		UPDATE idempotency_keys
+		SET status_code = ?, content_type = ?, body = ?
+		WHERE key = ?
+	`
+
+	res, err := r.db.ExecContext(ctx, query, statusCode, contentType, body, key)
+	if err != nil {
+		return fmt.Errorf("complete idempotency key: %w", err)
+	}
+	return requireAffected(res)
+}
+
+// Release освобождает незавершённый ключ.
+func (r *IdempotencyDb) Release(ctx context.Context, key string) error {
+	const query = `
+		DELETE FROM idempotency_keys
+		WHERE key = ? AND status_code IS NULL
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, key); err != nil {
+		return fmt.Errorf("release idempotency key: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpired удаляет просроченные записи и возвращает их количество.
+func (r *IdempotencyDb) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	const query = `
+		DELETE FROM idempotency_keys
+		WHERE expires_at <= ?
+	`
+
+	res, err := r.db.ExecContext(ctx, query, formatTime(now))
+	if err != nil {
+		return 0, fmt.Errorf("delete expired idempotency keys: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("delete expired idempotency keys: %w", err)
+	}
+	return n, nil
+}
@@ -0,0 +1,255 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"pr-reviewer-assigment-service/internal/application/repository"
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+type ImportDb struct {
+	db *sql.DB
+}
+
+func NewImportDb(db *sql.DB) *ImportDb {
+	return &ImportDb{db: db}
+}
+
+// Existing возвращает, какие из перечисленных ключей уже есть в базе.
+func (r *ImportDb) Existing(ctx context.Context, keys repository.ImportKeys) (repository.ImportKeys, error) {
+	var (
+		found repository.ImportKeys
+		err   error
+	)
+
+	found.Teams, err = existing(ctx, r.db,
+		`SELECT team_name FROM teams WHERE team_name IN (SELECT value FROM json_each(?))`, keys.Teams)
+	if err != nil {
+		return found, fmt.Errorf("query existing teams: %w", err)
+	}
+	found.Users, err = existing(ctx, r.db,
+		`SELECT user_id FROM users WHERE user_id IN (SELECT value FROM json_each(?))`, keys.Users)
+	if err != nil {
+		return found, fmt.Errorf("query existing users: %w", err)
+	}
+	found.PullRequests, err = existing(ctx, r.db,
+		`SELECT pull_request_id FROM pull_requests WHERE pull_request_id IN (SELECT value FROM json_each(?))`, keys.PullRequests)
+	if err != nil {
+		return found, fmt.Errorf("query existing pull_requests: %w", err)
+	}
+	found.Escalations, err = existing(ctx, r.db,
+		`SELECT pull_request_id FROM review_escalations WHERE pull_request_id IN (SELECT value FROM json_each(?))`, keys.Escalations)
+	if err != nil {
+		return found, fmt.Errorf("query existing review_escalations: %w", err)
+	}
+	found.ReviewSLAs, err = existing(ctx, r.db,
+		`SELECT team_name FROM review_slas WHERE team_name IN (SELECT value FROM json_each(?))`, keys.ReviewSLAs)
+	if err != nil {
+		return found, fmt.Errorf("query existing review_slas: %w", err)
+	}
+	found.CodeOwnerRules, err = existing(ctx, r.db,
+		`SELECT position FROM code_owner_rules WHERE position IN (SELECT value FROM json_each(?))`, keys.CodeOwnerRules)
+	if err != nil {
+		return found, fmt.Errorf("query existing code_owner_rules: %w", err)
+	}
+	found.ReviewerRules, err = existing(ctx, r.db,
+		`SELECT rule_id FROM reviewer_rules WHERE rule_id IN (SELECT value FROM json_each(?))`, keys.ReviewerRules)
+	if err != nil {
+		return found, fmt.Errorf("query existing reviewer_rules: %w", err)
+	}
+	found.Unavailability, err = existing(ctx, r.db,
+		`SELECT unavailability_id FROM user_unavailability WHERE unavailability_id IN (SELECT value FROM json_each(?))`, keys.Unavailability)
+	if err != nil {
+		return found, fmt.Errorf("query existing user_unavailability: %w", err)
+	}
+
+	return found, nil
+}
+
+// existing передаёт ключи запросу одним JSON-массивом и возвращает найденные.
+func existing[K any](ctx context.Context, db *sql.DB, query string, keys []K) ([]K, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	arg, err := json.Marshal(keys)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.QueryContext(ctx, query, string(arg))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []K
+	for rows.Next() {
+		var key K
+		if err := rows.Scan(&key); err != nil {
+			return nil, err
+		}
+		result = append(result, key)
+	}
+	return result, rows.Err()
+}
+
+// Load загружает записи в одной транзакции подготовленными запросами, по строке на запись.
+// Повтор ключа - repository.ErrAlreadyExists, ссылка на отсутствующую запись - repository.ErrNotFound.
+func (r *ImportDb) Load(ctx context.Context, batch *domain.ImportBatch) (err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	if err = insertRows(ctx, tx, "teams", []string{"team_name"}, batch.Teams,
+		func(t domain.ImportTeam) []any {
+			return []any{t.TeamName}
+		}); err != nil {
+		return err
+	}
+
+	if err = insertRows(ctx, tx, "users",
+		[]string{"user_id", "username", "team_name", "is_active", "max_open_reviews", "skills"}, batch.Users,
+		func(u domain.ImportUser) []any {
+			var teamName any // Пользователь без команды хранится с NULL
+			if u.TeamName != "" {
+				teamName = u.TeamName
+			}
+			return []any{u.UserID, u.Username, teamName, u.IsActive, u.MaxOpenReviews, stringList(u.Skills)}
+		}); err != nil {
+		return err
+	}
+
+	if err = insertRows(ctx, tx, "pull_requests",
+		[]string{"pull_request_id", "pull_request_name", "author_id", "status", "created_at", "merged_at"},
+		batch.PullRequests,
+		func(pr domain.ImportPullRequest) []any {
+			return []any{
+				pr.PullRequestID, pr.PullRequestName, pr.AuthorID, pr.Status,
+				formatTimePtr(pr.CreatedAt), formatTimePtr(pr.MergedAt),
+			}
+		}); err != nil {
+		return err
+	}
+
+	var reviewers []importReviewer
+	for _, pr := range batch.PullRequests {
+		times, err := pr.ReviewerAssignedTimes()
+		if err != nil {
+			return err
+		}
+		for i, userID := range pr.AssignedReviewers {
+			reviewers = append(reviewers, importReviewer{
+				pullRequestID: pr.PullRequestID,
+				userID:        userID,
+				position:      i,
+				assignedAt:    times[i],
+			})
+		}
+	}
+	if err = insertRows(ctx, tx, "pull_request_reviewers",
+		[]string{"pull_request_id", "user_id", "position", "assigned_at"}, reviewers,
+		func(r importReviewer) []any {
+			return []any{r.pullRequestID, r.userID, r.position, formatTime(r.assignedAt)}
+		}); err != nil {
+		return err
+	}
+
+	if err = insertRows(ctx, tx, "review_escalations", []string{"pull_request_id", "escalated_at"}, batch.Escalations,
+		func(e domain.ImportEscalation) []any {
+			return []any{e.PullRequestID, formatTime(e.EscalatedAt)}
+		}); err != nil {
+		return err
+	}
+
+	if err = insertRows(ctx, tx, "review_slas", []string{"team_name", "sla_seconds", "policy"}, batch.ReviewSLAs,
+		func(sla domain.ImportReviewSLA) []any {
+			return []any{sla.TeamName, int64(sla.SLA / time.Second), string(sla.Policy)}
+		}); err != nil {
+		return err
+	}
+
+	if err = insertRows(ctx, tx, "code_owner_rules",
+		[]string{"position", "pattern", "owner_users", "owner_teams"}, batch.CodeOwnerRules,
+		func(rule domain.ImportCodeOwnerRule) []any {
+			return []any{rule.Position, rule.Pattern, stringList(rule.Users), stringList(rule.Teams)}
+		}); err != nil {
+		return err
+	}
+
+	if err = insertRows(ctx, tx, "reviewer_rules",
+		[]string{"rule_id", "kind", "author_user_id", "author_skill", "reviewer_user_id", "reviewer_skill", "description", "created_at"},
+		batch.ReviewerRules,
+		func(rule domain.ImportReviewerRule) []any {
+			return []any{
+				rule.ID, string(rule.Kind),
+				nullIfEmpty(rule.Author.UserID), nullIfEmpty(rule.Author.Skill),
+				nullIfEmpty(rule.Reviewer.UserID), nullIfEmpty(rule.Reviewer.Skill),
+				rule.Description, formatTime(rule.CreatedAt),
+			}
+		}); err != nil {
+		return err
+	}
+
+	return insertRows(ctx, tx, "user_unavailability",
+		[]string{"unavailability_id", "user_id", "starts_at", "ends_at", "reason", "released_at"}, batch.Unavailability,
+		func(u domain.ImportUnavailability) []any {
+			return []any{u.ID, u.UserID, formatTime(u.StartsAt), formatTime(u.EndsAt), u.Reason, formatTimePtr(u.ReleasedAt)}
+		})
+}
+
+// nullIfEmpty возвращает NULL для пустой строки: так хранится незаданная часть селектора правила.
+func nullIfEmpty(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// importReviewer - строка pull_request_reviewers для загрузки.
+type importReviewer struct {
+	pullRequestID string
+	userID        string
+	position      int
+	assignedAt    time.Time
+}
+
+// insertRows вставляет items в таблицу одним подготовленным запросом.
+func insertRows[T any](ctx context.Context, tx *sql.Tx, table string, columns []string, items []T, row func(T) []any) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	query := "INSERT INTO " + table + " (" + strings.Join(columns, ", ") + ")" +
+		" VALUES (?" + strings.Repeat(", ?", len(columns)-1) + ")"
+	stmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("prepare insert into %s: %w", table, err)
+	}
+	defer stmt.Close()
+
+	for _, item := range items {
+		if _, err := stmt.ExecContext(ctx, row(item)...); err != nil {
+			switch {
+			case isUniqueViolation(err):
+				return repository.ErrAlreadyExists
+			case isForeignKeyViolation(err):
+				return repository.ErrNotFound
+			}
+			return fmt.Errorf("insert into %s: %w", table, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+-- Схема соответствует миграциям Postgres 001-013.
+-- Время - текст в формате 2006-01-02T15:04:05.000000Z (UTC), списки - JSON-массивы, флаги - 0 или 1.
+
+CREATE TABLE teams (
+   team_name TEXT PRIMARY KEY
+);
+
+CREATE TABLE users (
+   user_id          TEXT PRIMARY KEY,
+   username         TEXT    NOT NULL,
+   team_name        TEXT    NULL, -- NULL - пользователь вне команд
+   is_active        INTEGER NOT NULL DEFAULT 1 CHECK (is_active IN (0, 1)),
+   max_open_reviews INTEGER NULL CHECK (max_open_reviews >= 0), -- NULL - без лимита
+   skills           TEXT    NOT NULL DEFAULT '[]',
+
+   CONSTRAINT fk_users_team
+       FOREIGN KEY (team_name)
+           REFERENCES teams(team_name)
+           ON UPDATE CASCADE
+           ON DELETE RESTRICT
+);
+
+CREATE INDEX idx_users_team ON users (team_name);
+
+CREATE TABLE pull_requests (
+   pull_request_id   TEXT PRIMARY KEY,
+   pull_request_name TEXT NOT NULL,
+   author_id         TEXT NOT NULL,
+   status            TEXT NOT NULL CHECK (status IN ('OPEN', 'MERGED')),
+   created_at        TEXT NOT NULL,
+   merged_at         TEXT NULL,
+
+   CONSTRAINT fk_pull_requests_author
+       FOREIGN KEY (author_id)
+           REFERENCES users(user_id)
+           ON UPDATE CASCADE
+           ON DELETE RESTRICT
+);
+
+CREATE INDEX idx_pull_requests_author ON pull_requests (author_id);
+CREATE INDEX idx_pull_requests_open_created ON pull_requests (created_at) WHERE status = 'OPEN';
+
+CREATE TABLE pull_request_reviewers (
+   pull_request_id TEXT    NOT NULL,
+   user_id         TEXT    NOT NULL,
+   position        INTEGER NOT NULL CHECK (position IN (0, 1)), -- порядок ревьюверов в PR, максимум 2
+   assigned_at     TEXT    NOT NULL,
+
+   PRIMARY KEY (pull_request_id, user_id),
+
+   CONSTRAINT pull_request_reviewers_position
+       UNIQUE (pull_request_id, position),
+
+   CONSTRAINT fk_pull_request_reviewers_pull_request
+       FOREIGN KEY (pull_request_id)
+           REFERENCES pull_requests(pull_request_id)
+           ON UPDATE CASCADE
+           ON DELETE CASCADE,
+
+   CONSTRAINT fk_pull_request_reviewers_user
+       FOREIGN KEY (user_id)
+           REFERENCES users(user_id)
+           ON UPDATE CASCADE
+           ON DELETE RESTRICT
+);
+
+CREATE INDEX idx_pull_request_reviewers_user ON pull_request_reviewers (user_id);
+
+CREATE TABLE idempotency_keys (
+   key          TEXT PRIMARY KEY,
+   request_hash TEXT    NOT NULL,
+   status_code  INTEGER NULL, -- NULL, пока исходный запрос выполняется
+   content_type TEXT    NULL,
+   body         BLOB    NULL,
+   created_at   TEXT    NOT NULL,
+   expires_at   TEXT    NOT NULL
+);
+
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
+
+CREATE TABLE user_unavailability (
+   unavailability_id TEXT PRIMARY KEY,
+   user_id           TEXT NOT NULL,
+   starts_at         TEXT NOT NULL,
+   ends_at           TEXT NOT NULL,
+   reason            TEXT NOT NULL DEFAULT '',
+   released_at       TEXT NULL, -- когда пользователя сняли с открытых ревью; NULL - ещё не снимали
+
+   CONSTRAINT fk_user_unavailability_user
+       FOREIGN KEY (user_id)
+           REFERENCES users(user_id)
+           ON UPDATE CASCADE
+           ON DELETE CASCADE,
+
+   CONSTRAINT user_unavailability_period
+       CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX idx_user_unavailability_user ON user_unavailability (user_id, ends_at);
+
+-- Правила CODEOWNERS в порядке файла; при совпадении нескольких правил действует последнее.
+CREATE TABLE code_owner_rules (
+   position    INTEGER PRIMARY KEY,
+   pattern     TEXT NOT NULL,
+   owner_users TEXT NOT NULL DEFAULT '[]',
+   owner_teams TEXT NOT NULL DEFAULT '[]'
+);
+
+-- Правила подбора ревьюверов. В каждом селекторе задан либо user_id, либо навык.
+CREATE TABLE reviewer_rules (
+   rule_id          TEXT PRIMARY KEY,
+   kind             TEXT NOT NULL CHECK (kind IN ('EXCLUDE', 'REQUIRE')),
+   author_user_id   TEXT NULL,
+   author_skill     TEXT NULL,
+   reviewer_user_id TEXT NULL,
+   reviewer_skill   TEXT NULL,
+   description      TEXT NOT NULL DEFAULT '',
+   created_at       TEXT NOT NULL,
+
+   CONSTRAINT fk_reviewer_rules_author
+       FOREIGN KEY (author_user_id)
+           REFERENCES users(user_id)
+           ON UPDATE CASCADE
+           ON DELETE CASCADE,
+
+   CONSTRAINT fk_reviewer_rules_reviewer
+       FOREIGN KEY (reviewer_user_id)
+           REFERENCES users(user_id)
+           ON UPDATE CASCADE
+           ON DELETE CASCADE,
+
+   CONSTRAINT reviewer_rules_author_selector
+       CHECK ((author_user_id IS NULL) <> (author_skill IS NULL)),
+
+   CONSTRAINT reviewer_rules_reviewer_selector
+       CHECK ((reviewer_user_id IS NULL) <> (reviewer_skill IS NULL))
+);
+
+-- SLA ревью команды: сколько PR автора из команды может оставаться OPEN и что делать после.
+CREATE TABLE review_slas (
+   team_name   TEXT PRIMARY KEY,
+   sla_seconds INTEGER NOT NULL CHECK (sla_seconds > 0),
+   policy      TEXT    NOT NULL CHECK (policy IN ('NOTIFY', 'REASSIGN')),
+
+   CONSTRAINT fk_review_slas_team
+       FOREIGN KEY (team_name)
+           REFERENCES teams(team_name)
+           ON UPDATE CASCADE
+           ON DELETE CASCADE
+);
+
+-- Последняя эскалация просроченного PR; следующая - не раньше чем через SLA после неё.
+CREATE TABLE review_escalations (
+   pull_request_id TEXT PRIMARY KEY,
+   escalated_at    TEXT NOT NULL,
+
+   CONSTRAINT fk_review_escalations_pull_request
+       FOREIGN KEY (pull_request_id)
+           REFERENCES pull_requests(pull_request_id)
+           ON UPDATE CASCADE
+           ON DELETE CASCADE
+);
@@ -0,0 +1,366 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"pr-reviewer-assigment-service/internal/application/repository"
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+// reviewersColumn собирает ревьюверов PR pr из pull_request_reviewers в порядке назначения (JSON-массив).
+const reviewersColumn = `(
+	SELECT json_group_array(prr.user_id ORDER BY prr.position)
+	FROM pull_request_reviewers prr
+	WHERE prr.pull_request_id = pr.pull_request_id
+)`
+
+// reviewerTimesColumn собирает время назначения ревьюверов PR pr в том же порядке, что и reviewersColumn.
+const reviewerTimesColumn = `(
+	SELECT json_group_array(prr.assigned_at ORDER BY prr.position)
+	FROM pull_request_reviewers prr
+	WHERE prr.pull_request_id = pr.pull_request_id
+)`
+
+// setReviewerTimes заполняет pr.ReviewerAssignedAt по времени назначения из reviewerTimesColumn.
+func setReviewerTimes(pr *domain.PullRequest, times stringList) error {
+	pr.ReviewerAssignedAt = make(map[string]time.Time, len(times))
+	for i, raw := range times {
+		at, err := time.Parse(timeLayout, raw)
+		if err != nil {
+			return fmt.Errorf("parse assigned_at: %w", err)
+		}
+		if i < len(pr.AssignedReviewers) {
+			pr.ReviewerAssignedAt[pr.AssignedReviewers[i]] = at
+		}
+	}
+	return nil
+}
+
+type PullRequestDb struct {
+	db *sql.DB
+}
+
+func NewPullRequestDb(db *sql.DB) *PullRequestDb {
+	return &PullRequestDb{db: db}
+}
+
+// Create создаёт новый PR вместе с назначениями ревьюверов. Время создания и назначения задаёт сервис,
+// репозиторий его не выбирает.
+// Если PR с таким ID уже существует - возвращает repository.ErrAlreadyExists.
+func (r *PullRequestDb) Create(ctx context.Context, pr *domain.PullRequest) (err error) {
+	const query = `
+		INSERT INTO pull_requests (
+			pull_request_id,
+			pull_request_name,
+			author_id,
+			status,
+			created_at,
+			merged_at
+		)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+
+	if pr.CreatedAt == nil {
+		return fmt.Errorf("insert pull_request %s: created_at is not set", pr.PullRequestID)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	_, err = tx.ExecContext(ctx, query,
+		pr.PullRequestID,
+		pr.PullRequestName,
+		pr.AuthorID,
+		pr.Status,
+		formatTime(*pr.CreatedAt),
+		formatTimePtr(pr.MergedAt),
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return repository.ErrAlreadyExists
+		}
+		return fmt.Errorf("insert pull_request %s: %w", pr.PullRequestID, err)
+	}
+
+	return saveReviewers(ctx, tx, pr)
+}
+
+// GetByID возвращает PR по ID.
+// Если не найден - возвращает repository.ErrNotFound.
+func (r *PullRequestDb) GetByID(ctx context.Context, id string) (*domain.PullRequest, error) {
+	query := `
+		SELECT
+			pr.pull_request_id,
+			pr.pull_request_name,
+			pr.author_id,
+			pr.status,
+			` + reviewersColumn + `,
+			` + reviewerTimesColumn + `,
+			pr.created_at,
+			pr.merged_at
+		FROM pull_requests pr
+		WHERE pr.pull_request_id = ?
+	`
+
+	var (
+		pr            domain.PullRequest
+		reviewerTimes stringList
+		createdAt     nullTime
+		mergedAt      nullTime
+	)
+
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&pr.PullRequestID,
+		&pr.PullRequestName,
+		&pr.AuthorID,
+		&pr.Status,
+		(*stringList)(&pr.AssignedReviewers),
+		&reviewerTimes,
+		&createdAt,
+		&mergedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("query pull_request by id: %w", err)
+	}
+
+	if err := setReviewerTimes(&pr, reviewerTimes); err != nil {
+		return nil, fmt.Errorf("query pull_request by id: %w", err)
+	}
+	pr.CreatedAt = createdAt.Ptr()
+	pr.MergedAt = mergedAt.Ptr()
+
+	return &pr, nil
+}
+
+// Update обновляет существующий PR (например, после merge или reassignment).
+// Если CreatedAt не задан, сохранённое время создания не меняется.
+// Время назначения ревьюверов берётся из pr.ReviewerAssignedAt, как и при создании.
+// Если PR не найден - возвращает repository.ErrNotFound.
+func (r *PullRequestDb) Update(ctx context.Context, pr *domain.PullRequest) (err error) {
+	const query = `
+		UPDATE pull_requests
+		SET
+			pull_request_name = ?,
+			author_id         = ?,
+			status            = ?,
+			created_at        = COALESCE(?, created_at),
+			merged_at         = ?
+		WHERE pull_request_id = ?
+	`
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	res, err := tx.ExecContext(ctx, query,
+		pr.PullRequestName,
+		pr.AuthorID,
+		pr.Status,
+		formatTimePtr(pr.CreatedAt),
+		formatTimePtr(pr.MergedAt),
+		pr.PullRequestID,
+	)
+	if err != nil {
+		return fmt.Errorf("update pull_request %s: %w", pr.PullRequestID, err)
+	}
+
+	if err = requireAffected(res); err != nil {
+		return err
+	}
+
+	return saveReviewers(ctx, tx, pr)
+}
+
+// saveReviewers приводит назначения PR к pr.AssignedReviewers с временем назначения из pr.ReviewerAssignedAt.
+//
+// Ограничение уникальности позиции в SQLite нельзя отложить до конца транзакции, поэтому назначения
+// не обновляются на месте (ревьюверы могут поменяться местами), а удаляются и вставляются заново.
+func saveReviewers(ctx context.Context, tx *sql.Tx, pr *domain.PullRequest) error {
+	times, err := pr.ReviewerAssignedTimes()
+	if err != nil {
+		return fmt.Errorf("save reviewers: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		DELETE FROM pull_request_reviewers
+		WHERE pull_request_id = ?
+	`, pr.PullRequestID); err != nil {
+		return fmt.Errorf("delete reviewers of pull_request %s: %w", pr.PullRequestID, err)
+	}
+
+	const insertQuery = `
+		INSERT INTO pull_request_reviewers (pull_request_id, user_id, position, assigned_at)
+		VALUES (?, ?, ?, ?)
+	`
+	for position, userID := range pr.AssignedReviewers {
+		if _, err := tx.ExecContext(ctx, insertQuery, pr.PullRequestID, userID, position, formatTime(times[position])); err != nil {
+			return fmt.Errorf("save reviewer %s of pull_request %s: %w", userID, pr.PullRequestID, err)
+		}
+	}
+	return nil
+}
+
+// ListByReviewer возвращает список PR'ов, где пользователь назначен ревьювером.
+func (r *PullRequestDb) ListByReviewer(ctx context.Context, reviewerID string) ([]domain.PullRequestShort, error) {
+	const query = `
+		SELECT
+			pr.pull_request_id,
+			pr.pull_request_name,
+			pr.author_id,
+			pr.status
+		FROM pull_request_reviewers prr
+		JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id
+		WHERE prr.user_id = ?
+		ORDER BY pr.pull_request_id
+	`
+
+	result, err := r.listShort(ctx, query, reviewerID)
+	if err != nil {
+		return nil, fmt.Errorf("list pull_requests by reviewer: %w", err)
+	}
+	return result, nil
+}
+
+// ListByAuthor возвращает список PR'ов автора.
+func (r *PullRequestDb) ListByAuthor(ctx context.Context, authorID string) ([]domain.PullRequestShort, error) {
+	const query = `
+		SELECT
+			pull_request_id,
+			pull_request_name,
+			author_id,
+			status
+		FROM pull_requests
+		WHERE author_id = ?
+		ORDER BY pull_request_id
+	`
+
+	result, err := r.listShort(ctx, query, authorID)
+	if err != nil {
+		return nil, fmt.Errorf("list pull_requests by author: %w", err)
+	}
+	return result, nil
+}
+
+func (r *PullRequestDb) listShort(ctx context.Context, query string, args ...any) ([]domain.PullRequestShort, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []domain.PullRequestShort
+	for rows.Next() {
+		var pr domain.PullRequestShort
+		if err := rows.Scan(
+			&pr.PullRequestID,
+			&pr.PullRequestName,
+			&pr.AuthorID,
+			&pr.Status,
+		); err != nil {
+			return nil, fmt.Errorf("scan pull_request row: %w", err)
+		}
+		result = append(result, pr)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate pull_request rows: %w", err)
+	}
+
+	return result, nil
+}
+
+// CountOpenByReviewers возвращает число открытых PR у каждого из ревьюверов.
+// Ревьюверов без открытых PR в результате нет.
+func (r *PullRequestDb) CountOpenByReviewers(ctx context.Context, reviewerIDs []string) (map[string]int, error) {
+	const query = `
+		SELECT prr.user_id, COUNT(*)
+		FROM pull_request_reviewers prr
+		JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id
+		WHERE prr.user_id IN (SELECT value FROM json_each(?))
+		  AND pr.status = 'OPEN'
+		GROUP BY prr.user_id
+	`
+
+	counts := make(map[string]int, len(reviewerIDs))
+	if len(reviewerIDs) == 0 {
+		return counts, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, stringList(reviewerIDs))
+	if err != nil {
+		return nil, fmt.Errorf("count open reviews: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id    string
+			count int
+		)
+		if err := rows.Scan(&id, &count); err != nil {
+			return nil, fmt.Errorf("scan open review count: %w", err)
+		}
+		counts[id] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate open review counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+// GetReviewerStats получает статистику назначений по ревьюверам.
+func (r *PullRequestDb) GetReviewerStats(ctx context.Context) ([]domain.ReviewerStat, error) {
+	const query = `
+		SELECT user_id, COUNT(*) AS review_count
+		FROM pull_request_reviewers
+		GROUP BY user_id
+		ORDER BY user_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("query reviewer stats: %w", err)
+	}
+	defer rows.Close()
+
+	stats := make([]domain.ReviewerStat, 0)
+	for rows.Next() {
+		var stat domain.ReviewerStat
+		if err := rows.Scan(&stat.UserID, &stat.ReviewCount); err != nil {
+			return nil, fmt.Errorf("scan reviewer stat: %w", err)
+		}
+		stats = append(stats, stat)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate reviewer stats: %w", err)
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,122 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"pr-reviewer-assigment-service/internal/application/repository"
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+type ReviewerRuleDb struct {
+	db *sql.DB
+}
+
+func NewReviewerRuleDb(db *sql.DB) *ReviewerRuleDb {
+	return &ReviewerRuleDb{db: db}
+}
+
+// Create сохраняет правило. Если пользователя из селектора нет - repository.ErrNotFound.
+func (r *ReviewerRuleDb) Create(ctx context.Context, rule *domain.ReviewerRule) error {
+	const query = `
+		INSERT INTO reviewer_rules (
+			rule_id, kind, author_user_id, author_skill, reviewer_user_id, reviewer_skill, description, created_at
+		)
+		VALUES (?, ?, NULLIF(?, ''), NULLIF(?, ''), NULLIF(?, ''), NULLIF(?, ''), ?, ?)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		rule.ID,
+		rule.Kind,
+		rule.Author.UserID,
+		rule.Author.Skill,
+		rule.Reviewer.UserID,
+		rule.Reviewer.Skill,
+		rule.Description,
+		formatTime(rule.CreatedAt),
+	)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return repository.ErrNotFound
+		}
+		return fmt.Errorf("insert reviewer rule %s: %w", rule.ID, err)
+	}
+
+	return nil
+}
+
+const reviewerRuleColumns = `rule_id, kind,
+	COALESCE(author_user_id, ''), COALESCE(author_skill, ''),
+	COALESCE(reviewer_user_id, ''), COALESCE(reviewer_skill, ''),
+	description, created_at`
+
+func scanReviewerRule(row rowScanner) (*domain.ReviewerRule, error) {
+	var (
+		rule      domain.ReviewerRule
+		createdAt nullTime
+	)
+	err := row.Scan(
+		&rule.ID,
+		&rule.Kind,
+		&rule.Author.UserID,
+		&rule.Author.Skill,
+		&rule.Reviewer.UserID,
+		&rule.Reviewer.Skill,
+		&rule.Description,
+		&createdAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	rule.CreatedAt = createdAt.Time
+	return &rule, nil
+}
+
+// List возвращает все правила в порядке создания.
+func (r *ReviewerRuleDb) List(ctx context.Context) (domain.RuleSet, error) {
+	const query = `
+		SELECT ` + reviewerRuleColumns + `
+		FROM reviewer_rules
+		ORDER BY created_at, rule_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("list reviewer rules: %w", err)
+	}
+	defer rows.Close()
+
+	var result domain.RuleSet
+	for rows.Next() {
+		rule, err := scanReviewerRule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan reviewer rule: %w", err)
+		}
+		result = append(result, *rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate reviewer rules: %w", err)
+	}
+
+	return result, nil
+}
+
+// Delete удаляет правило и возвращает его. Если правила нет - repository.ErrNotFound.
+func (r *ReviewerRuleDb) Delete(ctx context.Context, ruleID string) (*domain.ReviewerRule, error) {
+	const query = `
+		DELETE FROM reviewer_rules
+		WHERE rule_id = ?
+		RETURNING ` + reviewerRuleColumns
+
+	rule, err := scanReviewerRule(r.db.QueryRowContext(ctx, query, ruleID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("delete reviewer rule %s: %w", ruleID, err)
+	}
+	return rule, nil
+}
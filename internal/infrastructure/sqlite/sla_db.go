@@ -0,0 +1,134 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"pr-reviewer-assigment-service/internal/application/repository"
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+type ReviewSLADb struct {
+	db *sql.DB
+}
+
+func NewReviewSLADb(db *sql.DB) *ReviewSLADb {
+	return &ReviewSLADb{db: db}
+}
+
+// Set задаёт или заменяет SLA команды. Если команды нет - repository.ErrNotFound.
+func (r *ReviewSLADb) Set(ctx context.Context, sla domain.ReviewSLA) error {
+	const query = `
+		INSERT INTO review_slas (team_name, sla_seconds, policy)
+		VALUES (?, ?, ?)
+		ON CONFLICT (team_name) DO UPDATE
+		SET sla_seconds = excluded.sla_seconds,
+		    policy      = excluded.policy
+	`
+
+	_, err := r.db.ExecContext(ctx, query, sla.TeamName, int64(sla.SLA/time.Second), sla.Policy)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return repository.ErrNotFound
+		}
+		return fmt.Errorf("upsert review sla %s: %w", sla.TeamName, err)
+	}
+	return nil
+}
+
+// Delete снимает SLA команды.
+func (r *ReviewSLADb) Delete(ctx context.Context, teamName string) error {
+	const query = `DELETE FROM review_slas WHERE team_name = ?`
+
+	if _, err := r.db.ExecContext(ctx, query, teamName); err != nil {
+		return fmt.Errorf("delete review sla %s: %w", teamName, err)
+	}
+	return nil
+}
+
+// ListOverdue возвращает открытые PR, у которых к моменту now истёк SLA команды автора.
+// Пустой teamName - все команды.
+//
+// Время хранится текстом, и прибавить к нему SLA в запросе без потери микросекунд нельзя,
+// поэтому запрос отбирает открытые PR команд с SLA, а срок проверяется здесь.
+func (r *ReviewSLADb) ListOverdue(ctx context.Context, teamName string, now time.Time) ([]domain.OverduePullRequest, error) {
+	query := `
+		SELECT
+			pr.pull_request_id,
+			pr.pull_request_name,
+			pr.author_id,
+			s.team_name,
+			` + reviewersColumn + `,
+			pr.created_at,
+			s.sla_seconds,
+			s.policy,
+			e.escalated_at
+		FROM pull_requests pr
+		JOIN users u ON u.user_id = pr.author_id
+		JOIN review_slas s ON s.team_name = u.team_name
+		LEFT JOIN review_escalations e ON e.pull_request_id = pr.pull_request_id
+		WHERE pr.status = 'OPEN'
+		  AND pr.created_at <= ?1
+		  AND (?2 = '' OR s.team_name = ?2)
+		ORDER BY pr.created_at, pr.pull_request_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, formatTime(now), teamName)
+	if err != nil {
+		return nil, fmt.Errorf("query overdue pull_requests: %w", err)
+	}
+	defer rows.Close()
+
+	result := make([]domain.OverduePullRequest, 0)
+	for rows.Next() {
+		var (
+			o           domain.OverduePullRequest
+			createdAt   nullTime
+			slaSeconds  int64
+			escalatedAt nullTime
+		)
+		if err := rows.Scan(
+			&o.PullRequestID,
+			&o.PullRequestName,
+			&o.AuthorID,
+			&o.TeamName,
+			(*stringList)(&o.AssignedReviewers),
+			&createdAt,
+			&slaSeconds,
+			&o.Policy,
+			&escalatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan overdue pull_request row: %w", err)
+		}
+		o.CreatedAt = createdAt.Time
+		o.SLA = time.Duration(slaSeconds) * time.Second
+		o.EscalatedAt = escalatedAt.Ptr()
+		if o.CreatedAt.Add(o.SLA).After(now) {
+			continue
+		}
+		result = append(result, o)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate overdue pull_request rows: %w", err)
+	}
+
+	return result, nil
+}
+
+// MarkEscalated запоминает момент последней эскалации PR.
+func (r *ReviewSLADb) MarkEscalated(ctx context.Context, prID string, at time.Time) error {
+	const query = `
+		INSERT INTO review_escalations (pull_request_id, escalated_at)
+		VALUES (?, ?)
+		ON CONFLICT (pull_request_id) DO UPDATE
+		SET escalated_at = excluded.escalated_at
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, prID, formatTime(at)); err != nil {
+		return fmt.Errorf("mark pull_request %s escalated: %w", prID, err)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package sqlite_test
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"pr-reviewer-assigment-service/internal/application/repository/repositorytest"
+	"pr-reviewer-assigment-service/internal/domain"
+	"pr-reviewer-assigment-service/internal/infrastructure/sqlite"
+)
+
+func newRepos(t *testing.T) repositorytest.Repositories {
+	t.Helper()
+	db, err := sqlite.Open(context.Background(), filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	return repositorytest.Repositories{
+		Users:          sqlite.NewUserDb(db),
+		Teams:          sqlite.NewTeamDb(db),
+		PullRequests:   sqlite.NewPullRequestDb(db),
+		CodeOwners:     sqlite.NewCodeOwnerDb(db),
+		SLAs:           sqlite.NewReviewSLADb(db),
+		Rules:          sqlite.NewReviewerRuleDb(db),
+		Unavailability: sqlite.NewUnavailabilityDb(db),
+		Export:         sqlite.NewExportDb(db),
+		Import:         sqlite.NewImportDb(db),
+	}
+}
+
+func TestContract(t *testing.T) {
+	repositorytest.Run(t, newRepos)
+}
+
+func TestOpen_ReopenKeepsData(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "test.db")
+
+	db, err := sqlite.Open(ctx, path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if err := sqlite.NewTeamDb(db).Create(ctx, &domain.Team{TeamName: "backend"}); err != nil {
+		t.Fatalf("create team: %v", err)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	// Повторное открытие не применяет миграции заново.
+	db, err = sqlite.Open(ctx, path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer db.Close()
+
+	if _, err := sqlite.NewTeamDb(db).GetByName(ctx, "backend"); err != nil {
+		t.Fatalf("expected team to survive reopen, got %v", err)
+	}
+}
+
+func TestPullRequestDb_Update_SwapsReviewers(t *testing.T) {
+	ctx := context.Background()
+	r := newRepos(t)
+
+	if err := r.Teams.Create(ctx, &domain.Team{TeamName: "backend"}); err != nil {
+		t.Fatalf("create team: %v", err)
+	}
+	if err := r.Users.BulkUpsert(ctx, []domain.User{
+		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+		{UserID: "u3", Username: "Carol", TeamName: "backend", IsActive: true},
+	}); err != nil {
+		t.Fatalf("BulkUpsert: %v", err)
+	}
+
+	created := time.Date(2025, 10, 20, 9, 0, 0, 0, time.UTC)
+	pr := &domain.PullRequest{
+		PullRequestID:     "pr-1",
+		PullRequestName:   "Add search",
+		AuthorID:          "u1",
+		Status:            string(domain.StatusOpen),
+		AssignedReviewers: []string{"u2", "u3"},
+		CreatedAt:         &created,
+	}
+	pr.MarkAssigned("u2", created)
+	pr.MarkAssigned("u3", created)
+	if err := r.PullRequests.Create(ctx, pr); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	// Позиции 0 и 1 меняются местами в одной транзакции.
+	pr.AssignedReviewers = []string{"u3", "u2"}
+	if err := r.PullRequests.Update(ctx, pr); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	got, err := r.PullRequests.GetByID(ctx, "pr-1")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if !slices.Equal(got.AssignedReviewers, []string{"u3", "u2"}) {
+		t.Fatalf("expected [u3 u2], got %v", got.AssignedReviewers)
+	}
+}
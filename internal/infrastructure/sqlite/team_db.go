@@ -0,0 +1,245 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"pr-reviewer-assigment-service/internal/application/repository"
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+type TeamDb struct {
+	db *sql.DB
+}
+
+func NewTeamDb(db *sql.DB) *TeamDb {
+	return &TeamDb{db: db}
+}
+
+// Create создаёт новую команду.
+// Если команда с таким именем уже существует - возвращает repository.ErrAlreadyExists.
+func (r *TeamDb) Create(ctx context.Context, team *domain.Team) error {
+	const query = `
+		INSERT INTO teams (team_name)
+		VALUES (?)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, team.TeamName)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return repository.ErrAlreadyExists
+		}
+		return fmt.Errorf("insert team %s: %w", team.TeamName, err)
+	}
+
+	return nil
+}
+
+// GetByName возвращает команду вместе с участниками.
+// Если команда не найдена - возвращает repository.ErrNotFound.
+func (r *TeamDb) GetByName(ctx context.Context, teamName string) (*domain.Team, error) {
+	const queryTeam = `
+		SELECT team_name
+		FROM teams
+		WHERE team_name = ?
+	`
+
+	var name string
+	err := r.db.QueryRowContext(ctx, queryTeam, teamName).Scan(&name)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("query team by name: %w", err)
+	}
+
+	const queryMembers = `
+		SELECT user_id, username, is_active
+		FROM users
+		WHERE team_name = ?
+		ORDER BY user_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, queryMembers, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("query team members: %w", err)
+	}
+	defer rows.Close()
+
+	members := make([]domain.TeamMember, 0)
+	for rows.Next() {
+		var m domain.TeamMember
+		if err := rows.Scan(&m.UserID, &m.Username, &m.IsActive); err != nil {
+			return nil, fmt.Errorf("scan team member: %w", err)
+		}
+		members = append(members, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate team members: %w", err)
+	}
+
+	return &domain.Team{TeamName: name, Members: members}, nil
+}
+
+// Rename переименовывает команду. Участники и SLA переезжают благодаря ON UPDATE CASCADE,
+// владельцы кода переименовываются в той же транзакции.
+// Если команды нет - repository.ErrNotFound, если новое имя занято - repository.ErrAlreadyExists.
+func (r *TeamDb) Rename(ctx context.Context, oldName, newName string) (err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	const query = `
+		UPDATE teams
+		SET team_name = ?
+		WHERE team_name = ?
+	`
+
+	res, err := tx.ExecContext(ctx, query, newName, oldName)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return repository.ErrAlreadyExists
+		}
+		return fmt.Errorf("rename team %s: %w", oldName, err)
+	}
+	if err = requireAffected(res); err != nil {
+		return err
+	}
+
+	const ownersQuery = `
+		UPDATE code_owner_rules
+		SET owner_teams = (
+			SELECT json_group_array(CASE WHEN value = ?1 THEN ?2 ELSE value END)
+			FROM json_each(code_owner_rules.owner_teams)
+		)
+		WHERE EXISTS (SELECT 1 FROM json_each(code_owner_rules.owner_teams) WHERE value = ?1)
+	`
+
+	if _, err = tx.ExecContext(ctx, ownersQuery, oldName, newName); err != nil {
+		return fmt.Errorf("rename team %s in code owners: %w", oldName, err)
+	}
+
+	return nil
+}
+
+// Delete удаляет команду вместе с её SLA (ON DELETE CASCADE) и убирает её из владельцев кода.
+// Если команды нет - repository.ErrNotFound.
+// Пока в команде есть участники, удаление запрещено внешним ключом fk_users_team.
+func (r *TeamDb) Delete(ctx context.Context, teamName string) (err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	const query = `
+		DELETE FROM teams
+		WHERE team_name = ?
+	`
+
+	res, err := tx.ExecContext(ctx, query, teamName)
+	if err != nil {
+		return fmt.Errorf("delete team %s: %w", teamName, err)
+	}
+	if err = requireAffected(res); err != nil {
+		return err
+	}
+
+	const ownersQuery = `
+		UPDATE code_owner_rules
+		SET owner_teams = (
+			SELECT json_group_array(value)
+			FROM json_each(code_owner_rules.owner_teams)
+			WHERE value <> ?1
+		)
+		WHERE EXISTS (SELECT 1 FROM json_each(code_owner_rules.owner_teams) WHERE value = ?1)
+	`
+
+	if _, err = tx.ExecContext(ctx, ownersQuery, teamName); err != nil {
+		return fmt.Errorf("delete team %s from code owners: %w", teamName, err)
+	}
+
+	return nil
+}
+
+// List возвращает страницу команд с количеством участников одним запросом.
+// Общее число команд под фильтром считается оконной функцией по сгруппированным строкам.
+func (r *TeamDb) List(ctx context.Context, filter repository.TeamListFilter) ([]domain.TeamSummary, int, error) {
+	const query = `
+		SELECT t.team_name,
+		       COUNT(u.user_id)                             AS member_count,
+		       COUNT(u.user_id) FILTER (WHERE u.is_active) AS active_member_count,
+		       COUNT(*) OVER ()                             AS total
+		FROM teams t
+		LEFT JOIN users u ON u.team_name = t.team_name
+		WHERE t.team_name LIKE ? ESCAPE '\'
+		GROUP BY t.team_name
+		ORDER BY t.team_name
+		LIMIT ? OFFSET ?
+	`
+
+	pattern := likeEscaper.Replace(filter.Prefix) + "%"
+
+	rows, err := r.db.QueryContext(ctx, query, pattern, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("query teams: %w", err)
+	}
+	defer rows.Close()
+
+	teams := make([]domain.TeamSummary, 0, filter.Limit)
+	total := 0
+	for rows.Next() {
+		var t domain.TeamSummary
+		if err := rows.Scan(&t.TeamName, &t.MemberCount, &t.ActiveMemberCount, &total); err != nil {
+			return nil, 0, fmt.Errorf("scan team summary: %w", err)
+		}
+		teams = append(teams, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("iterate teams: %w", err)
+	}
+
+	// За пределами последней страницы строк нет, и окно не даёт total - досчитываем отдельно.
+	if len(teams) == 0 && filter.Offset > 0 {
+		const countQuery = `
+			SELECT COUNT(*)
+			FROM teams
+			WHERE team_name LIKE ? ESCAPE '\'
+		`
+		if err := r.db.QueryRowContext(ctx, countQuery, pattern).Scan(&total); err != nil {
+			return nil, 0, fmt.Errorf("count teams: %w", err)
+		}
+	}
+
+	return teams, total, nil
+}
+
+// requireAffected возвращает repository.ErrNotFound, если запрос не затронул ни одной строки.
+func requireAffected(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected: %w", err)
+	}
+	if n == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"pr-reviewer-assigment-service/internal/application/repository"
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+type UnavailabilityDb struct {
+	db *sql.DB
+}
+
+func NewUnavailabilityDb(db *sql.DB) *UnavailabilityDb {
+	return &UnavailabilityDb{db: db}
+}
+
+const unavailabilityColumns = `unavailability_id, user_id, starts_at, ends_at, reason, released_at`
+
+// Create сохраняет новый период недоступности.
+func (r *UnavailabilityDb) Create(ctx context.Context, u *domain.Unavailability) error {
+	const query = `
+		INSERT INTO user_unavailability (` + unavailabilityColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		u.ID, u.UserID, formatTime(u.StartsAt), formatTime(u.EndsAt), u.Reason, formatTimePtr(u.ReleasedAt))
+	if err != nil {
+		return fmt.Errorf("insert unavailability for %s: %w", u.UserID, err)
+	}
+
+	return nil
+}
+
+// ListByUser возвращает незакончившиеся периоды пользователя по времени начала.
+func (r *UnavailabilityDb) ListByUser(ctx context.Context, userID string, now time.Time) ([]domain.Unavailability, error) {
+	const query = `
+		SELECT ` + unavailabilityColumns + `
+		FROM user_unavailability
+		WHERE user_id = ? AND ends_at > ?
+		ORDER BY starts_at, unavailability_id
+	`
+
+	return r.list(ctx, query, userID, formatTime(now))
+}
+
+// Delete удаляет период и возвращает его. Если периода нет - repository.ErrNotFound.
+func (r *UnavailabilityDb) Delete(ctx context.Context, id string) (*domain.Unavailability, error) {
+	const query = `
+		DELETE FROM user_unavailability
+		WHERE unavailability_id = ?
+		RETURNING ` + unavailabilityColumns
+
+	u, err := scanUnavailability(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("delete unavailability %s: %w", id, err)
+	}
+
+	return u, nil
+}
+
+// ListUnreleased возвращает идущие сейчас периоды, по которым пользователя ещё не снимали с ревью.
+func (r *UnavailabilityDb) ListUnreleased(ctx context.Context, now time.Time) ([]domain.Unavailability, error) {
+	const query = `
+		SELECT ` + unavailabilityColumns + `
+		FROM user_unavailability
+		WHERE released_at IS NULL AND starts_at <= ?1 AND ends_at > ?1
+		ORDER BY starts_at, unavailability_id
+	`
+
+	return r.list(ctx, query, formatTime(now))
+}
+
+// MarkReleased отмечает, что пользователь снят с ревью.
+func (r *UnavailabilityDb) MarkReleased(ctx context.Context, id string, at time.Time) error {
+	const query = `
+		UPDATE user_unavailability
+		SET released_at = ?
+		WHERE unavailability_id = ?
+	`
+
+	res, err := r.db.ExecContext(ctx, query, formatTime(at), id)
+	if err != nil {
+		return fmt.Errorf("mark unavailability %s released: %w", id, err)
+	}
+
+	return requireAffected(res)
+}
+
+func (r *UnavailabilityDb) list(ctx context.Context, query string, args ...any) ([]domain.Unavailability, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query unavailability: %w", err)
+	}
+	defer rows.Close()
+
+	result := make([]domain.Unavailability, 0)
+	for rows.Next() {
+		u, err := scanUnavailability(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan unavailability: %w", err)
+		}
+		result = append(result, *u)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate unavailability: %w", err)
+	}
+
+	return result, nil
+}
+
+// rowScanner - общее у *sql.Row и *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanUnavailability(row rowScanner) (*domain.Unavailability, error) {
+	var (
+		u                            domain.Unavailability
+		startsAt, endsAt, releasedAt nullTime
+	)
+	if err := row.Scan(&u.ID, &u.UserID, &startsAt, &endsAt, &u.Reason, &releasedAt); err != nil {
+		return nil, err
+	}
+	u.StartsAt = startsAt.Time
+	u.EndsAt = endsAt.Time
+	u.ReleasedAt = releasedAt.Ptr()
+	return &u, nil
+}
@@ -0,0 +1,290 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"pr-reviewer-assigment-service/internal/application/repository"
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+// userColumns - колонки users в порядке userScanTargets.
+const userColumns = `user_id, username, COALESCE(team_name, ''), is_active, max_open_reviews, skills`
+
+// availableCondition - пользователь активен и в момент из двух параметров (см. availableArgs)
+// не в периоде недоступности.
+const availableCondition = `is_active = 1
+	AND NOT EXISTS (
+		SELECT 1
+		FROM user_unavailability ua
+		WHERE ua.user_id = users.user_id AND ua.starts_at <= ? AND ua.ends_at > ?
+	)`
+
+// userScanTargets возвращает поля пользователя для Scan в порядке userColumns.
+func userScanTargets(u *domain.User) []any {
+	return []any{&u.UserID, &u.Username, &u.TeamName, &u.IsActive, &u.MaxOpenReviews, (*stringList)(&u.Skills)}
+}
+
+type UserDb struct {
+	db *sql.DB
+}
+
+func NewUserDb(db *sql.DB) *UserDb {
+	return &UserDb{db: db}
+}
+
+// BulkUpsert создаёт или обновляет нескольких пользователей в одной транзакции.
+// Лимит открытых ревью и навыки у существующих пользователей не меняются.
+// При повторе user_id побеждает последняя запись.
+func (r *UserDb) BulkUpsert(ctx context.Context, users []domain.User) (err error) {
+	if len(users) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO users (user_id, username, team_name, is_active)
+		VALUES (?, ?, NULLIF(?, ''), ?)
+		ON CONFLICT (user_id) DO UPDATE SET
+			username  = excluded.username,
+			team_name = excluded.team_name,
+			is_active = excluded.is_active
+	`)
+	if err != nil {
+		return fmt.Errorf("prepare upsert users: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, u := range users {
+		if _, err = stmt.ExecContext(ctx, u.UserID, u.Username, u.TeamName, u.IsActive); err != nil {
+			return fmt.Errorf("upsert user %s: %w", u.UserID, err)
+		}
+	}
+
+	return nil
+}
+
+// GetByID возвращает пользователя по user_id.
+func (r *UserDb) GetByID(ctx context.Context, userID string) (*domain.User, error) {
+	const query = `
+		SELECT ` + userColumns + `
+		FROM users
+		WHERE user_id = ?
+	`
+
+	var u domain.User
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(userScanTargets(&u)...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("query user by id: %w", err)
+	}
+
+	return &u, nil
+}
+
+// SetActive обновляет флаг активности пользователя и возвращает обновлённого пользователя.
+func (r *UserDb) SetActive(ctx context.Context, userID string, active bool) (*domain.User, error) {
+	return r.update(ctx, "is_active = ?", active, userID)
+}
+
+// SetTeam переводит пользователя в команду и возвращает обновлённого пользователя.
+// Пустой teamName выводит пользователя из команды (team_name = NULL).
+func (r *UserDb) SetTeam(ctx context.Context, userID string, teamName string) (*domain.User, error) {
+	return r.update(ctx, "team_name = NULLIF(?, '')", teamName, userID)
+}
+
+// SetMaxOpenReviews задаёт лимит открытых ревью пользователя и возвращает обновлённого пользователя.
+// nil снимает лимит.
+func (r *UserDb) SetMaxOpenReviews(ctx context.Context, userID string, limit *int) (*domain.User, error) {
+	return r.update(ctx, "max_open_reviews = ?", limit, userID)
+}
+
+// SetSkills заменяет теги экспертизы пользователя и возвращает обновлённого пользователя.
+func (r *UserDb) SetSkills(ctx context.Context, userID string, skills []string) (*domain.User, error) {
+	return r.update(ctx, "skills = ?", stringList(skills), userID)
+}
+
+// update меняет одну колонку пользователя выражением set и возвращает обновлённого пользователя.
+// Если пользователь не найден - возвращает repository.ErrNotFound.
+func (r *UserDb) update(ctx context.Context, set string, value any, userID string) (*domain.User, error) {
+	query := `
+		UPDATE users
+		SET ` + set + `
+		WHERE user_id = ?
+		RETURNING ` + userColumns
+
+	var u domain.User
+	err := r.db.QueryRowContext(ctx, query, value, userID).Scan(userScanTargets(&u)...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("update user %s: %w", userID, err)
+	}
+
+	return &u, nil
+}
+
+// ListByIDs возвращает существующих пользователей из списка.
+// Если onlyActive == true, возвращаются только активные пользователи, которые в момент now не в периоде недоступности.
+func (r *UserDb) ListByIDs(ctx context.Context, userIDs []string, onlyActive bool, now time.Time) ([]domain.User, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		SELECT ` + userColumns + `
+		FROM users
+		WHERE user_id IN (SELECT value FROM json_each(?))
+	`
+	args := []any{stringList(userIDs)}
+
+	if onlyActive {
+		query += " AND " + availableCondition
+		args = append(args, availableArgs(now)...)
+	}
+
+	return r.list(ctx, query, args...)
+}
+
+// ListByTeam возвращает пользователей команды.
+// Если onlyActive == true, возвращаются только активные пользователи, которые в момент now не в периоде недоступности.
+func (r *UserDb) ListByTeam(ctx context.Context, teamName string, onlyActive bool, now time.Time) ([]domain.User, error) {
+	query := `
+		SELECT ` + userColumns + `
+		FROM users
+		WHERE team_name = ?
+	`
+	args := []any{teamName}
+
+	if onlyActive {
+		query += " AND " + availableCondition
+		args = append(args, availableArgs(now)...)
+	}
+
+	return r.list(ctx, query, args...)
+}
+
+// availableArgs возвращает параметры availableCondition для момента now.
+func availableArgs(now time.Time) []any {
+	at := formatTime(now)
+	return []any{at, at}
+}
+
+// list выполняет запрос, выбирающий userColumns, и собирает пользователей.
+func (r *UserDb) list(ctx context.Context, query string, args ...any) ([]domain.User, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list users: %w", err)
+	}
+	defer rows.Close()
+
+	var result []domain.User
+	for rows.Next() {
+		var u domain.User
+		if err := rows.Scan(userScanTargets(&u)...); err != nil {
+			return nil, fmt.Errorf("scan user row: %w", err)
+		}
+		result = append(result, u)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate user rows: %w", err)
+	}
+
+	return result, nil
+}
+
+// openReviewCountColumn считает открытые PR, где пользователь u назначен ревьювером.
+// Назначения ищутся по индексу idx_pull_request_reviewers_user.
+const openReviewCountColumn = `(
+	SELECT COUNT(*)
+	FROM pull_request_reviewers prr
+	JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id
+	WHERE prr.user_id = u.user_id AND pr.status = 'OPEN'
+)`
+
+// GetSummary возвращает пользователя вместе с числом открытых ревью.
+// Если пользователь не найден - возвращает repository.ErrNotFound.
+func (r *UserDb) GetSummary(ctx context.Context, userID string) (*domain.UserSummary, error) {
+	query := `
+		SELECT ` + userColumns + `, ` + openReviewCountColumn + `
+		FROM users u
+		WHERE u.user_id = ?
+	`
+
+	var s domain.UserSummary
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(append(userScanTargets(&s.User), &s.OpenReviewCount)...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("query user summary: %w", err)
+	}
+
+	return &s, nil
+}
+
+// List возвращает пользователей под фильтром, упорядоченных по user_id.
+// Пагинация по ключу: следующая страница начинается после filter.AfterUserID.
+func (r *UserDb) List(ctx context.Context, filter repository.UserListFilter) ([]domain.UserSummary, error) {
+	query := `
+		SELECT ` + userColumns + `, ` + openReviewCountColumn + `
+		FROM users u
+		WHERE u.user_id > ?
+	`
+	args := []any{filter.AfterUserID}
+
+	if filter.TeamName != "" {
+		query += " AND u.team_name = ?"
+		args = append(args, filter.TeamName)
+	}
+	if filter.IsActive != nil {
+		query += " AND u.is_active = ?"
+		args = append(args, *filter.IsActive)
+	}
+	if filter.Username != "" {
+		query += ` AND u.username LIKE ? ESCAPE '\'`
+		args = append(args, "%"+likeEscaper.Replace(filter.Username)+"%")
+	}
+
+	query += " ORDER BY u.user_id LIMIT ?"
+	args = append(args, filter.Limit)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list users: %w", err)
+	}
+	defer rows.Close()
+
+	result := make([]domain.UserSummary, 0, filter.Limit)
+	for rows.Next() {
+		var s domain.UserSummary
+		if err := rows.Scan(append(userScanTargets(&s.User), &s.OpenReviewCount)...); err != nil {
+			return nil, fmt.Errorf("scan user summary: %w", err)
+		}
+		result = append(result, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate user summaries: %w", err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,12 @@
+CREATE TABLE idempotency_keys (
+   key            TEXT PRIMARY KEY,
+   request_hash   TEXT        NOT NULL,
+-- NULL, пока исходный запрос выполняется
+   status_code    INTEGER     NULL,
+   content_type   TEXT        NULL,
+   body           BYTEA       NULL,
+   created_at     TIMESTAMPTZ NOT NULL,
+   expires_at     TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
@@ -0,0 +1,2 @@
+-- Откат невозможен, пока есть пользователи без команды: их нужно сначала вернуть в команды.
+ALTER TABLE users ALTER COLUMN team_name SET NOT NULL;
@@ -0,0 +1,2 @@
+-- Пользователь, удалённый из команды, остаётся в базе (он может быть автором PR), но без команды.
+ALTER TABLE users ALTER COLUMN team_name DROP NOT NULL;
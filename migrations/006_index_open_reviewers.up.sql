@@ -0,0 +1,2 @@
+-- Нагрузка ревьювера (число открытых ревью) считается через assigned_reviewers @> ARRAY[user_id].
+CREATE INDEX idx_pull_requests_open_reviewers ON pull_requests USING GIN (assigned_reviewers) WHERE status = 'OPEN';
@@ -0,0 +1,20 @@
+CREATE TABLE user_unavailability (
+   unavailability_id TEXT PRIMARY KEY,
+   user_id           TEXT        NOT NULL,
+   starts_at         TIMESTAMPTZ NOT NULL,
+   ends_at           TIMESTAMPTZ NOT NULL,
+   reason            TEXT        NOT NULL DEFAULT '',
+-- Когда фоновая задача сняла пользователя с открытых ревью; NULL - ещё не снимала
+   released_at       TIMESTAMPTZ NULL,
+
+   CONSTRAINT fk_user_unavailability_user
+       FOREIGN KEY (user_id)
+           REFERENCES users(user_id)
+           ON UPDATE CASCADE
+           ON DELETE CASCADE,
+
+   CONSTRAINT user_unavailability_period
+       CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX idx_user_unavailability_user ON user_unavailability (user_id, ends_at);
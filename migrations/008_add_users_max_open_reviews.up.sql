@@ -0,0 +1,2 @@
+-- Лимит одновременно открытых ревью пользователя; NULL - без лимита.
+ALTER TABLE users ADD COLUMN max_open_reviews INTEGER NULL CHECK (max_open_reviews >= 0);
@@ -0,0 +1,2 @@
+-- Теги экспертизы пользователя (например, go, postgres); сопоставляются с метками PR.
+ALTER TABLE users ADD COLUMN skills TEXT[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,7 @@
+-- Правила CODEOWNERS в порядке файла; при совпадении нескольких правил действует последнее.
+CREATE TABLE code_owner_rules (
+   position    INTEGER PRIMARY KEY,
+   pattern     TEXT   NOT NULL,
+   owner_users TEXT[] NOT NULL DEFAULT '{}',
+   owner_teams TEXT[] NOT NULL DEFAULT '{}'
+);
@@ -0,0 +1,29 @@
+-- Правила подбора ревьюверов. В каждом селекторе задан либо user_id, либо навык.
+CREATE TABLE reviewer_rules (
+   rule_id          TEXT PRIMARY KEY,
+   kind             TEXT        NOT NULL CHECK (kind IN ('EXCLUDE', 'REQUIRE')),
+   author_user_id   TEXT        NULL,
+   author_skill     TEXT        NULL,
+   reviewer_user_id TEXT        NULL,
+   reviewer_skill   TEXT        NULL,
+   description      TEXT        NOT NULL DEFAULT '',
+   created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+   CONSTRAINT fk_reviewer_rules_author
+       FOREIGN KEY (author_user_id)
+           REFERENCES users(user_id)
+           ON UPDATE CASCADE
+           ON DELETE CASCADE,
+
+   CONSTRAINT fk_reviewer_rules_reviewer
+       FOREIGN KEY (reviewer_user_id)
+           REFERENCES users(user_id)
+           ON UPDATE CASCADE
+           ON DELETE CASCADE,
+
+   CONSTRAINT reviewer_rules_author_selector
+       CHECK ((author_user_id IS NULL) <> (author_skill IS NULL)),
+
+   CONSTRAINT reviewer_rules_reviewer_selector
+       CHECK ((reviewer_user_id IS NULL) <> (reviewer_skill IS NULL))
+);
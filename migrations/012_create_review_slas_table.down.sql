@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_pull_requests_open_created;
+DROP TABLE IF EXISTS review_escalations;
+DROP TABLE IF EXISTS review_slas;
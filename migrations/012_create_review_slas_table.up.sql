@@ -0,0 +1,26 @@
+-- SLA ревью команды: сколько PR автора из команды может оставаться OPEN и что делать после.
+CREATE TABLE review_slas (
+   team_name   TEXT PRIMARY KEY,
+   sla_seconds BIGINT NOT NULL CHECK (sla_seconds > 0),
+   policy      TEXT   NOT NULL CHECK (policy IN ('NOTIFY', 'REASSIGN')),
+
+   CONSTRAINT fk_review_slas_team
+       FOREIGN KEY (team_name)
+           REFERENCES teams(team_name)
+           ON UPDATE CASCADE
+           ON DELETE CASCADE
+);
+
+-- Последняя эскалация просроченного PR; следующая - не раньше чем через SLA после неё.
+CREATE TABLE review_escalations (
+   pull_request_id TEXT PRIMARY KEY,
+   escalated_at    TIMESTAMPTZ NOT NULL,
+
+   CONSTRAINT fk_review_escalations_pull_request
+       FOREIGN KEY (pull_request_id)
+           REFERENCES pull_requests(pull_request_id)
+           ON UPDATE CASCADE
+           ON DELETE CASCADE
+);
+
+CREATE INDEX idx_pull_requests_open_created ON pull_requests (created_at) WHERE status = 'OPEN';
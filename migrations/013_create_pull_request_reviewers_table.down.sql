@@ -0,0 +1,17 @@
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS assigned_reviewers TEXT[] NOT NULL DEFAULT '{}';
+
+UPDATE pull_requests pr
+SET assigned_reviewers = ARRAY(
+   SELECT r.user_id
+   FROM pull_request_reviewers r
+   WHERE r.pull_request_id = pr.pull_request_id
+   ORDER BY r.position
+);
+
+ALTER TABLE pull_requests
+   ADD CONSTRAINT assigned_reviewers_max_2
+       CHECK (cardinality(assigned_reviewers) <= 2);
+
+CREATE INDEX IF NOT EXISTS idx_pull_requests_open_reviewers ON pull_requests USING GIN (assigned_reviewers) WHERE status = 'OPEN';
+
+DROP TABLE IF EXISTS pull_request_reviewers;
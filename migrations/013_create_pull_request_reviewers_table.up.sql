@@ -0,0 +1,39 @@
+-- Назначения ревьюверов вместо pull_requests.assigned_reviewers: по user_id можно искать через индекс.
+CREATE TABLE pull_request_reviewers (
+   pull_request_id TEXT        NOT NULL,
+   user_id         TEXT        NOT NULL,
+   position        SMALLINT    NOT NULL CHECK (position IN (0, 1)), -- порядок ревьюверов в PR, максимум 2
+   assigned_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+   PRIMARY KEY (pull_request_id, user_id),
+
+   -- Отложенная проверка: при переназначении ревьюверы могут поменяться местами в одной транзакции.
+   CONSTRAINT pull_request_reviewers_position
+       UNIQUE (pull_request_id, position) DEFERRABLE INITIALLY DEFERRED,
+
+   CONSTRAINT fk_pull_request_reviewers_pull_request
+       FOREIGN KEY (pull_request_id)
+           REFERENCES pull_requests(pull_request_id)
+           ON UPDATE CASCADE
+           ON DELETE CASCADE
+);
+
+CREATE INDEX idx_pull_request_reviewers_user ON pull_request_reviewers (user_id);
+
+-- Время назначения раньше не хранилось, для перенесённых ревьюверов берётся время создания PR.
+INSERT INTO pull_request_reviewers (pull_request_id, user_id, position, assigned_at)
+SELECT pr.pull_request_id, r.user_id, r.ord - 1, pr.created_at
+FROM pull_requests pr, unnest(pr.assigned_reviewers) WITH ORDINALITY AS r(user_id, ord);
+
+-- В массиве могли остаться ID несуществующих пользователей. NOT VALID не проверяет перенесённые строки,
+-- чтобы такие ссылки не потерялись, но запрещает новые.
+ALTER TABLE pull_request_reviewers
+   ADD CONSTRAINT fk_pull_request_reviewers_user
+       FOREIGN KEY (user_id)
+           REFERENCES users(user_id)
+           ON UPDATE CASCADE
+           ON DELETE RESTRICT
+       NOT VALID;
+
+DROP INDEX IF EXISTS idx_pull_requests_open_reviewers;
+ALTER TABLE pull_requests DROP COLUMN assigned_reviewers;
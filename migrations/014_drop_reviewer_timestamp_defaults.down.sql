@@ -0,0 +1,2 @@
+ALTER TABLE pull_request_reviewers ALTER COLUMN assigned_at SET DEFAULT NOW();
+ALTER TABLE reviewer_rules ALTER COLUMN created_at SET DEFAULT NOW();
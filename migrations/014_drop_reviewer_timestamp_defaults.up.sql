@@ -0,0 +1,4 @@
+-- Время назначения и создания правила задаёт сервис по своим часам: без значения вставка должна падать,
+-- а не молча брать время базы.
+ALTER TABLE reviewer_rules ALTER COLUMN created_at DROP DEFAULT;
+ALTER TABLE pull_request_reviewers ALTER COLUMN assigned_at DROP DEFAULT;
@@ -0,0 +1,9 @@
+-- Удалённые строки не восстанавливаются, ключ возвращается в состояние после миграции 013.
+ALTER TABLE pull_request_reviewers DROP CONSTRAINT fk_pull_request_reviewers_user;
+ALTER TABLE pull_request_reviewers
+   ADD CONSTRAINT fk_pull_request_reviewers_user
+       FOREIGN KEY (user_id)
+           REFERENCES users(user_id)
+           ON UPDATE CASCADE
+           ON DELETE RESTRICT
+       NOT VALID;
@@ -0,0 +1,6 @@
+-- Проверка ссылок, перенесённых миграцией 013 без проверки ключа. Несуществующих ревьюверов
+-- назначить уже нельзя, поэтому такие строки удаляются; до миграции их видно в GET /admin/consistency.
+DELETE FROM pull_request_reviewers prr
+WHERE NOT EXISTS (SELECT 1 FROM users u WHERE u.user_id = prr.user_id);
+
+ALTER TABLE pull_request_reviewers VALIDATE CONSTRAINT fk_pull_request_reviewers_user;
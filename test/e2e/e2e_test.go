@@ -16,7 +16,9 @@ import (
 
 	"pr-reviewer-assigment-service/internal/api"
 	"pr-reviewer-assigment-service/internal/api/httphandlers"
+	"pr-reviewer-assigment-service/internal/api/httpmiddleware"
 	"pr-reviewer-assigment-service/internal/application/service"
+	"pr-reviewer-assigment-service/internal/infrastructure/events"
 	"pr-reviewer-assigment-service/internal/infrastructure/postgres"
 )
 
@@ -77,6 +79,12 @@ func teardownTestDB(t *testing.T, db *testDB) {
 
 func migrateSchema(ctx context.Context, db *pgxpool.Pool) error {
 	_, err := db.Exec(ctx, `
+		DROP TABLE IF EXISTS review_escalations;
+		DROP TABLE IF EXISTS review_slas;
+		DROP TABLE IF EXISTS reviewer_rules;
+		DROP TABLE IF EXISTS code_owner_rules;
+		DROP TABLE IF EXISTS user_unavailability;
+		DROP TABLE IF EXISTS pull_request_reviewers;
 		DROP TABLE IF EXISTS pull_requests;
 		DROP TABLE IF EXISTS users;
 		DROP TABLE IF EXISTS teams;
@@ -88,8 +96,10 @@ func migrateSchema(ctx context.Context, db *pgxpool.Pool) error {
 		CREATE TABLE users (
 			user_id   TEXT PRIMARY KEY,
 			username  TEXT NOT NULL,
-			team_name TEXT NOT NULL,
+			team_name TEXT NULL,
 			is_active BOOLEAN NOT NULL DEFAULT TRUE,
+			max_open_reviews INTEGER NULL CHECK (max_open_reviews >= 0),
+			skills    TEXT[] NOT NULL DEFAULT '{}',
 			CONSTRAINT fk_users_team
 				FOREIGN KEY (team_name)
 				REFERENCES teams(team_name)
@@ -102,16 +112,75 @@ func migrateSchema(ctx context.Context, db *pgxpool.Pool) error {
 			pull_request_name   TEXT        NOT NULL,
 			author_id           TEXT        NOT NULL,
 			status              TEXT        NOT NULL CHECK (status IN ('OPEN', 'MERGED')),
-			assigned_reviewers  TEXT[]      NOT NULL DEFAULT '{}',
 			created_at          TIMESTAMPTZ NOT NULL DEFAULT NOW(),
 			merged_at           TIMESTAMPTZ NULL,
 			CONSTRAINT fk_pull_requests_author
 				FOREIGN KEY (author_id)
 				REFERENCES users(user_id)
 				ON UPDATE CASCADE
-				ON DELETE RESTRICT,
-			CONSTRAINT assigned_reviewers_max_2
-				CHECK (cardinality(assigned_reviewers) <= 2)
+				ON DELETE RESTRICT
+		);
+
+		CREATE TABLE pull_request_reviewers (
+			pull_request_id TEXT        NOT NULL REFERENCES pull_requests(pull_request_id) ON UPDATE CASCADE ON DELETE CASCADE,
+			user_id         TEXT        NOT NULL,
+			position        SMALLINT    NOT NULL CHECK (position IN (0, 1)),
+			assigned_at     TIMESTAMPTZ NOT NULL,
+			PRIMARY KEY (pull_request_id, user_id),
+			UNIQUE (pull_request_id, position) DEFERRABLE INITIALLY DEFERRED,
+			CONSTRAINT fk_pull_request_reviewers_user
+				FOREIGN KEY (user_id)
+				REFERENCES users(user_id)
+				ON UPDATE CASCADE
+				ON DELETE RESTRICT
+		);
+		CREATE INDEX ON pull_request_reviewers (user_id);
+
+		CREATE TABLE user_unavailability (
+			unavailability_id TEXT PRIMARY KEY,
+			user_id           TEXT        NOT NULL,
+			starts_at         TIMESTAMPTZ NOT NULL,
+			ends_at           TIMESTAMPTZ NOT NULL,
+			reason            TEXT        NOT NULL DEFAULT '',
+			released_at       TIMESTAMPTZ NULL,
+			CONSTRAINT fk_user_unavailability_user
+				FOREIGN KEY (user_id)
+				REFERENCES users(user_id)
+				ON UPDATE CASCADE
+				ON DELETE CASCADE,
+			CONSTRAINT user_unavailability_period
+				CHECK (ends_at > starts_at)
+		);
+
+		CREATE TABLE code_owner_rules (
+			position    INTEGER PRIMARY KEY,
+			pattern     TEXT   NOT NULL,
+			owner_users TEXT[] NOT NULL DEFAULT '{}',
+			owner_teams TEXT[] NOT NULL DEFAULT '{}'
+		);
+
+		CREATE TABLE reviewer_rules (
+			rule_id          TEXT PRIMARY KEY,
+			kind             TEXT        NOT NULL CHECK (kind IN ('EXCLUDE', 'REQUIRE')),
+			author_user_id   TEXT        NULL REFERENCES users(user_id) ON UPDATE CASCADE ON DELETE CASCADE,
+			author_skill     TEXT        NULL,
+			reviewer_user_id TEXT        NULL REFERENCES users(user_id) ON UPDATE CASCADE ON DELETE CASCADE,
+			reviewer_skill   TEXT        NULL,
+			description      TEXT        NOT NULL DEFAULT '',
+			created_at       TIMESTAMPTZ NOT NULL,
+			CHECK ((author_user_id IS NULL) <> (author_skill IS NULL)),
+			CHECK ((reviewer_user_id IS NULL) <> (reviewer_skill IS NULL))
+		);
+
+		CREATE TABLE review_slas (
+			team_name   TEXT PRIMARY KEY REFERENCES teams(team_name) ON UPDATE CASCADE ON DELETE CASCADE,
+			sla_seconds BIGINT NOT NULL CHECK (sla_seconds > 0),
+			policy      TEXT   NOT NULL CHECK (policy IN ('NOTIFY', 'REASSIGN'))
+		);
+
+		CREATE TABLE review_escalations (
+			pull_request_id TEXT PRIMARY KEY REFERENCES pull_requests(pull_request_id) ON UPDATE CASCADE ON DELETE CASCADE,
+			escalated_at    TIMESTAMPTZ NOT NULL
 		);
 	`)
 	return err
@@ -123,22 +192,55 @@ func newTestServer(t *testing.T, db *testDB) *httptest.Server {
 	userRepo := postgres.NewUserDb(db.pool)
 	teamRepo := postgres.NewTeamDb(db.pool)
 	prRepo := postgres.NewPullRequestDb(db.pool)
-
-	teamService := service.NewTeamService(userRepo, teamRepo)
-	userService := service.NewUserService(userRepo, prRepo)
-	prService := service.NewPullRequestService(prRepo, userRepo, teamRepo)
+	unavailabilityRepo := postgres.NewUnavailabilityDb(db.pool)
+	codeOwnerRepo := postgres.NewCodeOwnerDb(db.pool)
+	ruleRepo := postgres.NewReviewerRuleDb(db.pool)
+	slaRepo := postgres.NewReviewSLADb(db.pool)
+	importRepo := postgres.NewImportDb(db.pool)
+	exportRepo := postgres.NewExportDb(db.pool)
+	consistencyRepo := postgres.NewConsistencyDb(db.pool)
+
+	clock := service.SystemClock{}
+	ids := service.UUIDGenerator{}
+
+	prService := service.NewPullRequestService(prRepo, userRepo, teamRepo, codeOwnerRepo, ruleRepo, clock)
+	teamService := service.NewTeamService(userRepo, teamRepo, prRepo, prService)
+	userService := service.NewUserService(userRepo, prRepo, teamRepo, prService)
 	statsService := service.NewStatsService(prRepo)
+	availabilityService := service.NewAvailabilityService(userRepo, unavailabilityRepo, prService, clock, ids)
+	codeOwnerService := service.NewCodeOwnerService(codeOwnerRepo, userRepo, teamRepo)
+	ruleService := service.NewRuleService(ruleRepo, userRepo, prRepo, clock, ids)
+	slaService := service.NewSLAService(slaRepo, teamRepo, prService, events.NewLogPublisher(nil), clock)
+	importService := service.NewImportService(importRepo)
+	exportService := service.NewExportService(exportRepo)
+	consistencyService := service.NewConsistencyService(consistencyRepo, prService)
 
 	teamHandlers := httphandlers.NewTeamHandlers(teamService)
-	userHandlers := httphandlers.NewUserHandlers(userService)
+	userHandlers := httphandlers.NewUserHandlers(userService, availabilityService)
 	prHandlers := httphandlers.NewPullRequestHandlers(prService)
 	statsHandlers := httphandlers.NewStatsHandlers(statsService)
+	codeOwnerHandlers := httphandlers.NewCodeOwnerHandlers(codeOwnerService)
+	ruleHandlers := httphandlers.NewRuleHandlers(ruleService)
+	slaHandlers := httphandlers.NewSLAHandlers(slaService)
+	adminHandlers := httphandlers.NewAdminHandlers(importService, exportService, consistencyService)
+
+	// Все сценарии прогоняются со строгой проверкой по OpenAPI-спецификации:
+	// ответ, расходящийся со спекой, превращается в 500 CONTRACT_VIOLATION и валит тест.
+	validator, err := httpmiddleware.NewOpenAPIValidator(api.OpenAPISpec, httpmiddleware.OpenAPIStrict)
+	if err != nil {
+		t.Fatalf("failed to load openapi spec: %v", err)
+	}
 
 	router := api.NewRouter(
 		teamHandlers,
 		userHandlers,
 		prHandlers,
 		statsHandlers,
+		codeOwnerHandlers,
+		ruleHandlers,
+		slaHandlers,
+		adminHandlers,
+		validator.Middleware,
 	)
 
 	return httptest.NewServer(router)
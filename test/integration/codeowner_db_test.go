@@ -0,0 +1,46 @@
+package integration_test
+
+import (
+	"context"
+	"testing"
+
+	"pr-reviewer-assigment-service/internal/domain"
+	pg "pr-reviewer-assigment-service/internal/infrastructure/postgres"
+)
+
+func TestCodeOwnerDb_Replace_And_List(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	repo := pg.NewCodeOwnerDb(db.Pool)
+
+	if err := repo.Replace(ctx, []domain.CodeOwnerRule{
+		{Position: 3, Pattern: "/migrations/", Teams: []string{"platform"}},
+		{Position: 1, Pattern: "*.go", Users: []string{"u1", "u2"}},
+	}); err != nil {
+		t.Fatalf("Replace: %v", err)
+	}
+
+	rules, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(rules) != 2 || rules[0].Pattern != "*.go" || rules[1].Pattern != "/migrations/" {
+		t.Fatalf("expected rules in file order, got %+v", rules)
+	}
+	if len(rules[0].Users) != 2 || len(rules[0].Teams) != 0 || len(rules[1].Teams) != 1 {
+		t.Fatalf("unexpected owners: %+v", rules)
+	}
+
+	if err := repo.Replace(ctx, []domain.CodeOwnerRule{{Position: 1, Pattern: "docs/", Users: []string{"u3"}}}); err != nil {
+		t.Fatalf("Replace: %v", err)
+	}
+	rules, err = repo.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(rules) != 1 || rules[0].Pattern != "docs/" {
+		t.Fatalf("expected previous rules to be replaced, got %+v", rules)
+	}
+}
@@ -0,0 +1,86 @@
+package integration_test
+
+import (
+	"context"
+	"testing"
+
+	"pr-reviewer-assigment-service/internal/domain"
+	pg "pr-reviewer-assigment-service/internal/infrastructure/postgres"
+)
+
+func TestConsistencyDb_Check_And_RemoveDanglingReviewers(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	repo := pg.NewConsistencyDb(db.Pool)
+
+	if _, err := db.Pool.Exec(ctx, `
+		INSERT INTO teams (team_name) VALUES ('backend');
+		INSERT INTO users (user_id, username, team_name, is_active)
+		VALUES ('u1', 'Alice', 'backend', TRUE),
+		       ('u2', 'Bob', 'backend', FALSE),
+		       ('u3', 'Carol', NULL, TRUE);
+		INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status)
+		VALUES ('pr-1', 'A', 'u1', 'OPEN'),
+		       ('pr-2', 'B', 'u3', 'OPEN'),
+		       ('pr-3', 'C', 'u3', 'MERGED');
+		INSERT INTO pull_request_reviewers (pull_request_id, user_id, position, assigned_at)
+		VALUES ('pr-1', 'u2', 0, NOW()),
+		       ('pr-3', 'u2', 0, NOW());
+
+		-- Состояние после миграции 013: в массиве были ID несуществующих пользователей, ключ не проверен.
+		ALTER TABLE pull_request_reviewers DROP CONSTRAINT fk_pull_request_reviewers_user;
+		INSERT INTO pull_request_reviewers (pull_request_id, user_id, position, assigned_at) VALUES ('pr-2', 'ghost', 0, NOW());
+		ALTER TABLE pull_request_reviewers
+			ADD CONSTRAINT fk_pull_request_reviewers_user
+			FOREIGN KEY (user_id) REFERENCES users(user_id) ON UPDATE CASCADE ON DELETE RESTRICT NOT VALID;
+	`); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	issues, err := repo.Check(ctx)
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	want := []domain.ConsistencyIssue{
+		{Kind: domain.IssueAuthorWithoutTeam, PullRequestID: "pr-2", UserID: "u3"},
+		{Kind: domain.IssueDanglingReviewer, PullRequestID: "pr-2", UserID: "ghost"},
+		{Kind: domain.IssueInactiveReviewer, PullRequestID: "pr-1", UserID: "u2"},
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("expected %+v, got %+v", want, issues)
+	}
+	for i := range want {
+		if issues[i] != want[i] {
+			t.Fatalf("issue %d: expected %+v, got %+v", i, want[i], issues[i])
+		}
+	}
+
+	removed, err := repo.RemoveDanglingReviewers(ctx)
+	if err != nil {
+		t.Fatalf("RemoveDanglingReviewers: %v", err)
+	}
+	if removed != 1 {
+		t.Fatalf("expected 1 removed reviewer, got %d", removed)
+	}
+
+	var validated bool
+	if err := db.Pool.QueryRow(ctx,
+		`SELECT convalidated FROM pg_constraint WHERE conname = 'fk_pull_request_reviewers_user'`,
+	).Scan(&validated); err != nil {
+		t.Fatalf("query constraint: %v", err)
+	}
+	// Ключ проверяет миграция 015, ремонт только исправляет данные.
+	if validated {
+		t.Fatalf("expected repair to leave the reviewer foreign key untouched")
+	}
+
+	issues, err = repo.Check(ctx)
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	if len(issues) != 2 || issues[0].Kind != domain.IssueAuthorWithoutTeam || issues[1].Kind != domain.IssueInactiveReviewer {
+		t.Fatalf("expected dangling reviewer to be gone, got %+v", issues)
+	}
+}
@@ -0,0 +1,32 @@
+package integration_test
+
+import (
+	"context"
+	"testing"
+
+	"pr-reviewer-assigment-service/internal/application/repository/repositorytest"
+	pg "pr-reviewer-assigment-service/internal/infrastructure/postgres"
+)
+
+// TestContract прогоняет общий контракт репозиториев на Postgres - тот же, что и на хранилище в памяти.
+func TestContract(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	repositorytest.Run(t, func(t *testing.T) repositorytest.Repositories {
+		if _, err := db.Pool.Exec(context.Background(), `TRUNCATE teams, users, pull_requests, code_owner_rules CASCADE`); err != nil {
+			t.Fatalf("truncate: %v", err)
+		}
+		return repositorytest.Repositories{
+			Users:          pg.NewUserDb(db.Pool),
+			Teams:          pg.NewTeamDb(db.Pool),
+			PullRequests:   pg.NewPullRequestDb(db.Pool),
+			CodeOwners:     pg.NewCodeOwnerDb(db.Pool),
+			SLAs:           pg.NewReviewSLADb(db.Pool),
+			Rules:          pg.NewReviewerRuleDb(db.Pool),
+			Unavailability: pg.NewUnavailabilityDb(db.Pool),
+			Export:         pg.NewExportDb(db.Pool),
+			Import:         pg.NewImportDb(db.Pool),
+		}
+	})
+}
@@ -0,0 +1,132 @@
+package integration_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"pr-reviewer-assigment-service/internal/domain"
+	pg "pr-reviewer-assigment-service/internal/infrastructure/postgres"
+)
+
+// recordingSink запоминает записи выгрузки в порядке получения.
+type recordingSink struct {
+	records []string
+	users   []domain.User
+	prs     []domain.PullRequest
+	failOn  string
+}
+
+var errSinkFailed = errors.New("sink failed")
+
+func (s *recordingSink) add(record string) error {
+	if record == s.failOn {
+		return errSinkFailed
+	}
+	s.records = append(s.records, record)
+	return nil
+}
+
+func (s *recordingSink) Team(teamName string) error { return s.add("team:" + teamName) }
+
+func (s *recordingSink) User(u *domain.User) error {
+	s.users = append(s.users, *u)
+	return s.add("user:" + u.UserID)
+}
+
+func (s *recordingSink) PullRequest(pr *domain.PullRequest) error {
+	s.prs = append(s.prs, *pr)
+	return s.add("pr:" + pr.PullRequestID)
+}
+
+func (s *recordingSink) Escalation(prID string, _ time.Time) error {
+	return s.add("escalation:" + prID)
+}
+
+func (s *recordingSink) ReviewSLA(sla *domain.ReviewSLA) error { return s.add("sla:" + sla.TeamName) }
+
+func (s *recordingSink) CodeOwnerRule(rule *domain.CodeOwnerRule) error {
+	return s.add("codeowners:" + strconv.Itoa(rule.Position))
+}
+
+func (s *recordingSink) ReviewerRule(rule *domain.ReviewerRule) error {
+	return s.add("rule:" + rule.ID)
+}
+
+func (s *recordingSink) Unavailability(u *domain.Unavailability) error {
+	return s.add("unavailability:" + u.ID)
+}
+
+func TestExportDb(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	userRepo := pg.NewUserDb(db.Pool)
+	prRepo := pg.NewPullRequestDb(db.Pool)
+	exportRepo := pg.NewExportDb(db.Pool)
+
+	if _, err := db.Pool.Exec(ctx, `INSERT INTO teams (team_name) VALUES ('payments'), ('backend')`); err != nil {
+		t.Fatalf("insert teams: %v", err)
+	}
+	if err := userRepo.BulkUpsert(ctx, []domain.User{
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		{UserID: "u3", Username: "Charlie", TeamName: "payments", IsActive: false},
+	}); err != nil {
+		t.Fatalf("BulkUpsert: %v", err)
+	}
+	if _, err := userRepo.SetTeam(ctx, "u3", ""); err != nil {
+		t.Fatalf("SetTeam: %v", err)
+	}
+	if _, err := userRepo.SetSkills(ctx, "u1", []string{"go"}); err != nil {
+		t.Fatalf("SetSkills: %v", err)
+	}
+
+	created := time.Date(2025, 9, 1, 9, 0, 0, 0, time.UTC)
+	later := created.Add(time.Hour)
+	for _, pr := range []domain.PullRequest{
+		{PullRequestID: "pr-b", PullRequestName: "Later", AuthorID: "u1", Status: "MERGED", AssignedReviewers: []string{"u2"}, CreatedAt: &later, MergedAt: &later},
+		{PullRequestID: "pr-a", PullRequestName: "First", AuthorID: "u2", Status: "OPEN", AssignedReviewers: []string{}, CreatedAt: &created},
+	} {
+		for _, id := range pr.AssignedReviewers {
+			pr.MarkAssigned(id, *pr.CreatedAt)
+		}
+		if err := prRepo.Create(ctx, &pr); err != nil {
+			t.Fatalf("Create %s: %v", pr.PullRequestID, err)
+		}
+	}
+
+	var sink recordingSink
+	if err := exportRepo.Export(ctx, &sink); err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+
+	want := []string{"team:backend", "team:payments", "user:u1", "user:u2", "user:u3", "pr:pr-a", "pr:pr-b"}
+	if !slices.Equal(sink.records, want) {
+		t.Fatalf("got %v, want %v", sink.records, want)
+	}
+	if !slices.Equal(sink.users[0].Skills, []string{"go"}) || sink.users[2].TeamName != "" || sink.users[2].IsActive {
+		t.Fatalf("unexpected users: %+v", sink.users)
+	}
+	if merged := sink.prs[1]; merged.MergedAt == nil || !merged.MergedAt.Equal(later) || !slices.Equal(merged.AssignedReviewers, []string{"u2"}) {
+		t.Fatalf("unexpected merged pr: %+v", merged)
+	}
+	if at := sink.prs[1].ReviewerAssignedAt["u2"]; !at.Equal(later) {
+		t.Fatalf("expected u2 assigned_at %v to be exported, got %v", later, at)
+	}
+	if open := sink.prs[0]; open.MergedAt != nil || !open.CreatedAt.Equal(created) {
+		t.Fatalf("unexpected open pr: %+v", open)
+	}
+
+	failing := recordingSink{failOn: "user:u2"}
+	if err := exportRepo.Export(ctx, &failing); !errors.Is(err, errSinkFailed) {
+		t.Fatalf("expected sink error, got %v", err)
+	}
+	if !slices.Equal(failing.records, []string{"team:backend", "team:payments", "user:u1"}) {
+		t.Fatalf("expected export to stop at the failing record, got %v", failing.records)
+	}
+}
@@ -0,0 +1,70 @@
+package integration_test
+
+import (
+	"context"
+	"errors"
+	"pr-reviewer-assigment-service/internal/application/repository"
+	"pr-reviewer-assigment-service/internal/domain"
+	pg "pr-reviewer-assigment-service/internal/infrastructure/postgres"
+	"testing"
+	"time"
+)
+
+func TestIdempotencyDb_Reserve_Complete_And_Expire(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	repo := pg.NewIdempotencyDb(db.Pool)
+
+	now := time.Now().UTC()
+	rec := &domain.IdempotencyRecord{
+		Key:         "k1",
+		RequestHash: "hash-1",
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(time.Hour),
+	}
+
+	if _, err := repo.Reserve(ctx, rec, now.Add(-time.Minute)); err != nil {
+		t.Fatalf("Reserve: %v", err)
+	}
+
+	existing, err := repo.Reserve(ctx, rec, now.Add(-time.Minute))
+	if !errors.Is(err, repository.ErrAlreadyExists) {
+		t.Fatalf("expected ErrAlreadyExists, got %v", err)
+	}
+	if existing.Completed() {
+		t.Fatalf("expected pending record")
+	}
+
+	if err := repo.Complete(ctx, "k1", 201, "application/json", []byte(`{"ok":true}`)); err != nil {
+		t.Fatalf("Complete: %v", err)
+	}
+
+	existing, err = repo.Reserve(ctx, rec, now.Add(-time.Minute))
+	if !errors.Is(err, repository.ErrAlreadyExists) {
+		t.Fatalf("expected ErrAlreadyExists, got %v", err)
+	}
+	if existing.StatusCode != 201 || string(existing.Body) != `{"ok":true}` {
+		t.Fatalf("unexpected stored response: %+v", existing)
+	}
+
+	later := now.Add(2 * time.Hour)
+	reused := &domain.IdempotencyRecord{
+		Key:         "k1",
+		RequestHash: "hash-2",
+		CreatedAt:   later,
+		ExpiresAt:   later.Add(time.Hour),
+	}
+	if _, err := repo.Reserve(ctx, reused, later.Add(-time.Minute)); err != nil {
+		t.Fatalf("expected expired key to be reusable, got %v", err)
+	}
+
+	deleted, err := repo.DeleteExpired(ctx, later.Add(2*time.Hour))
+	if err != nil {
+		t.Fatalf("DeleteExpired: %v", err)
+	}
+	if deleted != 1 {
+		t.Fatalf("expected 1 deleted record, got %d", deleted)
+	}
+}
@@ -0,0 +1,117 @@
+package integration_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"pr-reviewer-assigment-service/internal/application/repository"
+	"pr-reviewer-assigment-service/internal/domain"
+	pg "pr-reviewer-assigment-service/internal/infrastructure/postgres"
+)
+
+func TestImportDb(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	importRepo := pg.NewImportDb(db.Pool)
+	userRepo := pg.NewUserDb(db.Pool)
+	prRepo := pg.NewPullRequestDb(db.Pool)
+
+	if _, err := db.Pool.Exec(ctx, `INSERT INTO teams (team_name) VALUES ('backend')`); err != nil {
+		t.Fatalf("insert teams: %v", err)
+	}
+	if err := userRepo.BulkUpsert(ctx, []domain.User{{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}}); err != nil {
+		t.Fatalf("BulkUpsert: %v", err)
+	}
+
+	existing, err := importRepo.Existing(ctx, repository.ImportKeys{
+		Teams:        []string{"backend", "payments"},
+		Users:        []string{"u1", "u2"},
+		PullRequests: []string{"pr-1"},
+	})
+	if err != nil {
+		t.Fatalf("Existing: %v", err)
+	}
+	if !slices.Equal(existing.Teams, []string{"backend"}) || !slices.Equal(existing.Users, []string{"u1"}) || len(existing.PullRequests) != 0 {
+		t.Fatalf("unexpected existing keys: %+v", existing)
+	}
+
+	// Больше importBatchSize пользователей, чтобы загрузка шла несколькими COPY.
+	created := time.Date(2025, 9, 1, 9, 0, 0, 0, time.UTC)
+	merged := created.Add(26 * time.Hour)
+	limit := 3
+	batch := &domain.ImportBatch{Teams: []domain.ImportTeam{{Line: 1, TeamName: "payments"}}}
+	for i := range 1500 {
+		batch.Users = append(batch.Users, domain.ImportUser{Line: i + 2, User: domain.User{
+			UserID: fmt.Sprintf("p%d", i), Username: fmt.Sprintf("Payments %d", i), TeamName: "payments", IsActive: i%2 == 0,
+		}})
+	}
+	batch.Users[0].MaxOpenReviews = &limit
+	batch.Users[0].Skills = []string{"go"}
+	batch.PullRequests = []domain.ImportPullRequest{
+		{Line: 1600, PullRequest: domain.PullRequest{
+			PullRequestID: "pr-1", PullRequestName: "Old", AuthorID: "u1", Status: "MERGED",
+			AssignedReviewers: []string{"p0", "p2"}, CreatedAt: &created, MergedAt: &merged,
+		}},
+		{Line: 1601, PullRequest: domain.PullRequest{
+			PullRequestID: "pr-2", PullRequestName: "Open", AuthorID: "p1", Status: "OPEN", CreatedAt: &created,
+		}},
+	}
+	reassigned := created.Add(3 * time.Hour)
+	batch.PullRequests[0].MarkAssigned("p0", created)
+	batch.PullRequests[0].MarkAssigned("p2", reassigned)
+
+	if err := importRepo.Load(ctx, batch); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	p0, err := userRepo.GetByID(ctx, "p0")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if p0.TeamName != "payments" || !p0.IsActive || p0.MaxOpenReviews == nil || *p0.MaxOpenReviews != 3 || !slices.Equal(p0.Skills, []string{"go"}) {
+		t.Fatalf("unexpected imported user: %+v", p0)
+	}
+	users, err := userRepo.ListByTeam(ctx, "payments", false, time.Time{})
+	if err != nil || len(users) != 1500 {
+		t.Fatalf("expected 1500 imported users, got %d, %v", len(users), err)
+	}
+	pr, err := prRepo.GetByID(ctx, "pr-1")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if pr.Status != "MERGED" || !pr.CreatedAt.Equal(created) || pr.MergedAt == nil || !pr.MergedAt.Equal(merged) ||
+		!slices.Equal(pr.AssignedReviewers, []string{"p0", "p2"}) {
+		t.Fatalf("unexpected imported pr: %+v", pr)
+	}
+	if !pr.ReviewerAssignedAt["p0"].Equal(created) || !pr.ReviewerAssignedAt["p2"].Equal(reassigned) {
+		t.Fatalf("expected assigned_at to be restored from the file, got %v", pr.ReviewerAssignedAt)
+	}
+	if pr, err := prRepo.GetByID(ctx, "pr-2"); err != nil || len(pr.AssignedReviewers) != 0 || pr.MergedAt != nil {
+		t.Fatalf("unexpected imported open pr: %+v, %v", pr, err)
+	}
+
+	// Повтор ключа откатывает всю загрузку.
+	conflict := &domain.ImportBatch{
+		Teams: []domain.ImportTeam{{Line: 1, TeamName: "platform"}},
+		Users: []domain.ImportUser{{Line: 2, User: domain.User{UserID: "u1", Username: "Alice", TeamName: "platform", IsActive: true}}},
+	}
+	if err := importRepo.Load(ctx, conflict); !errors.Is(err, repository.ErrAlreadyExists) {
+		t.Fatalf("expected ErrAlreadyExists, got %v", err)
+	}
+	if existing, err := importRepo.Existing(ctx, repository.ImportKeys{Teams: []string{"platform"}}); err != nil || len(existing.Teams) != 0 {
+		t.Fatalf("expected platform team to be rolled back, got %+v, %v", existing, err)
+	}
+
+	missing := &domain.ImportBatch{
+		Users: []domain.ImportUser{{Line: 1, User: domain.User{UserID: "x1", Username: "Ghost", TeamName: "ghost", IsActive: true}}},
+	}
+	if err := importRepo.Load(ctx, missing); !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for unknown team, got %v", err)
+	}
+}
@@ -17,7 +17,7 @@ type testDB struct {
 	Container *tc.PostgresContainer
 }
 
-func setupTestDB(t *testing.T) *testDB {
+func setupTestDB(t testing.TB) *testDB {
 	t.Helper()
 
 	ctx := context.Background()
@@ -59,7 +59,7 @@ func setupTestDB(t *testing.T) *testDB {
 	}
 }
 
-func teardownTestDB(t *testing.T, db *testDB) {
+func teardownTestDB(t testing.TB, db *testDB) {
 	t.Helper()
 	ctx := context.Background()
 	db.Pool.Close()
@@ -70,6 +70,13 @@ func teardownTestDB(t *testing.T, db *testDB) {
 
 func migrateTestSchema(ctx context.Context, db *pgxpool.Pool) error {
 	_, err := db.Exec(ctx, `
+		DROP TABLE IF EXISTS idempotency_keys;
+		DROP TABLE IF EXISTS review_escalations;
+		DROP TABLE IF EXISTS review_slas;
+		DROP TABLE IF EXISTS reviewer_rules;
+		DROP TABLE IF EXISTS code_owner_rules;
+		DROP TABLE IF EXISTS user_unavailability;
+		DROP TABLE IF EXISTS pull_request_reviewers;
 		DROP TABLE IF EXISTS pull_requests;
 		DROP TABLE IF EXISTS users;
 		DROP TABLE IF EXISTS teams;
@@ -81,8 +88,10 @@ func migrateTestSchema(ctx context.Context, db *pgxpool.Pool) error {
 		CREATE TABLE users (
 			user_id   TEXT PRIMARY KEY,
 			username  TEXT NOT NULL,
-			team_name TEXT NOT NULL,
+			team_name TEXT NULL,
 			is_active BOOLEAN NOT NULL DEFAULT TRUE,
+			max_open_reviews INTEGER NULL CHECK (max_open_reviews >= 0),
+			skills    TEXT[] NOT NULL DEFAULT '{}',
 			CONSTRAINT fk_users_team
 				FOREIGN KEY (team_name)
 				REFERENCES teams(team_name)
@@ -95,16 +104,85 @@ func migrateTestSchema(ctx context.Context, db *pgxpool.Pool) error {
 			pull_request_name   TEXT        NOT NULL,
 			author_id           TEXT        NOT NULL,
 			status              TEXT        NOT NULL CHECK (status IN ('OPEN', 'MERGED')),
-			assigned_reviewers  TEXT[]      NOT NULL DEFAULT '{}',
 			created_at          TIMESTAMPTZ NOT NULL DEFAULT NOW(),
 			merged_at           TIMESTAMPTZ NULL,
 			CONSTRAINT fk_pull_requests_author
 				FOREIGN KEY (author_id)
 				REFERENCES users(user_id)
 				ON UPDATE CASCADE
-				ON DELETE RESTRICT,
-			CONSTRAINT assigned_reviewers_max_2
-				CHECK (cardinality(assigned_reviewers) <= 2)
+				ON DELETE RESTRICT
+		);
+
+		CREATE TABLE pull_request_reviewers (
+			pull_request_id TEXT        NOT NULL REFERENCES pull_requests(pull_request_id) ON UPDATE CASCADE ON DELETE CASCADE,
+			user_id         TEXT        NOT NULL,
+			position        SMALLINT    NOT NULL CHECK (position IN (0, 1)),
+			assigned_at     TIMESTAMPTZ NOT NULL,
+			PRIMARY KEY (pull_request_id, user_id),
+			UNIQUE (pull_request_id, position) DEFERRABLE INITIALLY DEFERRED,
+			CONSTRAINT fk_pull_request_reviewers_user
+				FOREIGN KEY (user_id)
+				REFERENCES users(user_id)
+				ON UPDATE CASCADE
+				ON DELETE RESTRICT
+		);
+		CREATE INDEX ON pull_request_reviewers (user_id);
+
+		CREATE TABLE user_unavailability (
+			unavailability_id TEXT PRIMARY KEY,
+			user_id           TEXT        NOT NULL,
+			starts_at         TIMESTAMPTZ NOT NULL,
+			ends_at           TIMESTAMPTZ NOT NULL,
+			reason            TEXT        NOT NULL DEFAULT '',
+			released_at       TIMESTAMPTZ NULL,
+			CONSTRAINT fk_user_unavailability_user
+				FOREIGN KEY (user_id)
+				REFERENCES users(user_id)
+				ON UPDATE CASCADE
+				ON DELETE CASCADE,
+			CONSTRAINT user_unavailability_period
+				CHECK (ends_at > starts_at)
+		);
+
+		CREATE TABLE code_owner_rules (
+			position    INTEGER PRIMARY KEY,
+			pattern     TEXT   NOT NULL,
+			owner_users TEXT[] NOT NULL DEFAULT '{}',
+			owner_teams TEXT[] NOT NULL DEFAULT '{}'
+		);
+
+		CREATE TABLE reviewer_rules (
+			rule_id          TEXT PRIMARY KEY,
+			kind             TEXT        NOT NULL CHECK (kind IN ('EXCLUDE', 'REQUIRE')),
+			author_user_id   TEXT        NULL REFERENCES users(user_id) ON UPDATE CASCADE ON DELETE CASCADE,
+			author_skill     TEXT        NULL,
+			reviewer_user_id TEXT        NULL REFERENCES users(user_id) ON UPDATE CASCADE ON DELETE CASCADE,
+			reviewer_skill   TEXT        NULL,
+			description      TEXT        NOT NULL DEFAULT '',
+			created_at       TIMESTAMPTZ NOT NULL,
+			CHECK ((author_user_id IS NULL) <> (author_skill IS NULL)),
+			CHECK ((reviewer_user_id IS NULL) <> (reviewer_skill IS NULL))
+		);
+
+		CREATE TABLE review_slas (
+			team_name   TEXT PRIMARY KEY REFERENCES teams(team_name) ON UPDATE CASCADE ON DELETE CASCADE,
+			sla_seconds BIGINT NOT NULL CHECK (sla_seconds > 0),
+			policy      TEXT   NOT NULL CHECK (policy IN ('NOTIFY', 'REASSIGN'))
+		);
+
+		CREATE TABLE review_escalations (
+			pull_request_id TEXT PRIMARY KEY REFERENCES pull_requests(pull_request_id) ON UPDATE CASCADE ON DELETE CASCADE,
+			escalated_at    TIMESTAMPTZ NOT NULL
+		);
+
+		CREATE TABLE idempotency_keys (
+			key            TEXT PRIMARY KEY,
+			request_hash   TEXT        NOT NULL,
+			status_code    INTEGER     NULL,
+			content_type   TEXT        NULL,
+			body           BYTEA       NULL,
+			created_at     TIMESTAMPTZ NOT NULL,
+			expires_at     TIMESTAMPTZ NOT NULL
 		);
 	`)
 	return err
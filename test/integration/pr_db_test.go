@@ -48,6 +48,10 @@ func TestPullRequestDb_Create_GetByID_And_Stats(t *testing.T) {
 		CreatedAt:         &now,
 	}
 
+	pr1.MarkAssigned("u2", now)
+	pr1.MarkAssigned("u3", now)
+	pr2.MarkAssigned("u2", now)
+
 	if err := prRepo.Create(ctx, pr1); err != nil {
 		t.Fatalf("Create pr1: %v", err)
 	}
@@ -85,3 +89,153 @@ func TestPullRequestDb_Create_GetByID_And_Stats(t *testing.T) {
 		t.Fatalf("expected u3 review_count=1, got %d", u3Count)
 	}
 }
+
+func TestPullRequestDb_CountOpenByReviewers(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	userRepo := pg.NewUserDb(db.Pool)
+	prRepo := pg.NewPullRequestDb(db.Pool)
+
+	if _, err := db.Pool.Exec(ctx, `INSERT INTO teams (team_name) VALUES ('backend')`); err != nil {
+		t.Fatalf("insert team: %v", err)
+	}
+	if err := userRepo.BulkUpsert(ctx, []domain.User{
+		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+		{UserID: "u3", Username: "Charlie", TeamName: "backend", IsActive: true},
+	}); err != nil {
+		t.Fatalf("BulkUpsert users: %v", err)
+	}
+	if _, err := db.Pool.Exec(ctx, `
+		INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status)
+		VALUES ('pr-1', 'A', 'u1', 'OPEN'),
+		       ('pr-2', 'B', 'u1', 'OPEN'),
+		       ('pr-3', 'C', 'u1', 'MERGED');
+		INSERT INTO pull_request_reviewers (pull_request_id, user_id, position, assigned_at)
+		VALUES ('pr-1', 'u2', 0, NOW()), ('pr-1', 'u3', 1, NOW()),
+		       ('pr-2', 'u2', 0, NOW()),
+		       ('pr-3', 'u3', 0, NOW())
+	`); err != nil {
+		t.Fatalf("insert pull requests: %v", err)
+	}
+
+	counts, err := prRepo.CountOpenByReviewers(ctx, []string{"u2", "u3", "u1"})
+	if err != nil {
+		t.Fatalf("CountOpenByReviewers: %v", err)
+	}
+	if len(counts) != 2 || counts["u2"] != 2 || counts["u3"] != 1 {
+		t.Fatalf("expected u2=2, u3=1, got %v", counts)
+	}
+
+	limit := 1
+	user, err := userRepo.SetMaxOpenReviews(ctx, "u2", &limit)
+	if err != nil {
+		t.Fatalf("SetMaxOpenReviews: %v", err)
+	}
+	if user.MaxOpenReviews == nil || *user.MaxOpenReviews != 1 {
+		t.Fatalf("expected max_open_reviews=1, got %v", user.MaxOpenReviews)
+	}
+
+	// Повторный upsert (например, через /team/add) не сбрасывает лимит.
+	if err := userRepo.BulkUpsert(ctx, []domain.User{
+		{UserID: "u2", Username: "Bobby", TeamName: "backend", IsActive: true},
+	}); err != nil {
+		t.Fatalf("BulkUpsert: %v", err)
+	}
+	user, err = userRepo.GetByID(ctx, "u2")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if user.MaxOpenReviews == nil || *user.MaxOpenReviews != 1 {
+		t.Fatalf("expected limit to survive upsert, got %v", user.MaxOpenReviews)
+	}
+}
+
+func TestPullRequestDb_Update_Reviewers(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	userRepo := pg.NewUserDb(db.Pool)
+	prRepo := pg.NewPullRequestDb(db.Pool)
+
+	if _, err := db.Pool.Exec(ctx, `INSERT INTO teams (team_name) VALUES ('backend')`); err != nil {
+		t.Fatalf("insert team: %v", err)
+	}
+	if err := userRepo.BulkUpsert(ctx, []domain.User{
+		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+		{UserID: "u3", Username: "Charlie", TeamName: "backend", IsActive: true},
+		{UserID: "u4", Username: "Dave", TeamName: "backend", IsActive: true},
+	}); err != nil {
+		t.Fatalf("BulkUpsert users: %v", err)
+	}
+
+	createdAt := time.Date(2025, 10, 20, 9, 0, 0, 0, time.UTC)
+	pr := &domain.PullRequest{
+		PullRequestID:     "pr-1",
+		PullRequestName:   "Add feature",
+		AuthorID:          "u1",
+		Status:            "OPEN",
+		AssignedReviewers: []string{"u2", "u3"},
+		CreatedAt:         &createdAt,
+	}
+	pr.MarkAssigned("u2", createdAt)
+	pr.MarkAssigned("u3", createdAt)
+	if err := prRepo.Create(ctx, pr); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	// Переназначение u2 -> u4 и перестановка оставшегося ревьювера в одной записи.
+	reassignedAt := createdAt.Add(time.Hour)
+	pr.AssignedReviewers = []string{"u3", "u4"}
+	pr.MarkAssigned("u4", reassignedAt)
+	pr.CreatedAt = nil
+	if err := prRepo.Update(ctx, pr); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	got, err := prRepo.GetByID(ctx, "pr-1")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if len(got.AssignedReviewers) != 2 || got.AssignedReviewers[0] != "u3" || got.AssignedReviewers[1] != "u4" {
+		t.Fatalf("expected reviewers [u3 u4], got %v", got.AssignedReviewers)
+	}
+
+	var kept time.Time
+	if err := db.Pool.QueryRow(ctx,
+		`SELECT assigned_at FROM pull_request_reviewers WHERE pull_request_id = 'pr-1' AND user_id = 'u3'`,
+	).Scan(&kept); err != nil {
+		t.Fatalf("query assigned_at: %v", err)
+	}
+	if !kept.Equal(createdAt) {
+		t.Fatalf("expected u3 assigned_at to stay %v, got %v", createdAt, kept)
+	}
+	if at := got.ReviewerAssignedAt["u4"]; !at.Equal(reassignedAt) {
+		t.Fatalf("expected u4 assigned_at %v from the caller, got %v", reassignedAt, at)
+	}
+
+	u2, err := prRepo.ListByReviewer(ctx, "u2")
+	if err != nil {
+		t.Fatalf("ListByReviewer: %v", err)
+	}
+	if len(u2) != 0 {
+		t.Fatalf("expected no PRs for u2 after reassignment, got %+v", u2)
+	}
+
+	pr.AssignedReviewers = []string{"u3", "missing"}
+	pr.MarkAssigned("missing", reassignedAt)
+	if err := prRepo.Update(ctx, pr); err == nil {
+		t.Fatalf("expected error for unknown reviewer")
+	}
+	got, err = prRepo.GetByID(ctx, "pr-1")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if len(got.AssignedReviewers) != 2 || got.AssignedReviewers[1] != "u4" {
+		t.Fatalf("expected failed update to be rolled back, got %v", got.AssignedReviewers)
+	}
+}
@@ -0,0 +1,99 @@
+package integration_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"pr-reviewer-assigment-service/internal/application/repository"
+	"pr-reviewer-assigment-service/internal/domain"
+	pg "pr-reviewer-assigment-service/internal/infrastructure/postgres"
+)
+
+func TestReviewerRuleDb(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	userRepo := pg.NewUserDb(db.Pool)
+	ruleRepo := pg.NewReviewerRuleDb(db.Pool)
+
+	if _, err := db.Pool.Exec(ctx, `INSERT INTO teams (team_name) VALUES ('backend')`); err != nil {
+		t.Fatalf("insert team: %v", err)
+	}
+	if err := userRepo.BulkUpsert(ctx, []domain.User{
+		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+	}); err != nil {
+		t.Fatalf("BulkUpsert: %v", err)
+	}
+
+	exclude := &domain.ReviewerRule{
+		ID: "r1", Kind: domain.RuleExclude,
+		Author: domain.UserSelector{UserID: "u1"}, Reviewer: domain.UserSelector{UserID: "u2"},
+		Description: "conflict of interest",
+		CreatedAt:   time.Date(2025, 10, 20, 9, 0, 0, 0, time.UTC),
+	}
+	require := &domain.ReviewerRule{
+		ID: "r2", Kind: domain.RuleRequire,
+		Author: domain.UserSelector{Skill: "junior"}, Reviewer: domain.UserSelector{Skill: "senior"},
+		CreatedAt: time.Date(2025, 10, 20, 9, 1, 0, 0, time.UTC),
+	}
+	for _, r := range []*domain.ReviewerRule{exclude, require} {
+		if err := ruleRepo.Create(ctx, r); err != nil {
+			t.Fatalf("Create %s: %v", r.ID, err)
+		}
+	}
+
+	err := ruleRepo.Create(ctx, &domain.ReviewerRule{
+		ID: "r3", Kind: domain.RuleExclude,
+		Author: domain.UserSelector{UserID: "ghost"}, Reviewer: domain.UserSelector{Skill: "senior"},
+	})
+	if !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for unknown user, got %v", err)
+	}
+
+	rules, err := ruleRepo.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(rules) != 2 || !sameRule(rules[0], *exclude) || !sameRule(rules[1], *require) {
+		t.Fatalf("expected r1 and r2 in insertion order, got %+v", rules)
+	}
+
+	deleted, err := ruleRepo.Delete(ctx, "r1")
+	if err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if !sameRule(*deleted, *exclude) {
+		t.Fatalf("expected deleted r1, got %+v", deleted)
+	}
+	if _, err := ruleRepo.Delete(ctx, "r1"); !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	// Правила пользователя удаляются вместе с ним.
+	if err := ruleRepo.Create(ctx, exclude); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := db.Pool.Exec(ctx, `DELETE FROM users WHERE user_id = 'u2'`); err != nil {
+		t.Fatalf("delete user: %v", err)
+	}
+	rules, err = ruleRepo.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(rules) != 1 || rules[0].ID != "r2" {
+		t.Fatalf("expected only r2 after user deletion, got %+v", rules)
+	}
+}
+
+// sameRule сравнивает правила; время создания - через Equal, база возвращает его в своём часовом поясе.
+func sameRule(a, b domain.ReviewerRule) bool {
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return false
+	}
+	a.CreatedAt, b.CreatedAt = time.Time{}, time.Time{}
+	return a == b
+}
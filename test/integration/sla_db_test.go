@@ -0,0 +1,112 @@
+package integration_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"pr-reviewer-assigment-service/internal/application/repository"
+	"pr-reviewer-assigment-service/internal/domain"
+	pg "pr-reviewer-assigment-service/internal/infrastructure/postgres"
+)
+
+func TestReviewSLADb(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	userRepo := pg.NewUserDb(db.Pool)
+	prRepo := pg.NewPullRequestDb(db.Pool)
+	slaRepo := pg.NewReviewSLADb(db.Pool)
+
+	if _, err := db.Pool.Exec(ctx, `INSERT INTO teams (team_name) VALUES ('backend'), ('payments')`); err != nil {
+		t.Fatalf("insert teams: %v", err)
+	}
+	if err := userRepo.BulkUpsert(ctx, []domain.User{
+		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+		{UserID: "u3", Username: "Charlie", TeamName: "payments", IsActive: true},
+	}); err != nil {
+		t.Fatalf("BulkUpsert: %v", err)
+	}
+
+	if err := slaRepo.Set(ctx, domain.ReviewSLA{TeamName: "ghost", SLA: time.Hour, Policy: domain.EscalationNotify}); !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for unknown team, got %v", err)
+	}
+	if err := slaRepo.Set(ctx, domain.ReviewSLA{TeamName: "backend", SLA: time.Hour, Policy: domain.EscalationNotify}); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if err := slaRepo.Set(ctx, domain.ReviewSLA{TeamName: "backend", SLA: 24 * time.Hour, Policy: domain.EscalationReassign}); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if err := slaRepo.Set(ctx, domain.ReviewSLA{TeamName: "payments", SLA: 24 * time.Hour, Policy: domain.EscalationNotify}); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	created := func(ago time.Duration) *time.Time {
+		t := now.Add(-ago)
+		return &t
+	}
+	for _, pr := range []domain.PullRequest{
+		{PullRequestID: "pr-old", PullRequestName: "Old", AuthorID: "u1", Status: "OPEN", AssignedReviewers: []string{"u2"}, CreatedAt: created(48 * time.Hour)},
+		{PullRequestID: "pr-late", PullRequestName: "Late", AuthorID: "u1", Status: "OPEN", AssignedReviewers: []string{}, CreatedAt: created(25 * time.Hour)},
+		{PullRequestID: "pr-fresh", PullRequestName: "Fresh", AuthorID: "u1", Status: "OPEN", AssignedReviewers: []string{}, CreatedAt: created(time.Hour)},
+		{PullRequestID: "pr-merged", PullRequestName: "Merged", AuthorID: "u1", Status: "MERGED", AssignedReviewers: []string{}, CreatedAt: created(72 * time.Hour)},
+		{PullRequestID: "pr-payments", PullRequestName: "Payments", AuthorID: "u3", Status: "OPEN", AssignedReviewers: []string{}, CreatedAt: created(30 * time.Hour)},
+	} {
+		for _, id := range pr.AssignedReviewers {
+			pr.MarkAssigned(id, *pr.CreatedAt)
+		}
+		if err := prRepo.Create(ctx, &pr); err != nil {
+			t.Fatalf("Create %s: %v", pr.PullRequestID, err)
+		}
+	}
+
+	overdue, err := slaRepo.ListOverdue(ctx, "backend", now)
+	if err != nil {
+		t.Fatalf("ListOverdue: %v", err)
+	}
+	if len(overdue) != 2 || overdue[0].PullRequestID != "pr-old" || overdue[1].PullRequestID != "pr-late" {
+		t.Fatalf("expected pr-old and pr-late, got %+v", overdue)
+	}
+	old := overdue[0]
+	if old.TeamName != "backend" || old.SLA != 24*time.Hour || old.Policy != domain.EscalationReassign ||
+		len(old.AssignedReviewers) != 1 || old.EscalatedAt != nil || old.OverdueBy(now) != 24*time.Hour {
+		t.Fatalf("unexpected overdue pr: %+v", old)
+	}
+
+	all, err := slaRepo.ListOverdue(ctx, "", now)
+	if err != nil {
+		t.Fatalf("ListOverdue: %v", err)
+	}
+	if len(all) != 3 || all[1].PullRequestID != "pr-payments" {
+		t.Fatalf("expected pr-old, pr-payments, pr-late, got %+v", all)
+	}
+
+	if err := slaRepo.MarkEscalated(ctx, "pr-old", now.Add(-time.Hour)); err != nil {
+		t.Fatalf("MarkEscalated: %v", err)
+	}
+	if err := slaRepo.MarkEscalated(ctx, "pr-old", now); err != nil {
+		t.Fatalf("MarkEscalated: %v", err)
+	}
+	overdue, err = slaRepo.ListOverdue(ctx, "backend", now)
+	if err != nil {
+		t.Fatalf("ListOverdue: %v", err)
+	}
+	if overdue[0].EscalatedAt == nil || !overdue[0].EscalatedAt.Equal(now) {
+		t.Fatalf("expected pr-old escalated at %s, got %+v", now, overdue[0].EscalatedAt)
+	}
+
+	if err := slaRepo.Delete(ctx, "backend"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	overdue, err = slaRepo.ListOverdue(ctx, "backend", now)
+	if err != nil {
+		t.Fatalf("ListOverdue: %v", err)
+	}
+	if len(overdue) != 0 {
+		t.Fatalf("expected no overdue PRs without SLA, got %+v", overdue)
+	}
+}
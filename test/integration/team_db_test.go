@@ -2,6 +2,8 @@ package integration_test
 
 import (
 	"context"
+	"errors"
+	"pr-reviewer-assigment-service/internal/application/repository"
 	"pr-reviewer-assigment-service/internal/domain"
 	pg "pr-reviewer-assigment-service/internal/infrastructure/postgres"
 	"testing"
@@ -48,3 +50,123 @@ func TestTeamDb_Create_And_GetByName(t *testing.T) {
 		t.Fatalf("expected 2 members, got %d", len(got.Members))
 	}
 }
+
+func TestTeamDb_Rename_And_Delete(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	teamRepo := pg.NewTeamDb(db.Pool)
+
+	for _, name := range []string{"backend", "payments"} {
+		if err := teamRepo.Create(ctx, &domain.Team{TeamName: name}); err != nil {
+			t.Fatalf("Create %s: %v", name, err)
+		}
+	}
+	if _, err := db.Pool.Exec(ctx, `
+		INSERT INTO users (user_id, username, team_name, is_active)
+		VALUES ('u1', 'Alice', 'backend', true)
+	`); err != nil {
+		t.Fatalf("insert users: %v", err)
+	}
+
+	if err := teamRepo.Rename(ctx, "backend", "payments"); !errors.Is(err, repository.ErrAlreadyExists) {
+		t.Fatalf("expected ErrAlreadyExists, got %v", err)
+	}
+	if err := teamRepo.Rename(ctx, "missing", "core"); !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if err := teamRepo.Rename(ctx, "backend", "core"); err != nil {
+		t.Fatalf("Rename: %v", err)
+	}
+
+	core, err := teamRepo.GetByName(ctx, "core")
+	if err != nil {
+		t.Fatalf("GetByName: %v", err)
+	}
+	if len(core.Members) != 1 || core.Members[0].UserID != "u1" {
+		t.Fatalf("expected members to follow the rename, got %+v", core.Members)
+	}
+
+	if err := teamRepo.Delete(ctx, "core"); err == nil {
+		t.Fatalf("expected delete of non-empty team to fail")
+	}
+	if _, err := db.Pool.Exec(ctx, `UPDATE users SET team_name = NULL WHERE user_id = 'u1'`); err != nil {
+		t.Fatalf("detach user: %v", err)
+	}
+	if err := teamRepo.Delete(ctx, "core"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err := teamRepo.Delete(ctx, "core"); !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestTeamDb_List(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	teamRepo := pg.NewTeamDb(db.Pool)
+
+	for _, name := range []string{"backend", "Billing", "b_team", "frontend"} {
+		if err := teamRepo.Create(ctx, &domain.Team{TeamName: name}); err != nil {
+			t.Fatalf("Create %s: %v", name, err)
+		}
+	}
+	if _, err := db.Pool.Exec(ctx, `
+		INSERT INTO users (user_id, username, team_name, is_active)
+		VALUES ('u1', 'Alice', 'backend', true),
+		       ('u2', 'Bob', 'backend', false),
+		       ('u3', 'Carol', 'frontend', true)
+	`); err != nil {
+		t.Fatalf("insert users: %v", err)
+	}
+
+	// Порядок имён в разном регистре зависит от collation базы, поэтому сравниваем множества.
+	teams, total, err := teamRepo.List(ctx, repository.TeamListFilter{Prefix: "b", Limit: 2})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if total != 3 || len(teams) != 2 {
+		t.Fatalf("expected 2 of 3 teams, got %+v (total %d)", teams, total)
+	}
+
+	rest, _, err := teamRepo.List(ctx, repository.TeamListFilter{Prefix: "b", Limit: 2, Offset: 2})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	got := make(map[string]bool)
+	for _, tm := range append(teams, rest...) {
+		got[tm.TeamName] = true
+	}
+	if len(got) != 3 || !got["backend"] || !got["Billing"] || !got["b_team"] {
+		t.Fatalf("expected backend, Billing and b_team across pages, got %v", got)
+	}
+
+	// '_' в префиксе ищется буквально, а не как любой символ.
+	teams, total, err = teamRepo.List(ctx, repository.TeamListFilter{Prefix: "b_", Limit: 10})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if total != 1 || len(teams) != 1 || teams[0].TeamName != "b_team" {
+		t.Fatalf("expected only b_team, got %+v (total %d)", teams, total)
+	}
+
+	teams, _, err = teamRepo.List(ctx, repository.TeamListFilter{Prefix: "back", Limit: 10})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	wantBackend := domain.TeamSummary{TeamName: "backend", MemberCount: 2, ActiveMemberCount: 1}
+	if len(teams) != 1 || teams[0] != wantBackend {
+		t.Fatalf("expected [%+v], got %+v", wantBackend, teams)
+	}
+
+	teams, total, err = teamRepo.List(ctx, repository.TeamListFilter{Limit: 10, Offset: 10})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(teams) != 0 || total != 4 {
+		t.Fatalf("expected empty page with total 4, got %+v (total %d)", teams, total)
+	}
+}
@@ -0,0 +1,103 @@
+package integration_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"pr-reviewer-assigment-service/internal/application/repository"
+	"pr-reviewer-assigment-service/internal/domain"
+	pg "pr-reviewer-assigment-service/internal/infrastructure/postgres"
+)
+
+func TestUnavailabilityDb(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	userRepo := pg.NewUserDb(db.Pool)
+	unavailabilityRepo := pg.NewUnavailabilityDb(db.Pool)
+
+	if _, err := db.Pool.Exec(ctx, `INSERT INTO teams (team_name) VALUES ('backend')`); err != nil {
+		t.Fatalf("insert team: %v", err)
+	}
+	if err := userRepo.BulkUpsert(ctx, []domain.User{
+		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+	}); err != nil {
+		t.Fatalf("BulkUpsert: %v", err)
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	current := &domain.Unavailability{ID: "ua-1", UserID: "u2", StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour), Reason: "vacation"}
+	future := &domain.Unavailability{ID: "ua-2", UserID: "u2", StartsAt: now.Add(24 * time.Hour), EndsAt: now.Add(48 * time.Hour)}
+	past := &domain.Unavailability{ID: "ua-3", UserID: "u2", StartsAt: now.Add(-48 * time.Hour), EndsAt: now.Add(-24 * time.Hour)}
+	for _, u := range []*domain.Unavailability{current, future, past} {
+		if err := unavailabilityRepo.Create(ctx, u); err != nil {
+			t.Fatalf("Create %s: %v", u.ID, err)
+		}
+	}
+
+	active, err := userRepo.ListByTeam(ctx, "backend", true, now)
+	if err != nil {
+		t.Fatalf("ListByTeam: %v", err)
+	}
+	if len(active) != 1 || active[0].UserID != "u1" {
+		t.Fatalf("expected only u1 to be available, got %+v", active)
+	}
+	// Доступность считается на переданный момент, а не на время базы.
+	active, err = userRepo.ListByTeam(ctx, "backend", true, now.Add(12*time.Hour))
+	if err != nil {
+		t.Fatalf("ListByTeam: %v", err)
+	}
+	if len(active) != 2 {
+		t.Fatalf("expected both users to be available between periods, got %+v", active)
+	}
+
+	periods, err := unavailabilityRepo.ListByUser(ctx, "u2", now)
+	if err != nil {
+		t.Fatalf("ListByUser: %v", err)
+	}
+	if len(periods) != 2 || periods[0].ID != "ua-1" || periods[1].ID != "ua-2" {
+		t.Fatalf("expected ua-1 and ua-2, got %+v", periods)
+	}
+
+	unreleased, err := unavailabilityRepo.ListUnreleased(ctx, now)
+	if err != nil {
+		t.Fatalf("ListUnreleased: %v", err)
+	}
+	if len(unreleased) != 1 || unreleased[0].ID != "ua-1" {
+		t.Fatalf("expected ua-1 to be unreleased, got %+v", unreleased)
+	}
+
+	if err := unavailabilityRepo.MarkReleased(ctx, "ua-1", now); err != nil {
+		t.Fatalf("MarkReleased: %v", err)
+	}
+	unreleased, err = unavailabilityRepo.ListUnreleased(ctx, now)
+	if err != nil {
+		t.Fatalf("ListUnreleased: %v", err)
+	}
+	if len(unreleased) != 0 {
+		t.Fatalf("expected no unreleased periods, got %+v", unreleased)
+	}
+
+	deleted, err := unavailabilityRepo.Delete(ctx, "ua-1")
+	if err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if deleted.ReleasedAt == nil || !deleted.ReleasedAt.Equal(now) {
+		t.Fatalf("expected deleted period to keep released_at, got %+v", deleted)
+	}
+	if _, err := unavailabilityRepo.Delete(ctx, "ua-1"); !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	active, err = userRepo.ListByTeam(ctx, "backend", true, now)
+	if err != nil {
+		t.Fatalf("ListByTeam: %v", err)
+	}
+	if len(active) != 2 {
+		t.Fatalf("expected both users to be available after delete, got %+v", active)
+	}
+}
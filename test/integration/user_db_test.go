@@ -2,9 +2,15 @@ package integration_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"pr-reviewer-assigment-service/internal/application/repository"
 	"pr-reviewer-assigment-service/internal/domain"
 	pg "pr-reviewer-assigment-service/internal/infrastructure/postgres"
 	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 func TestUserDb_BulkUpsert_And_GetByID(t *testing.T) {
@@ -44,3 +50,282 @@ func TestUserDb_BulkUpsert_And_GetByID(t *testing.T) {
 		t.Fatalf("expected u2 IsActive=true, got %v", updated.IsActive)
 	}
 }
+
+func TestUserDb_SetTeam(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	userRepo := pg.NewUserDb(db.Pool)
+
+	_, err := db.Pool.Exec(ctx, `INSERT INTO teams (team_name) VALUES ('backend'), ('frontend')`)
+	if err != nil {
+		t.Fatalf("insert teams: %v", err)
+	}
+
+	if err := userRepo.BulkUpsert(ctx, []domain.User{
+		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+	}); err != nil {
+		t.Fatalf("BulkUpsert: %v", err)
+	}
+
+	moved, err := userRepo.SetTeam(ctx, "u1", "frontend")
+	if err != nil {
+		t.Fatalf("SetTeam: %v", err)
+	}
+	if moved.TeamName != "frontend" {
+		t.Fatalf("expected frontend, got %s", moved.TeamName)
+	}
+
+	removed, err := userRepo.SetTeam(ctx, "u1", "")
+	if err != nil {
+		t.Fatalf("SetTeam remove: %v", err)
+	}
+	if removed.TeamName != "" {
+		t.Fatalf("expected no team, got %s", removed.TeamName)
+	}
+
+	got, err := userRepo.GetByID(ctx, "u1")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.TeamName != "" {
+		t.Fatalf("expected no team after reload, got %s", got.TeamName)
+	}
+
+	frontend, err := userRepo.ListByTeam(ctx, "frontend", false, time.Time{})
+	if err != nil {
+		t.Fatalf("ListByTeam: %v", err)
+	}
+	if len(frontend) != 0 {
+		t.Fatalf("expected empty frontend, got %+v", frontend)
+	}
+
+	if _, err := userRepo.SetTeam(ctx, "missing", "backend"); err == nil {
+		t.Fatalf("expected error for missing user")
+	}
+}
+
+func TestUserDb_List_And_GetSummary(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	userRepo := pg.NewUserDb(db.Pool)
+
+	if _, err := db.Pool.Exec(ctx, `INSERT INTO teams (team_name) VALUES ('backend'), ('frontend')`); err != nil {
+		t.Fatalf("insert teams: %v", err)
+	}
+	if err := userRepo.BulkUpsert(ctx, []domain.User{
+		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		{UserID: "u2", Username: "Alina", TeamName: "backend", IsActive: false},
+		{UserID: "u3", Username: "Malik", TeamName: "backend", IsActive: true},
+		{UserID: "u4", Username: "Bob", TeamName: "frontend", IsActive: true},
+	}); err != nil {
+		t.Fatalf("BulkUpsert: %v", err)
+	}
+	if _, err := db.Pool.Exec(ctx, `
+		INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status)
+		VALUES ('pr-1', 'A', 'u4', 'OPEN'),
+		       ('pr-2', 'B', 'u4', 'OPEN'),
+		       ('pr-3', 'C', 'u4', 'MERGED');
+		INSERT INTO pull_request_reviewers (pull_request_id, user_id, position, assigned_at)
+		VALUES ('pr-1', 'u1', 0, NOW()), ('pr-1', 'u3', 1, NOW()),
+		       ('pr-2', 'u1', 0, NOW()),
+		       ('pr-3', 'u1', 0, NOW())
+	`); err != nil {
+		t.Fatalf("insert pull requests: %v", err)
+	}
+
+	u1, err := userRepo.GetSummary(ctx, "u1")
+	if err != nil {
+		t.Fatalf("GetSummary: %v", err)
+	}
+	if u1.OpenReviewCount != 2 {
+		t.Fatalf("expected 2 open reviews for u1, got %d", u1.OpenReviewCount)
+	}
+
+	page, err := userRepo.List(ctx, repository.UserListFilter{TeamName: "backend", Username: "LI", Limit: 1})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(page) != 1 || page[0].UserID != "u1" {
+		t.Fatalf("expected [u1], got %+v", page)
+	}
+
+	active := true
+	page, err = userRepo.List(ctx, repository.UserListFilter{
+		TeamName:    "backend",
+		IsActive:    &active,
+		Username:    "li",
+		AfterUserID: "u1",
+		Limit:       10,
+	})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(page) != 1 || page[0].UserID != "u3" || page[0].OpenReviewCount != 1 {
+		t.Fatalf("expected [u3 with 1 open review], got %+v", page)
+	}
+}
+
+func TestUserDb_SetSkills_And_ListByIDs(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	repo := pg.NewUserDb(db.Pool)
+
+	if _, err := db.Pool.Exec(ctx, `INSERT INTO teams (team_name) VALUES ('backend')`); err != nil {
+		t.Fatalf("insert team: %v", err)
+	}
+	if err := repo.BulkUpsert(ctx, []domain.User{
+		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: false},
+		{UserID: "u3", Username: "Carol", TeamName: "backend", IsActive: true},
+	}); err != nil {
+		t.Fatalf("BulkUpsert: %v", err)
+	}
+
+	user, err := repo.SetSkills(ctx, "u1", []string{"go", "postgres"})
+	if err != nil {
+		t.Fatalf("SetSkills: %v", err)
+	}
+	if len(user.Skills) != 2 || user.Skills[0] != "go" || user.Skills[1] != "postgres" {
+		t.Fatalf("unexpected skills: %v", user.Skills)
+	}
+
+	// Повторный upsert не должен сбрасывать навыки.
+	if err := repo.BulkUpsert(ctx, []domain.User{{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}}); err != nil {
+		t.Fatalf("BulkUpsert: %v", err)
+	}
+	got, err := repo.GetByID(ctx, "u1")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if len(got.Skills) != 2 {
+		t.Fatalf("expected skills to survive upsert, got %v", got.Skills)
+	}
+
+	if _, err := repo.SetSkills(ctx, "nope", nil); !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	users, err := repo.ListByIDs(ctx, []string{"u1", "u2", "ghost"}, true, time.Now())
+	if err != nil {
+		t.Fatalf("ListByIDs: %v", err)
+	}
+	if len(users) != 1 || users[0].UserID != "u1" {
+		t.Fatalf("expected only active u1, got %+v", users)
+	}
+
+	users, err = repo.ListByIDs(ctx, []string{"u1", "u2"}, false, time.Time{})
+	if err != nil {
+		t.Fatalf("ListByIDs: %v", err)
+	}
+	if len(users) != 2 {
+		t.Fatalf("expected u1 and u2, got %+v", users)
+	}
+}
+
+func TestUserDb_BulkUpsert_LastDuplicateWins(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	repo := pg.NewUserDb(db.Pool)
+
+	if _, err := db.Pool.Exec(ctx, `INSERT INTO teams (team_name) VALUES ('backend')`); err != nil {
+		t.Fatalf("insert team: %v", err)
+	}
+	if err := repo.BulkUpsert(ctx, []domain.User{
+		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		{UserID: "u2", Username: "Bob", IsActive: true},
+		{UserID: "u1", Username: "Alice B.", TeamName: "backend", IsActive: false},
+	}); err != nil {
+		t.Fatalf("BulkUpsert: %v", err)
+	}
+
+	u1, err := repo.GetByID(ctx, "u1")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if u1.Username != "Alice B." || u1.IsActive {
+		t.Fatalf("expected last u1 record to win, got %+v", u1)
+	}
+	u2, err := repo.GetByID(ctx, "u2")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if u2.TeamName != "" {
+		t.Fatalf("expected u2 without team, got %q", u2.TeamName)
+	}
+
+	if err := repo.BulkUpsert(ctx, []domain.User{{UserID: "u3", Username: "Carol", TeamName: "missing"}}); err == nil {
+		t.Fatalf("expected error for unknown team")
+	}
+	if _, err := repo.GetByID(ctx, "u3"); !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("expected failed upsert to be rolled back, got %v", err)
+	}
+}
+
+// BenchmarkUserDb_BulkUpsert сравнивает загрузку 10k пользователей через COPY
+// с прежней вставкой по одному запросу на пользователя.
+func BenchmarkUserDb_BulkUpsert(b *testing.B) {
+	ctx := context.Background()
+	db := setupTestDB(b)
+	defer teardownTestDB(b, db)
+
+	if _, err := db.Pool.Exec(ctx, `INSERT INTO teams (team_name) VALUES ('backend')`); err != nil {
+		b.Fatalf("insert team: %v", err)
+	}
+	users := make([]domain.User, 10_000)
+	for i := range users {
+		users[i] = domain.User{
+			UserID:   fmt.Sprintf("u%05d", i),
+			Username: fmt.Sprintf("User %d", i),
+			TeamName: "backend",
+			IsActive: i%2 == 0,
+		}
+	}
+
+	b.Run("copy", func(b *testing.B) {
+		repo := pg.NewUserDb(db.Pool)
+		for b.Loop() {
+			if err := repo.BulkUpsert(ctx, users); err != nil {
+				b.Fatalf("BulkUpsert: %v", err)
+			}
+		}
+	})
+
+	b.Run("row_by_row", func(b *testing.B) {
+		for b.Loop() {
+			if err := upsertUsersRowByRow(ctx, db.Pool, users); err != nil {
+				b.Fatalf("upsert: %v", err)
+			}
+		}
+	})
+}
+
+// upsertUsersRowByRow - прежняя реализация BulkUpsert: запрос на каждого пользователя в одной транзакции.
+func upsertUsersRowByRow(ctx context.Context, pool *pgxpool.Pool, users []domain.User) error {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	for _, u := range users {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO users (user_id, username, team_name, is_active)
+			VALUES ($1, $2, NULLIF($3, ''), $4)
+			ON CONFLICT (user_id) DO UPDATE SET
+				username  = EXCLUDED.username,
+				team_name = EXCLUDED.team_name,
+				is_active = EXCLUDED.is_active
+		`, u.UserID, u.Username, u.TeamName, u.IsActive); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
@@ -3,6 +3,17 @@ import { check, sleep } from "k6";
 import { Trend, Rate } from "test/k6/metrics";
 
 const BASE_URL = __ENV.BASE_URL || "http://localhost:8080";
+// Токен клиента с ролью admin из AUTH_TOKENS (или JWT); без него сервис отвечает 401.
+// Не нужен, только если сервис запущен с AUTH_DISABLED=true.
+const API_TOKEN = __ENV.API_TOKEN || "";
+
+// authHeaders добавляет к заголовкам Authorization, если задан API_TOKEN.
+function authHeaders(headers = {}) {
+    if (API_TOKEN) {
+        return Object.assign({ Authorization: `Bearer ${API_TOKEN}` }, headers);
+    }
+    return headers;
+}
 
 // Метрики
 const createPrDuration = new Trend("create_pr_duration");
@@ -31,7 +42,7 @@ export function setup() {
         ],
     });
 
-    const headers = { "Content-Type": "application/json" };
+    const headers = authHeaders({ "Content-Type": "application/json" });
 
     const res = http.post(`${BASE_URL}/team/add`, teamPayload, { headers });
 
@@ -45,7 +56,7 @@ export function setup() {
 }
 
 export default function (data) {
-    const headers = { "Content-Type": "application/json" };
+    const headers = authHeaders({ "Content-Type": "application/json" });
 
     const prId = `pr-${__VU}-${__ITER}`;
     const createPrPayload = JSON.stringify({
@@ -73,6 +84,7 @@ export default function (data) {
 
     const resReview = http.get(
         `${BASE_URL}/users/getReview?user_id=${data.authorId}`,
+        { headers: authHeaders() },
     );
     getReviewDuration.add(resReview.timings.duration);
 
@@ -84,7 +96,7 @@ export default function (data) {
         errorRate.add(1);
     }
 
-    const resStats = http.get(`${BASE_URL}/stats/reviewers`);
+    const resStats = http.get(`${BASE_URL}/stats/reviewers`, { headers: authHeaders() });
     statsDuration.add(resStats.timings.duration);
 
     const okStats = check(resStats, {